@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
+	"github.com/fatih/color"
 	"github.com/felixge/fgprof"
 	"github.com/go-logr/logr"
 	"github.com/jpillora/overseer"
@@ -25,10 +26,13 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/config"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/genericentropy"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/handlers"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/log"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/output"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/output/webhook"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/tui"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/updater"
@@ -53,21 +57,52 @@ var (
 	allowVerificationOverlap   = cli.Flag("allow-verification-overlap", "Allow verification of similar credentials across detectors").Bool()
 	filterUnverified           = cli.Flag("filter-unverified", "Only output first unverified result per chunk per detector if there are more than one results.").Bool()
 	filterEntropy              = cli.Flag("filter-entropy", "Filter unverified results with Shannon entropy. Start with 3.0.").Float64()
+	entropyThresholds          = cli.Flag("entropy-threshold", "Minimum Shannon entropy, in bits per character, of the secrets of a detector type, e.g. 'GenericEntropy=4.0'. Overrides --filter-entropy and the default threshold of the detector for that type. You can repeat this flag.").StringMap()
+	entropyDetector            = cli.Flag("entropy-detector", "Find generic high-entropy strings near words like key, secret, or token. Off by default because it is noisy.").Bool()
+	entropyDetectorThreshold   = cli.Flag("entropy-detector-threshold", "Minimum Shannon entropy, in bits per character, of strings found by --entropy-detector. 0 uses the default.").Default("0").Float64()
+	entropyDetectorCharset     = cli.Flag("entropy-detector-charset", "Characters of strings found by --entropy-detector: base64 or hex.").Default("base64").Enum("base64", "hex")
+	dbConnectionDetector       = cli.Flag("db-connection-detector", "Find database connection strings, e.g. postgres:// URLs and MySQL DSNs, and report their host, user and database. Off by default because the postgres, mongodb, redis and jdbc detectors find most of them.").Bool()
+	verifyDB                   = cli.Flag("verify-db", "Verify the connection strings found by --db-connection-detector by connecting to their databases and pinging them. Off by default since it connects to the hosts found in the data.").Bool()
+	minConfidence              = cli.Flag("min-confidence", "Only output unverified results with at least this confidence: low, medium, or high.").Default("low").Enum("low", "medium", "high")
 	scanEntireChunk            = cli.Flag("scan-entire-chunk", "Scan the entire chunk for secrets.").Hidden().Default("false").Bool()
 	compareDetectionStrategies = cli.Flag("compare-detection-strategies", "Compare different detection strategies for matching spans").Hidden().Default("false").Bool()
 	configFilename             = cli.Flag("config", "Path to configuration file.").ExistingFile()
 	// rules = cli.Flag("rules", "Path to file with custom rules.").String()
 	printAvgDetectorTime = cli.Flag("print-avg-detector-time", "Print the average time spent on each detector.").Bool()
+	printSummary         = cli.Flag("print-summary", "Print a summary of the data scanned and the results found by each detector once the scan ends.").Bool()
+	detectorTiming       = cli.Flag("detector-timing", "Record the time spent in each detector finding and verifying secrets, and add it to the summary printed by --print-summary, to find the detectors slowing the scan down.").Bool()
 	noUpdate             = cli.Flag("no-update", "Don't check for updates.").Bool()
 	fail                 = cli.Flag("fail", "Exit with code 183 if results are found.").Bool()
 	verifiers            = cli.Flag("verifier", "Set custom verification endpoints.").StringMap()
 	customVerifiersOnly  = cli.Flag("custom-verifiers-only", "Only use custom verification endpoints.").Bool()
+	verificationRate     = cli.Flag("verification-rate-limit", "Maximum number of verification requests per second for each detector type. 0 means unlimited.").Default("0").Float64()
+	verificationWorkers  = cli.Flag("verification-concurrency", "Maximum number of verification requests sent at once, independently of --concurrency. 0 means unlimited.").Default("0").Int()
+	verificationBreaker  = cli.Flag("verification-breaker-threshold", "Stop verifying the results of a detector after this many consecutive verification requests failed, e.g. because its service is down, until a verification request succeeds again after --verification-breaker-cooldown. Results that aren't verified are reported as unverified, with a provider unavailable verification error. 0 disables it.").Default("0").Int()
+	breakerCooldown      = cli.Flag("verification-breaker-cooldown", "How long the results of a detector aren't verified once --verification-breaker-threshold trips.").Default("1m").Duration()
+	verificationCache    = cli.Flag("verification-cache", "Reuse the responses to the verification requests of a secret for its later occurrences in the scan.").Bool()
+	verificationCacheTTL = cli.Flag("verification-cache-ttl", "How long the responses to verification requests are reused. 0 reuses them until the end of the scan.").Default("0").Duration()
+	verificationProxy    = cli.Flag("verification-proxy", "URL of the HTTP, HTTPS or SOCKS5 proxy to verify secrets through. Hosts matching NO_PROXY are requested directly.").String()
+	contentTypeGating    = cli.Flag("content-type-gating", "Skip detectors that can't find secrets in the content type or file extension of the data, e.g. private keys in images. Data of unknown type is scanned by all detectors.").Bool()
+	maxScanDuration      = cli.Flag("max-scan-duration", "Maximum time to scan for. Once exceeded, sources stop reading new data, the data already read is scanned, and the scan exits successfully as a partial scan. 0 means unlimited.").Default("0").Duration()
+	resultsBuffer        = cli.Flag("results-buffer-size", "Maximum number of results buffered while waiting to be output. Scanning slows down when the buffer is full. 0 uses the default.").Default("0").Int()
+	stopOnFirstVerified  = cli.Flag("stop-on-first-verified", "Stop scanning as soon as a verified secret is found, and exit with code 183.").Bool()
+	debugChunksDir       = cli.Flag("debug-chunks-dir", "Write every chunk scanned, with its source metadata, to this directory, in files named after their location in the source, to troubleshoot secrets that aren't found. The data is redacted unless --debug-chunks-full is set.").String()
+	debugChunksFull      = cli.Flag("debug-chunks-full", "Write the full data of the chunks to --debug-chunks-dir, including the secrets it contains, instead of redacting it.").Bool()
+	userAgent            = cli.Flag("user-agent", "User-Agent of the requests sent to verify secrets and to scan sources that support it, e.g. GCS. Defaults to TruffleHog.").String()
+	chunkOverlap         = cli.Flag("chunk-overlap", "Number of bytes each chunk overlaps the next one by, so secrets straddling the boundary between two chunks are found. Raise it to find long secrets, e.g. large private keys. Must be smaller than the chunk size of 10KB. (Byte units eg. 512B, 2KB, 4MB)").Bytes()
 	archiveMaxSize       = cli.Flag("archive-max-size", "Maximum size of archive to scan. (Byte units eg. 512B, 2KB, 4MB)").Bytes()
 	archiveMaxDepth      = cli.Flag("archive-max-depth", "Maximum depth of archive to scan.").Int()
 	archiveTimeout       = cli.Flag("archive-timeout", "Maximum time to spend extracting an archive.").Duration()
 	includeDetectors     = cli.Flag("include-detectors", "Comma separated list of detector types to include. Protobuf name or IDs may be used, as well as ranges.").Default("all").String()
 	excludeDetectors     = cli.Flag("exclude-detectors", "Comma separated list of detector types to exclude. Protobuf name or IDs may be used, as well as ranges. IDs defined here take precedence over the include list.").String()
-	jobReportFile        = cli.Flag("output-report", "Write a scan report to the provided path.").Hidden().OpenFile(os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+	jobReportFile        = cli.Flag("output-report", "Write a scan report to the provided path.").Hidden().String()
+	outputFile           = cli.Flag("output", "Write the results to the provided path instead of stdout. The results are gzipped as they're written if the path ends in .gz.").String()
+	outputGzip           = cli.Flag("output-gzip", "Gzip the results written to --output and the report written to --output-report, whatever the extension of their path.").Bool()
+	webhookURL           = cli.Flag("webhook-url", "Also POST each result as JSON to this URL as it is found.").String()
+	webhookHeaders       = cli.Flag("webhook-header", "Header to add to webhook requests, e.g. 'Authorization=Bearer token'. You can repeat this flag.").StringMap()
+	webhookPolicy        = cli.Flag("webhook-policy", "What to do with results when the webhook can't keep up: block, which slows the scan down, or drop.").Default("block").Enum("block", "drop")
+	allowlistFile        = cli.Flag("allowlist", "Path to a YAML file of secrets known to be benign, e.g. test fixtures, whose results are suppressed. Each entry has a detector, the value of the secret or its sha256 hash, and optionally a path glob the file the secret is found in must match.").ExistingFile()
+	extraLabels          = cli.Flag("label", "Label to attach to every result, e.g. 'env=prod', output as an additional field of JSON results unless it's named like one of their fields. You can repeat this flag.").StringMap()
 
 	gitScan             = cli.Command("git", "Find credentials in git repositories.")
 	gitScanURI          = gitScan.Arg("uri", "Git repository URL. https://, file://, or ssh:// schema expected.").Required().String()
@@ -77,7 +112,10 @@ var (
 	gitScanSinceCommit  = gitScan.Flag("since-commit", "Commit to start scan from.").String()
 	gitScanBranch       = gitScan.Flag("branch", "Branch to scan.").String()
 	gitScanMaxDepth     = gitScan.Flag("max-depth", "Maximum depth of commits to scan.").Int()
+	gitScanBranchDepth  = gitScan.Flag("max-depth-per-branch", "Maximum depth of commits to scan for each branch.").Int()
+	gitScanAllowlist    = gitScan.Flag("path-allowlist", "Glob of file paths to skip, e.g. 'vendor/**' or '**/*.min.js'. You can repeat this flag.").Strings()
 	gitScanBare         = gitScan.Flag("bare", "Scan bare repository (e.g. useful while using in pre-receive hooks)").Bool()
+	gitScanIgnoreFiles  = gitScan.Flag("use-ignore-files", "Skip the paths listed by the .trufflehogignore files of the scanned commit, with the syntax of .gitignore files. Only use it with trusted repositories, since anyone who can commit can hide files from the scan.").Bool()
 	_                   = gitScan.Flag("allow", "No-op flag for backwards compat.").Bool()
 	_                   = gitScan.Flag("entropy", "No-op flag for backwards compat.").Bool()
 	_                   = gitScan.Flag("regex", "No-op flag for backwards compat.").Bool()
@@ -115,6 +153,13 @@ var (
 	// filesystemScanRecursive = filesystemScan.Flag("recursive", "Scan recursively.").Short('r').Bool()
 	filesystemScanIncludePaths = filesystemScan.Flag("include-paths", "Path to file with newline separated regexes for files to include in scan.").Short('i').String()
 	filesystemScanExcludePaths = filesystemScan.Flag("exclude-paths", "Path to file with newline separated regexes for files to exclude in scan.").Short('x').String()
+	filesystemScanAllowlist    = filesystemScan.Flag("path-allowlist", "Glob of file paths to skip, e.g. 'vendor/**' or '**/*.min.js'. You can repeat this flag.").Strings()
+	filesystemSkipBinaries     = filesystemScan.Flag("skip-binaries", "Skip files whose content is binary.").Bool()
+	filesystemNullThreshold    = filesystemScan.Flag("binary-threshold", "Fraction of null bytes in the first 8KB of a file above which it is treated as binary by --skip-binaries.").Default("0").Float64()
+	filesystemScanPaths        = filesystemScan.Flag("scan-paths", "Also scan the paths of files for secrets.").Bool()
+	filesystemContinuations    = filesystemScan.Flag("join-line-continuations", "Join lines continued with a trailing backslash, so secrets wrapped across lines are found. Secrets are reported on the line they start on.").Bool()
+	filesystemMmapThreshold    = filesystemScan.Flag("mmap-threshold", "Size above which files are memory-mapped rather than read with buffered IO. 0 disables memory-mapping. (Byte units eg. 512B, 2KB, 4MB)").Default("64MB").Bytes()
+	filesystemIgnoreFiles      = filesystemScan.Flag("use-ignore-files", "Skip the paths listed by the .trufflehogignore files of the scanned directories, with the syntax of .gitignore files. Only use it with trusted content, since anyone who can write to a directory can hide files from the scan.").Bool()
 
 	s3Scan              = cli.Command("s3", "Find credentials in S3 buckets.")
 	s3ScanKey           = s3Scan.Flag("key", "S3 key used to authenticate. Can be provided with environment variable AWS_ACCESS_KEY_ID.").Envar("AWS_ACCESS_KEY_ID").String()
@@ -125,18 +170,75 @@ var (
 	s3ScanBuckets       = s3Scan.Flag("bucket", "Name of S3 bucket to scan. You can repeat this flag. Incompatible with --ignore-bucket.").Strings()
 	s3ScanIgnoreBuckets = s3Scan.Flag("ignore-bucket", "Name of S3 bucket to ignore. You can repeat this flag. Incompatible with --bucket.").Strings()
 	s3ScanMaxObjectSize = s3Scan.Flag("max-object-size", "Maximum size of objects to scan. Objects larger than this will be skipped. (Byte units eg. 512B, 2KB, 4MB)").Default("250MB").Bytes()
-
-	gcsScan           = cli.Command("gcs", "Find credentials in GCS buckets.")
-	gcsProjectID      = gcsScan.Flag("project-id", "GCS project ID used to authenticate. Can NOT be used with unauth scan. Can be provided with environment variable GOOGLE_CLOUD_PROJECT.").Envar("GOOGLE_CLOUD_PROJECT").String()
-	gcsCloudEnv       = gcsScan.Flag("cloud-environment", "Use Application Default Credentials, IAM credentials to authenticate.").Bool()
-	gcsServiceAccount = gcsScan.Flag("service-account", "Path to GCS service account JSON file.").ExistingFile()
-	gcsWithoutAuth    = gcsScan.Flag("without-auth", "Scan GCS buckets without authentication. This will only work for public buckets").Bool()
-	gcsAPIKey         = gcsScan.Flag("api-key", "GCS API key used to authenticate. Can be provided with environment variable GOOGLE_API_KEY.").Envar("GOOGLE_API_KEY").String()
-	gcsIncludeBuckets = gcsScan.Flag("include-buckets", "Buckets to scan. Comma separated list of buckets. You can repeat this flag. Globs are supported").Short('I').Strings()
-	gcsExcludeBuckets = gcsScan.Flag("exclude-buckets", "Buckets to exclude from scan. Comma separated list of buckets. Globs are supported").Short('X').Strings()
-	gcsIncludeObjects = gcsScan.Flag("include-objects", "Objects to scan. Comma separated list of objects. you can repeat this flag. Globs are supported").Short('i').Strings()
-	gcsExcludeObjects = gcsScan.Flag("exclude-objects", "Objects to exclude from scan. Comma separated list of objects. You can repeat this flag. Globs are supported").Short('x').Strings()
-	gcsMaxObjectSize  = gcsScan.Flag("max-object-size", "Maximum size of objects to scan. Objects larger than this will be skipped. (Byte units eg. 512B, 2KB, 4MB)").Default("10MB").Bytes()
+	s3ScanPaths         = s3Scan.Flag("scan-paths", "Also scan the keys of objects for secrets.").Bool()
+	s3ScanRestore       = s3Scan.Flag("restore-archived-objects", "Request the restore of objects in the GLACIER and DEEP_ARCHIVE storage classes and scan them once restored, which can take hours and is billed. They are skipped otherwise.").Bool()
+
+	gcsScan            = cli.Command("gcs", "Find credentials in GCS buckets.")
+	gcsProjectID       = gcsScan.Flag("project-id", "GCS project ID used to authenticate. Can NOT be used with unauth scan. Can be provided with environment variable GOOGLE_CLOUD_PROJECT.").Envar("GOOGLE_CLOUD_PROJECT").String()
+	gcsCloudEnv        = gcsScan.Flag("cloud-environment", "Use Application Default Credentials, IAM credentials to authenticate.").Bool()
+	gcsServiceAccount  = gcsScan.Flag("service-account", "Path to GCS service account JSON file.").ExistingFile()
+	gcsWIF             = gcsScan.Flag("workload-identity-federation", "Path to the external account configuration of a Workload Identity Federation pool, as created by gcloud iam workload-identity-pools create-cred-config, to authenticate with the token of an external identity provider, e.g. GitHub Actions OIDC, instead of a service account key.").ExistingFile()
+	gcsImpersonate     = gcsScan.Flag("impersonate-service-account", "Email of a service account to impersonate with the Application Default Credentials, e.g. to scan the buckets of another project. Implies --cloud-environment.").String()
+	gcsDelegates       = gcsScan.Flag("impersonation-delegate", "Service account in the delegation chain to --impersonate-service-account, each one able to create tokens for the next. You can repeat this flag.").Strings()
+	gcsWithoutAuth     = gcsScan.Flag("without-auth", "Scan GCS buckets without authentication. This will only work for public buckets").Bool()
+	gcsAPIKey          = gcsScan.Flag("api-key", "GCS API key used to authenticate. Can be provided with environment variable GOOGLE_API_KEY.").Envar("GOOGLE_API_KEY").String()
+	gcsIncludeBuckets  = gcsScan.Flag("include-buckets", "Buckets to scan. Comma separated list of buckets. You can repeat this flag. Globs are supported. Use --include-buckets=@path to read the buckets from a file, one per line, where lines starting with # are comments.").Short('I').Strings()
+	gcsExcludeBuckets  = gcsScan.Flag("exclude-buckets", "Buckets to exclude from scan. Comma separated list of buckets. Globs are supported. Use --exclude-buckets=@path to read the buckets from a file, one per line, where lines starting with # are comments.").Short('X').Strings()
+	gcsIncludeObjects  = gcsScan.Flag("include-objects", "Objects to scan. Comma separated list of objects. you can repeat this flag. Globs are supported").Short('i').Strings()
+	gcsExcludeObjects  = gcsScan.Flag("exclude-objects", "Objects to exclude from scan. Comma separated list of objects. You can repeat this flag. Globs are supported").Short('x').Strings()
+	gcsIncludeTypes    = gcsScan.Flag("include-content-types", "Only scan objects whose content type matches one of these. Comma separated list of media types, such as text/* or application/json. You can repeat this flag.").Strings()
+	gcsExcludeTypes    = gcsScan.Flag("exclude-content-types", "Skip objects whose content type matches one of these, like --include-content-types, which it takes precedence over.").Strings()
+	gcsExcludeUnknown  = gcsScan.Flag("exclude-unknown-content-types", "Skip objects without a valid content type when filtering by content type.").Bool()
+	gcsSkipBinaries    = gcsScan.Flag("skip-binaries", "Skip objects whose content is binary, based on their content type or content.").Bool()
+	gcsNullThreshold   = gcsScan.Flag("binary-threshold", "Fraction of null bytes in the first 8KB of an object above which it is treated as binary by --skip-binaries.").Default("0").Float64()
+	gcsSignedURLs      = gcsScan.Flag("signed-url", "Signed URL of an object to scan instead of listing buckets. No other authentication is used. You can repeat this flag.").Strings()
+	gcsSignedURLsFile  = gcsScan.Flag("signed-urls-file", "Path to file with newline separated signed URLs of objects to scan instead of listing buckets.").String()
+	gcsSkipArchives    = gcsScan.Flag("disable-archive-handling", "Read objects as plain text without detecting or extracting archives. Faster for buckets that only contain text.").Bool()
+	gcsPrefixes        = gcsScan.Flag("prefix", "Only scan objects whose names start with this prefix. You can repeat this flag.").Strings()
+	gcsVersions        = gcsScan.Flag("include-versions", "Also scan noncurrent versions of objects in buckets with object versioning.").Bool()
+	gcsMaxObjects      = gcsScan.Flag("max-objects-per-bucket", "Maximum number of objects to scan in each bucket. 0 scans all objects.").Default("0").Int64()
+	gcsSampleObjects   = gcsScan.Flag("sample-objects", "Scan a random sample of --max-objects-per-bucket objects in each bucket instead of the first ones.").Bool()
+	gcsSampleRate      = gcsScan.Flag("sample-rate", "Scan each object with this probability, between 0 and 1, e.g. 0.05 to spot check about 5% of the objects. 0 scans all objects.").Default("0").Float64()
+	gcsSampleSeed      = gcsScan.Flag("sample-seed", "Seed of the objects selected by --sample-rate and --sample-objects, so runs with the same seed scan the same objects. Random if 0.").Default("0").Int64()
+	gcsRangeThreshold  = gcsScan.Flag("ranged-read-threshold", "Read objects of at least this size in ranges that are fetched concurrently. 0 reads objects in a single request. (Byte units eg. 512B, 2KB, 4MB)").Default("0").Bytes()
+	gcsRangeWorkers    = gcsScan.Flag("ranged-read-concurrency", "Maximum number of ranges of an object fetched at once by --ranged-read-threshold.").Default("4").Int()
+	gcsPrefetchDepth   = gcsScan.Flag("prefetch-depth", "Number of objects downloaded into memory ahead of being scanned, so downloads overlap with detection. 0 downloads objects as they're scanned.").Default("0").Int()
+	gcsPrefetchMemory  = gcsScan.Flag("prefetch-max-bytes", "Maximum memory of the objects downloaded ahead by --prefetch-depth. Larger objects are downloaded as they're scanned. (Byte units eg. 512B, 2KB, 4MB)").Default("64MB").Bytes()
+	gcsContinueOnError = gcsScan.Flag("continue-on-bucket-error", "Log and skip buckets that can't be listed, e.g. because of missing permissions, and scan the rest. The skipped buckets are reported when the scan ends.").Bool()
+	gcsPublicAccess    = gcsScan.Flag("report-public-access", "Report the objects and buckets that anyone on the internet can read, from their ACLs, even if they don't contain secrets.").Bool()
+	gcsScanPaths       = gcsScan.Flag("scan-paths", "Also scan the names of objects for secrets.").Bool()
+	gcsBucketIAM       = gcsScan.Flag("bucket-iam", "Report the principals that can read the objects of a bucket, from its IAM policy, with the findings in the bucket. Requires the storage.buckets.getIamPolicy permission.").Bool()
+	gcsProxy           = gcsScan.Flag("proxy", "URL of the HTTP, HTTPS or SOCKS5 proxy to request GCS through. Hosts matching NO_PROXY are requested directly. Use --verification-proxy to also verify secrets through it.").String()
+	gcsPrivateEndpoint = gcsScan.Flag("private-endpoint", "Connect to Google APIs through the restricted VIP (restricted.googleapis.com), to scan from inside a VPC Service Controls perimeter whose DNS doesn't route to it.").Bool()
+	gcsContinuations   = gcsScan.Flag("join-line-continuations", "Join lines continued with a trailing backslash, so secrets wrapped across lines are found.").Bool()
+	gcsNDJSON          = gcsScan.Flag("ndjson", "Scan objects as newline-delimited JSON, such as logs, reporting the line and byte offset of the record each secret is found in. Archives aren't extracted.").Bool()
+	gcsSkipArchived    = gcsScan.Flag("skip-archived-objects", "Skip objects in the COLDLINE and ARCHIVE storage classes, whose retrieval is billed.").Bool()
+	gcsDedupe          = gcsScan.Flag("dedupe-objects", "Skip objects with the same content as an object already scanned, e.g. copies of a file in several buckets, based on their stored MD5 or CRC32C hash.").Bool()
+	gcsSoftDeleted     = gcsScan.Flag("include-soft-deleted", "Also scan soft-deleted objects retained by the bucket's soft delete policy. Objects GCS won't serve until restored only have their name and custom metadata scanned.").Bool()
+	gcsMaxObjectSize   = gcsScan.Flag("max-object-size", "Maximum size of objects to scan. Objects larger than this will be skipped, unless --truncate-large-objects is set. (Byte units eg. 512B, 2KB, 4MB)").Default("10MB").Bytes()
+	gcsMinObjectSize   = gcsScan.Flag("min-object-size", "Minimum size of objects to scan. Smaller objects, e.g. icons, are skipped without being read. (Byte units eg. 512B, 2KB, 4MB)").Default("0").Bytes()
+	gcsTruncateLarge   = gcsScan.Flag("truncate-large-objects", "Scan the first --max-object-size bytes of larger objects instead of skipping them.").Bool()
+	gcsInventory       = gcsScan.Flag("inventory-manifest", "gs:// URI of a Storage Insights inventory report, in CSV or JSON, or of its manifest, listing the objects to scan instead of listing the buckets, which is far cheaper for very large buckets. --project-id isn't required.").String()
+
+	gcpSecretsScan           = cli.Command("gcp-secret-manager", "Find credentials in the secrets of Google Cloud Secret Manager.")
+	gcpSecretsProjectID      = gcpSecretsScan.Flag("project-id", "Project whose secrets are scanned. Can be provided with environment variable GOOGLE_CLOUD_PROJECT.").Envar("GOOGLE_CLOUD_PROJECT").Required().String()
+	gcpSecretsServiceAccount = gcpSecretsScan.Flag("service-account", "Path to a service account JSON file. Application Default Credentials are used if not set.").ExistingFile()
+	gcpSecretsInclude        = gcpSecretsScan.Flag("include-secrets", "Secrets to scan. Comma separated list of secret IDs. You can repeat this flag. Globs are supported").Short('i').Strings()
+	gcpSecretsExclude        = gcpSecretsScan.Flag("exclude-secrets", "Secrets to exclude from scan. Comma separated list of secret IDs. You can repeat this flag. Globs are supported").Short('x').Strings()
+
+	azureBlobScan              = cli.Command("azure-blob", "Find credentials in Azure Blob Storage containers.")
+	azureBlobAccountURL        = azureBlobScan.Flag("account-url", "Blob service URL of the storage account, e.g. https://account.blob.core.windows.net. Not needed with a connection string.").String()
+	azureBlobConnectionString  = azureBlobScan.Flag("connection-string", "Storage account connection string used to authenticate. Can be provided with environment variable AZURE_STORAGE_CONNECTION_STRING.").Envar("AZURE_STORAGE_CONNECTION_STRING").String()
+	azureBlobSASToken          = azureBlobScan.Flag("sas-token", "Shared access signature used to authenticate. Can be provided with environment variable AZURE_STORAGE_SAS_TOKEN.").Envar("AZURE_STORAGE_SAS_TOKEN").String()
+	azureBlobManagedIdentity   = azureBlobScan.Flag("managed-identity", "Use the managed identity of the host to authenticate.").Bool()
+	azureBlobManagedIdentityID = azureBlobScan.Flag("managed-identity-client-id", "Client ID of a user-assigned managed identity to authenticate with.").String()
+	azureBlobIncludeContainers = azureBlobScan.Flag("include-containers", "Containers to scan. Comma separated list of containers. You can repeat this flag. Globs are supported").Short('I').Strings()
+	azureBlobExcludeContainers = azureBlobScan.Flag("exclude-containers", "Containers to exclude from scan. Comma separated list of containers. Globs are supported").Short('X').Strings()
+	azureBlobIncludeBlobs      = azureBlobScan.Flag("include-blobs", "Blobs to scan. Comma separated list of blobs. You can repeat this flag. Globs are supported").Short('i').Strings()
+	azureBlobExcludeBlobs      = azureBlobScan.Flag("exclude-blobs", "Blobs to exclude from scan. Comma separated list of blobs. You can repeat this flag. Globs are supported").Short('x').Strings()
+	azureBlobSkipBinaries      = azureBlobScan.Flag("skip-binaries", "Skip blobs whose content is binary, based on their content type or content.").Bool()
+	azureBlobSkipArchives      = azureBlobScan.Flag("disable-archive-handling", "Read blobs as plain text without detecting or extracting archives.").Bool()
+	azureBlobMaxBlobSize       = azureBlobScan.Flag("max-blob-size", "Maximum size of blobs to scan. Blobs larger than this will be skipped. (Byte units eg. 512B, 2KB, 4MB)").Default("10MB").Bytes()
 
 	syslogScan     = cli.Command("syslog", "Scan syslog")
 	syslogAddress  = syslogScan.Flag("address", "Address and port to listen on for syslog. Example: 127.0.0.1:514").String()
@@ -145,11 +247,14 @@ var (
 	syslogTLSKey   = syslogScan.Flag("key", "Path to TLS key.").String()
 	syslogFormat   = syslogScan.Flag("format", "Log format. Can be rfc3164 or rfc5424").String()
 
+	stdinScan = cli.Command("stdin", "Find credentials in text piped to standard input, e.g. a pasted config file.")
+	stdinName = stdinScan.Flag("name", "Name of the input shown in the results, e.g. the file it was piped from.").Default("stdin").String()
+
 	circleCiScan      = cli.Command("circleci", "Scan CircleCI")
 	circleCiScanToken = circleCiScan.Flag("token", "CircleCI token. Can also be provided with environment variable").Envar("CIRCLECI_TOKEN").Required().String()
 
 	dockerScan       = cli.Command("docker", "Scan Docker Image")
-	dockerScanImages = dockerScan.Flag("image", "Docker image to scan. Use the file:// prefix to point to a local tarball, otherwise a image registry is assumed.").Required().Strings()
+	dockerScanImages = dockerScan.Flag("image", "Docker image to scan. Use the file:// prefix to point to a local tarball, or the oci:// prefix to point to a local OCI image layout, optionally followed by :tag or @digest. Otherwise a image registry is assumed.").Required().Strings()
 	dockerScanToken  = dockerScan.Flag("token", "Docker bearer token. Can also be provided with environment variable").Envar("DOCKER_TOKEN").String()
 
 	travisCiScan      = cli.Command("travisci", "Scan TravisCI")
@@ -330,6 +435,8 @@ func run(state overseer.State) {
 
 	logger.V(2).Info(fmt.Sprintf("trufflehog %s", version.BuildVersion))
 
+	common.SetUserAgent(*userAgent)
+
 	if *githubScanToken != "" {
 		// NOTE: this kludge is here to do an authenticated shallow commit
 		// TODO: refactor to better pass credentials
@@ -362,6 +469,11 @@ func run(state overseer.State) {
 		}
 	}
 
+	if *chunkOverlap != 0 {
+		if err := sources.ValidateChunkOverlap(int(*chunkOverlap), sources.ChunkSize); err != nil {
+			logFatal(err, "invalid chunk overlap")
+		}
+	}
 	if *archiveMaxSize != 0 {
 		handlers.SetArchiveMaxSize(int(*archiveMaxSize))
 	}
@@ -372,17 +484,34 @@ func run(state overseer.State) {
 		handlers.SetArchiveMaxTimeout(*archiveTimeout)
 	}
 
-	// Set how the engine will print its results.
+	// Set where and how the engine will print its results.
+	var out io.Writer
+	closeOutput := func() {}
+	if *outputFile != "" {
+		f, err := output.CreateFile(*outputFile, *outputGzip)
+		if err != nil {
+			logFatal(err, "failed to create output file")
+		}
+		out = f
+		closeOutput = func() {
+			if err := f.Close(); err != nil {
+				logger.Error(err, "error writing output file")
+			}
+		}
+		// Colors are only meant for terminals.
+		color.NoColor = true
+	}
+
 	var printer engine.Printer
 	switch {
 	case *jsonLegacy:
-		printer = new(output.LegacyJSONPrinter)
+		printer = &output.LegacyJSONPrinter{Writer: out}
 	case *jsonOut:
-		printer = new(output.JSONPrinter)
+		printer = &output.JSONPrinter{Writer: out}
 	case *gitHubActionsFormat:
-		printer = new(output.GitHubActionsPrinter)
+		printer = &output.GitHubActionsPrinter{Writer: out}
 	default:
-		printer = new(output.PlainPrinter)
+		printer = &output.PlainPrinter{Writer: out}
 	}
 
 	if !*jsonLegacy && !*jsonOut {
@@ -399,27 +528,91 @@ func run(state overseer.State) {
 		logFatal(err, "failed to configure results flag")
 	}
 
+	parsedMinConfidence, err := detectors.ParseConfidence(*minConfidence)
+	if err != nil {
+		logFatal(err, "failed to configure min confidence flag")
+	}
+
+	parsedEntropyThresholds, err := config.ParseEntropyThresholds(*entropyThresholds)
+	if err != nil {
+		logFatal(err, "failed to configure entropy thresholds")
+	}
+
+	var genericEntropyConfig *genericentropy.Config
+	if *entropyDetector {
+		genericEntropyConfig = &genericentropy.Config{
+			Threshold: *entropyDetectorThreshold,
+			Charset:   genericentropy.Charset(*entropyDetectorCharset),
+		}
+	}
+
+	var dispatcher engine.ResultsDispatcher = engine.NewPrinterDispatcher(printer)
+	var webhookPrinter *webhook.Printer
+	if *webhookURL != "" {
+		webhookPrinter, err = webhook.New(ctx, webhook.Config{
+			URL:     *webhookURL,
+			Headers: *webhookHeaders,
+			Policy:  webhook.Policy(*webhookPolicy),
+		})
+		if err != nil {
+			logFatal(err, "failed to configure webhook")
+		}
+		dispatcher = engine.MultiDispatcher{dispatcher, engine.NewPrinterDispatcher(webhookPrinter)}
+	}
+	closeWebhook := func() {
+		if webhookPrinter == nil {
+			return
+		}
+		if err := webhookPrinter.Close(); err != nil {
+			logger.Error(err, "error sending results to webhook")
+		}
+	}
+
 	engConf := engine.Config{
-		Concurrency:           *concurrency,
-		Detectors:             conf.Detectors,
-		Verify:                !*noVerification,
-		IncludeDetectors:      *includeDetectors,
-		ExcludeDetectors:      *excludeDetectors,
-		CustomVerifiersOnly:   *customVerifiersOnly,
-		VerifierEndpoints:     *verifiers,
-		Dispatcher:            engine.NewPrinterDispatcher(printer),
-		FilterUnverified:      *filterUnverified,
-		FilterEntropy:         *filterEntropy,
-		VerificationOverlap:   *allowVerificationOverlap,
-		Results:               parsedResults,
-		PrintAvgDetectorTime:  *printAvgDetectorTime,
-		ShouldScanEntireChunk: *scanEntireChunk,
+		Concurrency:                         *concurrency,
+		Detectors:                           conf.Detectors,
+		Verify:                              !*noVerification,
+		IncludeDetectors:                    *includeDetectors,
+		ExcludeDetectors:                    *excludeDetectors,
+		CustomVerifiersOnly:                 *customVerifiersOnly,
+		VerifierEndpoints:                   *verifiers,
+		VerificationRateLimit:               *verificationRate,
+		VerificationConcurrency:             *verificationWorkers,
+		VerificationCircuitBreakerThreshold: *verificationBreaker,
+		VerificationCircuitBreakerCooldown:  *breakerCooldown,
+		VerificationCache:                   *verificationCache,
+		VerificationCacheTTL:                *verificationCacheTTL,
+		VerificationProxy:                   *verificationProxy,
+		ResultsBufferSize:                   *resultsBuffer,
+		StopOnFirstVerified:                 *stopOnFirstVerified,
+		ChunkDebugDir:                       *debugChunksDir,
+		ChunkDebugFull:                      *debugChunksFull,
+		ExtraLabels:                         *extraLabels,
+		AllowlistFile:                       *allowlistFile,
+		MaxScanDuration:                     *maxScanDuration,
+		ContentTypeGating:                   *contentTypeGating,
+		Dispatcher:                          dispatcher,
+		FilterUnverified:                    *filterUnverified,
+		FilterEntropy:                       *filterEntropy,
+		EntropyThresholds:                   parsedEntropyThresholds,
+		VerificationOverlap:                 *allowVerificationOverlap,
+		Results:                             parsedResults,
+		OnlyVerified:                        *onlyVerified,
+		MinConfidence:                       parsedMinConfidence,
+		GenericEntropy:                      genericEntropyConfig,
+		DatabaseConnections:                 *dbConnectionDetector,
+		VerifyDatabaseConnections:           *verifyDB,
+		PrintAvgDetectorTime:                *printAvgDetectorTime,
+		DetectorTiming:                      *detectorTiming,
+		ShouldScanEntireChunk:               *scanEntireChunk,
 	}
 
 	if *compareDetectionStrategies {
 		if err := compareScans(ctx, cmd, engConf); err != nil {
 			logFatal(err, "error comparing detection strategies")
 		}
+		closeWebhook()
+		closeOutput()
 		return
 	}
 
@@ -427,6 +620,8 @@ func run(state overseer.State) {
 	if err != nil {
 		logFatal(err, "error running scan")
 	}
+	closeWebhook()
+	closeOutput()
 
 	// Print results.
 	logger.Info("finished scanning",
@@ -434,7 +629,12 @@ func run(state overseer.State) {
 		"bytes", metrics.BytesScanned,
 		"verified_secrets", metrics.VerifiedSecretsFound,
 		"unverified_secrets", metrics.UnverifiedSecretsFound,
+		"unverified_secrets_suppressed", metrics.UnverifiedSecretsSuppressed,
+		"allowlisted_secrets_suppressed", metrics.AllowlistedSecretsSuppressed,
 		"scan_duration", metrics.ScanDuration.String(),
+		"partial_scan", metrics.PartialScan,
+		"stopped_on_first_verified", metrics.StoppedOnFirstVerified,
+		"scan_id", metrics.ScanID,
 		"trufflehog_version", version.BuildVersion,
 	)
 
@@ -442,6 +642,10 @@ func run(state overseer.State) {
 		logger.V(2).Info("exiting with code 183 because results were found")
 		os.Exit(183)
 	}
+	if metrics.StoppedOnFirstVerified {
+		logger.V(2).Info("exiting with code 183 because a verified secret was found")
+		os.Exit(183)
+	}
 }
 
 func compareScans(ctx context.Context, cmd string, cfg engine.Config) error {
@@ -502,8 +706,12 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 
 	// Setup job report writer if provided
 	var jobReportWriter io.WriteCloser
-	if *jobReportFile != nil {
-		jobReportWriter = *jobReportFile
+	if *jobReportFile != "" {
+		f, err := output.CreateFile(*jobReportFile, *outputGzip)
+		if err != nil {
+			return scanMetrics, fmt.Errorf("error creating scan report: %w", err)
+		}
+		jobReportWriter = f
 	}
 
 	handleFinishedMetrics := func(ctx context.Context, finishedMetrics <-chan sources.UnitMetrics, jobReportWriter io.WriteCloser) {
@@ -538,9 +746,13 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 	opts := []func(*sources.SourceManager){
 		sources.WithConcurrentSources(cfg.Concurrency),
 		sources.WithConcurrentUnits(cfg.Concurrency),
+		sources.WithWorkerBudget(cfg.Concurrency),
 		sources.WithSourceUnits(),
 		sources.WithBufferedOutput(defaultOutputBufferSize),
 	}
+	if *chunkOverlap != 0 {
+		opts = append(opts, sources.WithChunkOverlap(int(*chunkOverlap)))
+	}
 
 	if jobReportWriter != nil {
 		unitHook, finishedMetrics := sources.NewUnitHook(ctx)
@@ -566,14 +778,17 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 	switch cmd {
 	case gitScan.FullCommand():
 		gitCfg := sources.GitConfig{
-			URI:              *gitScanURI,
-			IncludePathsFile: *gitScanIncludePaths,
-			ExcludePathsFile: *gitScanExcludePaths,
-			HeadRef:          *gitScanBranch,
-			BaseRef:          *gitScanSinceCommit,
-			MaxDepth:         *gitScanMaxDepth,
-			Bare:             *gitScanBare,
-			ExcludeGlobs:     *gitScanExcludeGlobs,
+			URI:               *gitScanURI,
+			IncludePathsFile:  *gitScanIncludePaths,
+			ExcludePathsFile:  *gitScanExcludePaths,
+			HeadRef:           *gitScanBranch,
+			BaseRef:           *gitScanSinceCommit,
+			MaxDepth:          *gitScanMaxDepth,
+			MaxDepthPerBranch: *gitScanBranchDepth,
+			Bare:              *gitScanBare,
+			ExcludeGlobs:      *gitScanExcludeGlobs,
+			PathAllowlist:     *gitScanAllowlist,
+			UseIgnoreFiles:    *gitScanIgnoreFiles,
 		}
 		if err = eng.ScanGit(ctx, gitCfg); err != nil {
 			return scanMetrics, fmt.Errorf("failed to scan Git: %v", err)
@@ -629,23 +844,32 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 		paths = append(paths, *filesystemPaths...)
 		paths = append(paths, *filesystemDirectories...)
 		cfg := sources.FilesystemConfig{
-			Paths:            paths,
-			IncludePathsFile: *filesystemScanIncludePaths,
-			ExcludePathsFile: *filesystemScanExcludePaths,
+			Paths:                 paths,
+			IncludePathsFile:      *filesystemScanIncludePaths,
+			ExcludePathsFile:      *filesystemScanExcludePaths,
+			PathAllowlist:         *filesystemScanAllowlist,
+			SkipBinaries:          *filesystemSkipBinaries,
+			BinaryThreshold:       *filesystemNullThreshold,
+			ScanPaths:             *filesystemScanPaths,
+			JoinLineContinuations: *filesystemContinuations,
+			UseIgnoreFiles:        *filesystemIgnoreFiles,
+			MmapThreshold:         int64(*filesystemMmapThreshold),
 		}
 		if err = eng.ScanFileSystem(ctx, cfg); err != nil {
 			return scanMetrics, fmt.Errorf("failed to scan filesystem: %v", err)
 		}
 	case s3Scan.FullCommand():
 		cfg := sources.S3Config{
-			Key:           *s3ScanKey,
-			Secret:        *s3ScanSecret,
-			SessionToken:  *s3ScanSessionToken,
-			Buckets:       *s3ScanBuckets,
-			IgnoreBuckets: *s3ScanIgnoreBuckets,
-			Roles:         *s3ScanRoleArns,
-			CloudCred:     *s3ScanCloudEnv,
-			MaxObjectSize: int64(*s3ScanMaxObjectSize),
+			Key:                    *s3ScanKey,
+			Secret:                 *s3ScanSecret,
+			SessionToken:           *s3ScanSessionToken,
+			Buckets:                *s3ScanBuckets,
+			IgnoreBuckets:          *s3ScanIgnoreBuckets,
+			Roles:                  *s3ScanRoleArns,
+			CloudCred:              *s3ScanCloudEnv,
+			MaxObjectSize:          int64(*s3ScanMaxObjectSize),
+			ScanPaths:              *s3ScanPaths,
+			RestoreArchivedObjects: *s3ScanRestore,
 		}
 		if err := eng.ScanS3(ctx, cfg); err != nil {
 			return scanMetrics, fmt.Errorf("failed to scan S3: %v", err)
@@ -662,6 +886,11 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 		if err := eng.ScanSyslog(ctx, cfg); err != nil {
 			return scanMetrics, fmt.Errorf("failed to scan syslog: %v", err)
 		}
+	case stdinScan.FullCommand():
+		cfg := sources.StdinConfig{Name: *stdinName}
+		if err := eng.ScanStdin(ctx, cfg); err != nil {
+			return scanMetrics, fmt.Errorf("failed to scan stdin: %v", err)
+		}
 	case circleCiScan.FullCommand():
 		if err := eng.ScanCircleCI(ctx, *circleCiScanToken); err != nil {
 			return scanMetrics, fmt.Errorf("failed to scan CircleCI: %v", err)
@@ -672,21 +901,86 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 		}
 	case gcsScan.FullCommand():
 		cfg := sources.GCSConfig{
-			ProjectID:      *gcsProjectID,
-			CloudCred:      *gcsCloudEnv,
-			ServiceAccount: *gcsServiceAccount,
-			WithoutAuth:    *gcsWithoutAuth,
-			ApiKey:         *gcsAPIKey,
-			IncludeBuckets: commaSeparatedToSlice(*gcsIncludeBuckets),
-			ExcludeBuckets: commaSeparatedToSlice(*gcsExcludeBuckets),
-			IncludeObjects: commaSeparatedToSlice(*gcsIncludeObjects),
-			ExcludeObjects: commaSeparatedToSlice(*gcsExcludeObjects),
-			Concurrency:    *concurrency,
-			MaxObjectSize:  int64(*gcsMaxObjectSize),
+			ProjectID:                  *gcsProjectID,
+			CloudCred:                  *gcsCloudEnv,
+			ServiceAccount:             *gcsServiceAccount,
+			ImpersonateServiceAccount:  *gcsImpersonate,
+			ImpersonationDelegates:     commaSeparatedToSlice(*gcsDelegates),
+			WorkloadIdentityFederation: *gcsWIF,
+			WithoutAuth:                *gcsWithoutAuth,
+			ApiKey:                     *gcsAPIKey,
+			IncludeBuckets:             commaSeparatedToSlice(*gcsIncludeBuckets),
+			ExcludeBuckets:             commaSeparatedToSlice(*gcsExcludeBuckets),
+			IncludeObjects:             commaSeparatedToSlice(*gcsIncludeObjects),
+			ExcludeObjects:             commaSeparatedToSlice(*gcsExcludeObjects),
+			IncludeContentTypes:        commaSeparatedToSlice(*gcsIncludeTypes),
+			ExcludeContentTypes:        commaSeparatedToSlice(*gcsExcludeTypes),
+			ExcludeUnknownContentTypes: *gcsExcludeUnknown,
+			Concurrency:                *concurrency,
+			MaxObjectSize:              int64(*gcsMaxObjectSize),
+			MinObjectSize:              int64(*gcsMinObjectSize),
+			TruncateLargeObjects:       *gcsTruncateLarge,
+			SkipBinaries:               *gcsSkipBinaries,
+			BinaryThreshold:            *gcsNullThreshold,
+			SignedURLs:                 *gcsSignedURLs,
+			SignedURLsFile:             *gcsSignedURLsFile,
+			DisableArchiveHandling:     *gcsSkipArchives,
+			Prefixes:                   *gcsPrefixes,
+			IncludeVersions:            *gcsVersions,
+			MaxObjectsPerBucket:        *gcsMaxObjects,
+			SampleObjects:              *gcsSampleObjects,
+			SampleRate:                 *gcsSampleRate,
+			SampleSeed:                 *gcsSampleSeed,
+			RangedReadThreshold:        int64(*gcsRangeThreshold),
+			RangedReadConcurrency:      *gcsRangeWorkers,
+			PrefetchDepth:              *gcsPrefetchDepth,
+			PrefetchMaxBytes:           int64(*gcsPrefetchMemory),
+			ContinueOnBucketError:      *gcsContinueOnError,
+			ReportPublicAccess:         *gcsPublicAccess,
+			ScanPaths:                  *gcsScanPaths,
+			NDJSON:                     *gcsNDJSON,
+			JoinLineContinuations:      *gcsContinuations,
+			BucketIAM:                  *gcsBucketIAM,
+			Proxy:                      *gcsProxy,
+			PrivateEndpoint:            *gcsPrivateEndpoint,
+			SkipArchivedObjects:        *gcsSkipArchived,
+			DedupeObjects:              *gcsDedupe,
+			SoftDeletedObjects:         *gcsSoftDeleted,
+			InventoryManifest:          *gcsInventory,
 		}
 		if err := eng.ScanGCS(ctx, cfg); err != nil {
 			return scanMetrics, fmt.Errorf("failed to scan GCS: %v", err)
 		}
+	case gcpSecretsScan.FullCommand():
+		cfg := sources.GCPSecretManagerConfig{
+			ProjectID:      *gcpSecretsProjectID,
+			ServiceAccount: *gcpSecretsServiceAccount,
+			Concurrency:    *concurrency,
+			IncludeSecrets: commaSeparatedToSlice(*gcpSecretsInclude),
+			ExcludeSecrets: commaSeparatedToSlice(*gcpSecretsExclude),
+		}
+		if err := eng.ScanGCPSecretManager(ctx, cfg); err != nil {
+			return scanMetrics, fmt.Errorf("failed to scan Secret Manager: %v", err)
+		}
+	case azureBlobScan.FullCommand():
+		cfg := sources.AzureBlobConfig{
+			AccountURL:              *azureBlobAccountURL,
+			ConnectionString:        *azureBlobConnectionString,
+			SASToken:                *azureBlobSASToken,
+			ManagedIdentity:         *azureBlobManagedIdentity,
+			ManagedIdentityClientID: *azureBlobManagedIdentityID,
+			Concurrency:             *concurrency,
+			IncludeContainers:       commaSeparatedToSlice(*azureBlobIncludeContainers),
+			ExcludeContainers:       commaSeparatedToSlice(*azureBlobExcludeContainers),
+			IncludeBlobs:            commaSeparatedToSlice(*azureBlobIncludeBlobs),
+			ExcludeBlobs:            commaSeparatedToSlice(*azureBlobExcludeBlobs),
+			MaxBlobSize:             int64(*azureBlobMaxBlobSize),
+			SkipBinaries:            *azureBlobSkipBinaries,
+			DisableArchiveHandling:  *azureBlobSkipArchives,
+		}
+		if err := eng.ScanAzureBlob(ctx, cfg); err != nil {
+			return scanMetrics, fmt.Errorf("failed to scan Azure Blob Storage: %v", err)
+		}
 	case dockerScan.FullCommand():
 		cfg := sources.DockerConfig{
 			BearerToken:       *dockerScanToken,
@@ -804,6 +1098,11 @@ func runSingleScan(ctx context.Context, cmd string, cfg engine.Config) (metrics,
 	if *printAvgDetectorTime {
 		printAverageDetectorTime(eng)
 	}
+	if *printSummary {
+		if err := eng.ScanSummary().Print(os.Stderr); err != nil {
+			return scanMetrics, fmt.Errorf("failed to print scan summary: %v", err)
+		}
+	}
 
 	return metrics{Metrics: eng.GetMetrics(), hasFoundResults: eng.HasFoundResults()}, nil
 }
@@ -1,6 +1,11 @@
 package memory
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -11,6 +16,11 @@ const (
 	defaultExpirationInterval = 12 * time.Hour
 	defaultPurgeInterval      = 13 * time.Hour
 	defaultExpiration         = cache.DefaultExpiration
+
+	// compressedContentsPrefix prefixes the contents compressed with gzip and
+	// encoded in base64, so they can be told apart from the comma-separated
+	// keys of uncompressed contents, which don't start with it.
+	compressedContentsPrefix = "~gz:"
 )
 
 // Cache wraps the go-cache library to provide an in-memory key-value store.
@@ -18,6 +28,10 @@ type Cache[T any] struct {
 	c             *cache.Cache
 	expiration    time.Duration
 	purgeInterval time.Duration
+	// compress is set if Contents compresses the contents that are at least
+	// compressMinSize bytes.
+	compress        bool
+	compressMinSize int
 }
 
 // CacheOption defines a function type used for configuring a Cache.
@@ -35,6 +49,17 @@ func WithPurgeInterval[T any](interval time.Duration) CacheOption[T] {
 	return func(c *Cache[T]) { c.purgeInterval = interval }
 }
 
+// WithCompressedContents returns a CacheOption to compress the contents
+// returned by Contents once they are at least minSize bytes, e.g. when they are
+// persisted as the resume info of a large scan. Smaller contents are left
+// uncompressed. Compressed contents are decoded by NewFromContents.
+func WithCompressedContents[T any](minSize int) CacheOption[T] {
+	return func(c *Cache[T]) {
+		c.compress = true
+		c.compressMinSize = minSize
+	}
+}
+
 // New constructs a new in-memory cache instance with optional configurations.
 // By default, it sets the expiration and purge intervals to 12 and 13 hours, respectively.
 // These defaults can be overridden using the functional options: WithExpirationInterval and WithPurgeInterval.
@@ -68,6 +93,50 @@ func NewWithData[T any](data []CacheEntry[T], opts ...CacheOption[T]) *Cache[T]
 	return instance
 }
 
+// NewFromContents constructs a new in-memory cache with the keys of contents,
+// as returned by Contents, each of them being its own value. Contents may be
+// compressed, or be the comma-separated keys persisted before they could be.
+// It also accepts CacheOption parameters to override default configuration values.
+func NewFromContents(contents string, opts ...CacheOption[string]) (*Cache[string], error) {
+	keys, err := DecodeContents(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]CacheEntry[string], len(keys))
+	for i, key := range keys {
+		entries[i] = CacheEntry[string]{Key: key, Value: key}
+	}
+	return NewWithData[string](entries, opts...), nil
+}
+
+// DecodeContents returns the keys of contents, as returned by Contents,
+// decompressing them if they are compressed.
+func DecodeContents(contents string) ([]string, error) {
+	if !strings.HasPrefix(contents, compressedContentsPrefix) {
+		if contents == "" {
+			return nil, nil
+		}
+		return strings.Split(contents, ","), nil
+	}
+
+	encoded := strings.NewReader(strings.TrimPrefix(contents, compressedContentsPrefix))
+	r, err := gzip.NewReader(base64.NewDecoder(base64.StdEncoding, encoded))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing cache contents: %w", err)
+	}
+	defer r.Close()
+
+	decompressed, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing cache contents: %w", err)
+	}
+	if len(decompressed) == 0 {
+		return nil, nil
+	}
+	return strings.Split(string(decompressed), ","), nil
+}
+
 // Set adds a key-value pair to the cache.
 func (c *Cache[T]) Set(key string, value T) {
 	c.c.Set(key, value, defaultExpiration)
@@ -131,11 +200,32 @@ func (c *Cache[T]) Values() []T {
 }
 
 // Contents returns a comma-separated string containing all keys in the cache.
+// If the cache was constructed WithCompressedContents, large contents are
+// compressed, see DecodeContents.
 func (c *Cache[T]) Contents() string {
 	items := c.c.Items()
 	res := make([]string, 0, len(items))
 	for k := range items {
 		res = append(res, k)
 	}
-	return strings.Join(res, ",")
+	contents := strings.Join(res, ",")
+	if !c.compress || len(contents) < c.compressMinSize {
+		return contents
+	}
+	return compressContents(contents)
+}
+
+// compressContents compresses contents with gzip and encodes them in base64,
+// behind compressedContentsPrefix.
+func compressContents(contents string) string {
+	var buf bytes.Buffer
+	buf.WriteString(compressedContentsPrefix)
+
+	// Writing to a buffer can't fail.
+	encoder := base64.NewEncoder(base64.StdEncoding, &buf)
+	w := gzip.NewWriter(encoder)
+	_, _ = w.Write([]byte(contents))
+	_ = w.Close()
+	_ = encoder.Close()
+	return buf.String()
 }
@@ -104,6 +104,92 @@ func TestCache_NewWithData(t *testing.T) {
 	}
 }
 
+func TestCache_CompressedContents(t *testing.T) {
+	c := New[string](WithCompressedContents[string](0))
+	keys := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("%032x", i)
+		keys = append(keys, key)
+		c.Set(key, key)
+	}
+
+	contents := c.Contents()
+	if !strings.HasPrefix(contents, compressedContentsPrefix) {
+		t.Fatalf("Expected compressed contents: %q", contents)
+	}
+	if len(contents) >= len(keys)*33 {
+		t.Fatalf("Expected contents to be smaller once compressed: %d bytes", len(contents))
+	}
+
+	// Round trip the compressed contents.
+	loaded, err := NewFromContents(contents)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	res := loaded.Keys()
+	sort.Strings(res)
+	if !cmp.Equal(keys, res) {
+		t.Fatalf("Unexpected keys: %v", res)
+	}
+	if v, ok := loaded.Get(keys[0]); !ok || v != keys[0] {
+		t.Fatalf("Unexpected value for %s: %v, %v", keys[0], v, ok)
+	}
+}
+
+func TestCache_CompressedContentsMinSize(t *testing.T) {
+	c := New[string](WithCompressedContents[string](1024))
+	c.Set("key1", "key1")
+	c.Set("key2", "key2")
+
+	// Small contents are left uncompressed.
+	contents := c.Contents()
+	res := strings.Split(contents, ",")
+	sort.Strings(res)
+	if !cmp.Equal([]string{"key1", "key2"}, res) {
+		t.Fatalf("Unexpected contents: %q", contents)
+	}
+
+	for i := 0; i < 1024; i++ {
+		c.Set(fmt.Sprintf("key%d", i), "")
+	}
+	if !strings.HasPrefix(c.Contents(), compressedContentsPrefix) {
+		t.Fatalf("Expected large contents to be compressed")
+	}
+}
+
+func TestNewFromContents(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     []string
+		wantErr  bool
+	}{
+		{name: "empty", contents: "", want: nil},
+		{name: "uncompressed", contents: "key1,key2,key3", want: []string{"key1", "key2", "key3"}},
+		{name: "compressed", contents: compressContents("key1,key2,key3"), want: []string{"key1", "key2", "key3"}},
+		{name: "compressed empty", contents: compressContents(""), want: nil},
+		{name: "corrupt", contents: compressedContentsPrefix + "not gzip", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewFromContents(tt.contents)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			res := c.Keys()
+			sort.Strings(res)
+			if len(res) != len(tt.want) || (len(res) > 0 && !cmp.Equal(tt.want, res)) {
+				t.Fatalf("Unexpected keys: %v", res)
+			}
+		})
+	}
+}
+
 func setupBenchmarks(b *testing.B) *Cache[string] {
 	b.Helper()
 
@@ -0,0 +1,71 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"strings"
+)
+
+// BinarySniffSize is the number of bytes at the start of a file that are
+// inspected to decide whether the file is binary.
+const BinarySniffSize = 8 * 1024
+
+// IsBinaryContent returns true if the fraction of null bytes in the first
+// BinarySniffSize bytes of data is greater than threshold. A threshold of 0
+// treats any null byte as a sign of binary content.
+func IsBinaryContent(data []byte, threshold float64) bool {
+	if len(data) > BinarySniffSize {
+		data = data[:BinarySniffSize]
+	}
+	if len(data) == 0 {
+		return false
+	}
+	nullBytes := bytes.Count(data, []byte{0})
+	return float64(nullBytes)/float64(len(data)) > threshold
+}
+
+// SniffBinary reads the start of r to decide whether its content is binary
+// using IsBinaryContent. The returned reader yields the entire content of r,
+// including the bytes read to sniff it.
+func SniffBinary(r io.Reader, threshold float64) (bool, io.Reader, error) {
+	bufReader := bufio.NewReaderSize(r, BinarySniffSize)
+	head, err := bufReader.Peek(BinarySniffSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, bufReader, err
+	}
+	return IsBinaryContent(head, threshold), bufReader, nil
+}
+
+// IsBinaryContentType returns whether the MIME content type describes binary
+// content. ok is false if the content type is missing, invalid, or too generic
+// to tell, e.g. "application/octet-stream".
+func IsBinaryContentType(contentType string) (binary bool, ok bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false, false
+	}
+
+	mainType, subType, _ := strings.Cut(mediaType, "/")
+	switch mainType {
+	case "text":
+		return false, true
+	case "image", "audio", "video", "font":
+		return true, true
+	case "application":
+		switch {
+		case strings.HasSuffix(subType, "+json"), strings.HasSuffix(subType, "+xml"):
+			return false, true
+		}
+		switch subType {
+		case "json", "xml", "javascript", "x-javascript", "ecmascript", "yaml", "x-yaml",
+			"toml", "x-sh", "x-shellscript", "sql", "graphql", "x-www-form-urlencoded", "x-pem-file":
+			return false, true
+		case "wasm", "x-executable", "x-sharedlib", "x-mach-binary", "x-msdownload", "java-vm", "vnd.microsoft.portable-executable":
+			return true, true
+		}
+	}
+	return false, false
+}
@@ -0,0 +1,74 @@
+package common
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsBinaryContent(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      []byte
+		threshold float64
+		want      bool
+	}{
+		{name: "empty", data: nil, want: false},
+		{name: "utf-8 text", data: []byte("héllo wörld\nsecret = \"abc\"\n"), want: false},
+		{name: "null byte", data: []byte("abc\x00def"), want: true},
+		{name: "under threshold", data: []byte("abcdefghi\x00"), threshold: 0.2, want: false},
+		{name: "over threshold", data: []byte("abc\x00\x00\x00"), threshold: 0.2, want: true},
+		{
+			name: "null byte after sniffed data",
+			data: append(bytes.Repeat([]byte("a"), BinarySniffSize), 0),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsBinaryContent(tt.data, tt.threshold))
+		})
+	}
+}
+
+func TestSniffBinary(t *testing.T) {
+	content := strings.Repeat("line of text\n", BinarySniffSize)
+
+	binary, r, err := SniffBinary(strings.NewReader(content), 0)
+	assert.NoError(t, err)
+	assert.False(t, binary)
+
+	// The sniffed bytes are not consumed.
+	got, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(got))
+}
+
+func TestIsBinaryContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		wantBinary  bool
+		wantOk      bool
+	}{
+		{contentType: "text/plain; charset=utf-8", wantBinary: false, wantOk: true},
+		{contentType: "application/json", wantBinary: false, wantOk: true},
+		{contentType: "application/vnd.api+json", wantBinary: false, wantOk: true},
+		{contentType: "image/png", wantBinary: true, wantOk: true},
+		{contentType: "video/mp4", wantBinary: true, wantOk: true},
+		{contentType: "application/x-executable", wantBinary: true, wantOk: true},
+		{contentType: "application/octet-stream", wantBinary: false, wantOk: false},
+		{contentType: "", wantBinary: false, wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			binary, ok := IsBinaryContentType(tt.contentType)
+			assert.Equal(t, tt.wantBinary, binary)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
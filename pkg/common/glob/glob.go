@@ -2,6 +2,7 @@ package glob
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/gobwas/glob"
 )
@@ -44,6 +45,20 @@ func WithIncludeGlobs(includes ...string) globFilterOpt {
 	}
 }
 
+// WithExcludePathGlobs adds exclude globs for file paths to the filter. A
+// leading "**/" also matches paths at the root, so "**/*.min.js" excludes both
+// "app.min.js" and "static/app.min.js".
+func WithExcludePathGlobs(excludes ...string) globFilterOpt {
+	globs := make([]string, 0, len(excludes))
+	for _, exclude := range excludes {
+		globs = append(globs, exclude)
+		if rootGlob, ok := strings.CutPrefix(exclude, "**/"); ok {
+			globs = append(globs, rootGlob)
+		}
+	}
+	return WithExcludeGlobs(globs...)
+}
+
 // NewGlobFilter creates a new Filter with the provided options.
 func NewGlobFilter(opts ...globFilterOpt) (*Filter, error) {
 	filter := &Filter{}
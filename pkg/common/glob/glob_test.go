@@ -95,6 +95,21 @@ func TestGlobFilterExcludePrecedence(t *testing.T) {
 	)
 }
 
+func TestGlobFilterExcludePaths(t *testing.T) {
+	filter, err := NewGlobFilter(WithExcludePathGlobs("vendor/**", "**/*.min.js"))
+	assert.NoError(t, err)
+
+	testGlobs(t, filter,
+		globTest{"vendor/", false},
+		globTest{"vendor/github.com/foo/bar.go", false},
+		globTest{"app.min.js", false},
+		globTest{"static/js/app.min.js", false},
+		globTest{"app.js", true},
+		globTest{"static/js/app.js", true},
+		globTest{"internal/vendor.go", true},
+	)
+}
+
 func TestGlobErrorContainsGlob(t *testing.T) {
 	invalidGlob := "[this is invalid because it doesn't close the capture group"
 	_, err := NewGlobFilter(WithExcludeGlobs(invalidGlob))
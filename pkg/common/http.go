@@ -1,6 +1,7 @@
 package common
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"io"
@@ -89,13 +90,49 @@ type CustomTransport struct {
 }
 
 func (t *CustomTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	req.Header.Add("User-Agent", "TruffleHog")
+	req.Header.Add("User-Agent", UserAgent())
+	if m, ok := req.Context().Value(requestMiddlewareKey{}).(RequestMiddleware); ok {
+		return m(t.T).RoundTrip(req)
+	}
 	return t.T.RoundTrip(req)
 }
 
+// RoundTripperFunc is a function that can be used as an http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// RequestMiddleware wraps the transport of a request, e.g. to wait before
+// the request is sent.
+type RequestMiddleware func(next http.RoundTripper) http.RoundTripper
+
+type requestMiddlewareKey struct{}
+
+// WithRequestMiddleware returns a copy of ctx whose requests sent by the HTTP
+// clients of this package go through m. This lets callers of detectors, which
+// make their verification requests with these clients, act on each request
+// rather than on each call. Middleware added later wraps the one added before.
+func WithRequestMiddleware(ctx context.Context, m RequestMiddleware) context.Context {
+	if inner, ok := ctx.Value(requestMiddlewareKey{}).(RequestMiddleware); ok {
+		outer := m
+		m = func(next http.RoundTripper) http.RoundTripper { return outer(inner(next)) }
+	}
+	return context.WithValue(ctx, requestMiddlewareKey{}, m)
+}
+
+// defaultTransport is http.DefaultTransport, with the proxy set by
+// WithHTTPProxy.
+var defaultTransport = func() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = proxyFromContext
+	return t
+}()
+
 func NewCustomTransport(T http.RoundTripper) *CustomTransport {
 	if T == nil {
-		T = http.DefaultTransport
+		T = defaultTransport
 	}
 	return &CustomTransport{T}
 }
@@ -155,7 +192,7 @@ func PinnedRetryableHttpClient() *http.Client {
 		TLSClientConfig: &tls.Config{
 			RootCAs: PinnedCertPool(),
 		},
-		Proxy: http.ProxyFromEnvironment,
+		Proxy: proxyFromContext,
 		DialContext: (&net.Dialer{
 			Timeout:   30 * time.Second,
 			KeepAlive: 30 * time.Second,
@@ -197,7 +234,7 @@ func RetryableHTTPClientTimeout(timeOutSeconds int64, opts ...ClientOption) *htt
 const DefaultResponseTimeout = 5 * time.Second
 
 var saneTransport = &http.Transport{
-	Proxy: http.ProxyFromEnvironment,
+	Proxy: proxyFromContext,
 	DialContext: (&net.Dialer{
 		Timeout:   2 * time.Second,
 		KeepAlive: 5 * time.Second,
@@ -222,3 +259,56 @@ func SaneHttpClientTimeOut(timeout time.Duration) *http.Client {
 	httpClient.Transport = NewCustomTransport(nil)
 	return httpClient
 }
+
+// RetryableSaneHttpClient returns a client like SaneHttpClient that retries
+// requests which are rate limited or fail with a server error. It backs off
+// exponentially between attempts, waiting as long as a Retry-After header
+// requests up to the maximum wait. Once the retries are exhausted, the last
+// response is returned as is.
+//
+// Detectors whose verification APIs are rate limited or flaky can use it so
+// that transient failures don't leave their findings unverified.
+func RetryableSaneHttpClient(opts ...ClientOption) *http.Client {
+	httpClient := retryablehttp.NewClient()
+	httpClient.RetryMax = 3
+	httpClient.RetryWaitMin = 1 * time.Second
+	httpClient.RetryWaitMax = 5 * time.Second
+	httpClient.Logger = nil
+	httpClient.CheckRetry = retryRateLimitedOrServerError
+	httpClient.Backoff = cappedRetryAfterBackoff
+	httpClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	httpClient.HTTPClient.Timeout = DefaultResponseTimeout
+	httpClient.HTTPClient.Transport = NewCustomTransport(saneTransport)
+
+	for _, opt := range opts {
+		opt(httpClient)
+	}
+	return httpClient.StandardClient()
+}
+
+// retryRateLimitedOrServerError retries responses that are rate limited or
+// failed with a server error. Requests that fail without a response are not
+// retried.
+func retryRateLimitedOrServerError(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true, nil
+	case resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// cappedRetryAfterBackoff is retryablehttp.DefaultBackoff, except that it
+// never waits longer than waitMax, even if a Retry-After header asks for it.
+func cappedRetryAfterBackoff(waitMin, waitMax time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	return min(retryablehttp.DefaultBackoff(waitMin, waitMax, attemptNum, resp), waitMax)
+}
@@ -243,3 +243,117 @@ func TestRetryableHTTPClientBackoff(t *testing.T) {
 		})
 	}
 }
+
+func TestRetryableSaneHttpClient(t *testing.T) {
+	testCases := []struct {
+		name             string
+		responseStatuses []int
+		retryAfter       string
+		expectedStatus   int
+		expectedRequests int
+	}{
+		{
+			name:             "Retry on 429 status until success",
+			responseStatuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:       "0",
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 2,
+		},
+		{
+			name:             "Retry on 5xx status until success",
+			responseStatuses: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 3,
+		},
+		{
+			name:             "Return last response after 3 retries",
+			responseStatuses: []int{http.StatusInternalServerError},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedRequests: 4,
+		},
+		{
+			name:             "Cap Retry-After at the maximum wait",
+			responseStatuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:       "3600",
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 2,
+		},
+		{
+			name:             "No retry on 4xx status",
+			responseStatuses: []int{http.StatusUnauthorized, http.StatusOK},
+			expectedStatus:   http.StatusUnauthorized,
+			expectedRequests: 1,
+		},
+		{
+			name:             "No retry on 501 status",
+			responseStatuses: []int{http.StatusNotImplemented, http.StatusOK},
+			expectedStatus:   http.StatusNotImplemented,
+			expectedRequests: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tc.responseStatuses[min(requests, len(tc.responseStatuses)-1)]
+				requests++
+				if tc.retryAfter != "" {
+					w.Header().Set("Retry-After", tc.retryAfter)
+				}
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			client := RetryableSaneHttpClient(
+				WithRetryWaitMin(1*time.Millisecond),
+				WithRetryWaitMax(10*time.Millisecond),
+			)
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+			assert.NoError(t, err)
+
+			resp, err := client.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tc.expectedRequests, requests)
+		})
+	}
+}
+
+func TestWithRequestMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var calls []string
+	middleware := func(name string) RequestMiddleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	ctx := WithRequestMiddleware(context.Background(), middleware("inner"))
+	ctx = WithRequestMiddleware(ctx, middleware("outer"))
+	client := SaneHttpClient()
+	for _, reqCtx := range []context.Context{ctx, context.Background()} {
+		req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, server.URL, nil)
+		assert.NoError(t, err)
+		resp, err := client.Do(req)
+		assert.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	// Only the request with the context went through the middleware.
+	assert.Equal(t, []string{"outer", "inner"}, calls)
+}
@@ -0,0 +1,96 @@
+// Package ignore matches paths against the patterns of .trufflehogignore
+// files, which list the paths of a repository or directory tree to skip with
+// the syntax and semantics of .gitignore files: patterns are relative to the
+// directory of their ignore file and only apply within it, a trailing slash
+// only matches directories, a leading slash anchors the pattern to the
+// directory, "**" matches any number of directories, and a leading "!"
+// re-includes paths excluded by an earlier pattern.
+package ignore
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+// FileName is the name of ignore files.
+const FileName = ".trufflehogignore"
+
+// Matcher matches slash-separated paths, relative to the root of the tree its
+// ignore files were read from. A nil Matcher ignores nothing.
+type Matcher struct {
+	// patterns are in increasing order of priority.
+	patterns []gitignore.Pattern
+}
+
+// Add adds the patterns of the ignore file read from r, found in dir, a
+// slash-separated path relative to the root of the tree. Ignore files have
+// precedence over those added before them, so they must be added from the
+// root of the tree down.
+func (m *Matcher) Add(dir string, r io.Reader) error {
+	var domain []string
+	if dir = path.Clean(dir); dir != "." {
+		domain = strings.Split(strings.TrimPrefix(dir, "/"), "/")
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+		m.patterns = append(m.patterns, gitignore.ParsePattern(line, domain))
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading %s: %w", path.Join(dir, FileName), err)
+	}
+	return nil
+}
+
+// AddDir adds the patterns of the ignore file of dir in fsys, if it has one.
+func (m *Matcher) AddDir(fsys fs.FS, dir string) error {
+	f, err := fsys.Open(path.Join(dir, FileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", path.Join(dir, FileName), err)
+	}
+	defer f.Close()
+
+	if info, err := f.Stat(); err == nil && !info.Mode().IsRegular() {
+		return nil
+	}
+	return m.Add(dir, f)
+}
+
+// Len returns the number of patterns of the Matcher.
+func (m *Matcher) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.patterns)
+}
+
+// Match returns true if the path, a directory if isDir is set, is ignored.
+// Like with .gitignore files, the paths within an ignored directory are
+// ignored, even if a later pattern re-includes them.
+func (m *Matcher) Match(p string, isDir bool) bool {
+	if m.Len() == 0 {
+		return false
+	}
+	parts := strings.Split(strings.TrimPrefix(path.Clean(p), "/"), "/")
+	matcher := gitignore.NewMatcher(m.patterns)
+	for i := 1; i < len(parts); i++ {
+		if matcher.Match(parts[:i], true) {
+			return true
+		}
+	}
+	return matcher.Match(parts, isDir)
+}
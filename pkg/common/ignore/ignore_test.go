@@ -0,0 +1,74 @@
+package ignore
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcher(t *testing.T) {
+	fsys := fstest.MapFS{
+		FileName: {Data: []byte(`# build outputs
+build/
+*.log
+!keep.log
+/root-only.txt
+docs/**/*.pem
+`)},
+		"app/" + FileName:   {Data: []byte("fixtures\r\n!/secret.log\n")},
+		"app/fixtures":      {Data: []byte("not a directory")},
+		"empty/" + FileName: {Data: []byte("\n# only comments\n")},
+	}
+	m := new(Matcher)
+	for _, dir := range []string{".", "app", "empty", "missing"} {
+		require.NoError(t, m.AddDir(fsys, dir))
+	}
+	assert.Equal(t, 7, m.Len())
+
+	tests := []struct {
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		{path: "build", isDir: true, ignored: true},
+		{path: "build/out/config.env", ignored: true},
+		{path: "src/build/config.env", ignored: true},
+		// Only directories match a trailing slash.
+		{path: "build"},
+		{path: "debug.log", ignored: true},
+		{path: "app/logs/debug.log", ignored: true},
+		{path: "keep.log"},
+		{path: "root-only.txt", ignored: true},
+		{path: "app/root-only.txt"},
+		{path: "docs/a/b/key.pem", ignored: true},
+		{path: "key.pem"},
+		// Nested ignore files only apply within their directory.
+		{path: "app/fixtures", ignored: true},
+		{path: "app/fixtures/aws.json", ignored: true},
+		{path: "fixtures/aws.json"},
+		{path: "app/secret.log"},
+		// Paths in ignored directories can't be re-included.
+		{path: "build/keep.log", ignored: true},
+		{path: "config.env"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.ignored, m.Match(tc.path, tc.isDir))
+		})
+	}
+}
+
+func TestMatcher_Nil(t *testing.T) {
+	var m *Matcher
+	assert.Zero(t, m.Len())
+	assert.False(t, m.Match("config.env", false))
+}
+
+func TestMatcher_AddReadError(t *testing.T) {
+	m := new(Matcher)
+	err := m.Add("app", strings.NewReader(strings.Repeat("x", 1<<17)))
+	assert.ErrorContains(t, err, "app/"+FileName)
+}
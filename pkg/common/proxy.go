@@ -0,0 +1,61 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+type httpProxyKey struct{}
+
+// WithHTTPProxy returns a copy of ctx whose requests sent by the HTTP clients
+// of this package, such as the ones detectors verify secrets with, go through
+// the proxy returned by proxy, e.g. ProxyFunc, rather than the proxy
+// configured by the environment.
+func WithHTTPProxy(ctx context.Context, proxy func(*http.Request) (*url.URL, error)) context.Context {
+	return context.WithValue(ctx, httpProxyKey{}, proxy)
+}
+
+// proxyFromContext returns the proxy to use for a request, which is the one
+// of its context set by WithHTTPProxy, if any, or the one configured by the
+// environment.
+func proxyFromContext(req *http.Request) (*url.URL, error) {
+	if proxy, ok := req.Context().Value(httpProxyKey{}).(func(*http.Request) (*url.URL, error)); ok {
+		return proxy(req)
+	}
+	return http.ProxyFromEnvironment(req)
+}
+
+// ProxyFunc returns a function to use as the Proxy of an http.Transport which
+// sends all the requests through proxy, except the ones to hosts matching
+// NO_PROXY. Like http.ProxyFromEnvironment, requests to localhost are never
+// proxied.
+func ProxyFunc(proxy *url.URL) func(*http.Request) (*url.URL, error) {
+	conf := httpproxy.FromEnvironment()
+	conf.HTTPProxy = proxy.String()
+	conf.HTTPSProxy = proxy.String()
+	fn := conf.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return fn(req.URL)
+	}
+}
+
+// ParseProxyURL parses the URL of an HTTP, HTTPS or SOCKS5 proxy.
+func ParseProxyURL(rawURL string) (*url.URL, error) {
+	proxy, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch proxy.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: the scheme must be http, https or socks5", rawURL)
+	}
+	if proxy.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: missing host", rawURL)
+	}
+	return proxy, nil
+}
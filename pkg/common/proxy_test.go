@@ -0,0 +1,77 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithHTTPProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer proxy.Close()
+	direct := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer direct.Close()
+
+	proxyURL, err := ParseProxyURL(proxy.URL)
+	require.NoError(t, err)
+	ctx := WithHTTPProxy(context.Background(), ProxyFunc(proxyURL))
+
+	for _, client := range []*http.Client{SaneHttpClient(), RetryableHTTPClient(), PinnedRetryableHttpClient()} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://verify.test/token", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		// Requests without the proxy in their context aren't proxied.
+		resp, err = client.Get(direct.URL)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	assert.Equal(t, []string{"http://verify.test/token", "http://verify.test/token", "http://verify.test/token"}, proxied)
+}
+
+func TestProxyFunc_NoProxy(t *testing.T) {
+	t.Setenv("NO_PROXY", ".googleapis.com")
+	proxy := &url.URL{Scheme: "http", Host: "proxy.test:3128"}
+	fn := ProxyFunc(proxy)
+
+	for target, want := range map[string]*url.URL{
+		"https://storage.googleapis.com/storage/v1/b": nil,
+		"https://verify.test/token":                   proxy,
+		"http://verify.test/token":                    proxy,
+	} {
+		req, err := http.NewRequest(http.MethodGet, target, nil)
+		require.NoError(t, err)
+		got, err := fn(req)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got, target)
+	}
+}
+
+func TestParseProxyURL(t *testing.T) {
+	for rawURL, wantErr := range map[string]bool{
+		"http://proxy.test:3128":           false,
+		"https://proxy.test":               false,
+		"socks5://user:pw@proxy.test:1080": false,
+		"ftp://proxy.test":                 true,
+		"proxy.test:3128":                  true,
+		"http://":                          true,
+	} {
+		_, err := ParseProxyURL(rawURL)
+		assert.Equal(t, wantErr, err != nil, rawURL)
+	}
+}
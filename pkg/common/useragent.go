@@ -0,0 +1,30 @@
+package common
+
+import "sync/atomic"
+
+// DefaultUserAgent is the User-Agent sent unless SetUserAgent was called.
+const DefaultUserAgent = "TruffleHog"
+
+// userAgent is the User-Agent of the requests sent by the HTTP clients
+// created by this package. See SetUserAgent.
+var userAgent atomic.Pointer[string]
+
+// SetUserAgent sets the User-Agent of the requests sent by the HTTP clients
+// created by this package, such as the ones detectors verify secrets with, and
+// by the sources that support it, e.g. GCS. It is meant to be called once,
+// before scanning. An empty User-Agent restores DefaultUserAgent.
+func SetUserAgent(ua string) {
+	if ua == "" {
+		userAgent.Store(nil)
+		return
+	}
+	userAgent.Store(&ua)
+}
+
+// UserAgent returns the User-Agent set by SetUserAgent, or DefaultUserAgent.
+func UserAgent() string {
+	if ua := userAgent.Load(); ua != nil {
+		return *ua
+	}
+	return DefaultUserAgent
+}
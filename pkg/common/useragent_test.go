@@ -0,0 +1,42 @@
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetUserAgent(t *testing.T) {
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("User-Agent"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	get := func(client *http.Client) {
+		t.Helper()
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	get(SaneHttpClient())
+	assert.Equal(t, []string{DefaultUserAgent}, got)
+
+	got = nil
+	SetUserAgent("acme-scanner/1.0")
+	defer SetUserAgent("")
+	for _, client := range []*http.Client{SaneHttpClient(), RetryableHTTPClient(), RetryableSaneHttpClient()} {
+		get(client)
+	}
+	assert.Equal(t, []string{"acme-scanner/1.0", "acme-scanner/1.0", "acme-scanner/1.0"}, got)
+
+	got = nil
+	SetUserAgent("")
+	get(SaneHttpClient())
+	assert.Equal(t, []string{DefaultUserAgent}, got)
+}
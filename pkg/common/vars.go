@@ -58,7 +58,7 @@ var (
 		"wmv":  {},
 
 		// documents
-		"pdf": {},
+		// PDFs aren't skipped, their text is extracted by the pdf handler.
 		"psd": {},
 
 		// fonts
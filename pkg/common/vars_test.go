@@ -25,8 +25,9 @@ func TestSkipFile(t *testing.T) {
 		})
 	}
 
-	// Add a test case for a file that should not be skipped.
+	// Add test cases for files that should not be skipped.
 	testCases = append(testCases, testCase{file: "test.txt", want: false})
+	testCases = append(testCases, testCase{file: "test.pdf", want: false})
 
 	for _, tt := range testCases {
 		t.Run(tt.file, func(t *testing.T) {
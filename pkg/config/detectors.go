@@ -116,6 +116,28 @@ func ParseVerifierEndpoints(verifierURLs map[string]string) (map[DetectorID][]st
 	return verifiers, nil
 }
 
+// ParseEntropyThresholds parses a map of user supplied entropy thresholds. The
+// input keys are detector types, which apply to all their versions, and the
+// values are minimum Shannon entropies in bits per character.
+func ParseEntropyThresholds(thresholds map[string]string) (map[dpb.DetectorType]float64, error) {
+	parsed := make(map[dpb.DetectorType]float64, len(thresholds))
+	for detectorID, rawThreshold := range thresholds {
+		key, err := ParseDetector(detectorID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid detector ID for entropy threshold: %w", err)
+		}
+		if key.Version != 0 {
+			return nil, fmt.Errorf("entropy threshold applies to all versions of detector %q", detectorID)
+		}
+		threshold, err := strconv.ParseFloat(strings.TrimSpace(rawThreshold), 64)
+		if err != nil || threshold < 0 {
+			return nil, fmt.Errorf("invalid entropy threshold %q, must be a non-negative number", rawThreshold)
+		}
+		parsed[key.ID] = threshold
+	}
+	return parsed, nil
+}
+
 func (id DetectorID) String() string {
 	name := dpb.DetectorType_name[int32(id.ID)]
 	if name == "" {
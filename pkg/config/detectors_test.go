@@ -45,3 +45,31 @@ func TestDetectorParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestParseEntropyThresholds(t *testing.T) {
+	tests := map[string]struct {
+		input    map[string]string
+		expected map[dpb.DetectorType]float64
+	}{
+		"named":           {map[string]string{"GenericEntropy": "4.2"}, map[dpb.DetectorType]float64{dpb.DetectorType_GenericEntropy: 4.2}},
+		"id and spaces":   {map[string]string{" 8 ": " 3 ", "aws": "0"}, map[dpb.DetectorType]float64{dpb.DetectorType_Github: 3, dpb.DetectorType_AWS: 0}},
+		"empty":           {map[string]string{}, map[dpb.DetectorType]float64{}},
+		"invalid name":    {map[string]string{"foo": "3"}, nil},
+		"with version":    {map[string]string{"github.v2": "3"}, nil},
+		"not a number":    {map[string]string{"github": "high"}, nil},
+		"negative":        {map[string]string{"github": "-1"}, nil},
+		"empty threshold": {map[string]string{"github": ""}, nil},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ParseEntropyThresholds(tt.input)
+			if tt.expected == nil {
+				assert.Error(t, gotErr)
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package detectors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Confidence is how likely the unverified results of a detector are to be real secrets.
+// Confidences are ordered, so results can be filtered by a minimum confidence.
+type Confidence int
+
+const (
+	// ConfidenceLow is for detectors whose patterns often match values that aren't secrets,
+	// e.g. generic high-entropy strings.
+	ConfidenceLow Confidence = iota + 1
+	// ConfidenceMedium is the default confidence of detectors.
+	ConfidenceMedium
+	// ConfidenceHigh is for detectors whose patterns rarely match anything but secrets,
+	// e.g. keys with a distinctive prefix and checksum.
+	ConfidenceHigh
+)
+
+// DefaultConfidence is the confidence of detectors that don't implement ConfidenceProvider.
+const DefaultConfidence = ConfidenceMedium
+
+// ConfidenceProvider is an optional interface that a detector can implement to
+// provide the confidence of its results when it differs from DefaultConfidence.
+type ConfidenceProvider interface {
+	Confidence() Confidence
+}
+
+// GetConfidence returns the confidence of the results of the detector.
+func GetConfidence(d Detector) Confidence {
+	if p, ok := d.(ConfidenceProvider); ok {
+		return p.Confidence()
+	}
+	return DefaultConfidence
+}
+
+// ParseConfidence parses the name of a confidence, as returned by Confidence.String.
+func ParseConfidence(s string) (Confidence, error) {
+	switch strings.ToLower(s) {
+	case "low":
+		return ConfidenceLow, nil
+	case "medium":
+		return ConfidenceMedium, nil
+	case "high":
+		return ConfidenceHigh, nil
+	default:
+		return 0, fmt.Errorf("invalid confidence %q, must be one of low, medium, or high", s)
+	}
+}
+
+func (c Confidence) String() string {
+	switch c {
+	case ConfidenceLow:
+		return "low"
+	case ConfidenceMedium:
+		return "medium"
+	case ConfidenceHigh:
+		return "high"
+	default:
+		return ""
+	}
+}
+
+// MarshalText encodes the confidence as its name, so it appears as a string in JSON output.
+func (c Confidence) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
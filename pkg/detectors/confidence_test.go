@@ -0,0 +1,41 @@
+package detectors
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// highConfidenceDetector is a verificationErrorDetector with high confidence.
+type highConfidenceDetector struct{ verificationErrorDetector }
+
+func (highConfidenceDetector) Confidence() Confidence { return ConfidenceHigh }
+
+func TestGetConfidence(t *testing.T) {
+	assert.Equal(t, DefaultConfidence, GetConfidence(verificationErrorDetector{}))
+	assert.Equal(t, ConfidenceHigh, GetConfidence(highConfidenceDetector{}))
+}
+
+func TestParseConfidence(t *testing.T) {
+	for _, c := range []Confidence{ConfidenceLow, ConfidenceMedium, ConfidenceHigh} {
+		got, err := ParseConfidence(c.String())
+		assert.NoError(t, err)
+		assert.Equal(t, c, got)
+	}
+
+	got, err := ParseConfidence("HIGH")
+	assert.NoError(t, err)
+	assert.Equal(t, ConfidenceHigh, got)
+
+	_, err = ParseConfidence("certain")
+	assert.Error(t, err)
+}
+
+func TestConfidence_MarshalJSON(t *testing.T) {
+	out, err := json.Marshal(Result{DetectorType: detectorspb.DetectorType(-1), Confidence: ConfidenceHigh})
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `"Confidence":"high"`)
+}
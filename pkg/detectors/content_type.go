@@ -0,0 +1,83 @@
+package detectors
+
+import (
+	"mime"
+	"slices"
+	"strings"
+)
+
+// ContentTypeProvider is an optional interface that a detector can implement to
+// declare the content it can find secrets in, so it can be skipped for chunks of
+// other content, e.g. images. See AppliesToContent.
+type ContentTypeProvider interface {
+	// ContentTypes returns the media types of the content, e.g. "application/json".
+	// The subtype may be a wildcard, e.g. "text/*".
+	ContentTypes() []string
+	// FileExtensions returns the extensions of the files, e.g. ".pem". Files with these
+	// extensions are scanned whatever their media type.
+	FileExtensions() []string
+}
+
+// genericMediaTypes don't tell what the content is, so they are treated as unknown.
+var genericMediaTypes = map[string]struct{}{
+	"application/octet-stream": {},
+	"binary/octet-stream":      {},
+	"application/unknown":      {},
+}
+
+// AppliesToContent returns whether the detector can find secrets in content of the
+// media type and file extension, either of which may be empty if unknown. If the media
+// type is unknown, it is determined from the extension. Detectors that don't implement
+// ContentTypeProvider apply to all content, and all detectors apply to content whose
+// media type can't be determined.
+func AppliesToContent(d Detector, contentType, extension string) bool {
+	p, ok := d.(ContentTypeProvider)
+	if !ok {
+		return true
+	}
+
+	extension = strings.ToLower(extension)
+	if extension != "" && slices.Contains(p.FileExtensions(), extension) {
+		return true
+	}
+
+	mediaType := parseMediaType(contentType)
+	if mediaType == "" && extension != "" {
+		mediaType = parseMediaType(mime.TypeByExtension(extension))
+	}
+	if mediaType == "" {
+		return true
+	}
+
+	for _, pattern := range p.ContentTypes() {
+		if matchesMediaType(pattern, mediaType) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMediaType returns the lowercase media type of a content type without its
+// parameters, or an empty string if it is invalid or generic.
+func parseMediaType(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	if _, ok := genericMediaTypes[mediaType]; ok {
+		return ""
+	}
+	return mediaType
+}
+
+func matchesMediaType(pattern, mediaType string) bool {
+	pattern = strings.ToLower(pattern)
+	if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+		typ, _, _ := strings.Cut(mediaType, "/")
+		return typ == prefix
+	}
+	return pattern == mediaType
+}
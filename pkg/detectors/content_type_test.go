@@ -0,0 +1,55 @@
+package detectors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// pemDetector only applies to text and PEM files.
+type pemDetector struct{}
+
+func (pemDetector) FromData(context.Context, bool, []byte) ([]Result, error) { return nil, nil }
+func (pemDetector) Keywords() []string                                       { return []string{"private key"} }
+func (pemDetector) Type() detectorspb.DetectorType                           { return detectorspb.DetectorType_PrivateKey }
+func (pemDetector) ContentTypes() []string                                   { return []string{"text/*", "application/x-pem-file"} }
+func (pemDetector) FileExtensions() []string                                 { return []string{".pem", ".key"} }
+
+// anyContentDetector doesn't declare the content it applies to.
+type anyContentDetector struct{}
+
+func (anyContentDetector) FromData(context.Context, bool, []byte) ([]Result, error) { return nil, nil }
+func (anyContentDetector) Keywords() []string                                       { return []string{"secret"} }
+func (anyContentDetector) Type() detectorspb.DetectorType                           { return detectorspb.DetectorType_PrivateKey }
+
+func TestAppliesToContent(t *testing.T) {
+	tests := []struct {
+		name        string
+		detector    Detector
+		contentType string
+		extension   string
+		want        bool
+	}{
+		{name: "image extension", detector: pemDetector{}, extension: ".png", want: false},
+		{name: "image content type", detector: pemDetector{}, contentType: "image/png", want: false},
+		{name: "unknown content", detector: pemDetector{}, want: true},
+		{name: "unknown extension", detector: pemDetector{}, extension: ".unknownext", want: true},
+		{name: "generic content type", detector: pemDetector{}, contentType: "application/octet-stream", extension: ".bin", want: true},
+		{name: "invalid content type", detector: pemDetector{}, contentType: "not a media type", want: true},
+		{name: "declared extension", detector: pemDetector{}, contentType: "application/octet-stream", extension: ".PEM", want: true},
+		{name: "declared extension overrides content type", detector: pemDetector{}, contentType: "image/png", extension: ".key", want: true},
+		{name: "wildcard content type", detector: pemDetector{}, contentType: "text/plain; charset=utf-8", want: true},
+		{name: "exact content type", detector: pemDetector{}, contentType: "Application/X-PEM-File", want: true},
+		{name: "content type from extension", detector: pemDetector{}, extension: ".html", want: true},
+		{name: "detector without declared content", detector: anyContentDetector{}, extension: ".png", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, AppliesToContent(tt.detector, tt.contentType, tt.extension))
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package dbconnection
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	regexp "github.com/wasilibs/go-re2"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/auth"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// Scanner finds the connection strings of databases that embed a password:
+// the postgres://, mysql://, mongodb://, mongodb+srv://, redis://, and
+// rediss:// URLs, and the DSNs of the Go MySQL driver, such as
+// user:password@tcp(host:3306)/db. The host, user, and database of the
+// connection strings are reported in the extra data of the results, and the
+// normalized connection strings are their raw secrets.
+//
+// It isn't one of the default detectors, since the postgres, mongodb, redis,
+// jdbc and uri detectors find most of the same connection strings.
+type Scanner struct {
+	// VerifyConnections verifies the connection strings by connecting to
+	// their databases and pinging them, without running any query. It's off
+	// by default, since it connects to the hosts found in the data.
+	VerifyConnections bool
+
+	timeout time.Duration // Zero value means "default timeout"
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+
+const defaultTimeout = 5 * time.Second
+
+// The dialects of the connection strings.
+const (
+	dialectPostgres = "postgres"
+	dialectMySQL    = "mysql"
+	dialectMongoDB  = "mongodb"
+	dialectRedis    = "redis"
+)
+
+var (
+	urlPat      = regexp.MustCompile(`(?i)\b(?:postgres(?:ql)?|mysql|mongodb(?:\+srv)?|rediss?)://[^\s'"<>` + "`" + `]+`)
+	mysqlDSNPat = regexp.MustCompile(`[^\s'"<>:@/` + "`" + `]+:[^\s'"<>@` + "`" + `]+@tcp\([^\s()]+\)/[^\s'"<>?` + "`" + `]*(?:\?[^\s'"<>` + "`" + `]*)?`)
+)
+
+// connection is a connection string found in the data.
+type connection struct {
+	raw string
+	// normalized is the connection string with its scheme and host in lower
+	// case for URLs, or formatted by the driver for MySQL DSNs.
+	normalized string
+	// redacted is the normalized connection string without its password.
+	redacted string
+	dialect  string
+	host     string
+	user     string
+	password string
+	database string
+	// url is the URL of the connection string, if it's one.
+	url *url.URL
+	// mysqlConfig is the configuration of a MySQL connection string.
+	mysqlConfig *mysql.Config
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"postgres", "mysql", "mongodb", "redis", "@tcp("}
+}
+
+// FromData will find and optionally verify database connection strings in a
+// given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	seen := make(map[string]struct{})
+	var conns []connection
+	for _, match := range urlPat.FindAllString(dataStr, -1) {
+		if conn, ok := parseURL(match); ok {
+			conns = append(conns, conn)
+		}
+	}
+	for _, match := range mysqlDSNPat.FindAllString(dataStr, -1) {
+		if conn, ok := parseMySQLDSN(match); ok {
+			conns = append(conns, conn)
+		}
+	}
+
+	for _, conn := range conns {
+		if _, ok := seen[conn.normalized]; ok {
+			continue
+		}
+		seen[conn.normalized] = struct{}{}
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_DatabaseConnectionString,
+			Raw:          []byte(conn.normalized),
+			RawV2:        []byte(conn.raw),
+			Redacted:     conn.redacted,
+			ExtraData: map[string]string{
+				"dialect": conn.dialect,
+				"host":    conn.host,
+				"user":    conn.user,
+			},
+		}
+		if conn.database != "" {
+			s1.ExtraData["database"] = conn.database
+		}
+
+		if verify && s.VerifyConnections {
+			timeout := s.timeout
+			if timeout == 0 {
+				timeout = defaultTimeout
+			}
+			verified, verificationErr := verifyConnection(ctx, conn, timeout)
+			s1.Verified = verified
+			s1.SetVerificationError(verificationErr, conn.password)
+		}
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// parseURL parses a connection string URL, which must have a password.
+func parseURL(match string) (connection, bool) {
+	// Connection strings are often followed by punctuation, e.g. in prose or
+	// in the arguments of a function.
+	match = strings.TrimRight(match, ".,;:)]}")
+	u, err := url.Parse(match)
+	if err != nil || u.User == nil || u.Host == "" {
+		return connection{}, false
+	}
+	password, ok := u.User.Password()
+	if !ok || !isPassword(password) {
+		return connection{}, false
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	conn := connection{
+		raw:        match,
+		normalized: u.String(),
+		redacted:   detectors.RedactURL(*u),
+		host:       u.Host,
+		user:       u.User.Username(),
+		password:   password,
+		database:   strings.TrimPrefix(u.Path, "/"),
+		url:        u,
+	}
+	switch {
+	case strings.HasPrefix(u.Scheme, "postgres"):
+		conn.dialect = dialectPostgres
+	case u.Scheme == "mysql":
+		conn.dialect = dialectMySQL
+		conn.mysqlConfig = mysql.NewConfig()
+		conn.mysqlConfig.User = conn.user
+		conn.mysqlConfig.Passwd = password
+		conn.mysqlConfig.Net = "tcp"
+		conn.mysqlConfig.Addr = hostWithPort(u.Host, "3306")
+		conn.mysqlConfig.DBName = conn.database
+	case strings.HasPrefix(u.Scheme, "mongodb"):
+		conn.dialect = dialectMongoDB
+	default:
+		conn.dialect = dialectRedis
+	}
+	return conn, true
+}
+
+// parseMySQLDSN parses a DSN of the Go MySQL driver, which must have a
+// password.
+func parseMySQLDSN(match string) (connection, bool) {
+	cfg, err := mysql.ParseDSN(match)
+	if err != nil || !isPassword(cfg.Passwd) {
+		return connection{}, false
+	}
+	redacted := cfg.Clone()
+	redacted.Passwd = "********"
+	return connection{
+		raw:         match,
+		normalized:  cfg.FormatDSN(),
+		redacted:    redacted.FormatDSN(),
+		dialect:     dialectMySQL,
+		host:        cfg.Addr,
+		user:        cfg.User,
+		password:    cfg.Passwd,
+		database:    cfg.DBName,
+		mysqlConfig: cfg,
+	}, true
+}
+
+// isPassword returns whether the password of a connection string could be a
+// real one, rather than a redacted password or a template variable.
+func isPassword(password string) bool {
+	switch {
+	case password == "", strings.Trim(password, "*x") == "":
+		return false
+	case strings.HasPrefix(password, "$"), strings.HasPrefix(password, "{{"), strings.HasPrefix(password, "<"):
+		return false
+	default:
+		return true
+	}
+}
+
+func hostWithPort(host, port string) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// verifyConnection authenticates to the database of the connection string.
+// The error is only returned if whether the credentials are valid couldn't
+// be determined, e.g. because the database is unreachable.
+func verifyConnection(ctx context.Context, conn connection, timeout time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	switch conn.dialect {
+	case dialectPostgres:
+		return verifyPostgres(ctx, conn.url)
+	case dialectMySQL:
+		return verifyMySQL(ctx, conn.mysqlConfig, timeout)
+	case dialectMongoDB:
+		return verifyMongoDB(ctx, conn.url, timeout)
+	case dialectRedis:
+		return verifyRedis(conn.url, timeout)
+	default:
+		return false, fmt.Errorf("unsupported dialect %q", conn.dialect)
+	}
+}
+
+func verifyPostgres(ctx context.Context, u *url.URL) (bool, error) {
+	connector, err := pq.NewConnector(u.String())
+	if err != nil {
+		return false, err
+	}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	err = db.PingContext(ctx)
+	var pqErr *pq.Error
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.As(err, &pqErr) && pqErr.Code.Class() == "28":
+		// invalid_authorization_specification or invalid_password.
+		return false, nil
+	case errors.As(err, &pqErr) && pqErr.Code == "3D000":
+		// invalid_catalog_name: the database doesn't exist, which is only
+		// known once authenticated.
+		return true, nil
+	case errors.Is(err, pq.ErrSSLNotSupported) && !u.Query().Has("sslmode"):
+		// SSL is required by default, but servers may not support it, and
+		// the connection string doesn't require it.
+		plain := *u
+		query := u.Query()
+		query.Set("sslmode", "disable")
+		plain.RawQuery = query.Encode()
+		return verifyPostgres(ctx, &plain)
+	default:
+		return false, err
+	}
+}
+
+func verifyMySQL(ctx context.Context, cfg *mysql.Config, timeout time.Duration) (bool, error) {
+	cfg = cfg.Clone()
+	cfg.Timeout = timeout
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return false, err
+	}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	err = db.PingContext(ctx)
+	var mysqlErr *mysql.MySQLError
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.As(err, &mysqlErr) && mysqlErr.Number == 1045:
+		// Access denied for the user.
+		return false, nil
+	case errors.As(err, &mysqlErr) && (mysqlErr.Number == 1044 || mysqlErr.Number == 1049):
+		// Access denied to, or unknown, database, which is only known once
+		// authenticated.
+		return true, nil
+	default:
+		return false, err
+	}
+}
+
+func verifyMongoDB(ctx context.Context, u *url.URL, timeout time.Duration) (bool, error) {
+	client, err := mongo.Connect(ctx, options.Client().SetTimeout(timeout).ApplyURI(u.String()))
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = client.Disconnect(context.Background())
+	}()
+
+	err = client.Ping(ctx, readpref.Primary())
+	var connErr topology.ConnectionError
+	var authErr *auth.Error
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.As(err, &connErr) && errors.As(connErr.Unwrap(), &authErr):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func verifyRedis(u *url.URL, timeout time.Duration) (bool, error) {
+	opt, err := redis.ParseURL(u.String())
+	if err != nil {
+		return false, err
+	}
+	opt.DialTimeout = timeout
+	opt.ReadTimeout = timeout
+	client := redis.NewClient(opt)
+	defer client.Close()
+
+	err = client.Ping().Err()
+	switch {
+	case err == nil:
+		return true, nil
+	case strings.Contains(err.Error(), "WRONGPASS"), strings.Contains(err.Error(), "invalid password"), strings.Contains(err.Error(), "NOAUTH"):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (s Scanner) Type() detectorspb.DetectorType {
+	return detectorspb.DetectorType_DatabaseConnectionString
+}
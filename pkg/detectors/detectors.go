@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"net/url"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
@@ -16,11 +17,26 @@ import (
 )
 
 // Detector defines an interface for scanning for and verifying secrets.
+//
+// Detectors outside of this repository can be added with Register. The engine
+// calls a detector as follows:
+//   - FromData is only called with data containing one of its keywords,
+//     usually a window of the chunk around the keywords rather than the whole
+//     chunk. It is called concurrently, so it must be safe for concurrent use.
+//   - The context of FromData has a timeout, and verification requests must
+//     honor it.
+//   - Results must have their DetectorType set to Type, and Raw set to the
+//     secret, which identifies it for deduplication. If verification fails
+//     without telling whether the secret is valid, the result must have a
+//     verification error, see Result.SetVerificationError.
+//   - Detectors are told apart by their Type and Version, see Versioner, so
+//     these must not be those of another detector.
 type Detector interface {
 	// FromData will scan bytes for results, and optionally verify them.
 	FromData(ctx context.Context, verify bool, data []byte) ([]Result, error)
 	// Keywords are used for efficiently pre-filtering chunks using substring operations.
 	// Use unique identifiers that are part of the secret if you can, or the provider name.
+	// Keywords are matched case-insensitively.
 	Keywords() []string
 	// Type returns the DetectorType number from detectors.proto for the given detector.
 	Type() detectorspb.DetectorType
@@ -60,6 +76,13 @@ type EndpointCustomizer interface {
 	DefaultEndpoint() string
 }
 
+// EntropyThresholdCustomizer is an optional interface that a detector can
+// implement to support overriding the minimum Shannon entropy, in bits per
+// character, of the secrets it finds.
+type EntropyThresholdCustomizer interface {
+	SetEntropyThreshold(threshold float64)
+}
+
 type Result struct {
 	// DetectorType is the type of Detector.
 	DetectorType detectorspb.DetectorType
@@ -68,6 +91,9 @@ type Result struct {
 	// DecoderType is the type of Decoder.
 	DecoderType detectorspb.DecoderType
 	Verified    bool
+	// Revoked is set for unverified results whose service explicitly reported the secret as revoked or expired,
+	// telling secrets confirmed to be dead apart from secrets that are merely not valid.
+	Revoked bool
 	// Raw contains the raw secret identifier data. Prefer IDs over secrets since it is used for deduping after hashing.
 	Raw []byte
 	// RawV2 contains the raw secret identifier that is a combination of both the ID and the secret.
@@ -78,6 +104,9 @@ type Result struct {
 	Redacted       string
 	ExtraData      map[string]string
 	StructuredData *detectorspb.StructuredData
+	// Confidence is how likely the result is to be a real secret. If the detector doesn't set it,
+	// the engine sets it to the confidence of the detector, see GetConfidence.
+	Confidence Confidence
 
 	// This field should only be populated if the verification process itself failed in a way that provides no
 	// information about the verification status of the candidate secret, such as if the verification request timed out.
@@ -85,9 +114,13 @@ type Result struct {
 }
 
 // SetVerificationError is the only way to set a verification error. Any sensitive values should be passed-in as secrets to be redacted.
+// Detectors can wrap ErrRateLimited to categorize the error, see VerificationErrorCategory.
 func (r *Result) SetVerificationError(err error, secrets ...string) {
 	if err != nil {
-		r.verificationError = redactSecrets(err, secrets...)
+		r.verificationError = &categorizedError{
+			error:    redactSecrets(err, secrets...),
+			category: categorizeVerificationError(err),
+		}
 	}
 }
 
@@ -96,6 +129,15 @@ func (r *Result) VerificationError() error {
 	return r.verificationError
 }
 
+// VerificationErrorCategory returns the category of the verification error, or an empty category if there is none.
+func (r *Result) VerificationErrorCategory() VerificationErrorCategory {
+	var err *categorizedError
+	if errors.As(r.verificationError, &err) {
+		return err.category
+	}
+	return ""
+}
+
 // redactSecrets replaces all instances of the given secrets with [REDACTED] in the error message.
 func redactSecrets(err error, secrets ...string) error {
 	lastErr := unwrapToLast(err)
@@ -125,6 +167,9 @@ type ResultWithMetadata struct {
 	IsWordlistFalsePositive bool
 	// SourceMetadata contains source-specific contextual information.
 	SourceMetadata *source_metadatapb.MetaData
+	// Position is where the secret was found within the data of the source,
+	// if the source knows where its chunks start.
+	Position *sources.Position
 	// SourceID is the ID of the source that the API uses to map secrets to specific sources.
 	SourceID sources.SourceID
 	// JobID is the ID of the job that the API uses to map secrets to specific jobs.
@@ -136,6 +181,14 @@ type ResultWithMetadata struct {
 	SourceType sourcespb.SourceType
 	// SourceName is the name of the Source.
 	SourceName string
+	// Labels are the key/values attached to every result of the scan, e.g.
+	// the environment or team, for the systems the results are sent to.
+	Labels map[string]string
+	// DetectedAt is when the engine reported the result, in UTC.
+	DetectedAt time.Time
+	// ScanID is the ID of the scan the result was found by, shared by all
+	// of its results, to correlate them in audit trails.
+	ScanID string
 	Result
 	// Data from the sources.Chunk which this result was emitted for
 	Data []byte
@@ -145,6 +198,7 @@ type ResultWithMetadata struct {
 func CopyMetadata(chunk *sources.Chunk, result Result) ResultWithMetadata {
 	return ResultWithMetadata{
 		SourceMetadata: chunk.SourceMetadata,
+		Position:       chunk.Position,
 		SourceID:       chunk.SourceID,
 		JobID:          chunk.JobID,
 		SecretID:       chunk.SecretID,
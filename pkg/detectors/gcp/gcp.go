@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"strings"
 
 	regexp "github.com/wasilibs/go-re2"
@@ -23,7 +24,7 @@ var _ detectors.MaxSecretSizeProvider = (*Scanner)(nil)
 var _ detectors.StartOffsetProvider = (*Scanner)(nil)
 
 var (
-	keyPat = regexp.MustCompile(`\{[^{]+auth_provider_x509_cert_url[^}]+\}`)
+	keyPat = regexp.MustCompile(`\{[^{]+(?:auth_provider_x509_cert_url|service_account)[^}]+\}`)
 )
 
 type gcpKey struct {
@@ -48,10 +49,12 @@ func trimCarrots(s string) string {
 // Keywords are used for efficiently pre-filtering chunks.
 // Use identifiers in the secret preferably, or the provider name.
 func (s Scanner) Keywords() []string {
-	return []string{"provider_x509"}
+	return []string{"provider_x509", "service_account"}
 }
 
-const maxGCPKeySize = 2048
+// maxGCPKeySize must fit an entire service account key, which is mostly made
+// up of a 2048 bit RSA private key, after the "service_account" keyword.
+const maxGCPKeySize = 4096
 
 // MaxSecretSize returns the maximum size of a secret that this detector can find.
 func (Scanner) MaxSecretSize() int64 { return maxGCPKeySize }
@@ -80,6 +83,11 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			continue
 		}
 
+		// Keys without the certificate URL are only matched on their service account structure.
+		if creds.AuthProviderX509CertURL == "" && !isServiceAccountKey(creds) {
+			continue
+		}
+
 		// for Slack mangling (mailto scheme and hyperlinks)
 		if strings.Contains(creds.ClientEmail, `<mailto:`) {
 			creds.ClientEmail = strings.Split(strings.Split(creds.ClientEmail, `<mailto:`)[1], `|`)[0]
@@ -112,19 +120,13 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		s.ExtraData = map[string]string{
 			"rotation_guide": "https://howtorotate.com/docs/tutorials/gcp/",
 			"project":        creds.ProjectID,
+			"client_email":   creds.ClientEmail,
 		}
 
 		if verify {
-			credentials, err := google.CredentialsFromJSON(ctx, credBytes, "https://www.googleapis.com/auth/cloud-platform")
-			if err != nil {
-				continue
-			}
-			if credentials != nil {
-				_, err = credentials.TokenSource.Token()
-				if err == nil {
-					s.Verified = true
-				}
-			}
+			isVerified, verificationErr := verifyMatch(ctx, credBytes)
+			s.Verified = isVerified
+			s.SetVerificationError(verificationErr, creds.PrivateKey)
 		}
 
 		results = append(results, s)
@@ -133,6 +135,33 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return
 }
 
+// isServiceAccountKey reports whether the key has the fields required to
+// authenticate as a service account.
+func isServiceAccountKey(creds gcpKey) bool {
+	return creds.Type == "service_account" && creds.PrivateKey != "" && creds.ClientEmail != ""
+}
+
+// verifyMatch exchanges the service account key for an access token.
+func verifyMatch(ctx context.Context, credBytes []byte) (bool, error) {
+	credentials, err := google.CredentialsFromJSON(ctx, credBytes, "https://www.googleapis.com/auth/cloud-platform")
+	if err != nil {
+		// The key could not be parsed, e.g. because the private key is malformed.
+		return false, nil
+	}
+
+	if _, err := credentials.TokenSource.Token(); err != nil {
+		// Revoked or deleted keys are rejected by the token endpoint. Any
+		// other failure leaves the key's status unknown.
+		var authErr *google.AuthenticationError
+		if errors.As(err, &authErr) && !authErr.Temporary() {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (s Scanner) IsFalsePositive(_ detectors.Result) (bool, string) {
 	return false, ""
 }
@@ -0,0 +1,54 @@
+package gcspublicaccess
+
+import (
+	"context"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// Scanner reports the GCS objects and buckets that anyone on the internet can
+// read. They aren't secrets, but are found by the GCS source when it's set to
+// report public access, which reports each ACL entry granting access to
+// allUsers as a line of the form:
+//
+//	gcs public access: allUsers READER gs://bucket/path/to/object
+//
+// The exposures can't be verified any further than the source already did.
+type Scanner struct{}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+
+var exposurePat = regexp.MustCompile(`(?m)^gcs public access: (allUsers) ([A-Z]+) (gs://[^/\s]+(?:/[^\r\n]*)?)$`)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"gcs public access"}
+}
+
+// FromData will find the public access reported by the GCS source in a given set of bytes.
+func (s Scanner) FromData(_ context.Context, _ bool, data []byte) (results []detectors.Result, err error) {
+	for _, match := range exposurePat.FindAllStringSubmatch(string(data), -1) {
+		entity, role, resource := match[1], match[2], match[3]
+		results = append(results, detectors.Result{
+			DetectorType: detectorspb.DetectorType_GCSPublicAccess,
+			Raw:          []byte(resource),
+			RawV2:        []byte(resource + " " + entity + ":" + role),
+			Redacted:     resource,
+			ExtraData: map[string]string{
+				"entity":   entity,
+				"role":     role,
+				"resource": resource,
+			},
+		})
+	}
+	return results, nil
+}
+
+func (s Scanner) Type() detectorspb.DetectorType {
+	return detectorspb.DetectorType_GCSPublicAccess
+}
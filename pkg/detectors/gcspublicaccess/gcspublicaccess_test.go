@@ -0,0 +1,60 @@
+package gcspublicaccess
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+func TestGCSPublicAccess_FromData(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+
+	tests := []struct {
+		name  string
+		input string
+		want  []map[string]string
+	}{
+		{
+			name:  "public object",
+			input: "gcs public access: allUsers READER gs://assets/reports/q1 summary.pdf\n",
+			want: []map[string]string{
+				{"entity": "allUsers", "role": "READER", "resource": "gs://assets/reports/q1 summary.pdf"},
+			},
+		},
+		{
+			name:  "public bucket",
+			input: "gcs public access: allUsers READER gs://assets\ngcs public access: allUsers WRITER gs://assets",
+			want: []map[string]string{
+				{"entity": "allUsers", "role": "READER", "resource": "gs://assets"},
+				{"entity": "allUsers", "role": "WRITER", "resource": "gs://assets"},
+			},
+		},
+		{
+			name:  "not reported by the source",
+			input: "the report says gcs public access: allUsers READER gs://assets",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.NotEmpty(t, ahoCorasickCore.FindDetectorMatches([]byte(test.input)))
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			var got []map[string]string
+			for _, r := range results {
+				assert.Equal(t, r.ExtraData["resource"], string(r.Raw))
+				assert.False(t, r.Verified)
+				got = append(got, r.ExtraData)
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package genericentropy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// Charset is the set of characters that strings found by the detector are made of.
+type Charset string
+
+const (
+	// CharsetBase64 matches strings of standard and URL-safe base64 characters, with optional padding.
+	CharsetBase64 Charset = "base64"
+	// CharsetHex matches strings of hexadecimal characters.
+	CharsetHex Charset = "hex"
+)
+
+const (
+	// DefaultThreshold is the default minimum Shannon entropy, in bits per character.
+	DefaultThreshold = 4.5
+	// DefaultMinLength is the default minimum length of strings.
+	DefaultMinLength = 32
+)
+
+// defaultAllowlist matches common high-entropy values that aren't secrets.
+var defaultAllowlist = []string{
+	`^[0-9A-Fa-f]{8}(?:-[0-9A-Fa-f]{4}){3}-[0-9A-Fa-f]{12}$`, // UUID
+	`^(?:[A-Fa-f0-9]{32}|[A-Fa-f0-9]{40}|[A-Fa-f0-9]{64})$`,  // MD5, SHA-1, and SHA-256 hex digests
+}
+
+// Config configures the detector. Zero values use the defaults.
+type Config struct {
+	// Threshold is the minimum Shannon entropy of strings, in bits per character.
+	Threshold float64
+	// MinLength is the minimum length of strings.
+	MinLength int
+	// Charset is the set of characters of strings. It defaults to CharsetBase64.
+	Charset Charset
+	// Allowlist are regexes of strings to ignore, in addition to UUIDs and hex digests.
+	Allowlist []string
+}
+
+// Scanner finds strings with high entropy, which may be secrets that no other
+// detector knows about. It is noisy, so it isn't one of the default detectors.
+type Scanner struct {
+	threshold float64
+	tokenPat  *regexp.Regexp
+	allowlist []*regexp.Regexp
+}
+
+// Ensure the Scanner satisfies the interfaces at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+var _ detectors.ConfidenceProvider = (*Scanner)(nil)
+var _ detectors.EntropyThresholdCustomizer = (*Scanner)(nil)
+
+// New returns a Scanner configured with cfg.
+func New(cfg Config) (*Scanner, error) {
+	if cfg.Threshold == 0 {
+		cfg.Threshold = DefaultThreshold
+	}
+	if cfg.MinLength == 0 {
+		cfg.MinLength = DefaultMinLength
+	}
+	if cfg.Threshold < 0 || cfg.MinLength < 0 {
+		return nil, fmt.Errorf("entropy threshold and minimum length must not be negative")
+	}
+
+	var pat string
+	switch cfg.Charset {
+	case CharsetBase64, "":
+		pat = fmt.Sprintf(`[A-Za-z0-9+/_-]{%d,}={0,2}`, cfg.MinLength)
+	case CharsetHex:
+		pat = fmt.Sprintf(`[A-Fa-f0-9]{%d,}`, cfg.MinLength)
+	default:
+		return nil, fmt.Errorf("invalid charset %q, must be one of base64 or hex", cfg.Charset)
+	}
+
+	s := &Scanner{threshold: cfg.Threshold, tokenPat: regexp.MustCompile(pat)}
+	for _, allow := range slices.Concat(defaultAllowlist, cfg.Allowlist) {
+		re, err := regexp.Compile(allow)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowlist regex %q: %w", allow, err)
+		}
+		s.allowlist = append(s.allowlist, re)
+	}
+	return s, nil
+}
+
+// The detector would otherwise run on every chunk, so only look for strings
+// near words that usually come with secrets.
+var keywords = []string{"key", "secret", "token", "pass", "cred", "auth"}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return keywords
+}
+
+// FromData will find high-entropy strings in a given set of bytes. They can't be verified.
+func (s Scanner) FromData(_ context.Context, _ bool, data []byte) (results []detectors.Result, err error) {
+	for _, token := range s.tokenPat.FindAllString(string(data), -1) {
+		if detectors.StringShannonEntropy(token) < s.threshold || s.isAllowlisted(token) {
+			continue
+		}
+
+		results = append(results, detectors.Result{
+			DetectorType: detectorspb.DetectorType_GenericEntropy,
+			Raw:          []byte(token),
+		})
+	}
+
+	return results, nil
+}
+
+// SetEntropyThreshold overrides the threshold of the configuration.
+func (s *Scanner) SetEntropyThreshold(threshold float64) {
+	s.threshold = threshold
+}
+
+func (s Scanner) isAllowlisted(token string) bool {
+	for _, re := range s.allowlist {
+		if re.MatchString(token) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Scanner) Type() detectorspb.DetectorType {
+	return detectorspb.DetectorType_GenericEntropy
+}
+
+// Confidence is low, since many high-entropy strings aren't secrets.
+func (s Scanner) Confidence() detectors.Confidence {
+	return detectors.ConfidenceLow
+}
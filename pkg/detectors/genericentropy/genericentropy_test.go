@@ -0,0 +1,92 @@
+package genericentropy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+func TestGenericEntropy_Pattern(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   Config
+		input string
+		want  []string
+	}{
+		{
+			name:  "base64 key",
+			input: `secret: "Zx9mQ2vL7pK4nR8tW1yB6cF3hJ5dG0sA+eU/iO=="`,
+			want:  []string{"Zx9mQ2vL7pK4nR8tW1yB6cF3hJ5dG0sA+eU/iO=="},
+		},
+		{
+			name:  "base64 key with low entropy",
+			input: "password=AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+		},
+		{
+			name:  "base64 key shorter than the minimum length",
+			input: "secret: Zx9mQ2vL7pK4nR8tW1yB",
+		},
+		{
+			name:  "UUID",
+			cfg:   Config{Threshold: 3.5},
+			input: "token=3fc0b7f7-da09-4ae7-a9c8-d69824b1819b",
+		},
+		{
+			name:  "hex key",
+			cfg:   Config{Threshold: 3.5, Charset: CharsetHex},
+			input: "auth_key=9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822c",
+			want:  []string{"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822c"},
+		},
+		{
+			name: "hex digests",
+			cfg:  Config{Threshold: 3.5, Charset: CharsetHex},
+			input: `
+				sha1 key 2fd4e1c67a2d28fced849ee1bb76e7391b93eb12
+				sha256 key e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855
+			`,
+		},
+		{
+			name:  "custom allowlist",
+			cfg:   Config{Allowlist: []string{`EXAMPLE`}},
+			input: `api_key = "wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := New(test.cfg)
+			assert.NoError(t, err)
+
+			ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			if len(matchedDetectors) == 0 {
+				t.Errorf("keywords '%v' not matched by: %s", d.Keywords(), test.input)
+				return
+			}
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			assert.NoError(t, err)
+
+			var got []string
+			for _, r := range results {
+				got = append(got, string(r.Raw))
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	_, err := New(Config{Charset: "binary"})
+	assert.Error(t, err)
+
+	_, err = New(Config{Allowlist: []string{"("}})
+	assert.Error(t, err)
+
+	_, err = New(Config{MinLength: -1})
+	assert.Error(t, err)
+}
@@ -0,0 +1,40 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitHub_FromData_RetriesRateLimitedVerification(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("X-OAuth-Scopes", "repo")
+		_, _ = fmt.Fprint(w, `{"login": "octocat", "type": "User"}`)
+	}))
+	defer server.Close()
+
+	s := Scanner{}
+	assert.NoError(t, s.SetEndpoints(server.URL))
+
+	data := []byte(`github_token = "0123456789abcdef0123456789abcdef01234567"`)
+	results, err := s.FromData(context.Background(), true, data)
+	assert.NoError(t, err)
+	if assert.Len(t, results, 1) {
+		assert.True(t, results[0].Verified)
+		assert.NoError(t, results[0].VerificationError())
+		assert.Equal(t, "octocat", results[0].ExtraData["username"])
+		assert.Equal(t, "repo", results[0].ExtraData["scopes"])
+	}
+	assert.Equal(t, 2, requests)
+}
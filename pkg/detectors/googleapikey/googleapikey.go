@@ -0,0 +1,348 @@
+package googleapikey
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// Scanner finds Google API keys. When verifying, the key is probed against a
+// few common Google APIs with read-only requests, and the APIs it can call are
+// reported in its extra data. Keys restricted to HTTP referrers, e.g. those of
+// web pages, are probed again with the referrers of the URLs found alongside
+// them.
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	defaultClient = common.SaneHttpClient()
+
+	keyPat = regexp.MustCompile(`\b(AIza[0-9A-Za-z_-]{35})(?:[^0-9A-Za-z_-]|\z)`)
+	// urlPat matches the hosts of URLs, which may be the referrers a key is
+	// restricted to.
+	urlPat = regexp.MustCompile(`\bhttps?://([a-zA-Z0-9-]+(?:\.[a-zA-Z0-9-]+)*\.[a-zA-Z]{2,})\b`)
+)
+
+// maxReferrers caps the referrers a key restricted to HTTP referrers is probed
+// with.
+const maxReferrers = 3
+
+// probe is a read-only request to a Google API, which tells whether a key can
+// call it.
+type probe struct {
+	api string
+	// url is requested with the key as its key query parameter.
+	url string
+	// maps is set for the Maps web services, which report errors in the
+	// status field of 200 responses.
+	maps bool
+}
+
+// probes are the APIs keys are probed against. They're free or billed per
+// request, and each is requested once per key, or once per referrer.
+var probes = []probe{
+	{api: "geocoding", url: "https://maps.googleapis.com/maps/api/geocode/json?address=Mountain+View", maps: true},
+	{api: "youtube", url: "https://www.googleapis.com/youtube/v3/videos?part=id&id=jNQXAC9IVRw"},
+	{api: "generative_language", url: "https://generativelanguage.googleapis.com/v1beta/models?pageSize=1"},
+	{api: "translation", url: "https://translation.googleapis.com/language/translate/v2/languages"},
+}
+
+// Reasons of the errors of Google APIs, see
+// https://cloud.google.com/apis/design/errors#error_info.
+const (
+	reasonKeyInvalid        = "API_KEY_INVALID"
+	reasonReferrerBlocked   = "API_KEY_HTTP_REFERRER_BLOCKED"
+	reasonServiceBlocked    = "API_KEY_SERVICE_BLOCKED"
+	reasonServiceDisabled   = "SERVICE_DISABLED"
+	reasonRateLimitExceeded = "RATE_LIMIT_EXCEEDED"
+)
+
+// restrictions are the application restrictions of keys, by the reason of
+// the errors of the requests they block.
+var restrictions = map[string]string{
+	reasonReferrerBlocked:         "http_referrer",
+	"API_KEY_IP_ADDRESS_BLOCKED":  "ip_address",
+	"API_KEY_ANDROID_APP_BLOCKED": "android_app",
+	"API_KEY_IOS_APP_BLOCKED":     "ios_app",
+}
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"AIza"}
+}
+
+// FromData will find and optionally verify Google API keys in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	seen := make(map[string]struct{})
+	var referrers []string
+	if verify {
+		referrers = findReferrers(dataStr)
+	}
+	for _, match := range keyPat.FindAllStringSubmatch(dataStr, -1) {
+		key := match[1]
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_GoogleApiKey,
+			Raw:          []byte(key),
+		}
+
+		if verify {
+			client := s.client
+			if client == nil {
+				client = defaultClient
+			}
+
+			caps, verificationErr := probeKey(ctx, client, key, referrers)
+			s1.Verified = caps.valid
+			s1.ExtraData = caps.extraData()
+			s1.SetVerificationError(verificationErr, key)
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// findReferrers returns the referrers of the URLs in data, other than those
+// of Google APIs.
+func findReferrers(data string) []string {
+	var referrers []string
+	seen := make(map[string]struct{})
+	for _, match := range urlPat.FindAllStringSubmatch(data, -1) {
+		host := strings.ToLower(match[1])
+		if host == "googleapis.com" || strings.HasSuffix(host, ".googleapis.com") {
+			continue
+		}
+		if _, ok := seen[host]; ok {
+			continue
+		}
+		seen[host] = struct{}{}
+		referrers = append(referrers, "https://"+host+"/")
+		if len(referrers) == maxReferrers {
+			break
+		}
+	}
+	return referrers
+}
+
+// capabilities are what probing a key found out about it.
+type capabilities struct {
+	// valid is set if any API recognized the key, even if it can't call it.
+	valid bool
+	// apis are the APIs the key can call.
+	apis []string
+	// restriction is the application restriction that blocked a request,
+	// and referrer the referrer the key could be used with, if any.
+	restriction string
+	referrer    string
+}
+
+func (c capabilities) extraData() map[string]string {
+	if !c.valid {
+		return nil
+	}
+	extraData := map[string]string{"accessible_apis": strings.Join(c.apis, ",")}
+	if c.restriction != "" {
+		extraData["restriction"] = c.restriction
+	}
+	if c.referrer != "" {
+		extraData["referrer"] = c.referrer
+	}
+	return extraData
+}
+
+// probeKey probes the key against each API. Once a referrer unlocks an API for
+// a key restricted to HTTP referrers, the remaining APIs are probed with it.
+// The key is invalid if an API rejects it. Errors are only returned if no API
+// recognized the key.
+func probeKey(ctx context.Context, client *http.Client, key string, referrers []string) (capabilities, error) {
+	var caps capabilities
+	var errs []error
+	for _, p := range probes {
+		reason, err := probeAPI(ctx, client, p, key, caps.referrer)
+		// The Maps web services can't be called with keys restricted to
+		// HTTP referrers, whatever the referrer.
+		if reason == reasonReferrerBlocked && caps.referrer == "" && !p.maps {
+			for _, referrer := range referrers {
+				if r, e := probeAPI(ctx, client, p, key, referrer); e == nil && r != reasonReferrerBlocked {
+					reason, err = r, nil
+					caps.referrer = referrer
+					break
+				}
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.api, err))
+			continue
+		}
+
+		switch reason {
+		case "":
+			caps.apis = append(caps.apis, p.api)
+		case reasonKeyInvalid:
+			return capabilities{}, nil
+		}
+		if restriction, ok := restrictions[reason]; ok && caps.restriction == "" {
+			caps.restriction = restriction
+		}
+		caps.valid = true
+	}
+	if caps.referrer != "" {
+		caps.restriction = restrictions[reasonReferrerBlocked]
+	}
+	sort.Strings(caps.apis)
+
+	if !caps.valid {
+		return caps, errors.Join(errs...)
+	}
+	return caps, nil
+}
+
+type apiError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			Reason string `json:"reason"`
+		} `json:"details"`
+		Errors []struct {
+			Reason string `json:"reason"`
+		} `json:"errors"`
+	} `json:"error"`
+}
+
+type mapsResponse struct {
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error_message"`
+}
+
+// probeAPI requests the API with the key, and the referrer if set. It returns
+// the reason the request failed, or "" if the key can call the API.
+func probeAPI(ctx context.Context, client *http.Client, p probe, key, referrer string) (string, error) {
+	u, err := url.Parse(p.url)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set("key", key)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if referrer != "" {
+		req.Header.Set("Referer", referrer)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	if p.maps && res.StatusCode == http.StatusOK {
+		var mapsRes mapsResponse
+		if err := json.NewDecoder(res.Body).Decode(&mapsRes); err != nil {
+			return "", fmt.Errorf("failed to decode response: %w", err)
+		}
+		return mapsReason(mapsRes)
+	}
+
+	switch {
+	case res.StatusCode == http.StatusOK:
+		return "", nil
+	case res.StatusCode == http.StatusTooManyRequests:
+		return reasonRateLimitExceeded, nil
+	case res.StatusCode == http.StatusBadRequest || res.StatusCode == http.StatusForbidden:
+		var apiErr apiError
+		if err := json.NewDecoder(res.Body).Decode(&apiErr); err != nil {
+			return "", fmt.Errorf("failed to decode response: %w", err)
+		}
+		for _, detail := range apiErr.Error.Details {
+			if detail.Reason != "" {
+				return detail.Reason, nil
+			}
+		}
+		// Some APIs, e.g. YouTube, only report the reason of the error in
+		// their legacy format, and disabled APIs in the message.
+		for _, e := range apiErr.Error.Errors {
+			switch e.Reason {
+			case "keyInvalid":
+				return reasonKeyInvalid, nil
+			case "accessNotConfigured":
+				return reasonServiceDisabled, nil
+			case "quotaExceeded", "rateLimitExceeded":
+				return reasonRateLimitExceeded, nil
+			}
+		}
+		if strings.Contains(apiErr.Error.Message, "API key not valid") {
+			return reasonKeyInvalid, nil
+		}
+		if apiErr.Error.Status != "" {
+			return apiErr.Error.Status, nil
+		}
+		return "", fmt.Errorf("unexpected HTTP response status %d: %s", res.StatusCode, apiErr.Error.Message)
+	default:
+		return "", fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+}
+
+// mapsReason returns the reason of the status of a response of the Maps web
+// services, see
+// https://developers.google.com/maps/documentation/geocoding/requests-geocoding#StatusCodes.
+func mapsReason(res mapsResponse) (string, error) {
+	switch res.Status {
+	case "OK", "ZERO_RESULTS":
+		return "", nil
+	case "OVER_QUERY_LIMIT", "OVER_DAILY_LIMIT":
+		return reasonRateLimitExceeded, nil
+	case "REQUEST_DENIED":
+		switch msg := strings.ToLower(res.ErrorMessage); {
+		case strings.Contains(msg, "api key is invalid"):
+			return reasonKeyInvalid, nil
+		case strings.Contains(msg, "referer restrictions"):
+			return reasonReferrerBlocked, nil
+		case strings.Contains(msg, "not authorized"):
+			return reasonServiceBlocked, nil
+		case strings.Contains(msg, "not activated"), strings.Contains(msg, "not enabled"):
+			return reasonServiceDisabled, nil
+		default:
+			return res.Status, nil
+		}
+	default:
+		return "", fmt.Errorf("unexpected status %q: %s", res.Status, res.ErrorMessage)
+	}
+}
+
+func (s Scanner) Type() detectorspb.DetectorType {
+	return detectorspb.DetectorType_GoogleApiKey
+}
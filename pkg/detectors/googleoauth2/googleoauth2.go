@@ -0,0 +1,45 @@
+package googleoauth2
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// Scanner finds Google OAuth2 access tokens, and the client secrets of
+// Google OAuth clients with the client IDs and refresh tokens found alongside
+// them.
+type Scanner struct {
+	client *http.Client
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+
+var defaultClient = common.SaneHttpClient()
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"ya29.", "GOCSPX-"}
+}
+
+// FromData will find and optionally verify Googleoauth2 secrets in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	if verify && s.client == nil {
+		s.client = defaultClient
+	}
+
+	results = append(results, s.accessTokensFromData(ctx, verify, dataStr)...)
+	results = append(results, s.clientSecretsFromData(ctx, verify, dataStr)...)
+	return results, nil
+}
+
+func (s Scanner) Type() detectorspb.DetectorType {
+	return detectorspb.DetectorType_GoogleOauth2
+}
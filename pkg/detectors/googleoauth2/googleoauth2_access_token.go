@@ -11,39 +11,18 @@ import (
 
 	regexp "github.com/wasilibs/go-re2"
 
-	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
-	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
 )
 
-type Scanner struct {
-	client *http.Client
-}
-
-// Ensure the Scanner satisfies the interface at compile time.
-var _ detectors.Detector = (*Scanner)(nil)
-
-var (
-	defaultClient = common.SaneHttpClient()
-
-	// There is conflicting information about the expected length of access tokens.
-	// 10 seems like a reasonable minimum that will weed out placeholders.
-	//
-	// https://cloud.google.com/docs/authentication/token-types#access
-	// https://github.com/GoogleChrome/developer.chrome.com/blob/51dd7dd5d510ed85d86f5a91cb8fde50b62351c7/site/en/docs/webstore/using_webstore_api/index.md?plain=1#L95
-	keyPat = regexp.MustCompile(`\b(ya29\.(?i:[a-z0-9_-]{10,}))(?:[^a-z0-9_-]|\z)`)
-)
-
-// Keywords are used for efficiently pre-filtering chunks.
-// Use identifiers in the secret preferably, or the provider name.
-func (s Scanner) Keywords() []string {
-	return []string{"ya29."}
-}
-
-// FromData will find and optionally verify Googleoauth2 secrets in a given set of bytes.
-func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
-	dataStr := string(data)
+// There is conflicting information about the expected length of access tokens.
+// 10 seems like a reasonable minimum that will weed out placeholders.
+//
+// https://cloud.google.com/docs/authentication/token-types#access
+// https://github.com/GoogleChrome/developer.chrome.com/blob/51dd7dd5d510ed85d86f5a91cb8fde50b62351c7/site/en/docs/webstore/using_webstore_api/index.md?plain=1#L95
+var keyPat = regexp.MustCompile(`\b(ya29\.(?i:[a-z0-9_-]{10,}))(?:[^a-z0-9_-]|\z)`)
 
+// accessTokensFromData finds and optionally verifies access tokens.
+func (s Scanner) accessTokensFromData(ctx context.Context, verify bool, dataStr string) (results []detectors.Result) {
 	tokens := make(map[string]struct{})
 	for _, matches := range keyPat.FindAllStringSubmatch(dataStr, -1) {
 		tokens[matches[1]] = struct{}{}
@@ -56,10 +35,6 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		}
 
 		if verify {
-			if s.client == nil {
-				s.client = defaultClient
-			}
-
 			verified, extraData, vErr := s.verify(ctx, token)
 			s1.Verified = verified
 			s1.ExtraData = extraData
@@ -68,7 +43,7 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 
 		results = append(results, s1)
 	}
-	return
+	return results
 }
 
 func (s Scanner) verify(ctx context.Context, token string) (bool, map[string]string, error) {
@@ -134,7 +109,3 @@ type tokenInfo struct {
 type errorInfo struct {
 	Error string `json:"error_description"`
 }
-
-func (s Scanner) Type() detectorspb.DetectorType {
-	return detectorspb.DetectorType_GoogleOauth2
-}
@@ -0,0 +1,148 @@
+package googleoauth2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+)
+
+const tokenURL = "https://oauth2.googleapis.com/token"
+
+var (
+	clientIDPat     = regexp.MustCompile(`\b([0-9]{6,}-[a-z0-9]{32}\.apps\.googleusercontent\.com)\b`)
+	clientSecretPat = regexp.MustCompile(`\b(GOCSPX-[A-Za-z0-9_-]{28})(?:[^A-Za-z0-9_-]|\z)`)
+	refreshTokenPat = regexp.MustCompile(`\b(1//0[A-Za-z0-9_-]{40,})(?:[^A-Za-z0-9_-]|\z)`)
+)
+
+// clientSecretsFromData finds and optionally verifies Google OAuth client
+// credentials.
+func (s Scanner) clientSecretsFromData(ctx context.Context, verify bool, dataStr string) (results []detectors.Result) {
+	clientSecrets := uniqueMatches(clientSecretPat, dataStr)
+	clientIDs := uniqueMatches(clientIDPat, dataStr)
+	refreshTokens := uniqueMatches(refreshTokenPat, dataStr)
+	// Client secrets are reported even if their client ID or refresh token
+	// aren't found.
+	if len(clientIDs) == 0 {
+		clientIDs = []string{""}
+	}
+	if len(refreshTokens) == 0 {
+		refreshTokens = []string{""}
+	}
+
+	for _, clientSecret := range clientSecrets {
+		for _, clientID := range clientIDs {
+			for _, refreshToken := range refreshTokens {
+				s1 := detectors.Result{
+					DetectorType: s.Type(),
+					Raw:          []byte(clientSecret),
+					RawV2:        []byte(clientID + ":" + clientSecret + ":" + refreshToken),
+				}
+				if clientID != "" {
+					s1.ExtraData = map[string]string{"client_id": clientID}
+				}
+
+				if verify && clientID != "" {
+					verified, revoked, verificationErr := verifyMatch(ctx, s.client, clientID, clientSecret, refreshToken)
+					s1.Verified = verified
+					s1.Revoked = revoked
+					if refreshToken != "" {
+						s1.SetVerificationError(verificationErr, clientSecret, refreshToken)
+					} else {
+						s1.SetVerificationError(verificationErr, clientSecret)
+					}
+				}
+
+				results = append(results, s1)
+			}
+		}
+	}
+
+	return results
+}
+
+func uniqueMatches(pat *regexp.Regexp, data string) []string {
+	var matches []string
+	seen := make(map[string]struct{})
+	for _, match := range pat.FindAllStringSubmatch(data, -1) {
+		if _, ok := seen[match[1]]; ok {
+			continue
+		}
+		seen[match[1]] = struct{}{}
+		matches = append(matches, match[1])
+	}
+	return matches
+}
+
+// invalidRefreshToken is refreshed to verify client credentials found without
+// a refresh token. The client is authenticated before the token is checked,
+// so the refresh only fails with invalid_grant if the client is valid.
+const invalidRefreshToken = "1//0trufflehog"
+
+type tokenError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// verifyMatch refreshes the refresh token with the client credentials. A
+// refresh token that was revoked or expired fails with invalid_grant, and is
+// reported as revoked.
+func verifyMatch(ctx context.Context, client *http.Client, clientID, clientSecret, refreshToken string) (verified, revoked bool, err error) {
+	grantToken := refreshToken
+	if grantToken == "" {
+		grantToken = invalidRefreshToken
+	}
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+		"refresh_token": {grantToken},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, false, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return true, false, nil
+	case http.StatusBadRequest, http.StatusUnauthorized:
+		var tokenErr tokenError
+		if err := json.NewDecoder(res.Body).Decode(&tokenErr); err != nil {
+			return false, false, fmt.Errorf("failed to decode response: %w", err)
+		}
+		switch tokenErr.Error {
+		case "invalid_grant":
+			if refreshToken == "" {
+				// The client credentials are valid.
+				return true, false, nil
+			}
+			return false, true, nil
+		case "invalid_client", "unauthorized_client", "deleted_client":
+			return false, false, nil
+		default:
+			return false, false, fmt.Errorf("unexpected error %q: %s", tokenErr.Error, tokenErr.ErrorDescription)
+		}
+	case http.StatusTooManyRequests:
+		return false, false, fmt.Errorf("%w: unexpected HTTP response status %d", detectors.ErrRateLimited, res.StatusCode)
+	default:
+		return false, false, fmt.Errorf("unexpected HTTP response status %d", res.StatusCode)
+	}
+}
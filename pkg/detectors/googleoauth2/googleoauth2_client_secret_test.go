@@ -0,0 +1,195 @@
+package googleoauth2
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	testClientID     = "123456789012-abcdefghijklmnopqrstuvwxyz012345.apps.googleusercontent.com"
+	testClientSecret = "GOCSPX-AbCdEfGhIjKlMnOpQrStUvWxYz01"
+	// testRefreshToken was revoked.
+	testRefreshToken = "1//0gRevokedFixtureTokenAbCdEfGhIjKlMnOpQrStUvWxYz0123456789_-"
+)
+
+var testCredentials = `{
+	"client_id": "` + testClientID + `",
+	"client_secret": "` + testClientSecret + `",
+	"refresh_token": "` + testRefreshToken + `"
+}`
+
+func TestGoogleoauth2_ClientSecret_Pattern(t *testing.T) {
+	d := Scanner{}
+	ahoCorasickCore := ahocorasick.NewAhoCorasickCore([]detectors.Detector{d})
+	tests := []struct {
+		name      string
+		input     string
+		want      []string
+		wantRawV2 []string
+	}{
+		{
+			name:      "client credentials and refresh token",
+			input:     testCredentials,
+			want:      []string{testClientSecret},
+			wantRawV2: []string{testClientID + ":" + testClientSecret + ":" + testRefreshToken},
+		},
+		{
+			name:      "client credentials in environment variables",
+			input:     "GOOGLE_CLIENT_ID=" + testClientID + "\nGOOGLE_CLIENT_SECRET=" + testClientSecret + "\n",
+			want:      []string{testClientSecret},
+			wantRawV2: []string{testClientID + ":" + testClientSecret + ":"},
+		},
+		{
+			name:      "client secret alone",
+			input:     "secret: " + testClientSecret,
+			want:      []string{testClientSecret},
+			wantRawV2: []string{":" + testClientSecret + ":"},
+		},
+		{
+			name:  "malformed client secret",
+			input: "client_id: " + testClientID + "\nclient_secret: GOCSPX-AbCdEfGhIjKlMnOpQrStUvWxYz01234\n",
+		},
+		{
+			name:  "truncated client secret",
+			input: "client_secret: GOCSPX-AbCdEfGh",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchedDetectors := ahoCorasickCore.FindDetectorMatches([]byte(test.input))
+			require.NotEmpty(t, matchedDetectors, "keywords '%v' not matched by: %s", d.Keywords(), test.input)
+
+			results, err := d.FromData(context.Background(), false, []byte(test.input))
+			require.NoError(t, err)
+
+			var got, gotRawV2 []string
+			for _, r := range results {
+				got = append(got, string(r.Raw))
+				gotRawV2 = append(gotRawV2, string(r.RawV2))
+			}
+			assert.ElementsMatch(t, test.want, got)
+			assert.ElementsMatch(t, test.wantRawV2, gotRawV2)
+		})
+	}
+}
+
+// fakeTokenEndpoint responds to token refreshes with the status and body,
+// and records the form of the last request.
+func fakeTokenEndpoint(status int, body string, form *url.Values) *http.Client {
+	return &http.Client{
+		Transport: common.FakeTransport{
+			CreateResponse: func(req *http.Request) (*http.Response, error) {
+				data, err := io.ReadAll(req.Body)
+				if err != nil {
+					return nil, err
+				}
+				if *form, err = url.ParseQuery(string(data)); err != nil {
+					return nil, err
+				}
+				return &http.Response{
+					Request:    req,
+					Body:       io.NopCloser(strings.NewReader(body)),
+					StatusCode: status,
+				}, nil
+			},
+		},
+	}
+}
+
+func TestGoogleoauth2_ClientSecret_FromData(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            string
+		status           int
+		body             string
+		wantRefreshToken string
+		wantVerified     bool
+		wantRevoked      bool
+		wantErrCategory  detectors.VerificationErrorCategory
+	}{
+		{
+			name:             "valid refresh token",
+			input:            testCredentials,
+			status:           http.StatusOK,
+			body:             `{"access_token": "ya29.token", "expires_in": 3599, "token_type": "Bearer"}`,
+			wantRefreshToken: testRefreshToken,
+			wantVerified:     true,
+		},
+		{
+			name:             "revoked refresh token",
+			input:            testCredentials,
+			status:           http.StatusBadRequest,
+			body:             `{"error": "invalid_grant", "error_description": "Token has been expired or revoked."}`,
+			wantRefreshToken: testRefreshToken,
+			wantRevoked:      true,
+		},
+		{
+			name:             "invalid client",
+			input:            testCredentials,
+			status:           http.StatusUnauthorized,
+			body:             `{"error": "invalid_client", "error_description": "Unauthorized"}`,
+			wantRefreshToken: testRefreshToken,
+		},
+		{
+			name:             "valid client without refresh token",
+			input:            testClientID + "\n" + testClientSecret,
+			status:           http.StatusBadRequest,
+			body:             `{"error": "invalid_grant", "error_description": "Bad Request"}`,
+			wantRefreshToken: invalidRefreshToken,
+			wantVerified:     true,
+		},
+		{
+			name:             "server error",
+			input:            testCredentials,
+			status:           http.StatusInternalServerError,
+			wantRefreshToken: testRefreshToken,
+			wantErrCategory:  detectors.VerificationErrorUnknown,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var form url.Values
+			s := Scanner{client: fakeTokenEndpoint(test.status, test.body, &form)}
+
+			results, err := s.FromData(context.Background(), true, []byte(test.input))
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+
+			result := results[0]
+			assert.Equal(t, test.wantVerified, result.Verified)
+			assert.Equal(t, test.wantRevoked, result.Revoked)
+			assert.Equal(t, test.wantErrCategory, result.VerificationErrorCategory())
+			assert.Equal(t, map[string]string{"client_id": testClientID}, result.ExtraData)
+
+			assert.Equal(t, "refresh_token", form.Get("grant_type"))
+			assert.Equal(t, testClientID, form.Get("client_id"))
+			assert.Equal(t, testClientSecret, form.Get("client_secret"))
+			assert.Equal(t, test.wantRefreshToken, form.Get("refresh_token"))
+		})
+	}
+}
+
+func TestGoogleoauth2_ClientSecret_FromData_WithoutClientID(t *testing.T) {
+	s := Scanner{client: common.ConstantResponseHttpClient(http.StatusOK, "")}
+
+	// The client secret can't be verified without its client ID.
+	results, err := s.FromData(context.Background(), true, []byte("client_secret: "+testClientSecret))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Verified)
+	assert.NoError(t, results[0].VerificationError())
+	assert.Empty(t, results[0].ExtraData)
+}
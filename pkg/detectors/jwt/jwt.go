@@ -0,0 +1,136 @@
+package jwt
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	jwtlib "github.com/golang-jwt/jwt/v4"
+	regexp "github.com/wasilibs/go-re2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// Scanner finds JSON Web Tokens. A token is verified if it expires in the
+// future and is currently valid according to its nbf and iat claims, which
+// doesn't need any requests. Tokens without an exp claim and unsecured tokens,
+// with the "none" algorithm, are never verified. Signatures are only checked if
+// Keys are provided.
+type Scanner struct {
+	// Keys are used to check the signatures of tokens. A token is only
+	// verified if one of them matches its signing algorithm and signature.
+	// HMAC tokens take []byte keys, RSA tokens *rsa.PublicKey, ECDSA tokens
+	// *ecdsa.PublicKey and EdDSA tokens ed25519.PublicKey.
+	Keys []any
+}
+
+// Ensure the Scanner satisfies the interface at compile time.
+var _ detectors.Detector = (*Scanner)(nil)
+
+var (
+	// Both the header and the payload are JSON objects, so their base64url
+	// encodings start with "eyJ". The signature is empty for unsecured tokens.
+	tokenPat = regexp.MustCompile(`\b(eyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]*)`)
+
+	parser = jwtlib.NewParser()
+)
+
+// Keywords are used for efficiently pre-filtering chunks.
+// Use identifiers in the secret preferably, or the provider name.
+func (s Scanner) Keywords() []string {
+	return []string{"eyJ"}
+}
+
+// FromData will find and optionally verify JWTs in a given set of bytes.
+func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
+	dataStr := string(data)
+
+	uniqueMatches := make(map[string]struct{})
+	for _, match := range tokenPat.FindAllStringSubmatch(dataStr, -1) {
+		uniqueMatches[match[1]] = struct{}{}
+	}
+
+	for match := range uniqueMatches {
+		claims := jwtlib.MapClaims{}
+		token, parts, err := parser.ParseUnverified(match, claims)
+		if err != nil {
+			// Not a JWT, just something that looks like one.
+			continue
+		}
+
+		s1 := detectors.Result{
+			DetectorType: detectorspb.DetectorType_JWT,
+			Raw:          []byte(match),
+			ExtraData:    extraData(token, claims),
+		}
+
+		if verify {
+			s1.Verified = live(token, claims) && s.signatureValid(token, parts)
+		}
+
+		results = append(results, s1)
+	}
+
+	return results, nil
+}
+
+// live reports whether the token is signed and expires in the future. Tokens
+// without an exp claim pass claims.Valid, but nothing shows they're still in
+// use, and anyone can forge an unsecured token.
+func live(token *jwtlib.Token, claims jwtlib.MapClaims) bool {
+	if token.Method == jwtlib.SigningMethodNone {
+		return false
+	}
+	if _, ok := numericDate(claims["exp"]); !ok {
+		return false
+	}
+	return claims.Valid() == nil
+}
+
+// extraData returns the signing algorithm and the exp, iss and sub claims
+// of the token, if they are set.
+func extraData(token *jwtlib.Token, claims jwtlib.MapClaims) map[string]string {
+	data := map[string]string{}
+	if alg, ok := token.Header["alg"].(string); ok {
+		data["alg"] = alg
+	}
+	if exp, ok := numericDate(claims["exp"]); ok {
+		data["exp"] = exp.UTC().Format(time.RFC3339)
+	}
+	for _, claim := range []string{"iss", "sub"} {
+		if v, ok := claims[claim].(string); ok && v != "" {
+			data[claim] = v
+		}
+	}
+	return data
+}
+
+// numericDate converts a NumericDate claim, the number of seconds since the
+// epoch, into a time.
+func numericDate(v any) (time.Time, bool) {
+	secs, ok := v.(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(secs), 0), true
+}
+
+// signatureValid reports whether the token is signed by one of the keys of
+// the scanner. It is always true if the scanner has no keys.
+func (s Scanner) signatureValid(token *jwtlib.Token, parts []string) bool {
+	if len(s.Keys) == 0 {
+		return true
+	}
+	signingString := strings.Join(parts[:2], ".")
+	for _, key := range s.Keys {
+		if token.Method.Verify(signingString, parts[2], key) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Scanner) Type() detectorspb.DetectorType {
+	return detectorspb.DetectorType_JWT
+}
@@ -26,6 +26,7 @@ type Scanner struct {
 var _ detectors.Detector = (*Scanner)(nil)
 var _ detectors.CustomFalsePositiveChecker = (*Scanner)(nil)
 var _ detectors.MaxSecretSizeProvider = (*Scanner)(nil)
+var _ detectors.ContentTypeProvider = (*Scanner)(nil)
 
 var (
 	// TODO: add base64 encoded key support
@@ -44,6 +45,28 @@ const maxPrivateKeySize = 4096
 // ProvideMaxSecretSize returns the maximum size of a secret that this detector can find.
 func (s Scanner) MaxSecretSize() int64 { return maxPrivateKeySize }
 
+// ContentTypes returns the media types of text content, as keys are PEM encoded.
+func (s Scanner) ContentTypes() []string {
+	return []string{
+		"text/*",
+		"application/json",
+		"application/xml",
+		"application/yaml",
+		"application/x-yaml",
+		"application/toml",
+		"application/javascript",
+		"application/x-sh",
+		"application/x-pem-file",
+		"application/x-x509-ca-cert",
+		"application/pkcs8",
+	}
+}
+
+// FileExtensions returns the extensions of key files, whatever their media type.
+func (s Scanner) FileExtensions() []string {
+	return []string{".pem", ".key", ".crt", ".cer", ".p8", ".pkcs8", ".rsa"}
+}
+
 // FromData will find and optionally verify Privatekey secrets in a given set of bytes.
 func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (results []detectors.Result, err error) {
 	dataStr := string(data)
@@ -0,0 +1,54 @@
+package detectors
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	registryMu sync.Mutex
+	registry   []Detector
+)
+
+// Register adds a detector to the detectors of the engine, in addition to the
+// built-in ones. It lets applications embedding TruffleHog find secrets in
+// their internal formats without forking it, and is meant to be called from
+// an init function. See Detector for the contract detectors must follow.
+//
+// Register panics if the detector is nil, or if a detector of the same Type
+// and Version is already registered.
+func Register(d Detector) {
+	if d == nil {
+		panic("detectors: Register detector is nil")
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, registered := range registry {
+		if registered.Type() == d.Type() && detectorVersion(registered) == detectorVersion(d) {
+			panic(fmt.Sprintf("detectors: Register called twice for detector %s version %d", d.Type(), detectorVersion(d)))
+		}
+	}
+	registry = append(registry, d)
+}
+
+// Registered returns the detectors added with Register, in order.
+func Registered() []Detector {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	return append([]Detector(nil), registry...)
+}
+
+// unregisterAll removes the registered detectors. It is only used by tests.
+func unregisterAll() {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = nil
+}
+
+func detectorVersion(d Detector) int {
+	if v, ok := d.(Versioner); ok {
+		return v.Version()
+	}
+	return 0
+}
@@ -0,0 +1,30 @@
+package detectors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+type internalDetector struct{ version int }
+
+func (internalDetector) FromData(context.Context, bool, []byte) ([]Result, error) { return nil, nil }
+func (internalDetector) Keywords() []string                                       { return []string{"acme_"} }
+func (internalDetector) Type() detectorspb.DetectorType                           { return detectorspb.DetectorType(-1) }
+func (d internalDetector) Version() int                                           { return d.version }
+
+func TestRegister(t *testing.T) {
+	t.Cleanup(unregisterAll)
+
+	v1, v2 := internalDetector{version: 1}, internalDetector{version: 2}
+	Register(v1)
+	Register(v2)
+	assert.Equal(t, []Detector{v1, v2}, Registered())
+
+	assert.Panics(t, func() { Register(internalDetector{version: 1}) })
+	assert.Panics(t, func() { Register(nil) })
+	assert.Len(t, Registered(), 2)
+}
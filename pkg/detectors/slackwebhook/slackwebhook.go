@@ -1,7 +1,6 @@
 package slackwebhook
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -25,9 +24,11 @@ var _ detectors.Detector = (*Scanner)(nil)
 var (
 	defaultClient = common.SaneHttpClient()
 	// Make sure that your group is surrounded in boundary characters such as below to reduce false positives.
+	// The first group is the URL of the webhook, the second one the ID of its workspace, and the third one the ID
+	// of the incoming webhook integration or of the workflow app.
 	keyPats = map[string]*regexp.Regexp{
-		"Slack Service Web Hook":   regexp.MustCompile(`(https://hooks\.slack\.com/services/T[A-Z0-9]+/B[A-Z0-9]+/[A-Za-z0-9]{23,25})`),
-		"Slack Workflow Web Hook ": regexp.MustCompile(`(https://hooks\.slack\.com/workflows/T[A-Z0-9]+/A[A-Z0-9]+/[0-9]{17,19}/[A-Za-z0-9]{23,25})`),
+		"Slack Service Web Hook":   regexp.MustCompile(`(https://hooks\.slack\.com/services/(T[A-Z0-9]+)/(B[A-Z0-9]+)/[A-Za-z0-9]{23,25})`),
+		"Slack Workflow Web Hook ": regexp.MustCompile(`(https://hooks\.slack\.com/workflows/(T[A-Z0-9]+)/(A[A-Z0-9]+)/[0-9]{17,19}/[A-Za-z0-9]{23,25})`),
 	}
 )
 
@@ -45,10 +46,15 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 		matches := keyPat.FindAllStringSubmatch(dataStr, -1)
 
 		for _, match := range matches {
-			if len(match) != 2 {
+			if len(match) != 4 {
 				continue
 			}
 			resMatch := strings.TrimSpace(match[1])
+			idKey := "service_id"
+			if strings.HasPrefix(match[3], "A") {
+				// Workflow webhooks post as the app of their workflow.
+				idKey = "app_id"
+			}
 
 			s1 := detectors.Result{
 				DetectorType: detectorspb.DetectorType_SlackWebhook,
@@ -56,50 +62,20 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 			}
 			s1.ExtraData = map[string]string{
 				"rotation_guide": "https://howtorotate.com/docs/tutorials/slack-webhook/",
+				"team_id":        match[2],
+				idKey:            match[3],
 			}
 
 			if verify {
-
 				client := s.client
 				if client == nil {
 					client = defaultClient
 				}
 
-				// We don't want to actually send anything to webhooks we find. To verify them without spamming them, we
-				// send an intentionally malformed message and look for a particular expected error message.
-				payload := strings.NewReader(`intentionally malformed JSON from TruffleHog scan`)
-				req, err := http.NewRequestWithContext(ctx, "POST", resMatch, payload)
-				if err != nil {
-					continue
-				}
-				req.Header.Add("Content-Type", "application/json")
-				res, err := client.Do(req)
-				if err == nil {
-					defer res.Body.Close()
-					bodyBytes, err := io.ReadAll(res.Body)
-					if err != nil {
-						continue
-					}
-
-					defer res.Body.Close()
-
-					switch {
-					case res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusMultipleChoices:
-						// Hopefully this never happens - it means we actually sent something to a channel somewhere. But
-						// we at least know the secret is verified.
-						s1.Verified = true
-					case res.StatusCode == http.StatusBadRequest && bytes.Equal(bodyBytes, []byte("invalid_payload")):
-						s1.Verified = true
-					case res.StatusCode == http.StatusNotFound:
-						// Not a real webhook or the owning app's OAuth token has been revoked or the app has been deleted
-						// You might want to handle this case or log it.
-					default:
-						err = fmt.Errorf("unexpected HTTP response status %d: %s", res.StatusCode, bodyBytes)
-						s1.SetVerificationError(err, resMatch)
-					}
-				} else {
-					s1.SetVerificationError(err, resMatch)
-				}
+				verified, revoked, verificationErr := verifyMatch(ctx, client, resMatch)
+				s1.Verified = verified
+				s1.Revoked = revoked
+				s1.SetVerificationError(verificationErr, resMatch)
 			}
 
 			results = append(results, s1)
@@ -109,6 +85,64 @@ func (s Scanner) FromData(ctx context.Context, verify bool, data []byte) (result
 	return results, nil
 }
 
+// verifyMatch checks whether the webhook is live without posting a message to its channel. It sends an
+// intentionally malformed message, which a live webhook rejects with invalid_payload before posting anything. A
+// webhook that was revoked, or whose channel or workspace is gone, is reported as revoked, and a URL that isn't a
+// webhook as unverified.
+func verifyMatch(ctx context.Context, client *http.Client, webhookURL string) (verified, revoked bool, err error) {
+	payload := strings.NewReader(`intentionally malformed JSON from TruffleHog scan`)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, payload)
+	if err != nil {
+		return false, false, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return false, false, err
+	}
+	defer res.Body.Close()
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return false, false, err
+	}
+	body := strings.TrimSpace(string(bodyBytes))
+
+	switch {
+	case res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusMultipleChoices:
+		// Hopefully this never happens - it means we actually sent something to a channel somewhere. But
+		// we at least know the secret is verified.
+		return true, false, nil
+	case res.StatusCode == http.StatusBadRequest && (body == "invalid_payload" || body == "no_text"):
+		return true, false, nil
+	case isRevoked(res.StatusCode, body):
+		return false, true, nil
+	case res.StatusCode == http.StatusNotFound:
+		// Not a real webhook.
+		return false, false, nil
+	case res.StatusCode == http.StatusTooManyRequests:
+		return false, false, fmt.Errorf("%w: unexpected HTTP response status %d", detectors.ErrRateLimited, res.StatusCode)
+	default:
+		return false, false, fmt.Errorf("unexpected HTTP response status %d: %s", res.StatusCode, body)
+	}
+}
+
+// isRevoked returns true if Slack responded that the webhook can no longer post, because the token of its app
+// was revoked, the app or integration was removed, or its channel or workspace was archived or deleted.
+func isRevoked(statusCode int, body string) bool {
+	switch statusCode {
+	case http.StatusForbidden:
+		return body == "invalid_token"
+	case http.StatusNotFound:
+		return body == "no_service" || body == "no_team" || body == "no_active_hooks" || body == "channel_not_found"
+	case http.StatusGone:
+		return body == "channel_is_archived" || body == "team_disabled"
+	default:
+		return false
+	}
+}
+
 func (s Scanner) Type() detectorspb.DetectorType {
 	return detectorspb.DetectorType_SlackWebhook
 }
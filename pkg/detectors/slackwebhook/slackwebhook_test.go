@@ -6,6 +6,7 @@ package slackwebhook
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -53,7 +54,7 @@ func TestSlackWebhook_FromChunk(t *testing.T) {
 			want: []detectors.Result{
 				{
 					DetectorType: detectorspb.DetectorType_SlackWebhook,
-					ExtraData:    map[string]string{"rotation_guide": "https://howtorotate.com/docs/tutorials/slack-webhook/"},
+					ExtraData:    wantExtraData(secret),
 					Verified:     true,
 				},
 			},
@@ -71,7 +72,7 @@ func TestSlackWebhook_FromChunk(t *testing.T) {
 			want: []detectors.Result{
 				{
 					DetectorType: detectorspb.DetectorType_SlackWebhook,
-					ExtraData:    map[string]string{"rotation_guide": "https://howtorotate.com/docs/tutorials/slack-webhook/"},
+					ExtraData:    wantExtraData(deletedUserActiveWebhook),
 					Verified:     true,
 				},
 			},
@@ -89,8 +90,9 @@ func TestSlackWebhook_FromChunk(t *testing.T) {
 			want: []detectors.Result{
 				{
 					DetectorType: detectorspb.DetectorType_SlackWebhook,
-					ExtraData:    map[string]string{"rotation_guide": "https://howtorotate.com/docs/tutorials/slack-webhook/"},
+					ExtraData:    wantExtraData(deletedWebhook),
 					Verified:     false,
+					Revoked:      true,
 				},
 			},
 			wantErr:             false,
@@ -107,8 +109,9 @@ func TestSlackWebhook_FromChunk(t *testing.T) {
 			want: []detectors.Result{
 				{
 					DetectorType: detectorspb.DetectorType_SlackWebhook,
-					ExtraData:    map[string]string{"rotation_guide": "https://howtorotate.com/docs/tutorials/slack-webhook/"},
+					ExtraData:    wantExtraData(inactiveSecret),
 					Verified:     false,
+					Revoked:      true,
 				},
 			},
 			wantErr:             false,
@@ -125,7 +128,7 @@ func TestSlackWebhook_FromChunk(t *testing.T) {
 			want: []detectors.Result{
 				{
 					DetectorType: detectorspb.DetectorType_SlackWebhook,
-					ExtraData:    map[string]string{"rotation_guide": "https://howtorotate.com/docs/tutorials/slack-webhook/"},
+					ExtraData:    wantExtraData(secret),
 					Verified:     false,
 				},
 			},
@@ -143,7 +146,7 @@ func TestSlackWebhook_FromChunk(t *testing.T) {
 			want: []detectors.Result{
 				{
 					DetectorType: detectorspb.DetectorType_SlackWebhook,
-					ExtraData:    map[string]string{"rotation_guide": "https://howtorotate.com/docs/tutorials/slack-webhook/"},
+					ExtraData:    wantExtraData(secret),
 					Verified:     false,
 				},
 			},
@@ -174,6 +177,22 @@ func TestSlackWebhook_FromChunk(t *testing.T) {
 	}
 }
 
+// wantExtraData returns the extra data of the result for a webhook, which
+// includes the IDs in its URL:
+// https://hooks.slack.com/<services|workflows>/<team ID>/<service or app ID>/...
+func wantExtraData(webhookURL string) map[string]string {
+	parts := strings.Split(webhookURL, "/")
+	idKey := "service_id"
+	if parts[3] == "workflows" {
+		idKey = "app_id"
+	}
+	return map[string]string{
+		"rotation_guide": "https://howtorotate.com/docs/tutorials/slack-webhook/",
+		"team_id":        parts[4],
+		idKey:            parts[5],
+	}
+}
+
 func BenchmarkFromData(benchmark *testing.B) {
 	ctx := context.Background()
 	s := Scanner{}
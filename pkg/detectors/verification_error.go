@@ -0,0 +1,66 @@
+package detectors
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+)
+
+// VerificationErrorCategory classifies why a result could not be verified.
+type VerificationErrorCategory string
+
+const (
+	// VerificationErrorNetwork means the verification request failed to reach the service or timed out.
+	VerificationErrorNetwork VerificationErrorCategory = "network"
+	// VerificationErrorRateLimited means the service rate limited the verification request.
+	VerificationErrorRateLimited VerificationErrorCategory = "rate_limited"
+	// VerificationErrorProviderUnavailable means the verification request wasn't made because
+	// the previous requests to the service kept failing.
+	VerificationErrorProviderUnavailable VerificationErrorCategory = "provider_unavailable"
+	// VerificationErrorUnknown is used for any other verification error.
+	VerificationErrorUnknown VerificationErrorCategory = "unknown"
+)
+
+var (
+	// ErrRateLimited should be wrapped in the verification error of a result when the
+	// service responds to the verification request with a rate limit, e.g. a 429 status code.
+	ErrRateLimited = errors.New("verification rate limited")
+	// ErrProviderUnavailable is set as the verification error of a result by the engine when
+	// it skips verifying the result because the service appears to be down.
+	ErrProviderUnavailable = errors.New("provider unavailable")
+)
+
+// categorizeVerificationError returns the category of a verification error.
+func categorizeVerificationError(err error) VerificationErrorCategory {
+	var (
+		categorized *categorizedError
+		netErr      net.Error
+		urlErr      *url.Error
+	)
+	switch {
+	case errors.As(err, &categorized):
+		// The verification error of another result.
+		return categorized.category
+	case errors.Is(err, ErrProviderUnavailable):
+		return VerificationErrorProviderUnavailable
+	case errors.Is(err, ErrRateLimited):
+		return VerificationErrorRateLimited
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr), errors.As(err, &urlErr):
+		return VerificationErrorNetwork
+	default:
+		return VerificationErrorUnknown
+	}
+}
+
+// categorizedError is a redacted verification error, which keeps the category
+// of the original error since redacting it discards its type.
+type categorizedError struct {
+	error
+	category VerificationErrorCategory
+}
+
+// Unwrap returns the redacted error.
+func (e *categorizedError) Unwrap() error {
+	return e.error
+}
@@ -0,0 +1,88 @@
+package detectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// verificationErrorDetector returns a single unverified result with verifyErr as its verification error.
+type verificationErrorDetector struct{ verifyErr error }
+
+func (d verificationErrorDetector) FromData(_ context.Context, _ bool, data []byte) ([]Result, error) {
+	result := Result{DetectorType: detectorspb.DetectorType(-1), Raw: data}
+	result.SetVerificationError(d.verifyErr, string(data))
+	return []Result{result}, nil
+}
+
+func (verificationErrorDetector) Keywords() []string             { return []string{"fake"} }
+func (verificationErrorDetector) Type() detectorspb.DetectorType { return detectorspb.DetectorType(-1) }
+
+func TestVerificationErrorCategory(t *testing.T) {
+	const secret = "fake-secret"
+
+	tests := []struct {
+		name      string
+		verifyErr error
+		want      VerificationErrorCategory
+	}{
+		{
+			name: "no error",
+		},
+		{
+			name:      "network",
+			verifyErr: &url.Error{Op: "Get", URL: "https://example.com", Err: &net.OpError{Op: "dial", Err: fmt.Errorf("connection refused")}},
+			want:      VerificationErrorNetwork,
+		},
+		{
+			name:      "timeout",
+			verifyErr: fmt.Errorf("request failed: %w", context.DeadlineExceeded),
+			want:      VerificationErrorNetwork,
+		},
+		{
+			name:      "rate limited",
+			verifyErr: fmt.Errorf("unexpected HTTP response status 429: %w", ErrRateLimited),
+			want:      VerificationErrorRateLimited,
+		},
+		{
+			name: "error of another result",
+			verifyErr: func() error {
+				var result Result
+				result.SetVerificationError(ErrRateLimited)
+				return result.VerificationError()
+			}(),
+			want: VerificationErrorRateLimited,
+		},
+		{
+			name:      "provider unavailable",
+			verifyErr: ErrProviderUnavailable,
+			want:      VerificationErrorProviderUnavailable,
+		},
+		{
+			name:      "unknown",
+			verifyErr: fmt.Errorf("unexpected response for key %s", secret),
+			want:      VerificationErrorUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := verificationErrorDetector{verifyErr: tt.verifyErr}.FromData(context.Background(), true, []byte(secret))
+			assert.NoError(t, err)
+			if assert.Len(t, results, 1) {
+				assert.Equal(t, tt.want, results[0].VerificationErrorCategory())
+				if tt.verifyErr != nil {
+					assert.NotContains(t, results[0].VerificationError().Error(), secret)
+					assert.EqualError(t, errors.Unwrap(results[0].VerificationError()), results[0].VerificationError().Error())
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package ahocorasick
 
 import (
 	"bytes"
+	"fmt"
 	"strings"
 
 	ahocorasick "github.com/BobuSumisu/aho-corasick"
@@ -27,6 +28,19 @@ type DetectorKey struct {
 // Type returns the detector type of the key.
 func (k DetectorKey) Type() detectorspb.DetectorType { return k.detectorType }
 
+// Name returns the name of the detector of the key: the name of a custom
+// detector, or its type, with its version if it has several, e.g. AWS.v2.
+func (k DetectorKey) Name() string {
+	switch {
+	case k.customDetectorName != "":
+		return k.customDetectorName
+	case k.version > 0:
+		return fmt.Sprintf("%s.v%d", k.detectorType, k.version)
+	default:
+		return k.detectorType.String()
+	}
+}
+
 // spanCalculator is an interface that defines a method for calculating a match span
 // in the chunk data. This allows for different strategies to be used without changing the core logic.
 type spanCalculator interface {
@@ -0,0 +1,119 @@
+package engine
+
+import (
+	aCtx "context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/decoders"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// allowlistTestDetector reports the word following its keyword as a verified
+// AWS secret, so it isn't filtered out as a known false positive.
+type allowlistTestDetector struct{}
+
+var _ detectors.Detector = (*allowlistTestDetector)(nil)
+
+var allowlistTestPat = regexp.MustCompile(`allowlisted (\S+)`)
+
+func (allowlistTestDetector) FromData(_ aCtx.Context, _ bool, data []byte) ([]detectors.Result, error) {
+	var results []detectors.Result
+	for _, m := range allowlistTestPat.FindAllSubmatch(data, -1) {
+		results = append(results, detectors.Result{DetectorType: detectorspb.DetectorType_AWS, Verified: true, Raw: m[1]})
+	}
+	return results, nil
+}
+
+func (allowlistTestDetector) Keywords() []string             { return []string{"allowlisted"} }
+func (allowlistTestDetector) Type() detectorspb.DetectorType { return detectorspb.DetectorType_AWS }
+
+func TestEngine_AllowlistFile(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	hash := sha256.Sum256([]byte("hashed-secret"))
+	allowlist := `
+- detector: aws
+  value: example-secret
+- detector: AWS
+  sha256: ` + hex.EncodeToString(hash[:]) + `
+- detector: "2"
+  value: fixture-secret
+  path: "test/**"
+- detector: AWS.v2
+  value: versioned-secret
+- detector: Github
+  value: real-secret
+`
+	path := filepath.Join(t.TempDir(), "allowlist.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(allowlist), 0o600))
+
+	dispatcher := new(recordingDispatcher)
+	conf := Config{
+		Concurrency:   1,
+		Decoders:      decoders.DefaultDecoders(),
+		Detectors:     []detectors.Detector{allowlistTestDetector{}},
+		SourceManager: sources.NewManager(sources.WithBufferedOutput(64)),
+		Dispatcher:    dispatcher,
+		AllowlistFile: path,
+	}
+	e, err := NewEngine(ctx, &conf)
+	require.NoError(t, err)
+
+	e.Start(ctx)
+	source := &gcsChunksSource{objects: [][3]string{
+		// Suppressed by value, by hash, and in the path of the entry.
+		{"bucket", "docs/example.md", "allowlisted example-secret"},
+		{"bucket", "app/config.env", "allowlisted hashed-secret"},
+		{"bucket", "test/data/fixture.txt", "allowlisted fixture-secret"},
+		// Outside of the path of the entry.
+		{"bucket", "app/main.go", "allowlisted fixture-secret"},
+		// Of another version, and another detector.
+		{"bucket", "app/versioned.txt", "allowlisted versioned-secret"},
+		{"bucket", "app/real.txt", "allowlisted real-secret"},
+	}}
+	_, err = e.sourceManager.Run(ctx, "gcs", source)
+	require.NoError(t, err)
+	require.NoError(t, e.Finish(ctx))
+
+	assert.ElementsMatch(t, []string{"fixture-secret", "versioned-secret", "real-secret"}, dispatcher.raw)
+	assert.Equal(t, uint64(3), e.GetMetrics().AllowlistedSecretsSuppressed)
+}
+
+func TestParseResultAllowlist(t *testing.T) {
+	for name, allowlist := range map[string]string{
+		"unknown detector": "- {detector: nope, value: secret}",
+		"missing detector": "- {value: secret}",
+		"no secret":        "- {detector: AWS}",
+		"value and hash":   "- {detector: AWS, value: secret, sha256: " + hex.EncodeToString(make([]byte, sha256.Size)) + "}",
+		"invalid hash":     "- {detector: AWS, sha256: secret}",
+		"invalid path":     "- {detector: AWS, value: secret, path: 'test/[a'}",
+		"unknown field":    "- {detector: AWS, value: secret, file: test}",
+		"not a list":       "detector: AWS",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseResultAllowlist([]byte(allowlist))
+			assert.Error(t, err)
+		})
+	}
+
+	// Hashes are case-insensitive.
+	hash := sha256.Sum256([]byte("secret"))
+	allowlist, err := parseResultAllowlist([]byte("- {detector: AWS, sha256: " + strings.ToUpper(hex.EncodeToString(hash[:])) + "}"))
+	require.NoError(t, err)
+	res := detectors.Result{DetectorType: detectorspb.DetectorType_AWS, Raw: []byte("secret")}
+	assert.True(t, allowlist.allows(allowlistTestDetector{}, &res, nil))
+}
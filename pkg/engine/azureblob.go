@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/credentialspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/azureblob"
+)
+
+// ScanAzureBlob with the provided options.
+func (e *Engine) ScanAzureBlob(ctx context.Context, c sources.AzureBlobConfig) error {
+	connection := &sourcespb.AzureBlob{
+		AccountUrl:              c.AccountURL,
+		ManagedIdentityClientId: c.ManagedIdentityClientID,
+		IncludeContainers:       c.IncludeContainers,
+		ExcludeContainers:       c.ExcludeContainers,
+		IncludeBlobs:            c.IncludeBlobs,
+		ExcludeBlobs:            c.ExcludeBlobs,
+		MaxBlobSize:             c.MaxBlobSize,
+		SkipBinaries:            c.SkipBinaries,
+		DisableArchiveHandling:  c.DisableArchiveHandling,
+	}
+
+	// Make sure exactly one auth method is selected.
+	var selected int
+	if c.ConnectionString != "" {
+		selected++
+		connection.Credential = &sourcespb.AzureBlob_ConnectionString{ConnectionString: c.ConnectionString}
+	}
+	if c.SASToken != "" {
+		selected++
+		connection.Credential = &sourcespb.AzureBlob_SasToken{SasToken: c.SASToken}
+	}
+	if c.ManagedIdentity {
+		selected++
+		connection.Credential = &sourcespb.AzureBlob_ManagedIdentity{ManagedIdentity: &credentialspb.CloudEnvironment{}}
+	}
+	switch {
+	case selected == 0:
+		return fmt.Errorf("no auth method selected, please provide a connection string, SAS token or managed identity")
+	case selected > 1:
+		return fmt.Errorf("multiple auth methods selected, please select only one")
+	}
+	if c.ConnectionString == "" && c.AccountURL == "" {
+		return fmt.Errorf("account URL is required")
+	}
+
+	var conn anypb.Any
+	err := anypb.MarshalFrom(&conn, connection, proto.MarshalOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to marshal Azure Blob connection: %w", err)
+	}
+
+	sourceName := "trufflehog - azure blob"
+	sourceID, jobID, _ := e.sourceManager.GetIDs(ctx, sourceName, azureblob.SourceType)
+
+	azureSource := &azureblob.Source{}
+	if err := azureSource.Init(ctx, sourceName, jobID, sourceID, true, &conn, c.Concurrency); err != nil {
+		return err
+	}
+	_, err = e.sourceManager.Run(ctx, sourceName, azureSource)
+	return err
+}
@@ -0,0 +1,155 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// chunkDebugSink writes the chunks the engine scans to a directory, so users
+// can see exactly what data reached detection when a secret is missed. Each
+// chunk is written as <key>.<n>.chunk with its data, and <key>.<n>.json with
+// its source metadata, where key is the location of the chunk in its source,
+// see chunkDebugKey, and n counts the chunks of that location.
+type chunkDebugSink struct {
+	dir string
+	// redact masks the letters and digits of the data written, see
+	// redactChunkData, so that the secrets it contains aren't written to
+	// disk.
+	redact bool
+
+	mu     sync.Mutex
+	chunks map[string]int
+}
+
+// chunkDebugInfo is the source information written next to a chunk.
+type chunkDebugInfo struct {
+	SourceName     string          `json:"source_name"`
+	SourceType     string          `json:"source_type"`
+	SourceID       int64           `json:"source_id"`
+	JobID          int64           `json:"job_id"`
+	Size           int             `json:"size"`
+	Redacted       bool            `json:"redacted"`
+	SourceMetadata json.RawMessage `json:"source_metadata,omitempty"`
+}
+
+func newChunkDebugSink(dir string, redact bool) (*chunkDebugSink, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("error creating chunk debug directory: %w", err)
+	}
+	return &chunkDebugSink{dir: dir, redact: redact, chunks: make(map[string]int)}, nil
+}
+
+// write writes the data and source information of a chunk.
+func (s *chunkDebugSink) write(chunk *sources.Chunk) error {
+	key := chunkDebugKey(chunk)
+	s.mu.Lock()
+	n := s.chunks[key]
+	s.chunks[key]++
+	s.mu.Unlock()
+
+	base := filepath.Join(s.dir, filepath.FromSlash(key)) + fmt.Sprintf(".%d", n)
+	if err := os.MkdirAll(filepath.Dir(base), 0o700); err != nil {
+		return fmt.Errorf("error creating chunk debug directory: %w", err)
+	}
+
+	data := chunk.Data
+	if s.redact {
+		data = redactChunkData(data)
+	}
+	if err := os.WriteFile(base+".chunk", data, 0o600); err != nil {
+		return fmt.Errorf("error writing chunk data: %w", err)
+	}
+
+	info := chunkDebugInfo{
+		SourceName: chunk.SourceName,
+		SourceType: chunk.SourceType.String(),
+		SourceID:   int64(chunk.SourceID),
+		JobID:      int64(chunk.JobID),
+		Size:       len(chunk.Data),
+		Redacted:   s.redact,
+	}
+	if chunk.SourceMetadata != nil {
+		metadata, err := protojson.Marshal(chunk.SourceMetadata)
+		if err != nil {
+			return fmt.Errorf("error encoding chunk metadata: %w", err)
+		}
+		info.SourceMetadata = metadata
+	}
+	encoded, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding chunk info: %w", err)
+	}
+	if err := os.WriteFile(base+".json", encoded, 0o600); err != nil {
+		return fmt.Errorf("error writing chunk info: %w", err)
+	}
+	return nil
+}
+
+// chunkDebugKey returns the relative path the chunk is written to, made of
+// its source type and its location in the source, e.g. gcs/<bucket>/<object>
+// for GCS. Chunks of sources without a location are keyed by source name.
+func chunkDebugKey(chunk *sources.Chunk) string {
+	var location []string
+	switch meta := chunk.SourceMetadata.GetData().(type) {
+	case *source_metadatapb.MetaData_Gcs:
+		location = []string{meta.Gcs.GetBucket(), meta.Gcs.GetFilename()}
+	case *source_metadatapb.MetaData_AzureBlob:
+		location = []string{meta.AzureBlob.GetContainer(), meta.AzureBlob.GetBlob()}
+	case *source_metadatapb.MetaData_S3:
+		location = []string{meta.S3.GetBucket(), meta.S3.GetFile()}
+	case *source_metadatapb.MetaData_Filesystem:
+		location = []string{filepath.ToSlash(meta.Filesystem.GetFile())}
+	case *source_metadatapb.MetaData_Git:
+		location = []string{meta.Git.GetCommit(), meta.Git.GetFile()}
+	case *source_metadatapb.MetaData_Github:
+		location = []string{meta.Github.GetCommit(), meta.Github.GetFile()}
+	case *source_metadatapb.MetaData_Gitlab:
+		location = []string{meta.Gitlab.GetCommit(), meta.Gitlab.GetFile()}
+	default:
+		location = []string{chunk.SourceName}
+	}
+
+	sourceType := strings.ToLower(strings.TrimPrefix(chunk.SourceType.String(), "SOURCE_TYPE_"))
+	key := []string{sourceType}
+	for _, part := range location {
+		// Cleaning each rooted part removes its parent directory
+		// references, so chunks are written inside the directory of their
+		// bucket, commit, etc.
+		if part = strings.TrimPrefix(path.Clean("/"+part), "/"); part != "" {
+			key = append(key, part)
+		}
+	}
+	if len(key) == 1 {
+		key = append(key, "chunk")
+	}
+	return path.Join(key...)
+}
+
+// redactChunkData masks the letters and digits of data, keeping its length
+// and structure, e.g. its whitespace, punctuation, and non-printable bytes.
+func redactChunkData(data []byte) []byte {
+	redacted := make([]byte, len(data))
+	for i, b := range data {
+		switch {
+		case b >= 'a' && b <= 'z':
+			redacted[i] = 'x'
+		case b >= 'A' && b <= 'Z':
+			redacted[i] = 'X'
+		case b >= '0' && b <= '9':
+			redacted[i] = '0'
+		default:
+			redacted[i] = b
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,146 @@
+package engine
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/decoders"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// gcsChunksSource emits chunks of GCS objects, given as their bucket and
+// name, and data.
+type gcsChunksSource struct {
+	sources.Progress
+	objects [][3]string
+}
+
+var _ sources.Source = (*gcsChunksSource)(nil)
+
+func (*gcsChunksSource) Type() sourcespb.SourceType { return sourcespb.SourceType_SOURCE_TYPE_GCS }
+func (*gcsChunksSource) SourceID() sources.SourceID { return 0 }
+func (*gcsChunksSource) JobID() sources.JobID       { return 0 }
+func (*gcsChunksSource) Close() error               { return nil }
+func (*gcsChunksSource) Init(context.Context, string, sources.JobID, sources.SourceID, bool, *anypb.Any, int) error {
+	return nil
+}
+
+func (s *gcsChunksSource) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ ...sources.ChunkingTarget) error {
+	for _, o := range s.objects {
+		chunk := &sources.Chunk{
+			SourceName: "gcs",
+			SourceType: s.Type(),
+			SourceMetadata: &source_metadatapb.MetaData{
+				Data: &source_metadatapb.MetaData_Gcs{
+					Gcs: &source_metadatapb.GCS{Bucket: o[0], Filename: o[1]},
+				},
+			},
+			Data: []byte(o[2]),
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case chunksChan <- chunk:
+		}
+	}
+	return nil
+}
+
+func TestEngine_ChunkDebugDir(t *testing.T) {
+	tests := []struct {
+		name     string
+		full     bool
+		wantData string
+	}{
+		{name: "redacted", wantData: "xxxxxxxxxxxx XxxXx-0000"},
+		{name: "full", full: true, wantData: fakeDetectorKeyword + " SecRe-1234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			dir := filepath.Join(t.TempDir(), "chunks")
+			conf := Config{
+				Concurrency:    1,
+				Decoders:       decoders.DefaultDecoders(),
+				Detectors:      []detectors.Detector{fakeDetectorV1{}},
+				SourceManager:  sources.NewManager(sources.WithBufferedOutput(64)),
+				Dispatcher:     new(recordingDispatcher),
+				ChunkDebugDir:  dir,
+				ChunkDebugFull: tt.full,
+			}
+
+			e, err := NewEngine(ctx, &conf)
+			assert.NoError(t, err)
+
+			e.Start(ctx)
+			source := &gcsChunksSource{objects: [][3]string{
+				{"bucket", "dir/secret.txt", fakeDetectorKeyword + " SecRe-1234"},
+				{"bucket", "dir/secret.txt", "second chunk"},
+				{"other", "../../escape.txt", "outside"},
+			}}
+			_, err = e.sourceManager.Run(ctx, "gcs", source)
+			assert.NoError(t, err)
+			assert.NoError(t, e.Finish(ctx))
+
+			// The chunks are named after their bucket and object, and
+			// numbered in the order they are scanned. Objects can't escape
+			// the directory.
+			var files []string
+			err = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+				if err == nil && !d.IsDir() {
+					rel, _ := filepath.Rel(dir, path)
+					files = append(files, filepath.ToSlash(rel))
+				}
+				return err
+			})
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, []string{
+				"gcs/bucket/dir/secret.txt.0.chunk",
+				"gcs/bucket/dir/secret.txt.0.json",
+				"gcs/bucket/dir/secret.txt.1.chunk",
+				"gcs/bucket/dir/secret.txt.1.json",
+				"gcs/other/escape.txt.0.chunk",
+				"gcs/other/escape.txt.0.json",
+			}, files)
+
+			// With a single scanner worker, the chunks of an object are
+			// numbered in the order they were sent.
+			data, err := os.ReadFile(filepath.Join(dir, "gcs", "bucket", "dir", "secret.txt.0.chunk"))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantData, string(data))
+
+			encoded, err := os.ReadFile(filepath.Join(dir, "gcs", "bucket", "dir", "secret.txt.0.json"))
+			assert.NoError(t, err)
+			var info struct {
+				SourceType     string `json:"source_type"`
+				Size           int    `json:"size"`
+				Redacted       bool   `json:"redacted"`
+				SourceMetadata struct {
+					Gcs struct {
+						Bucket   string `json:"bucket"`
+						Filename string `json:"filename"`
+					} `json:"gcs"`
+				} `json:"source_metadata"`
+			}
+			assert.NoError(t, json.Unmarshal(encoded, &info))
+			assert.Equal(t, "SOURCE_TYPE_GCS", info.SourceType)
+			assert.Equal(t, len(fakeDetectorKeyword+" SecRe-1234"), info.Size)
+			assert.Equal(t, !tt.full, info.Redacted)
+			assert.Equal(t, "bucket", info.SourceMetadata.Gcs.Bucket)
+			assert.Equal(t, "dir/secret.txt", info.SourceMetadata.Gcs.Filename)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"path"
+	"slices"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+// chunkContent returns the media type and file extension of the content of a
+// chunk, either of which is empty if the source doesn't provide it. The media
+// type of an object doesn't apply to the files extracted from it.
+func chunkContent(md *source_metadatapb.MetaData) (contentType, extension string) {
+	switch meta := md.GetData().(type) {
+	case *source_metadatapb.MetaData_Gcs:
+		contentType = meta.Gcs.GetContentType()
+	case *source_metadatapb.MetaData_AzureBlob:
+		contentType = meta.AzureBlob.GetContentType()
+	}
+
+	file := sourceFile(md)
+	if entry := sourceArchiveEntry(md); entry != "" {
+		file, contentType = entry, ""
+	}
+	return contentType, path.Ext(file)
+}
+
+// sourceFile returns the file of the source a chunk is read from, such as
+// the name of a GCS object or the path of a file in a repository, or an empty
+// string if the source doesn't have files. For files extracted from archives,
+// it's the file of the archive, see sourceArchiveEntry.
+func sourceFile(md *source_metadatapb.MetaData) string {
+	switch meta := md.GetData().(type) {
+	case *source_metadatapb.MetaData_Gcs:
+		return meta.Gcs.GetFilename()
+	case *source_metadatapb.MetaData_AzureBlob:
+		return meta.AzureBlob.GetBlob()
+	case *source_metadatapb.MetaData_Filesystem:
+		return meta.Filesystem.GetFile()
+	case *source_metadatapb.MetaData_Git:
+		return meta.Git.GetFile()
+	case *source_metadatapb.MetaData_Github:
+		return meta.Github.GetFile()
+	case *source_metadatapb.MetaData_Gitlab:
+		return meta.Gitlab.GetFile()
+	case *source_metadatapb.MetaData_S3:
+		return meta.S3.GetFile()
+	default:
+		return ""
+	}
+}
+
+// sourceArchiveEntry returns the path of the file within the archive a chunk
+// was extracted from, or an empty string if it wasn't extracted from one.
+func sourceArchiveEntry(md *source_metadatapb.MetaData) string {
+	switch meta := md.GetData().(type) {
+	case *source_metadatapb.MetaData_Gcs:
+		return meta.Gcs.GetArchiveEntry()
+	case *source_metadatapb.MetaData_AzureBlob:
+		return meta.AzureBlob.GetArchiveEntry()
+	case *source_metadatapb.MetaData_Filesystem:
+		return meta.Filesystem.GetArchiveEntry()
+	case *source_metadatapb.MetaData_Git:
+		return meta.Git.GetArchiveEntry()
+	case *source_metadatapb.MetaData_S3:
+		return meta.S3.GetArchiveEntry()
+	default:
+		return ""
+	}
+}
+
+// applicableDetectors removes the detectors that can't find secrets in the
+// content of the chunk, see detectors.AppliesToContent.
+func applicableDetectors(md *source_metadatapb.MetaData, matches []*ahocorasick.DetectorMatch) []*ahocorasick.DetectorMatch {
+	contentType, extension := chunkContent(md)
+	if contentType == "" && extension == "" {
+		return matches
+	}
+	return slices.DeleteFunc(matches, func(m *ahocorasick.DetectorMatch) bool {
+		return !detectors.AppliesToContent(m.Detector, contentType, extension)
+	})
+}
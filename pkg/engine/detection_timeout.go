@@ -0,0 +1,72 @@
+package engine
+
+import (
+	aCtx "context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// detectionTimeout is how long a detector may take to find and verify the
+// secrets of a match.
+const detectionTimeout = 10 * time.Second
+
+// pausableTimeout is a context that times out once it ran for its timeout,
+// not counting the time it was paused. Detections are paused while their
+// verification requests are queued, so that the time spent waiting for the
+// rate limiter or a verification slot doesn't count against the detector.
+type pausableTimeout struct {
+	context.Context
+	cancel aCtx.CancelCauseFunc
+
+	mu      sync.Mutex
+	timer   *time.Timer
+	left    time.Duration
+	started time.Time
+	paused  int
+}
+
+func newPausableTimeout(ctx context.Context, timeout time.Duration) *pausableTimeout {
+	t := &pausableTimeout{left: timeout, started: time.Now()}
+	t.Context, t.cancel = context.WithCancelCause(ctx)
+	t.timer = time.AfterFunc(timeout, func() { t.cancel(aCtx.DeadlineExceeded) })
+	return t
+}
+
+// Err returns context.DeadlineExceeded once the context timed out, like the
+// context of context.WithTimeout.
+func (t *pausableTimeout) Err() error {
+	err := t.Context.Err()
+	if err != nil && errors.Is(aCtx.Cause(t.Context), aCtx.DeadlineExceeded) {
+		return aCtx.DeadlineExceeded
+	}
+	return err
+}
+
+// pause stops the timeout until resume is called as many times as pause.
+func (t *pausableTimeout) pause() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.paused++
+	if t.paused == 1 && t.timer.Stop() {
+		t.left -= time.Since(t.started)
+	}
+}
+
+func (t *pausableTimeout) resume() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.paused--
+	if t.paused == 0 && t.Context.Err() == nil {
+		t.started = time.Now()
+		t.timer.Reset(max(t.left, 0))
+	}
+}
+
+// stop releases the resources of the context, which is cancelled.
+func (t *pausableTimeout) stop() {
+	t.timer.Stop()
+	t.cancel(aCtx.Canceled)
+}
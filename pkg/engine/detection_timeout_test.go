@@ -0,0 +1,37 @@
+package engine
+
+import (
+	aCtx "context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+func TestPausableTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	timeout := newPausableTimeout(ctx, 50*time.Millisecond)
+	defer timeout.stop()
+
+	// The time spent paused doesn't count, however many times it's paused.
+	timeout.pause()
+	timeout.pause()
+	timeout.resume()
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(t, timeout.Err())
+	timeout.resume()
+
+	select {
+	case <-timeout.Done():
+	case <-time.After(time.Second):
+		t.Fatal("didn't time out once resumed")
+	}
+	assert.ErrorIs(t, timeout.Err(), aCtx.DeadlineExceeded)
+
+	stopped := newPausableTimeout(ctx, time.Minute)
+	stopped.stop()
+	assert.ErrorIs(t, stopped.Err(), aCtx.Canceled)
+}
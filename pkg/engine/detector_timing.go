@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+// DetectorTiming is the time spent in the FromData of a detector over a scan.
+// The time spent waiting for the verification rate limit or a verification
+// slot isn't included.
+type DetectorTiming struct {
+	// Calls is the number of times FromData was called.
+	Calls uint64
+	// Detection is the time spent in the calls that didn't verify the
+	// secrets they found.
+	Detection time.Duration
+	// Verification is the time spent in the calls that verified the secrets
+	// they found, including finding them.
+	Verification time.Duration
+}
+
+// Total returns the time spent in the detector.
+func (t DetectorTiming) Total() time.Duration {
+	return t.Detection + t.Verification
+}
+
+// detectorTimings accumulates the DetectorTiming of each detector, by name.
+type detectorTimings struct {
+	mu      sync.Mutex
+	timings map[string]DetectorTiming
+}
+
+func newDetectorTimings() *detectorTimings {
+	return &detectorTimings{timings: make(map[string]DetectorTiming)}
+}
+
+// record adds a call of the FromData of the detector to its timing.
+func (t *detectorTimings) record(name string, verify bool, elapsed time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timing := t.timings[name]
+	timing.Calls++
+	if verify {
+		timing.Verification += elapsed
+	} else {
+		timing.Detection += elapsed
+	}
+	t.timings[name] = timing
+}
+
+// get returns a copy of the timings.
+func (t *detectorTimings) get() map[string]DetectorTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timings := make(map[string]DetectorTiming, len(t.timings))
+	for name, timing := range t.timings {
+		timings[name] = timing
+	}
+	return timings
+}
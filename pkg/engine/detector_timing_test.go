@@ -0,0 +1,128 @@
+package engine
+
+import (
+	aCtx "context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/decoders"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+const slowVerification = 20 * time.Millisecond
+
+// slowVerifierDetector finds the keyword slow_ and takes slowVerification to
+// verify it.
+type slowVerifierDetector struct{}
+
+var _ detectors.Detector = slowVerifierDetector{}
+
+func (slowVerifierDetector) FromData(_ aCtx.Context, verify bool, data []byte) ([]detectors.Result, error) {
+	if verify {
+		time.Sleep(slowVerification)
+	}
+	return []detectors.Result{{DetectorType: slowVerifierDetector{}.Type(), Raw: []byte("slow_")}}, nil
+}
+
+func (slowVerifierDetector) Keywords() []string             { return []string{"slow_"} }
+func (slowVerifierDetector) Type() detectorspb.DetectorType { return detectorspb.DetectorType(-4) }
+
+func TestEngine_DetectorTiming(t *testing.T) {
+	files := map[string]string{
+		"a.env": "API_TOKEN=tok_valid_1\nSLOW=slow_1\n",
+		"b.env": "API_TOKEN=tok_invalid_1\n",
+	}
+	dir := t.TempDir()
+	for name, data := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(data), 0644))
+	}
+
+	scan := func(t *testing.T, timing bool) ScanSummary {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		conf := Config{
+			Concurrency:    1,
+			Decoders:       decoders.DefaultDecoders(),
+			Detectors:      []detectors.Detector{statusDetector{}, slowVerifierDetector{}},
+			Verify:         true,
+			SourceManager:  sources.NewManager(sources.WithSourceUnits(), sources.WithBufferedOutput(64)),
+			Dispatcher:     new(recordingDispatcher),
+			DetectorTiming: timing,
+		}
+		e, err := NewEngine(ctx, &conf)
+		require.NoError(t, err)
+
+		e.Start(ctx)
+		require.NoError(t, e.ScanFileSystem(ctx, sources.FilesystemConfig{Paths: []string{dir}}))
+		require.NoError(t, e.Finish(ctx))
+		return e.ScanSummary()
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		timings := scan(t, true).DetectorTimings
+		require.Len(t, timings, 2)
+
+		status := timings[detectorspb.DetectorType(-3).String()]
+		assert.Equal(t, uint64(2), status.Calls)
+		assert.Positive(t, status.Verification)
+		// Every call verified its results.
+		assert.Zero(t, status.Detection)
+
+		slow := timings[detectorspb.DetectorType(-4).String()]
+		assert.Equal(t, uint64(1), slow.Calls)
+		assert.GreaterOrEqual(t, slow.Verification, slowVerification)
+		assert.Equal(t, slow.Verification, slow.Total())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Nil(t, scan(t, false).DetectorTimings)
+	})
+}
+
+func TestDetectorTimings(t *testing.T) {
+	timings := newDetectorTimings()
+	timings.record("AWS", false, time.Second)
+	timings.record("AWS", true, 2*time.Second)
+	timings.record("Github", false, time.Second)
+
+	got := timings.get()
+	assert.Equal(t, map[string]DetectorTiming{
+		"AWS":    {Calls: 2, Detection: time.Second, Verification: 2 * time.Second},
+		"Github": {Calls: 1, Detection: time.Second},
+	}, got)
+
+	// The timings returned are a copy.
+	timings.record("Github", true, time.Second)
+	assert.Equal(t, uint64(1), got["Github"].Calls)
+}
+
+func TestScanSummary_PrintDetectorTimings(t *testing.T) {
+	summary := ScanSummary{
+		DetectorTimings: map[string]DetectorTiming{
+			"Github": {Calls: 3, Detection: time.Second},
+			"AWS":    {Calls: 2, Detection: time.Second, Verification: 2 * time.Second},
+			"Slack":  {Calls: 1, Detection: time.Millisecond},
+		},
+	}
+
+	var out strings.Builder
+	assert.NoError(t, summary.Print(&out))
+	assert.Equal(t, `Scanned 0 chunks (0 bytes)
+Found 0 results: 0 verified, 0 unverified, 0 unknown
+
+Time by detector:
+  AWS: 3s in 2 calls (1s detecting, 2s verifying)
+  Github: 1s in 3 calls (1s detecting, 0s verifying)
+  Slack: 1ms in 1 calls (1ms detecting, 0s verifying)
+`, out.String())
+}
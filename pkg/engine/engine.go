@@ -2,8 +2,12 @@ package engine
 
 import (
 	"bytes"
+	aCtx "context"
 	"errors"
 	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
 	"runtime"
 	"strconv"
 	"sync"
@@ -12,14 +16,19 @@ import (
 
 	"github.com/adrg/strutil"
 	"github.com/adrg/strutil/metrics"
+	"github.com/google/uuid"
 	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/sync/semaphore"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/config"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/decoders"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/dbconnection"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/genericentropy"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/giturl"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/output"
@@ -34,16 +43,43 @@ var errOverlap = errors.New(
 		"You can override this behavior by using the --allow-verification-overlap flag.",
 )
 
+// ErrMaxScanDurationExceeded is the cause the sources are cancelled with when
+// the scan runs for longer than Config.MaxScanDuration.
+var ErrMaxScanDurationExceeded = errors.New("maximum scan duration exceeded")
+
+// ErrVerifiedSecretFound is the cause the sources are cancelled with when a
+// verified secret is found and Config.StopOnFirstVerified is set.
+var ErrVerifiedSecretFound = errors.New("verified secret found")
+
 // Metrics for the scan engine for external consumption.
 type Metrics struct {
 	BytesScanned           uint64
 	ChunksScanned          uint64
 	VerifiedSecretsFound   uint64
 	UnverifiedSecretsFound uint64
-	AvgDetectorTime        map[string]time.Duration
+	// UnverifiedSecretsSuppressed is the number of unverified results dropped
+	// because the engine only reports verified results, or because their
+	// confidence is below the minimum confidence.
+	UnverifiedSecretsSuppressed uint64
+	// AllowlistedSecretsSuppressed is the number of results dropped because
+	// they match an entry of Config.AllowlistFile.
+	AllowlistedSecretsSuppressed uint64
+	AvgDetectorTime              map[string]time.Duration
+
+	// ScanID is the ID of the scan, a UUID set in the results it reports.
+	ScanID string
 
 	scanStartTime time.Time
 	ScanDuration  time.Duration
+
+	// PartialScan is set when the sources were stopped because the scan ran
+	// for longer than Config.MaxScanDuration. The results of the data read
+	// until then are still reported.
+	PartialScan bool
+
+	// StoppedOnFirstVerified is set when the scan was stopped because a
+	// verified secret was found and Config.StopOnFirstVerified is set.
+	StoppedOnFirstVerified bool
 }
 
 // runtimeMetrics for the scan engine for internal use by the engine.
@@ -86,6 +122,21 @@ func (p *PrinterDispatcher) Dispatch(ctx context.Context, result detectors.Resul
 	return p.printer.Print(ctx, &result)
 }
 
+// MultiDispatcher dispatches results to each of its dispatchers in order, e.g. to print results
+// and also send them to an external system.
+type MultiDispatcher []ResultsDispatcher
+
+// Dispatch sends the result to every dispatcher, even if an earlier one fails.
+func (m MultiDispatcher) Dispatch(ctx context.Context, result detectors.ResultWithMetadata) error {
+	var errs []error
+	for _, d := range m {
+		if err := d.Dispatch(ctx, result); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // Config used to configure the engine.
 type Config struct {
 	// Number of concurrent scanner workers,
@@ -100,6 +151,26 @@ type Config struct {
 	CustomVerifiersOnly           bool
 	VerifierEndpoints             map[string]string
 
+	// DetectorAllowlist restricts the detectors to those of the given types.
+	// DetectorDenylist removes the detectors of the given types.
+	// Only one of them may be set.
+	DetectorAllowlist []detectorspb.DetectorType
+	DetectorDenylist  []detectorspb.DetectorType
+
+	// GenericEntropy enables the generic high-entropy string detector, in
+	// addition to the other detectors, when set. It is off by default since
+	// it finds many strings that aren't secrets.
+	GenericEntropy *genericentropy.Config
+
+	// DatabaseConnections enables the database connection string detector, in
+	// addition to the other detectors. It is off by default since the dialect
+	// detectors, e.g. postgres and mongodb, find most of the same secrets.
+	DatabaseConnections bool
+	// VerifyDatabaseConnections verifies the database connection strings
+	// found by connecting to their databases, when Verify is set too. It is
+	// off by default since it connects to the hosts found in the data.
+	VerifyDatabaseConnections bool
+
 	// Verify determines whether the scanner will verify candidate secrets.
 	Verify bool
 
@@ -108,8 +179,25 @@ type Config struct {
 	Results               map[string]struct{}
 	LogFilteredUnverified bool
 
+	// OnlyVerified drops unverified results as soon as they are detected,
+	// before their line numbers, links, and other metadata are computed.
+	// Dropped results are counted in Metrics.UnverifiedSecretsSuppressed.
+	OnlyVerified bool
+
+	// MinConfidence drops unverified results whose confidence is lower, see
+	// detectors.Confidence. Verified results are always kept. The zero value
+	// keeps all results.
+	MinConfidence detectors.Confidence
+
 	// FilterEntropy filters out unverified results using Shannon entropy.
 	FilterEntropy float64
+	// EntropyThresholds override, by detector type, the minimum Shannon
+	// entropy of secrets, in bits per character. Detectors that gate their
+	// matches on entropy, see detectors.EntropyThresholdCustomizer, use it
+	// instead of their default, and the unverified results of the type are
+	// filtered with it instead of FilterEntropy. Types that aren't set use the
+	// defaults.
+	EntropyThresholds map[detectorspb.DetectorType]float64
 	// FilterUnverified sets the filterUnverified flag on the engine. If set to
 	// true, the engine will only return the first unverified result for a chunk for a detector.
 	FilterUnverified      bool
@@ -129,10 +217,98 @@ type Config struct {
 	// and should be avoided unless specified by the user.
 	PrintAvgDetectorTime bool
 
+	// DetectorTiming records the time spent in the FromData of each detector,
+	// finding and verifying secrets, in the ScanSummary. It's off by default to
+	// avoid its overhead.
+	DetectorTiming bool
+
 	// VerificationOverlap determines whether the scanner will attempt to verify candidate secrets
 	// that have been detected by multiple detectors.
 	// By default, it is set to true.
 	VerificationOverlap bool
+
+	// VerificationRateLimit is the maximum number of verification requests per second
+	// for each detector type. Requests over the limit are queued until they are permitted.
+	// Only the requests detectors make with the HTTP clients of the common package
+	// are limited. A value of 0 disables rate limiting.
+	VerificationRateLimit float64
+
+	// VerificationConcurrency is the maximum number of verification requests sent
+	// at once, independently of Concurrency, since verification is bound by network
+	// requests rather than by the CPU. Detection, including that of the detectors
+	// verifying secrets, isn't limited by it. Only the requests detectors make with
+	// the HTTP clients of the common package are limited. A value of 0 doesn't limit
+	// verification concurrency.
+	VerificationConcurrency int
+
+	// VerificationCircuitBreakerThreshold is the number of consecutive failed
+	// verification requests of a detector after which its results are no longer
+	// verified, until VerificationCircuitBreakerCooldown has elapsed and a
+	// verification request succeeds again. A value of 0 disables the circuit breaker.
+	VerificationCircuitBreakerThreshold int
+	// VerificationCircuitBreakerCooldown is how long the results of a detector
+	// aren't verified once its circuit breaker trips. Defaults to one minute.
+	VerificationCircuitBreakerCooldown time.Duration
+
+	// VerificationCache reuses the responses to the verification requests of a
+	// secret for its later occurrences within the scan, instead of sending them
+	// again. Only the requests detectors make with the HTTP clients of the common
+	// package are cached.
+	VerificationCache bool
+	// VerificationCacheTTL is how long verification responses are reused.
+	// A value of 0 reuses them until the end of the scan.
+	VerificationCacheTTL time.Duration
+
+	// VerificationProxy is the URL of the HTTP, HTTPS or SOCKS5 proxy the
+	// requests sent to verify secrets go through, except the ones to hosts
+	// matching NO_PROXY. Only the requests detectors make with the HTTP clients
+	// of the common package go through it. Sources aren't affected. If empty,
+	// the proxy configured by the environment is used.
+	VerificationProxy string
+
+	// ContentTypeGating skips the detectors that declare the content they can
+	// find secrets in for chunks of other content, based on the media type and
+	// file extension the source provides, see detectors.ContentTypeProvider.
+	// Chunks of unknown content are scanned by all detectors.
+	ContentTypeGating bool
+
+	// MaxScanDuration is the time budget of the scan. Once it is exceeded, the
+	// sources are cancelled and checkpoint their progress, and the chunks they
+	// already produced are scanned before Finish returns. A value of 0 doesn't
+	// limit the duration of the scan.
+	MaxScanDuration time.Duration
+
+	// ResultsBufferSize is the number of results buffered between the detector
+	// workers and the dispatcher. Once the buffer is full, detector workers wait
+	// for results to be dispatched, which in turn slows down chunk reading, so
+	// results don't accumulate in memory when output is slow.
+	// A value of 0 uses the default buffer size.
+	ResultsBufferSize int
+
+	// StopOnFirstVerified stops the scan as soon as a verified secret is
+	// reported: the sources are cancelled, and the chunks they already
+	// produced are dropped instead of being scanned. Results found until then
+	// are still reported. See Metrics.StoppedOnFirstVerified.
+	StopOnFirstVerified bool
+
+	// ChunkDebugDir is the directory the chunks scanned are written to, with
+	// their source metadata, to troubleshoot secrets that aren't found. The
+	// data of the chunks is redacted unless ChunkDebugFull is set, since it
+	// contains the secrets. Chunks aren't written if it is empty.
+	ChunkDebugDir  string
+	ChunkDebugFull bool
+
+	// ExtraLabels are key/values attached to every result, e.g. the
+	// environment, team, or ticket, for the systems the results are sent to.
+	// Output formats keep them apart from the fields of results.
+	ExtraLabels map[string]string
+
+	// AllowlistFile is the path of a YAML file listing the secrets known to
+	// be benign, e.g. test fixtures, by detector, as their value or its
+	// SHA-256 hash, optionally only in the files matching a glob. The
+	// results matching one of them are dropped, and counted in
+	// Metrics.AllowlistedSecretsSuppressed. See allowlistEntryConfig.
+	AllowlistFile string
 }
 
 // Engine represents the core scanning engine responsible for detecting secrets in input data.
@@ -152,13 +328,19 @@ type Engine struct {
 	// only the first one will be kept.
 	filterUnverified bool
 	// entropyFilter is used to filter out unverified results using Shannon entropy.
+	// entropyThresholds override it by detector type.
 	filterEntropy           float64
+	entropyThresholds       map[detectorspb.DetectorType]float64
 	notifyVerifiedResults   bool
 	notifyUnverifiedResults bool
 	notifyUnknownResults    bool
+	onlyVerified            bool
+	minConfidence           detectors.Confidence
 	retainFalsePositives    bool
 	verificationOverlap     bool
 	printAvgDetectorTime    bool
+	// contentTypeGating skips detectors that can't match the content of a chunk.
+	contentTypeGating bool
 	// By default, the engine will only scan a subset of the chunk if a detector matches the chunk.
 	// If this flag is set to true, the engine will scan the entire chunk.
 	scanEntireChunk bool
@@ -175,6 +357,8 @@ type Engine struct {
 	verificationOverlapWg         sync.WaitGroup
 	wgDetectorWorkers             sync.WaitGroup
 	WgNotifier                    sync.WaitGroup
+	// resultsBufferSize is the capacity of the results channel.
+	resultsBufferSize int
 
 	// Runtime information.
 	metrics runtimeMetrics
@@ -185,12 +369,65 @@ type Engine struct {
 	dispatcher ResultsDispatcher
 
 	// dedupeCache is used to deduplicate results by comparing the
-	// detector type, raw result, and source metadata
-	dedupeCache *lru.Cache[string, detectorspb.DecoderType]
+	// detector type, raw result, and location in the source. See dedupeKey.
+	dedupeCache *lru.Cache[string, struct{}]
 
 	// verify determines whether the scanner will attempt to verify candidate secrets.
 	verify bool
 
+	// verificationRateLimiter throttles verification requests per detector type.
+	// It is nil if verification is not rate limited.
+	verificationRateLimiter *verificationRateLimiter
+
+	// verificationSlots limits the number of verification requests sent at once.
+	// It is nil if verification concurrency is not limited.
+	verificationSlots *semaphore.Weighted
+
+	// verificationBreaker stops verifying the results of detectors whose
+	// service keeps failing. It is nil if the circuit breaker is disabled.
+	verificationBreaker *verificationCircuitBreaker
+
+	// maxScanDuration is the time budget of the scan, if set. scanBudget
+	// cancels the sources once it is exceeded, and partialScan records it.
+	maxScanDuration time.Duration
+	scanBudget      *time.Timer
+	partialScan     atomic.Bool
+
+	// stopOnFirstVerified stops the scan once a verified secret is reported,
+	// which stoppedOnVerified records.
+	stopOnFirstVerified bool
+	stoppedOnVerified   atomic.Bool
+
+	// verificationCache reuses verification responses within the scan.
+	// It is nil if responses aren't reused.
+	verificationCache *verificationCache
+
+	// verificationProxy returns the proxy of the verification requests. It is
+	// nil if they use the proxy configured by the environment.
+	verificationProxy func(*http.Request) (*url.URL, error)
+
+	// chunkDebugSink writes the chunks scanned for troubleshooting. It is nil
+	// if they aren't written.
+	chunkDebugSink *chunkDebugSink
+
+	// extraLabels are attached to every result. They are shared by the
+	// results, so they must not be modified.
+	extraLabels map[string]string
+
+	// scanID identifies the scan in its results. It is generated when the
+	// engine is started.
+	scanID string
+
+	// allowlist suppresses the results known to be benign. It is nil if
+	// there is no allowlist.
+	allowlist *resultAllowlist
+
+	// summary aggregates the ScanSummary of the scan.
+	summary *scanSummary
+	// detectorTimings records the time spent in each detector. It is nil if
+	// the timings aren't recorded.
+	detectorTimings *detectorTimings
+
 	// Note: bad hack only used for testing.
 	verificationOverlapTracker *verificationOverlapTracker
 }
@@ -205,19 +442,146 @@ func NewEngine(ctx context.Context, cfg *Config) (*Engine, error) {
 		verify:                        cfg.Verify,
 		filterUnverified:              cfg.FilterUnverified,
 		filterEntropy:                 cfg.FilterEntropy,
+		onlyVerified:                  cfg.OnlyVerified,
+		minConfidence:                 cfg.MinConfidence,
 		printAvgDetectorTime:          cfg.PrintAvgDetectorTime,
 		retainFalsePositives:          cfg.LogFilteredUnverified,
 		verificationOverlap:           cfg.VerificationOverlap,
 		sourceManager:                 cfg.SourceManager,
 		scanEntireChunk:               cfg.ShouldScanEntireChunk,
 		detectorVerificationOverrides: cfg.DetectorVerificationOverrides,
+		resultsBufferSize:             cfg.ResultsBufferSize,
+		maxScanDuration:               cfg.MaxScanDuration,
+		contentTypeGating:             cfg.ContentTypeGating,
+		stopOnFirstVerified:           cfg.StopOnFirstVerified,
+		summary:                       newScanSummary(),
+	}
+	if cfg.DetectorTiming {
+		engine.detectorTimings = newDetectorTimings()
 	}
 	if engine.sourceManager == nil {
 		return nil, fmt.Errorf("source manager is required")
 	}
 
+	if cfg.VerificationRateLimit < 0 {
+		return nil, fmt.Errorf("verification rate limit must not be negative")
+	}
+	if cfg.VerificationRateLimit > 0 {
+		engine.verificationRateLimiter = newVerificationRateLimiter(cfg.VerificationRateLimit, realClock{})
+	}
+
+	if cfg.VerificationConcurrency < 0 {
+		return nil, fmt.Errorf("verification concurrency must not be negative")
+	}
+	if cfg.VerificationConcurrency > 0 {
+		engine.verificationSlots = semaphore.NewWeighted(int64(cfg.VerificationConcurrency))
+	}
+
+	if cfg.VerificationCircuitBreakerThreshold < 0 {
+		return nil, fmt.Errorf("verification circuit breaker threshold must not be negative")
+	}
+	if cfg.VerificationCircuitBreakerCooldown < 0 {
+		return nil, fmt.Errorf("verification circuit breaker cooldown must not be negative")
+	}
+	if cfg.VerificationCircuitBreakerThreshold > 0 {
+		engine.verificationBreaker = newVerificationCircuitBreaker(
+			cfg.VerificationCircuitBreakerThreshold, cfg.VerificationCircuitBreakerCooldown, realClock{})
+	}
+
+	if cfg.MaxScanDuration < 0 {
+		return nil, fmt.Errorf("maximum scan duration must not be negative")
+	}
+
+	if cfg.VerificationCacheTTL < 0 {
+		return nil, fmt.Errorf("verification cache TTL must not be negative")
+	}
+	if cfg.VerificationCache {
+		cache, err := newVerificationCache(verificationCacheSize, cfg.VerificationCacheTTL, realClock{})
+		if err != nil {
+			return nil, err
+		}
+		engine.verificationCache = cache
+	}
+
+	if cfg.VerificationProxy != "" {
+		proxy, err := common.ParseProxyURL(cfg.VerificationProxy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure the verification proxy: %w", err)
+		}
+		engine.verificationProxy = common.ProxyFunc(proxy)
+	}
+
+	if cfg.ChunkDebugDir != "" {
+		sink, err := newChunkDebugSink(cfg.ChunkDebugDir, !cfg.ChunkDebugFull)
+		if err != nil {
+			return nil, err
+		}
+		engine.chunkDebugSink = sink
+		ctx.Logger().Info("writing the chunks scanned for debugging", "dir", cfg.ChunkDebugDir, "redacted", !cfg.ChunkDebugFull)
+	}
+
+	if cfg.ResultsBufferSize < 0 {
+		return nil, fmt.Errorf("results buffer size must not be negative")
+	}
+
+	for key := range cfg.ExtraLabels {
+		if key == "" {
+			return nil, fmt.Errorf("extra labels must have a name")
+		}
+	}
+	if len(cfg.ExtraLabels) > 0 {
+		engine.extraLabels = maps.Clone(cfg.ExtraLabels)
+	}
+
+	if cfg.AllowlistFile != "" {
+		allowlist, err := readResultAllowlist(cfg.AllowlistFile)
+		if err != nil {
+			return nil, err
+		}
+		engine.allowlist = allowlist
+	}
+
+	if len(cfg.DetectorAllowlist) > 0 && len(cfg.DetectorDenylist) > 0 {
+		return nil, fmt.Errorf("detector allowlist and denylist are mutually exclusive")
+	}
+
 	engine.setDefaults(ctx)
 
+	if cfg.GenericEntropy != nil {
+		d, err := genericentropy.New(*cfg.GenericEntropy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid generic entropy detector configuration: %w", err)
+		}
+		engine.detectors = append(engine.detectors, d)
+	}
+
+	if cfg.DatabaseConnections {
+		engine.detectors = append(engine.detectors, new(dbconnection.Scanner))
+	}
+
+	for detectorType, threshold := range cfg.EntropyThresholds {
+		if threshold < 0 {
+			return nil, fmt.Errorf("entropy threshold of %s must not be negative", detectorType)
+		}
+	}
+	if len(cfg.EntropyThresholds) > 0 {
+		engine.entropyThresholds = maps.Clone(cfg.EntropyThresholds)
+		for _, d := range engine.detectors {
+			threshold, ok := engine.entropyThresholds[d.Type()]
+			if customizer, isCustomizer := d.(detectors.EntropyThresholdCustomizer); ok && isCustomizer {
+				customizer.SetEntropyThreshold(threshold)
+			}
+		}
+	}
+
+	if cfg.VerifyDatabaseConnections {
+		for _, d := range engine.detectors {
+			if s, ok := d.(*dbconnection.Scanner); ok {
+				s.VerifyConnections = true
+			}
+		}
+	}
+
 	// Build include and exclude detector sets for filtering on engine initialization.
 	includeDetectorSet, excludeDetectorSet, err := buildDetectorSets(cfg)
 	if err != nil {
@@ -241,6 +605,22 @@ func NewEngine(ctx context.Context, cfg *Config) (*Engine, error) {
 		})
 	}
 
+	if len(cfg.DetectorAllowlist) > 0 {
+		allowlist := detectorTypesToSet(cfg.DetectorAllowlist)
+		filters = append(filters, func(d detectors.Detector) bool {
+			_, ok := allowlist[d.Type()]
+			return ok
+		})
+	}
+
+	if len(cfg.DetectorDenylist) > 0 {
+		denylist := detectorTypesToSet(cfg.DetectorDenylist)
+		filters = append(filters, func(d detectors.Detector) bool {
+			_, ok := denylist[d.Type()]
+			return !ok
+		})
+	}
+
 	// Apply custom verifier endpoints to detectors that support it.
 	detectorsWithCustomVerifierEndpoints, err := parseCustomVerifierEndpoints(cfg.VerifierEndpoints)
 	if err != nil {
@@ -311,10 +691,17 @@ func (e *Engine) setDefaults(ctx context.Context) {
 	if len(e.detectors) == 0 {
 		e.detectors = DefaultDetectors()
 	}
+	// Detectors registered by applications embedding the engine are used in
+	// addition to the configured ones.
+	e.detectors = append(e.detectors, detectors.Registered()...)
 
 	if e.dispatcher == nil {
 		e.dispatcher = NewPrinterDispatcher(new(output.PlainPrinter))
 	}
+
+	if e.resultsBufferSize == 0 {
+		e.resultsBufferSize = defaultChannelBuffer
+	}
 	e.notifyVerifiedResults = true
 	e.notifyUnverifiedResults = true
 	e.notifyUnknownResults = true
@@ -380,6 +767,15 @@ func detectorTypeToSet(detectors []config.DetectorID) map[config.DetectorID]stru
 	return out
 }
 
+// detectorTypesToSet is a helper function to convert a slice of detector types into a set.
+func detectorTypesToSet(types []detectorspb.DetectorType) map[detectorspb.DetectorType]struct{} {
+	out := make(map[detectorspb.DetectorType]struct{}, len(types))
+	for _, t := range types {
+		out[t] = struct{}{}
+	}
+	return out
+}
+
 // getWithDetectorID is a helper function to get a value from a map using a
 // detector's ID. This function behaves like a normal map lookup, with an extra
 // step of checking for the non-specific version of a detector.
@@ -439,7 +835,7 @@ func (e *Engine) initialize(ctx context.Context) error {
 	// TODO (ahrav): Determine the optimal cache size.
 	const cacheSize = 512 // number of entries in the LRU cache
 
-	cache, err := lru.New[string, detectorspb.DecoderType](cacheSize)
+	cache, err := lru.New[string, struct{}](cacheSize)
 	if err != nil {
 		return fmt.Errorf("failed to initialize LRU cache: %w", err)
 	}
@@ -464,7 +860,9 @@ func (e *Engine) initialize(ctx context.Context) error {
 	e.verificationOverlapChunksChan = make(
 		chan verificationOverlapChunk, defaultChannelBuffer*verificationOverlapChunksChanMultiplier,
 	)
-	e.results = make(chan detectors.ResultWithMetadata, defaultChannelBuffer)
+	// The results channel is bounded so that detection is held back when
+	// results can't be dispatched as fast as they are found.
+	e.results = make(chan detectors.ResultWithMetadata, e.resultsBufferSize)
 	e.dedupeCache = cache
 	ctx.Logger().V(4).Info("engine initialized")
 
@@ -494,6 +892,19 @@ func (r *verificationOverlapTracker) increment() {
 
 const ignoreTag = "trufflehog:ignore"
 
+// ScanID returns the ID of the scan set in its results. It is empty until the
+// engine is started.
+func (e *Engine) ScanID() string {
+	return e.scanID
+}
+
+// detectionTime returns the current time in UTC. It is measured from the
+// start of the scan with the monotonic clock, so the times of the results
+// don't go backwards if the wall clock is adjusted during the scan.
+func (e *Engine) detectionTime() time.Time {
+	return e.metrics.scanStartTime.Add(time.Since(e.metrics.scanStartTime)).UTC()
+}
+
 // HasFoundResults returns true if any results are found.
 func (e *Engine) HasFoundResults() bool {
 	return atomic.LoadUint32(&e.numFoundResults) > 0
@@ -522,6 +933,16 @@ func (e *Engine) GetMetrics() Metrics {
 	return result
 }
 
+// ScanSummary returns the summary of the data scanned and the results
+// reported so far. It is complete once Finish returns.
+func (e *Engine) ScanSummary() ScanSummary {
+	summary := e.summary.get()
+	if e.detectorTimings != nil {
+		summary.DetectorTimings = e.detectorTimings.get()
+	}
+	return summary
+}
+
 // GetDetectorsMetrics returns a copy of the average time taken by each detector.
 func (e *Engine) GetDetectorsMetrics() map[string]time.Duration {
 	e.metrics.mu.RLock()
@@ -567,9 +988,32 @@ func (e *Engine) DetectorAvgTime() map[string][]time.Duration {
 // detectors, and kickstarts all necessary workers. Once started, the engine
 // begins processing input data to identify secrets.
 func (e *Engine) Start(ctx context.Context) {
-	e.metrics = runtimeMetrics{Metrics: Metrics{scanStartTime: time.Now()}}
+	e.scanID = uuid.NewString()
+	e.metrics = runtimeMetrics{Metrics: Metrics{ScanID: e.scanID, scanStartTime: time.Now()}}
+	ctx.Logger().V(2).Info("starting scan", "scan_id", e.scanID)
 	e.sanityChecks(ctx)
 	e.startWorkers(ctx)
+	if e.maxScanDuration > 0 {
+		e.scanBudget = time.AfterFunc(e.maxScanDuration, func() { e.stopSources(ctx) })
+	}
+}
+
+// stopSources cancels the running sources once the scan exceeded its maximum
+// duration. The engine keeps scanning the chunks they already produced.
+func (e *Engine) stopSources(ctx context.Context) {
+	ctx.Logger().Info("maximum scan duration exceeded, stopping the scan", "max_scan_duration", e.maxScanDuration.String())
+	e.partialScan.Store(true)
+	e.sourceManager.Cancel(ErrMaxScanDurationExceeded)
+}
+
+// stopOnVerified cancels the running sources once a verified secret was
+// reported. The chunks already produced are dropped by the workers.
+func (e *Engine) stopOnVerified(ctx context.Context) {
+	if !e.stoppedOnVerified.CompareAndSwap(false, true) {
+		return
+	}
+	ctx.Logger().Info("verified secret found, stopping the scan")
+	e.sourceManager.Cancel(ErrVerifiedSecretFound)
 }
 
 var defaultChannelBuffer = runtime.NumCPU()
@@ -674,6 +1118,20 @@ func (e *Engine) Finish(ctx context.Context) error {
 	defer common.RecoverWithExit(ctx)
 	// Wait for the sources to finish putting chunks onto the chunks channel.
 	err := e.sourceManager.Wait()
+	if e.scanBudget != nil {
+		e.scanBudget.Stop()
+	}
+	// Sources stopped by the scan budget, or after a verified secret was
+	// found, may fail with their context's error, which doesn't make the scan
+	// fail.
+	partialScan := e.partialScan.Load()
+	if partialScan && (errors.Is(err, ErrMaxScanDurationExceeded) || errors.Is(err, aCtx.Canceled)) {
+		err = nil
+	}
+	stoppedOnVerified := e.stoppedOnVerified.Load()
+	if stoppedOnVerified && (errors.Is(err, ErrVerifiedSecretFound) || errors.Is(err, aCtx.Canceled)) {
+		err = nil
+	}
 
 	e.workersWg.Wait() // Wait for the workers to finish scanning chunks.
 
@@ -682,11 +1140,16 @@ func (e *Engine) Finish(ctx context.Context) error {
 
 	close(e.detectableChunksChan)
 	e.wgDetectorWorkers.Wait() // Wait for the detector workers to finish detecting chunks.
+	if e.verificationCache != nil {
+		e.verificationCache.clear()
+	}
 
 	close(e.results)    // Detector workers are done, close the results channel and call it a day.
 	e.WgNotifier.Wait() // Wait for the notifier workers to finish notifying results.
 
 	e.metrics.ScanDuration = time.Since(e.metrics.scanStartTime)
+	e.metrics.PartialScan = partialScan
+	e.metrics.StoppedOnFirstVerified = stoppedOnVerified
 
 	return err
 }
@@ -729,6 +1192,23 @@ func (e *Engine) scannerWorker(ctx context.Context) {
 	var wgVerificationOverlap sync.WaitGroup
 
 	for chunk := range e.ChunksChan() {
+		if e.stoppedOnVerified.Load() {
+			// Drain the chunks of the stopped sources.
+			continue
+		}
+		if err := chunk.Validate(); err != nil {
+			// Chunks that are only missing data have nothing to scan, which
+			// isn't a bug of their source.
+			if chunk.ValidateSkeleton() != nil {
+				ctx.Logger().Error(err, "skipping invalid chunk")
+			}
+			continue
+		}
+		if e.chunkDebugSink != nil {
+			if err := e.chunkDebugSink.write(chunk); err != nil {
+				ctx.Logger().Error(err, "error writing chunk for debugging")
+			}
+		}
 		startTime := time.Now()
 		sourceVerify := chunk.Verify
 		for _, decoder := range e.decoders {
@@ -739,6 +1219,9 @@ func (e *Engine) scannerWorker(ctx context.Context) {
 			}
 
 			matchingDetectors := e.ahoCorasickCore.FindDetectorMatches(decoded.Chunk.Data)
+			if e.contentTypeGating {
+				matchingDetectors = applicableDetectors(chunk.SourceMetadata, matchingDetectors)
+			}
 			if len(matchingDetectors) > 1 && !e.verificationOverlap {
 				wgVerificationOverlap.Add(1)
 				e.verificationOverlapChunksChan <- verificationOverlapChunk{
@@ -780,6 +1263,7 @@ func (e *Engine) scannerWorker(ctx context.Context) {
 
 		atomic.AddUint64(&e.metrics.ChunksScanned, 1)
 		atomic.AddUint64(&e.metrics.BytesScanned, uint64(dataSize))
+		e.summary.addChunk(chunk)
 	}
 
 	wgVerificationOverlap.Wait()
@@ -969,6 +1453,10 @@ func (e *Engine) verificationOverlapWorker(ctx context.Context) {
 
 func (e *Engine) detectorWorker(ctx context.Context) {
 	for data := range e.detectableChunksChan {
+		if e.stoppedOnVerified.Load() {
+			data.wgDoneFn()
+			continue
+		}
 		start := time.Now()
 		e.detectChunk(ctx, data)
 		chunksDetectedLatency.Observe(float64(time.Since(start).Milliseconds()))
@@ -980,9 +1468,7 @@ func (e *Engine) detectChunk(ctx context.Context, data detectableChunk) {
 	if e.printAvgDetectorTime {
 		start = time.Now()
 	}
-	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer common.Recover(ctx)
-	defer cancel()
 
 	isFalsePositive := detectors.GetFalsePositiveCheck(data.detector)
 
@@ -996,9 +1482,14 @@ func (e *Engine) detectChunk(ctx context.Context, data detectableChunk) {
 	for _, matchBytes := range matches {
 		matchCount++
 		detectBytesPerMatch.Observe(float64(len(matchBytes)))
-		results, err := data.detector.Detector.FromData(ctx, data.chunk.Verify, matchBytes)
+
+		results, err := e.fromData(ctx, data.detector, data.chunk.Verify, matchBytes)
 		if err != nil {
 			ctx.Logger().Error(err, "error scanning chunk")
+			// The scan is being cancelled.
+			if ctx.Err() != nil {
+				break
+			}
 			continue
 		}
 
@@ -1038,6 +1529,101 @@ func (e *Engine) detectChunk(ctx context.Context, data detectableChunk) {
 	data.wgDoneFn()
 }
 
+// fromData calls the detector's FromData. Results aren't verified while the
+// circuit breaker of the detector is open, and are reported with
+// detectors.ErrProviderUnavailable as their verification error instead.
+func (e *Engine) fromData(
+	ctx context.Context,
+	detector *ahocorasick.DetectorMatch,
+	verify bool,
+	match []byte,
+) ([]detectors.Result, error) {
+	if !verify || e.verificationBreaker == nil {
+		return e.detectorFromData(ctx, detector, verify, match)
+	}
+
+	if !e.verificationBreaker.allow(detector.Key) {
+		results, err := e.detectorFromData(ctx, detector, false, match)
+		for i := range results {
+			results[i].SetVerificationError(detectors.ErrProviderUnavailable)
+		}
+		return results, err
+	}
+
+	results, err := e.detectorFromData(ctx, detector, true, match)
+	e.verificationBreaker.record(ctx, detector.Key, results)
+	return results, err
+}
+
+// detectorFromData calls the detector's FromData with a timeout.
+func (e *Engine) detectorFromData(
+	ctx context.Context,
+	detector *ahocorasick.DetectorMatch,
+	verify bool,
+	match []byte,
+) ([]detectors.Result, error) {
+	timeout := newPausableTimeout(ctx, detectionTimeout)
+	defer timeout.stop()
+	var detectCtx aCtx.Context = timeout
+	if verify && e.verificationProxy != nil {
+		detectCtx = common.WithHTTPProxy(detectCtx, e.verificationProxy)
+	}
+	if verify && (e.verificationRateLimiter != nil || e.verificationSlots != nil) {
+		detectCtx = common.WithRequestMiddleware(detectCtx, e.verificationRequests(detector, timeout))
+	}
+	// Cached responses aren't sent again, so they aren't limited.
+	if verify && e.verificationCache != nil {
+		detectCtx = common.WithRequestMiddleware(detectCtx, e.verificationCache.middleware(detector.Key, timeout))
+	}
+	if e.detectorTimings == nil {
+		return detector.Detector.FromData(detectCtx, verify, match)
+	}
+
+	start := time.Now()
+	results, err := detector.Detector.FromData(detectCtx, verify, match)
+	e.detectorTimings.record(detector.Key.Name(), verify, time.Since(start))
+	return results, err
+}
+
+// verificationRequests returns the middleware of the verification requests
+// the detector makes with the HTTP clients of the common package, which waits
+// for the rate limiter of the detector's type and then for a verification slot
+// before each request. The slot is released once the response is received.
+// The detection's timeout is paused while waiting.
+func (e *Engine) verificationRequests(detector *ahocorasick.DetectorMatch, timeout *pausableTimeout) common.RequestMiddleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return common.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			timeout.pause()
+			err := e.waitToVerify(req.Context(), detector)
+			timeout.resume()
+			if err != nil {
+				return nil, err
+			}
+			if e.verificationSlots != nil {
+				defer e.verificationSlots.Release(1)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// waitToVerify waits for the rate limiter of the detector's type, and then
+// acquires a verification slot, so requests queued by the rate limiter don't
+// keep other detectors from verifying.
+func (e *Engine) waitToVerify(ctx aCtx.Context, detector *ahocorasick.DetectorMatch) error {
+	if e.verificationRateLimiter != nil {
+		if err := e.verificationRateLimiter.wait(context.AddLogger(ctx), detector.Type()); err != nil {
+			return fmt.Errorf("error waiting for verification rate limiter: %w", err)
+		}
+	}
+	if e.verificationSlots != nil {
+		if err := e.verificationSlots.Acquire(ctx, 1); err != nil {
+			return fmt.Errorf("error waiting for a verification slot: %w", err)
+		}
+	}
+	return nil
+}
+
 func (e *Engine) filterResults(
 	ctx context.Context,
 	detector *ahocorasick.DetectorMatch,
@@ -1049,8 +1635,12 @@ func (e *Engine) filterResults(
 	if !e.retainFalsePositives {
 		results = detectors.FilterKnownFalsePositives(ctx, detector.Detector, results)
 	}
-	if e.filterEntropy != 0 {
-		results = detectors.FilterResultsWithEntropy(ctx, results, e.filterEntropy, e.retainFalsePositives)
+	entropy := e.filterEntropy
+	if threshold, ok := e.entropyThresholds[detector.Detector.Type()]; ok {
+		entropy = threshold
+	}
+	if entropy != 0 {
+		results = detectors.FilterResultsWithEntropy(ctx, results, entropy, e.retainFalsePositives)
 	}
 	return results
 }
@@ -1061,6 +1651,20 @@ func (e *Engine) processResult(
 	res detectors.Result,
 	isFalsePositive func(detectors.Result) (bool, string),
 ) {
+	if res.Confidence == 0 {
+		res.Confidence = detectors.GetConfidence(data.detector.Detector)
+	}
+
+	// Drop unverified results before doing any work to report them.
+	if !res.Verified && (e.onlyVerified || res.Confidence < e.minConfidence) {
+		atomic.AddUint64(&e.metrics.UnverifiedSecretsSuppressed, 1)
+		return
+	}
+	if e.allowlist != nil && e.allowlist.allows(data.detector.Detector, &res, data.chunk.SourceMetadata) {
+		atomic.AddUint64(&e.metrics.AllowlistedSecretsSuppressed, 1)
+		return
+	}
+
 	ignoreLinePresent := false
 	if SupportsLineNumbers(data.chunk.SourceType) {
 		copyChunk := data.chunk
@@ -1082,6 +1686,8 @@ func (e *Engine) processResult(
 
 	secret := detectors.CopyMetadata(&data.chunk, res)
 	secret.DecoderType = data.decoder
+	secret.Position = FragmentPosition(&data.chunk, &res, data.decoder)
+	secret.Labels = e.extraLabels
 
 	if !res.Verified && res.Raw != nil {
 		isFp, _ := isFalsePositive(res)
@@ -1112,33 +1718,87 @@ func (e *Engine) notifierWorker(ctx context.Context) {
 		}
 		atomic.AddUint32(&e.numFoundResults, 1)
 
-		// Dedupe results by comparing the detector type, raw result, and source metadata.
-		// We want to avoid duplicate results with different decoder types, but we also
-		// want to include duplicate results with the same decoder type.
-		// Duplicate results with the same decoder type SHOULD have their own entry in the
-		// results list, this would happen if the same secret is found multiple times.
-		// Note: If the source type is postman, we dedupe the results regardless of decoder type.
-		key := fmt.Sprintf("%s%s%s%+v", result.DetectorType.String(), result.Raw, result.RawV2, result.SourceMetadata)
-		if val, ok := e.dedupeCache.Get(key); ok && (val != result.DecoderType ||
-			result.SourceType == sourcespb.SourceType_SOURCE_TYPE_POSTMAN) {
+		// Dedupe results of the same secret found in the same file, e.g. by
+		// different decoders or in the overlap of consecutive chunks, keeping
+		// the first one reported.
+		key, err := dedupeKey(result)
+		if err != nil {
+			ctx.Logger().Error(err, "error computing dedupe key")
+		} else if ok, _ := e.dedupeCache.ContainsOrAdd(key, struct{}{}); ok {
 			continue
 		}
-		e.dedupeCache.Add(key, result.DecoderType)
+		// Results are stamped as they are reported, so those of a notifier
+		// worker are reported in the order of their times.
+		result.DetectedAt = e.detectionTime()
+		result.ScanID = e.scanID
 
 		if result.Verified {
 			atomic.AddUint64(&e.metrics.VerifiedSecretsFound, 1)
 		} else {
 			atomic.AddUint64(&e.metrics.UnverifiedSecretsFound, 1)
 		}
+		e.summary.addResult(result)
 
 		if err := e.dispatcher.Dispatch(ctx, result); err != nil {
 			ctx.Logger().Error(err, "error notifying result")
 		}
+		if result.Verified && e.stopOnFirstVerified {
+			e.stopOnVerified(ctx)
+		}
 
 		chunksNotifiedLatency.Observe(float64(time.Since(startTime).Milliseconds()))
 	}
 }
 
+// dedupeKey identifies a result by its detector, its secret, and the file or
+// other location of its source it was found in, ignoring the line and the
+// offset within archive entries. Chunkers
+// overlap consecutive chunks, so a secret in the overlap is found in both
+// chunks. The chunks of a file are scanned in order, so the first result
+// reported is the earliest occurrence of the secret in the file. The same
+// secret in different files, commits, or other locations results in
+// different keys.
+func dedupeKey(result detectors.ResultWithMetadata) (string, error) {
+	raw := result.RawV2
+	if len(raw) == 0 {
+		raw = result.Raw
+	}
+
+	var location []byte
+	if result.SourceMetadata != nil {
+		md := proto.Clone(result.SourceMetadata)
+		md.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			if fd.Message() == nil {
+				return true
+			}
+			// Links of sources with line numbers point to the line.
+			msg := v.Message()
+			fields := msg.Descriptor().Fields()
+			// The data of archive entries is chunked like files, and the
+			// offset differs between overlapping chunks.
+			if offset := fields.ByName("archive_offset"); offset != nil {
+				msg.Clear(offset)
+			}
+			if line := fields.ByName("line"); line != nil {
+				msg.Clear(line)
+				if link := fields.ByName("link"); link != nil {
+					msg.Clear(link)
+				}
+			}
+			return true
+		})
+
+		var err error
+		location, err = proto.MarshalOptions{Deterministic: true}.Marshal(md)
+		if err != nil {
+			return "", fmt.Errorf("error marshalling source metadata: %w", err)
+		}
+	}
+
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%d\x00%s",
+		result.DetectorType, result.DetectorName, bytes.TrimSpace(raw), result.SourceType, location), nil
+}
+
 // SupportsLineNumbers determines if a line number can be found for a source type.
 func SupportsLineNumbers(sourceType sourcespb.SourceType) bool {
 	switch sourceType {
@@ -1175,6 +1835,23 @@ func FragmentLineOffset(chunk *sources.Chunk, result *detectors.Result) (int64,
 	return lineNumber, false
 }
 
+// FragmentPosition returns the position of the result within the data of the
+// source, from the position of the chunk it was found in, if known. Results
+// found in decoded data can't be located in the original data, so they are
+// positioned at the start of their chunk.
+func FragmentPosition(chunk *sources.Chunk, result *detectors.Result, decoder detectorspb.DecoderType) *sources.Position {
+	if chunk.Position == nil {
+		return nil
+	}
+	position := *chunk.Position
+	if decoder == detectorspb.DecoderType_PLAIN && len(result.Raw) > 0 {
+		if i := bytes.Index(chunk.Data, result.Raw); i >= 0 {
+			position = position.Advance(chunk.Data, i)
+		}
+	}
+	return &position
+}
+
 // FragmentFirstLineAndLink extracts the first line number and the link from the chunk metadata.
 // It returns:
 //   - The first line number of the fragment.
@@ -1193,6 +1870,7 @@ func FragmentFirstLineAndLink(chunk *sources.Chunk) (int64, *int64, string) {
 	switch metadata := chunk.SourceMetadata.GetData().(type) {
 	case *source_metadatapb.MetaData_Git:
 		fragmentStart = &metadata.Git.Line
+		link = metadata.Git.Link
 	case *source_metadatapb.MetaData_Github:
 		fragmentStart = &metadata.Github.Line
 		link = metadata.Github.Link
@@ -1237,6 +1915,8 @@ func UpdateLink(ctx context.Context, metadata *source_metadatapb.MetaData, link
 	newLink := giturl.UpdateLinkLineNumber(ctx, link, line)
 
 	switch meta := metadata.GetData().(type) {
+	case *source_metadatapb.MetaData_Git:
+		meta.Git.Link = newLink
 	case *source_metadatapb.MetaData_Github:
 		meta.Github.Link = newLink
 	case *source_metadatapb.MetaData_Gitlab:
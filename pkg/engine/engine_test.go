@@ -1,26 +1,39 @@
 package engine
 
 import (
+	"bytes"
 	aCtx "context"
 	"fmt"
+	"io"
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/gitlab/v2"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
 
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/config"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/custom_detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/decoders"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/dbconnection"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/genericentropy"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors/sentrytoken"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/custom_detectorspb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
@@ -194,6 +207,61 @@ func BenchmarkFragmentLineOffsetEnd(b *testing.B) {
 
 // Test to make sure that DefaultDecoders always returns the UTF8 decoder first.
 // Technically a decoder test but we want this to run and fail in CI
+func TestFragmentPosition(t *testing.T) {
+	data := []byte("line1\nline2\nkey = secret here\nline4")
+	start := &sources.Position{Offset: 100, Line: 10, Column: 3}
+
+	tests := []struct {
+		name     string
+		position *sources.Position
+		raw      string
+		decoder  detectorspb.DecoderType
+		want     *sources.Position
+	}{
+		{
+			name:     "found",
+			position: start,
+			raw:      "secret here",
+			decoder:  detectorspb.DecoderType_PLAIN,
+			want:     &sources.Position{Offset: 118, Line: 12, Column: 7},
+		},
+		{
+			name:     "on first line",
+			position: start,
+			raw:      "ine1",
+			decoder:  detectorspb.DecoderType_PLAIN,
+			want:     &sources.Position{Offset: 101, Line: 10, Column: 4},
+		},
+		{
+			name:     "not found",
+			position: start,
+			raw:      "other secret",
+			decoder:  detectorspb.DecoderType_PLAIN,
+			want:     start,
+		},
+		{
+			name:     "decoded",
+			position: start,
+			raw:      "secret here",
+			decoder:  detectorspb.DecoderType_BASE64,
+			want:     start,
+		},
+		{
+			name:    "unknown",
+			raw:     "secret here",
+			decoder: detectorspb.DecoderType_PLAIN,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunk := &sources.Chunk{Data: data, Position: tt.position}
+			got := FragmentPosition(chunk, &detectors.Result{Raw: []byte(tt.raw)}, tt.decoder)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestDefaultDecoders(t *testing.T) {
 	ds := decoders.DefaultDecoders()
 	if _, ok := ds[0].(*decoders.UTF8); !ok {
@@ -234,7 +302,7 @@ func BenchmarkSupportsLineNumbersLoop(b *testing.B) {
 	}
 }
 
-// TestEngine_DuplicateSecrets is a test that detects ALL duplicate secrets with the same decoder.
+// TestEngine_DuplicateSecrets is a test that reports a secret found multiple times in the same file once.
 func TestEngine_DuplicateSecrets(t *testing.T) {
 	ctx := context.Background()
 
@@ -273,10 +341,67 @@ func TestEngine_DuplicateSecrets(t *testing.T) {
 
 	// Wait for all the chunks to be processed.
 	assert.Nil(t, e.Finish(ctx))
-	want := uint64(5)
+	want := uint64(2)
 	assert.Equal(t, want, e.GetMetrics().UnverifiedSecretsFound)
 }
 
+// TestEngine_DedupeChunkOverlap is a test that reports a secret in the overlap of two chunks once,
+// but the same secret in different files once per file.
+func TestEngine_DedupeChunkOverlap(t *testing.T) {
+	const secret = "sentry 27ac84f4bcdb4fca9701f4d6f6f58cd7d96b69c9d9754d40800645a51d668f90\n"
+
+	// The secret is in the peek of the first chunk, which is also the start of the second chunk.
+	filler := strings.Repeat("filler line\n", sources.ChunkSize/len("filler line\n")+1)
+	overlap := filler + secret + filler
+
+	dir := t.TempDir()
+	overlapPath := filepath.Join(dir, "overlap.txt")
+	assert.NoError(t, os.WriteFile(overlapPath, []byte(overlap), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "other.txt"), []byte(secret), 0644))
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  uint64
+	}{
+		{name: "secret in chunk overlap", paths: []string{overlapPath}, want: 1},
+		{name: "same secret in different files", paths: []string{dir}, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			const defaultOutputBufferSize = 64
+			sourceManager := sources.NewManager(
+				sources.WithSourceUnits(),
+				sources.WithBufferedOutput(defaultOutputBufferSize),
+			)
+
+			conf := Config{
+				Concurrency:   1,
+				Decoders:      decoders.DefaultDecoders(),
+				Detectors:     []detectors.Detector{sentrytoken.Scanner{}},
+				Verify:        false,
+				SourceManager: sourceManager,
+				Dispatcher:    NewPrinterDispatcher(new(discardPrinter)),
+			}
+
+			e, err := NewEngine(ctx, &conf)
+			assert.NoError(t, err)
+
+			e.Start(ctx)
+
+			cfg := sources.FilesystemConfig{Paths: tt.paths}
+			assert.NoError(t, e.ScanFileSystem(ctx, cfg))
+
+			assert.Nil(t, e.Finish(ctx))
+			assert.Equal(t, tt.want, e.GetMetrics().UnverifiedSecretsFound)
+		})
+	}
+}
+
 // TestEngine_VersionedDetectorsVerifiedSecrets is a test that detects ALL verified secrets across
 // versioned detectors.
 func TestEngine_VersionedDetectorsVerifiedSecrets(t *testing.T) {
@@ -963,3 +1088,1346 @@ func TestEngine_ShouldVerifyChunk(t *testing.T) {
 		}
 	}
 }
+
+func TestDedupeKey(t *testing.T) {
+	gitResult := func(commit, file string, line int64, link string) detectors.ResultWithMetadata {
+		return detectors.ResultWithMetadata{
+			SourceType: sourcespb.SourceType_SOURCE_TYPE_GITHUB,
+			SourceMetadata: &source_metadatapb.MetaData{
+				Data: &source_metadatapb.MetaData_Github{
+					Github: &source_metadatapb.Github{Commit: commit, File: file, Line: line, Link: link},
+				},
+			},
+			Result: detectors.Result{DetectorType: detectorspb.DetectorType_AWS, Raw: []byte("AKIAEXAMPLE")},
+		}
+	}
+	base := gitResult("abc", "a.txt", 10, "https://github.com/org/repo/blob/abc/a.txt#L10")
+
+	tests := []struct {
+		name      string
+		other     func() detectors.ResultWithMetadata
+		wantEqual bool
+	}{
+		{
+			name: "different line and link",
+			other: func() detectors.ResultWithMetadata {
+				return gitResult("abc", "a.txt", 12, "https://github.com/org/repo/blob/abc/a.txt#L12")
+			},
+			wantEqual: true,
+		},
+		{
+			name: "raw with surrounding whitespace",
+			other: func() detectors.ResultWithMetadata {
+				r := gitResult("abc", "a.txt", 10, "")
+				r.Raw = []byte(" AKIAEXAMPLE\n")
+				return r
+			},
+			wantEqual: true,
+		},
+		{
+			name: "different decoder",
+			other: func() detectors.ResultWithMetadata {
+				r := gitResult("abc", "a.txt", 10, "")
+				r.DecoderType = detectorspb.DecoderType_BASE64
+				return r
+			},
+			wantEqual: true,
+		},
+		{
+			name:  "different file",
+			other: func() detectors.ResultWithMetadata { return gitResult("abc", "b.txt", 10, "") },
+		},
+		{
+			name:  "different commit",
+			other: func() detectors.ResultWithMetadata { return gitResult("def", "a.txt", 10, "") },
+		},
+		{
+			name: "different detector",
+			other: func() detectors.ResultWithMetadata {
+				r := gitResult("abc", "a.txt", 10, "")
+				r.DetectorType = detectorspb.DetectorType_CustomRegex
+				r.DetectorName = "custom"
+				return r
+			},
+		},
+	}
+
+	baseKey, err := dedupeKey(base)
+	assert.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := dedupeKey(tt.other())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantEqual, key == baseKey)
+		})
+	}
+
+	// The key is computed on a copy of the metadata.
+	assert.Equal(t, int64(10), base.SourceMetadata.GetGithub().GetLine())
+}
+
+func TestDedupeKey_ArchiveEntry(t *testing.T) {
+	entryResult := func(entry string, offset int64) detectors.ResultWithMetadata {
+		return detectors.ResultWithMetadata{
+			SourceType: sourcespb.SourceType_SOURCE_TYPE_GCS,
+			SourceMetadata: &source_metadatapb.MetaData{
+				Data: &source_metadatapb.MetaData_Gcs{
+					Gcs: &source_metadatapb.GCS{Bucket: "bucket", Filename: "backup.zip", ArchiveEntry: entry, ArchiveOffset: offset},
+				},
+			},
+			Result: detectors.Result{DetectorType: detectorspb.DetectorType_AWS, Raw: []byte("AKIAEXAMPLE")},
+		}
+	}
+
+	key := func(result detectors.ResultWithMetadata) string {
+		k, err := dedupeKey(result)
+		assert.NoError(t, err)
+		return k
+	}
+
+	// Overlapping chunks of the same entry have different offsets.
+	assert.Equal(t, key(entryResult("keys/a.pem", 0)), key(entryResult("keys/a.pem", 10240)))
+	assert.NotEqual(t, key(entryResult("keys/a.pem", 0)), key(entryResult("keys/b.pem", 0)))
+}
+
+type mixedVerificationDetector struct{}
+
+var _ detectors.Detector = (*mixedVerificationDetector)(nil)
+
+func (mixedVerificationDetector) FromData(_ aCtx.Context, _ bool, _ []byte) ([]detectors.Result, error) {
+	unknown := detectors.Result{DetectorType: detectorspb.DetectorType(-1), Raw: []byte("unknown secret")}
+	unknown.SetVerificationError(fmt.Errorf("verification timed out"))
+	return []detectors.Result{
+		{DetectorType: detectorspb.DetectorType(-1), Verified: true, Raw: []byte("verified secret")},
+		{DetectorType: detectorspb.DetectorType(-1), Raw: []byte("unverified secret")},
+		unknown,
+	}, nil
+}
+
+func (mixedVerificationDetector) Keywords() []string             { return []string{fakeDetectorKeyword} }
+func (mixedVerificationDetector) Type() detectorspb.DetectorType { return detectorspb.DetectorType(-1) }
+
+// lowConfidenceDetector is a mixedVerificationDetector with low confidence.
+type lowConfidenceDetector struct{ mixedVerificationDetector }
+
+var _ detectors.ConfidenceProvider = (*lowConfidenceDetector)(nil)
+
+func (lowConfidenceDetector) Confidence() detectors.Confidence { return detectors.ConfidenceLow }
+
+// recordingDispatcher records the raw value of every dispatched result.
+type recordingDispatcher struct {
+	mu          sync.Mutex
+	raw         []string
+	types       []detectorspb.DetectorType
+	confidences []detectors.Confidence
+	labels      []map[string]string
+	detectedAt  []time.Time
+	scanIDs     []string
+	metadata    []*source_metadatapb.MetaData
+	positions   []*sources.Position
+}
+
+func (d *recordingDispatcher) Dispatch(_ context.Context, result detectors.ResultWithMetadata) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.raw = append(d.raw, string(result.Raw))
+	d.types = append(d.types, result.DetectorType)
+	d.confidences = append(d.confidences, result.Confidence)
+	d.labels = append(d.labels, result.Labels)
+	d.detectedAt = append(d.detectedAt, result.DetectedAt)
+	d.scanIDs = append(d.scanIDs, result.ScanID)
+	d.metadata = append(d.metadata, result.SourceMetadata)
+	d.positions = append(d.positions, result.Position)
+	return nil
+}
+
+func TestEngine_OnlyVerified(t *testing.T) {
+	tests := []struct {
+		name           string
+		onlyVerified   bool
+		wantDispatched []string
+		wantSuppressed uint64
+	}{
+		{
+			name:           "only verified",
+			onlyVerified:   true,
+			wantDispatched: []string{"verified secret"},
+			wantSuppressed: 2,
+		},
+		{
+			name:           "all results",
+			wantDispatched: []string{"verified secret", "unverified secret", "unknown secret"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			path := filepath.Join(t.TempDir(), "secrets.txt")
+			assert.NoError(t, os.WriteFile(path, []byte(fakeDetectorKeyword+" secrets"), 0644))
+
+			const defaultOutputBufferSize = 64
+			sourceManager := sources.NewManager(
+				sources.WithSourceUnits(),
+				sources.WithBufferedOutput(defaultOutputBufferSize),
+			)
+
+			dispatcher := new(recordingDispatcher)
+			conf := Config{
+				Concurrency:   1,
+				Decoders:      decoders.DefaultDecoders(),
+				Detectors:     []detectors.Detector{mixedVerificationDetector{}},
+				Verify:        true,
+				OnlyVerified:  tt.onlyVerified,
+				SourceManager: sourceManager,
+				Dispatcher:    dispatcher,
+			}
+
+			e, err := NewEngine(ctx, &conf)
+			assert.NoError(t, err)
+
+			e.Start(ctx)
+
+			cfg := sources.FilesystemConfig{Paths: []string{path}}
+			assert.NoError(t, e.ScanFileSystem(ctx, cfg))
+
+			assert.Nil(t, e.Finish(ctx))
+			assert.ElementsMatch(t, tt.wantDispatched, dispatcher.raw)
+
+			metrics := e.GetMetrics()
+			assert.Equal(t, uint64(1), metrics.VerifiedSecretsFound)
+			assert.Equal(t, uint64(len(tt.wantDispatched)-1), metrics.UnverifiedSecretsFound)
+			assert.Equal(t, tt.wantSuppressed, metrics.UnverifiedSecretsSuppressed)
+		})
+	}
+}
+
+func TestEngine_ExtraLabels(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "secrets.txt")
+	assert.NoError(t, os.WriteFile(path, []byte(fakeDetectorKeyword+" secrets"), 0644))
+
+	labels := map[string]string{"env": "prod", "team": "security"}
+	dispatcher := new(recordingDispatcher)
+	conf := Config{
+		Concurrency:   1,
+		Decoders:      decoders.DefaultDecoders(),
+		Detectors:     []detectors.Detector{mixedVerificationDetector{}},
+		Verify:        true,
+		SourceManager: sources.NewManager(sources.WithBufferedOutput(64)),
+		Dispatcher:    dispatcher,
+		ExtraLabels:   labels,
+	}
+
+	e, err := NewEngine(ctx, &conf)
+	assert.NoError(t, err)
+	// The engine keeps its own copy.
+	labels["env"] = "dev"
+
+	e.Start(ctx)
+	assert.NoError(t, e.ScanFileSystem(ctx, sources.FilesystemConfig{Paths: []string{path}}))
+	assert.Nil(t, e.Finish(ctx))
+
+	assert.Len(t, dispatcher.labels, 3)
+	for _, got := range dispatcher.labels {
+		assert.Equal(t, map[string]string{"env": "prod", "team": "security"}, got)
+	}
+
+	conf.ExtraLabels = map[string]string{"": "unnamed"}
+	_, err = NewEngine(ctx, &conf)
+	assert.Error(t, err)
+}
+
+func TestEngine_MinConfidence(t *testing.T) {
+	tests := []struct {
+		name           string
+		detector       detectors.Detector
+		minConfidence  detectors.Confidence
+		wantDispatched []string
+		wantConfidence detectors.Confidence
+		wantSuppressed uint64
+	}{
+		{
+			name:           "default confidence, no minimum",
+			detector:       mixedVerificationDetector{},
+			wantDispatched: []string{"verified secret", "unverified secret", "unknown secret"},
+			wantConfidence: detectors.DefaultConfidence,
+		},
+		{
+			name:           "default confidence, medium minimum",
+			detector:       mixedVerificationDetector{},
+			minConfidence:  detectors.ConfidenceMedium,
+			wantDispatched: []string{"verified secret", "unverified secret", "unknown secret"},
+			wantConfidence: detectors.DefaultConfidence,
+		},
+		{
+			name:           "low confidence, no minimum",
+			detector:       lowConfidenceDetector{},
+			wantDispatched: []string{"verified secret", "unverified secret", "unknown secret"},
+			wantConfidence: detectors.ConfidenceLow,
+		},
+		{
+			name:           "low confidence, medium minimum",
+			detector:       lowConfidenceDetector{},
+			minConfidence:  detectors.ConfidenceMedium,
+			wantDispatched: []string{"verified secret"},
+			wantConfidence: detectors.ConfidenceLow,
+			wantSuppressed: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			path := filepath.Join(t.TempDir(), "secrets.txt")
+			assert.NoError(t, os.WriteFile(path, []byte(fakeDetectorKeyword+" secrets"), 0644))
+
+			const defaultOutputBufferSize = 64
+			sourceManager := sources.NewManager(
+				sources.WithSourceUnits(),
+				sources.WithBufferedOutput(defaultOutputBufferSize),
+			)
+
+			dispatcher := new(recordingDispatcher)
+			conf := Config{
+				Concurrency:   1,
+				Decoders:      decoders.DefaultDecoders(),
+				Detectors:     []detectors.Detector{tt.detector},
+				Verify:        true,
+				MinConfidence: tt.minConfidence,
+				SourceManager: sourceManager,
+				Dispatcher:    dispatcher,
+			}
+
+			e, err := NewEngine(ctx, &conf)
+			assert.NoError(t, err)
+
+			e.Start(ctx)
+
+			cfg := sources.FilesystemConfig{Paths: []string{path}}
+			assert.NoError(t, e.ScanFileSystem(ctx, cfg))
+
+			assert.Nil(t, e.Finish(ctx))
+			assert.ElementsMatch(t, tt.wantDispatched, dispatcher.raw)
+			for _, confidence := range dispatcher.confidences {
+				assert.Equal(t, tt.wantConfidence, confidence)
+			}
+			assert.Equal(t, tt.wantSuppressed, e.GetMetrics().UnverifiedSecretsSuppressed)
+		})
+	}
+}
+
+func TestEngine_ResultsBackpressure(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "secrets.txt")
+	assert.NoError(t, os.WriteFile(path, []byte(fakeDetectorKeyword+" secrets"), 0644))
+
+	const defaultOutputBufferSize = 64
+	sourceManager := sources.NewManager(
+		sources.WithSourceUnits(),
+		sources.WithBufferedOutput(defaultOutputBufferSize),
+	)
+
+	const (
+		numResults        = 50
+		resultsBufferSize = 2
+	)
+	dispatcher := &slowDispatcher{delay: 5 * time.Millisecond}
+	conf := Config{
+		Concurrency:       1,
+		Decoders:          decoders.DefaultDecoders(),
+		Detectors:         []detectors.Detector{manyResultsDetector{numResults: numResults}},
+		SourceManager:     sourceManager,
+		Dispatcher:        dispatcher,
+		ResultsBufferSize: resultsBufferSize,
+	}
+
+	e, err := NewEngine(ctx, &conf)
+	assert.NoError(t, err)
+	dispatcher.buffered = e.ResultsChan()
+	assert.Equal(t, resultsBufferSize, cap(e.ResultsChan()))
+
+	e.Start(ctx)
+
+	cfg := sources.FilesystemConfig{Paths: []string{path}}
+	assert.NoError(t, e.ScanFileSystem(ctx, cfg))
+
+	assert.Nil(t, e.Finish(ctx))
+
+	// Every result is dispatched, and since results are found faster than
+	// they are dispatched, the buffer fills up without growing past its size.
+	assert.Equal(t, numResults, dispatcher.dispatched)
+	assert.Equal(t, resultsBufferSize, dispatcher.maxBuffered)
+	assert.Equal(t, uint64(numResults), e.GetMetrics().UnverifiedSecretsFound)
+}
+
+func TestNewEngine_NegativeResultsBufferSize(t *testing.T) {
+	conf := Config{
+		SourceManager:     sources.NewManager(),
+		ResultsBufferSize: -1,
+	}
+	_, err := NewEngine(context.Background(), &conf)
+	assert.Error(t, err)
+}
+
+// manyResultsDetector returns numResults distinct results for every chunk.
+type manyResultsDetector struct {
+	numResults int
+}
+
+var _ detectors.Detector = (*manyResultsDetector)(nil)
+
+func (d manyResultsDetector) FromData(_ aCtx.Context, _ bool, _ []byte) ([]detectors.Result, error) {
+	results := make([]detectors.Result, 0, d.numResults)
+	for i := 0; i < d.numResults; i++ {
+		results = append(results, detectors.Result{
+			DetectorType: detectorspb.DetectorType(-1),
+			Raw:          []byte(fmt.Sprintf("secret %d", i)),
+		})
+	}
+	return results, nil
+}
+
+func (manyResultsDetector) Keywords() []string             { return []string{fakeDetectorKeyword} }
+func (manyResultsDetector) Type() detectorspb.DetectorType { return detectorspb.DetectorType(-1) }
+
+// slowDispatcher dispatches results slowly and records the most results that
+// were buffered waiting to be dispatched.
+type slowDispatcher struct {
+	delay    time.Duration
+	buffered chan detectors.ResultWithMetadata
+
+	mu          sync.Mutex
+	dispatched  int
+	maxBuffered int
+}
+
+func (d *slowDispatcher) Dispatch(_ context.Context, _ detectors.ResultWithMetadata) error {
+	time.Sleep(d.delay)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.dispatched++
+	d.maxBuffered = max(d.maxBuffered, len(d.buffered))
+	return nil
+}
+
+func TestEngine_DetectorAllowlistAndDenylist(t *testing.T) {
+	absPath, err := filepath.Abs("./testdata/secrets.txt")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		allowlist []detectorspb.DetectorType
+		denylist  []detectorspb.DetectorType
+		wantTypes []detectorspb.DetectorType
+	}{
+		{
+			name:      "all detectors",
+			wantTypes: []detectorspb.DetectorType{detectorspb.DetectorType_AWS, detectorspb.DetectorType_SentryToken},
+		},
+		{
+			name:      "allowlist",
+			allowlist: []detectorspb.DetectorType{detectorspb.DetectorType_AWS},
+			wantTypes: []detectorspb.DetectorType{detectorspb.DetectorType_AWS},
+		},
+		{
+			name:      "denylist",
+			denylist:  []detectorspb.DetectorType{detectorspb.DetectorType_AWS},
+			wantTypes: []detectorspb.DetectorType{detectorspb.DetectorType_SentryToken},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			const defaultOutputBufferSize = 64
+			sourceManager := sources.NewManager(
+				sources.WithSourceUnits(),
+				sources.WithBufferedOutput(defaultOutputBufferSize),
+			)
+
+			dispatcher := new(recordingDispatcher)
+			conf := Config{
+				Concurrency:       1,
+				Decoders:          decoders.DefaultDecoders(),
+				Detectors:         DefaultDetectors(),
+				DetectorAllowlist: tt.allowlist,
+				DetectorDenylist:  tt.denylist,
+				SourceManager:     sourceManager,
+				Dispatcher:        dispatcher,
+			}
+
+			e, err := NewEngine(ctx, &conf)
+			assert.NoError(t, err)
+
+			e.Start(ctx)
+
+			cfg := sources.FilesystemConfig{Paths: []string{absPath}}
+			assert.NoError(t, e.ScanFileSystem(ctx, cfg))
+
+			assert.Nil(t, e.Finish(ctx))
+			assert.ElementsMatch(t, tt.wantTypes, dispatcher.types)
+		})
+	}
+}
+
+func TestNewEngine_DetectorAllowlistAndDenylistExclusive(t *testing.T) {
+	conf := Config{
+		SourceManager:     sources.NewManager(),
+		DetectorAllowlist: []detectorspb.DetectorType{detectorspb.DetectorType_AWS},
+		DetectorDenylist:  []detectorspb.DetectorType{detectorspb.DetectorType_SentryToken},
+	}
+	_, err := NewEngine(context.Background(), &conf)
+	assert.Error(t, err)
+}
+
+func TestNewEngine_GenericEntropy(t *testing.T) {
+	hasGenericEntropy := func(e *Engine) bool {
+		for _, d := range e.detectors {
+			if d.Type() == detectorspb.DetectorType_GenericEntropy {
+				return true
+			}
+		}
+		return false
+	}
+
+	conf := Config{
+		Detectors:     []detectors.Detector{mixedVerificationDetector{}},
+		SourceManager: sources.NewManager(),
+	}
+	e, err := NewEngine(context.Background(), &conf)
+	assert.NoError(t, err)
+	assert.False(t, hasGenericEntropy(e))
+
+	conf.GenericEntropy = &genericentropy.Config{Threshold: 4}
+	e, err = NewEngine(context.Background(), &conf)
+	assert.NoError(t, err)
+	assert.True(t, hasGenericEntropy(e))
+
+	conf.GenericEntropy = &genericentropy.Config{Charset: "binary"}
+	_, err = NewEngine(context.Background(), &conf)
+	assert.Error(t, err)
+}
+
+func TestNewEngine_EntropyThresholds(t *testing.T) {
+	ctx := context.Background()
+
+	// The token has an entropy of about 4.29, below the default threshold of
+	// the generic entropy detector.
+	const token = "aGVsbG8gd29ybGQgdGhpcyBpcyBhIHRlc3Q="
+	genericEntropyMatches := func(thresholds map[detectorspb.DetectorType]float64) []string {
+		e, err := NewEngine(ctx, &Config{
+			Detectors:         []detectors.Detector{mixedVerificationDetector{}},
+			SourceManager:     sources.NewManager(),
+			GenericEntropy:    &genericentropy.Config{},
+			EntropyThresholds: thresholds,
+		})
+		assert.NoError(t, err)
+
+		var matches []string
+		for _, d := range e.detectors {
+			if d.Type() != detectorspb.DetectorType_GenericEntropy {
+				continue
+			}
+			results, err := d.FromData(ctx, false, []byte("secret="+token))
+			assert.NoError(t, err)
+			for _, r := range results {
+				matches = append(matches, string(r.Raw))
+			}
+		}
+		return matches
+	}
+
+	assert.Empty(t, genericEntropyMatches(nil))
+	assert.Equal(t, []string{token}, genericEntropyMatches(map[detectorspb.DetectorType]float64{
+		detectorspb.DetectorType_GenericEntropy: 4.2,
+	}))
+	assert.Empty(t, genericEntropyMatches(map[detectorspb.DetectorType]float64{
+		detectorspb.DetectorType_GenericEntropy: 4.4,
+	}))
+	// Thresholds of other detectors don't apply.
+	assert.Empty(t, genericEntropyMatches(map[detectorspb.DetectorType]float64{
+		detectorspb.DetectorType_AWS: 1,
+	}))
+
+	// The unverified results of a detector are filtered with the threshold of
+	// its type rather than FilterEntropy. "unverified secret" has an entropy
+	// of about 3.38, and "unknown secret" of about 3.32.
+	detector := mixedVerificationDetector{}
+	match := &ahocorasick.DetectorMatch{Key: ahocorasick.CreateDetectorKey(detector), Detector: detector}
+	filteredResults := func(filterEntropy float64, thresholds map[detectorspb.DetectorType]float64) []string {
+		e, err := NewEngine(ctx, &Config{
+			Detectors:     []detectors.Detector{detector},
+			SourceManager: sources.NewManager(),
+			// Keep the results matching the wordlist of false positives.
+			LogFilteredUnverified: true,
+			FilterEntropy:         filterEntropy,
+			EntropyThresholds:     thresholds,
+		})
+		assert.NoError(t, err)
+
+		results, err := detector.FromData(ctx, false, nil)
+		assert.NoError(t, err)
+		var raws []string
+		for _, r := range e.filterResults(ctx, match, results) {
+			raws = append(raws, string(r.Raw))
+		}
+		return raws
+	}
+
+	assert.ElementsMatch(t, []string{"verified secret"}, filteredResults(3.5, nil))
+	assert.ElementsMatch(t, []string{"verified secret", "unverified secret", "unknown secret"},
+		filteredResults(3.5, map[detectorspb.DetectorType]float64{detector.Type(): 3.3}))
+	assert.ElementsMatch(t, []string{"verified secret", "unverified secret"},
+		filteredResults(0, map[detectorspb.DetectorType]float64{detector.Type(): 3.35}))
+	assert.ElementsMatch(t, []string{"verified secret"},
+		filteredResults(0, map[detectorspb.DetectorType]float64{detector.Type(): 3.4}))
+
+	_, err := NewEngine(ctx, &Config{
+		Detectors:         []detectors.Detector{detector},
+		SourceManager:     sources.NewManager(),
+		EntropyThresholds: map[detectorspb.DetectorType]float64{detector.Type(): -1},
+	})
+	assert.Error(t, err)
+}
+
+func TestNewEngine_VerifyDatabaseConnections(t *testing.T) {
+	for _, verify := range []bool{false, true} {
+		scanner := new(dbconnection.Scanner)
+		conf := Config{
+			Detectors:                 []detectors.Detector{scanner},
+			SourceManager:             sources.NewManager(),
+			VerifyDatabaseConnections: verify,
+		}
+		_, err := NewEngine(context.Background(), &conf)
+		assert.NoError(t, err)
+		assert.Equal(t, verify, scanner.VerifyConnections)
+	}
+}
+
+func TestNewEngine_DatabaseConnections(t *testing.T) {
+	dbConnectionScanner := func(e *Engine) *dbconnection.Scanner {
+		for _, d := range e.detectors {
+			if s, ok := d.(*dbconnection.Scanner); ok {
+				return s
+			}
+		}
+		return nil
+	}
+
+	// The detector isn't one of the defaults.
+	conf := Config{SourceManager: sources.NewManager(), VerifyDatabaseConnections: true}
+	e, err := NewEngine(context.Background(), &conf)
+	assert.NoError(t, err)
+	assert.Nil(t, dbConnectionScanner(e))
+
+	conf.DatabaseConnections = true
+	e, err = NewEngine(context.Background(), &conf)
+	assert.NoError(t, err)
+	if s := dbConnectionScanner(e); assert.NotNil(t, s) {
+		assert.True(t, s.VerifyConnections)
+	}
+}
+
+// slowSource produces a chunk every interval until its context is cancelled.
+// It then checkpoints the number of chunks it produced as its resume info.
+type slowSource struct {
+	sources.Progress
+	interval time.Duration
+}
+
+var _ sources.Source = (*slowSource)(nil)
+
+func (*slowSource) Type() sourcespb.SourceType { return sourcespb.SourceType_SOURCE_TYPE_FILESYSTEM }
+func (*slowSource) SourceID() sources.SourceID { return 0 }
+func (*slowSource) JobID() sources.JobID       { return 0 }
+func (*slowSource) Close() error               { return nil }
+func (*slowSource) Init(context.Context, string, sources.JobID, sources.SourceID, bool, *anypb.Any, int) error {
+	return nil
+}
+
+func (s *slowSource) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ ...sources.ChunkingTarget) error {
+	for i := 0; ; i++ {
+		select {
+		case <-ctx.Done():
+			s.SetProgressOngoing("stopped", strconv.Itoa(i))
+			return ctx.Err()
+		case <-time.After(s.interval):
+		}
+
+		chunk := &sources.Chunk{
+			SourceName: "slow",
+			SourceType: s.Type(),
+			SourceMetadata: &source_metadatapb.MetaData{
+				Data: &source_metadatapb.MetaData_Filesystem{
+					Filesystem: &source_metadatapb.Filesystem{File: fmt.Sprintf("file-%d.txt", i)},
+				},
+			},
+			Data:   []byte(fakeDetectorKeyword + " secret"),
+			Verify: true,
+		}
+		select {
+		case <-ctx.Done():
+			s.SetProgressOngoing("stopped", strconv.Itoa(i))
+			return ctx.Err()
+		case chunksChan <- chunk:
+		}
+	}
+}
+
+func TestEngine_MaxScanDuration(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	sourceManager := sources.NewManager(
+		sources.WithSourceUnits(),
+		sources.WithBufferedOutput(64),
+	)
+
+	dispatcher := new(recordingDispatcher)
+	const maxScanDuration = 200 * time.Millisecond
+	conf := Config{
+		Concurrency:     1,
+		Decoders:        decoders.DefaultDecoders(),
+		Detectors:       []detectors.Detector{fakeDetectorV1{}},
+		Verify:          true,
+		MaxScanDuration: maxScanDuration,
+		SourceManager:   sourceManager,
+		Dispatcher:      dispatcher,
+	}
+
+	e, err := NewEngine(ctx, &conf)
+	assert.NoError(t, err)
+
+	start := time.Now()
+	e.Start(ctx)
+
+	source := &slowSource{interval: 10 * time.Millisecond}
+	_, err = e.sourceManager.Run(ctx, "slow", source)
+	assert.NoError(t, err)
+
+	// The scan stops near the budget without failing.
+	assert.NoError(t, e.Finish(ctx))
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, maxScanDuration)
+	assert.Less(t, elapsed, maxScanDuration+time.Second)
+	assert.True(t, e.GetMetrics().PartialScan)
+
+	// The source checkpointed its progress, and the chunks it produced before
+	// it stopped were all scanned.
+	resumeInfo := source.GetProgress().EncodedResumeInfo
+	produced, err := strconv.Atoi(resumeInfo)
+	assert.NoError(t, err)
+	assert.Positive(t, produced)
+	assert.Len(t, dispatcher.raw, produced)
+}
+
+func TestEngine_MaxScanDurationNotExceeded(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "secrets.txt")
+	assert.NoError(t, os.WriteFile(path, []byte(fakeDetectorKeyword+" secrets"), 0644))
+
+	sourceManager := sources.NewManager(
+		sources.WithSourceUnits(),
+		sources.WithBufferedOutput(64),
+	)
+
+	conf := Config{
+		Concurrency:     1,
+		Decoders:        decoders.DefaultDecoders(),
+		Detectors:       []detectors.Detector{fakeDetectorV1{}},
+		MaxScanDuration: time.Minute,
+		SourceManager:   sourceManager,
+		Dispatcher:      new(recordingDispatcher),
+	}
+
+	e, err := NewEngine(ctx, &conf)
+	assert.NoError(t, err)
+
+	e.Start(ctx)
+	assert.NoError(t, e.ScanFileSystem(ctx, sources.FilesystemConfig{Paths: []string{path}}))
+	assert.NoError(t, e.Finish(ctx))
+	assert.False(t, e.GetMetrics().PartialScan)
+}
+
+func TestEngine_StopOnFirstVerified(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	sourceManager := sources.NewManager(
+		sources.WithSourceUnits(),
+		sources.WithBufferedOutput(64),
+	)
+
+	dispatcher := new(recordingDispatcher)
+	conf := Config{
+		Concurrency:         1,
+		Decoders:            decoders.DefaultDecoders(),
+		Detectors:           []detectors.Detector{fakeDetectorV1{}},
+		Verify:              true,
+		StopOnFirstVerified: true,
+		SourceManager:       sourceManager,
+		Dispatcher:          dispatcher,
+	}
+
+	e, err := NewEngine(ctx, &conf)
+	assert.NoError(t, err)
+
+	start := time.Now()
+	e.Start(ctx)
+
+	// The source never finishes on its own, and every chunk it produces has
+	// a verified secret.
+	source := &slowSource{interval: 10 * time.Millisecond}
+	_, err = e.sourceManager.Run(ctx, "slow", source)
+	assert.NoError(t, err)
+
+	// The scan stops after the first verified secret without failing.
+	assert.NoError(t, e.Finish(ctx))
+	assert.Less(t, time.Since(start), time.Second)
+	assert.True(t, e.GetMetrics().StoppedOnFirstVerified)
+	assert.False(t, e.GetMetrics().PartialScan)
+
+	dispatcher.mu.Lock()
+	defer dispatcher.mu.Unlock()
+	assert.NotEmpty(t, dispatcher.raw)
+	assert.Equal(t, "fake secret v1", dispatcher.raw[0])
+	assert.NotEmpty(t, source.GetProgress().EncodedResumeInfo)
+}
+
+func TestEngine_StopOnFirstVerifiedWithoutVerifiedSecrets(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "secrets.txt")
+	assert.NoError(t, os.WriteFile(path, []byte(fakeDetectorKeyword+" secrets"), 0644))
+
+	dispatcher := new(recordingDispatcher)
+	conf := Config{
+		Concurrency:         1,
+		Decoders:            decoders.DefaultDecoders(),
+		Detectors:           []detectors.Detector{pemOnlyDetector{}},
+		Verify:              true,
+		StopOnFirstVerified: true,
+		SourceManager:       sources.NewManager(sources.WithSourceUnits()),
+		Dispatcher:          dispatcher,
+	}
+
+	// Unverified results don't stop the scan.
+	e, err := NewEngine(ctx, &conf)
+	assert.NoError(t, err)
+
+	e.Start(ctx)
+	assert.NoError(t, e.ScanFileSystem(ctx, sources.FilesystemConfig{Paths: []string{path}}))
+	assert.NoError(t, e.Finish(ctx))
+	assert.False(t, e.GetMetrics().StoppedOnFirstVerified)
+	assert.Equal(t, uint64(1), e.GetMetrics().UnverifiedSecretsFound)
+	assert.Len(t, dispatcher.raw, 1)
+}
+
+// apiKeyDetector reports the API keys of a fake provider.
+type apiKeyDetector struct{}
+
+var _ detectors.Detector = (*apiKeyDetector)(nil)
+
+var apiKeyPat = regexp.MustCompile(`apikey_[a-z0-9]{24}`)
+
+func (apiKeyDetector) FromData(_ aCtx.Context, _ bool, data []byte) ([]detectors.Result, error) {
+	var results []detectors.Result
+	for _, match := range apiKeyPat.FindAll(data, -1) {
+		results = append(results, detectors.Result{DetectorType: detectorspb.DetectorType(-6), Raw: match})
+	}
+	return results, nil
+}
+
+func (apiKeyDetector) Keywords() []string             { return []string{"apikey_"} }
+func (apiKeyDetector) Type() detectorspb.DetectorType { return detectorspb.DetectorType(-6) }
+
+// lineDispatcher records the line of each result found in a file.
+type lineDispatcher struct {
+	mu    sync.Mutex
+	lines map[string]int64
+}
+
+func (d *lineDispatcher) Dispatch(_ context.Context, result detectors.ResultWithMetadata) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.lines[string(result.Raw)] = result.SourceMetadata.GetFilesystem().GetLine()
+	return nil
+}
+
+func TestEngine_JoinLineContinuations(t *testing.T) {
+	const secret = "apikey_0123456789abcdefghijklmn"
+	path := filepath.Join(t.TempDir(), "settings.conf")
+	fixture := "# provider settings\n" +
+		"PROVIDER_URL=https://api.example.com\n" +
+		"PROVIDER_KEY=apikey_0123456789\\\n" +
+		"    abcdefghijklmn\n" +
+		"RETRIES=3\n"
+	assert.NoError(t, os.WriteFile(path, []byte(fixture), 0644))
+
+	for _, join := range []bool{false, true} {
+		t.Run(fmt.Sprintf("join=%t", join), func(t *testing.T) {
+			ctx := context.Background()
+			dispatcher := &lineDispatcher{lines: make(map[string]int64)}
+			e, err := NewEngine(ctx, &Config{
+				Concurrency:   1,
+				Decoders:      decoders.DefaultDecoders(),
+				Detectors:     []detectors.Detector{apiKeyDetector{}},
+				SourceManager: sources.NewManager(sources.WithBufferedOutput(64)),
+				Dispatcher:    dispatcher,
+			})
+			assert.NoError(t, err)
+			e.Start(ctx)
+
+			cfg := sources.FilesystemConfig{Paths: []string{path}, JoinLineContinuations: join}
+			assert.NoError(t, e.ScanFileSystem(ctx, cfg))
+			assert.NoError(t, e.Finish(ctx))
+
+			if !join {
+				// The wrapped secret isn't found.
+				assert.Empty(t, dispatcher.lines)
+				return
+			}
+			// The secret is reported on the line it starts on.
+			assert.Equal(t, map[string]int64{secret: 3}, dispatcher.lines)
+		})
+	}
+}
+
+// pemOnlyDetector reports the data it is given, and only applies to PEM files.
+type pemOnlyDetector struct{}
+
+var _ detectors.Detector = (*pemOnlyDetector)(nil)
+var _ detectors.ContentTypeProvider = (*pemOnlyDetector)(nil)
+
+func (pemOnlyDetector) FromData(_ aCtx.Context, _ bool, data []byte) ([]detectors.Result, error) {
+	return []detectors.Result{{
+		DetectorType: detectorspb.DetectorType(-1),
+		Raw:          bytes.TrimSpace(data),
+	}}, nil
+}
+
+func (pemOnlyDetector) Keywords() []string             { return []string{fakeDetectorKeyword} }
+func (pemOnlyDetector) Type() detectorspb.DetectorType { return detectorspb.DetectorType(-1) }
+func (pemOnlyDetector) ContentTypes() []string         { return []string{"application/x-pem-file"} }
+func (pemOnlyDetector) FileExtensions() []string       { return []string{".pem"} }
+
+func TestEngine_ContentTypeGating(t *testing.T) {
+	files := map[string]string{
+		"logo.png":   fakeDetectorKeyword + " in an image",
+		"server.pem": fakeDetectorKeyword + " in a pem file",
+		"id_rsa":     fakeDetectorKeyword + " in an unknown file",
+	}
+
+	tests := []struct {
+		name              string
+		contentTypeGating bool
+		want              []string
+	}{
+		{
+			name:              "gated",
+			contentTypeGating: true,
+			want:              []string{files["server.pem"], files["id_rsa"]},
+		},
+		{
+			name: "not gated",
+			want: []string{files["logo.png"], files["server.pem"], files["id_rsa"]},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			dir := t.TempDir()
+			for name, data := range files {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(data), 0644))
+			}
+
+			sourceManager := sources.NewManager(
+				sources.WithSourceUnits(),
+				sources.WithBufferedOutput(64),
+			)
+
+			dispatcher := new(recordingDispatcher)
+			conf := Config{
+				Concurrency:       1,
+				Decoders:          decoders.DefaultDecoders(),
+				Detectors:         []detectors.Detector{pemOnlyDetector{}},
+				ContentTypeGating: tt.contentTypeGating,
+				SourceManager:     sourceManager,
+				Dispatcher:        dispatcher,
+			}
+
+			e, err := NewEngine(ctx, &conf)
+			assert.NoError(t, err)
+
+			e.Start(ctx)
+			assert.NoError(t, e.ScanFileSystem(ctx, sources.FilesystemConfig{Paths: []string{dir}}))
+			assert.NoError(t, e.Finish(ctx))
+			assert.ElementsMatch(t, tt.want, dispatcher.raw)
+		})
+	}
+}
+
+func TestChunkContent(t *testing.T) {
+	tests := []struct {
+		name            string
+		metadata        *source_metadatapb.MetaData
+		wantContentType string
+		wantExtension   string
+	}{
+		{
+			name: "gcs object",
+			metadata: &source_metadatapb.MetaData{Data: &source_metadatapb.MetaData_Gcs{
+				Gcs: &source_metadatapb.GCS{Filename: "assets/logo.png", ContentType: "image/png"},
+			}},
+			wantContentType: "image/png",
+			wantExtension:   ".png",
+		},
+		{
+			name: "file in a gcs archive",
+			metadata: &source_metadatapb.MetaData{Data: &source_metadatapb.MetaData_Gcs{
+				Gcs: &source_metadatapb.GCS{Filename: "backup.zip", ContentType: "application/zip", ArchiveEntry: "keys/server.pem"},
+			}},
+			wantExtension: ".pem",
+		},
+		{
+			name: "filesystem",
+			metadata: &source_metadatapb.MetaData{Data: &source_metadatapb.MetaData_Filesystem{
+				Filesystem: &source_metadatapb.Filesystem{File: "/srv/logo.png"},
+			}},
+			wantExtension: ".png",
+		},
+		{
+			name:     "unknown",
+			metadata: &source_metadatapb.MetaData{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentType, extension := chunkContent(tt.metadata)
+			assert.Equal(t, tt.wantContentType, contentType)
+			assert.Equal(t, tt.wantExtension, extension)
+		})
+	}
+}
+
+// requestingDetector makes requests verifying the secret it finds in any
+// data, with a client of the common package whose transport is roundTrip.
+type requestingDetector struct {
+	requests int
+	// roundTrip responds to the requests, which are sent with the default
+	// transport of the common package if nil.
+	roundTrip common.RoundTripperFunc
+
+	// verifying counts the calls verifying at once, and sent the requests.
+	verifying, sent atomic.Int32
+}
+
+var _ detectors.Detector = (*requestingDetector)(nil)
+
+func (d *requestingDetector) FromData(ctx aCtx.Context, verify bool, _ []byte) ([]detectors.Result, error) {
+	result := detectors.Result{DetectorType: detectorspb.DetectorType(-1), Raw: []byte("requested secret")}
+	if !verify {
+		return []detectors.Result{result}, nil
+	}
+	d.verifying.Add(1)
+	defer d.verifying.Add(-1)
+
+	client := &http.Client{Transport: common.NewCustomTransport(nil)}
+	if d.roundTrip != nil {
+		client.Transport = common.NewCustomTransport(d.roundTrip)
+	}
+	for i := 0; i < d.requests; i++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://verify.example.com", nil)
+		if err != nil {
+			return nil, err
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			result.SetVerificationError(err)
+			return []detectors.Result{result}, nil
+		}
+		_ = res.Body.Close()
+		d.sent.Add(1)
+	}
+	result.Verified = true
+	return []detectors.Result{result}, nil
+}
+
+func (*requestingDetector) Keywords() []string             { return []string{fakeDetectorKeyword} }
+func (*requestingDetector) Type() detectorspb.DetectorType { return detectorspb.DetectorType(-1) }
+
+func okResponse(req *http.Request) (*http.Response, error) {
+	return &http.Response{Request: req, StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+}
+
+func TestEngine_VerificationRateLimit(t *testing.T) {
+	ctx := context.Background()
+
+	e, err := NewEngine(ctx, &Config{
+		VerificationRateLimit: 1,
+		SourceManager:         sources.NewManager(),
+	})
+	assert.NoError(t, err)
+	start := time.Unix(0, 0)
+	clk := &fakeClock{now: start}
+	e.verificationRateLimiter = newVerificationRateLimiter(1, clk)
+
+	detector := &requestingDetector{requests: 3, roundTrip: okResponse}
+	match := &ahocorasick.DetectorMatch{Key: ahocorasick.CreateDetectorKey(detector), Detector: detector}
+
+	// Detection without verification makes no requests, so it isn't limited.
+	_, err = e.fromData(ctx, match, false, []byte(fakeDetectorKeyword))
+	assert.NoError(t, err)
+	assert.Equal(t, start, clk.Now())
+
+	// Each request is limited, rather than each call of the detector.
+	results, err := e.fromData(ctx, match, true, []byte(fakeDetectorKeyword))
+	assert.NoError(t, err)
+	assert.True(t, results[0].Verified)
+	assert.Equal(t, int32(3), detector.sent.Load())
+	assert.Equal(t, 2*time.Second, clk.Now().Sub(start))
+}
+
+func TestEngine_VerificationConcurrency(t *testing.T) {
+	const (
+		verificationConcurrency = 2
+		numCalls                = 8
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	e, err := NewEngine(ctx, &Config{
+		Concurrency:             4,
+		VerificationConcurrency: verificationConcurrency,
+		SourceManager:           sources.NewManager(),
+		Dispatcher:              new(recordingDispatcher),
+	})
+	assert.NoError(t, err)
+
+	// Requests block until released, and count how many are sent at once.
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	detector := &requestingDetector{requests: 2, roundTrip: func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		<-release
+		return okResponse(req)
+	}}
+	match := &ahocorasick.DetectorMatch{Key: ahocorasick.CreateDetectorKey(detector), Detector: detector}
+
+	var wg sync.WaitGroup
+	for i := 0; i < numCalls; i++ {
+		for _, verify := range []bool{true, false} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := e.fromData(ctx, match, verify, []byte(fakeDetectorKeyword))
+				assert.NoError(t, err)
+			}()
+		}
+	}
+
+	// Requests are limited, while detection, even by the detectors verifying,
+	// isn't held up by them.
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return inFlight == verificationConcurrency && detector.verifying.Load() == numCalls
+	}, 5*time.Second, 10*time.Millisecond)
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, verificationConcurrency, maxInFlight)
+	assert.Equal(t, int32(2*numCalls), detector.sent.Load())
+}
+
+func TestNewEngine_NegativeVerificationConcurrency(t *testing.T) {
+	_, err := NewEngine(context.Background(), &Config{
+		VerificationConcurrency: -1,
+		SourceManager:           sources.NewManager(),
+	})
+	assert.Error(t, err)
+}
+
+func TestEngine_VerificationProxy(t *testing.T) {
+	ctx := context.Background()
+
+	// The proxy refuses to connect, so the requests fail once they reached it.
+	var mu sync.Mutex
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		proxied = append(proxied, r.Method+" "+r.Host)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer proxy.Close()
+
+	e, err := NewEngine(ctx, &Config{
+		VerificationProxy: proxy.URL,
+		SourceManager:     sources.NewManager(),
+	})
+	assert.NoError(t, err)
+
+	detector := &requestingDetector{requests: 1}
+	match := &ahocorasick.DetectorMatch{Key: ahocorasick.CreateDetectorKey(detector), Detector: detector}
+	results, err := e.fromData(ctx, match, true, []byte(fakeDetectorKeyword))
+	assert.NoError(t, err)
+	assert.Error(t, results[0].VerificationError())
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"CONNECT verify.example.com:443"}, proxied)
+}
+
+func TestNewEngine_InvalidVerificationProxy(t *testing.T) {
+	_, err := NewEngine(context.Background(), &Config{
+		VerificationProxy: "ftp://proxy.example.com",
+		SourceManager:     sources.NewManager(),
+	})
+	assert.Error(t, err)
+}
+
+// registeredDetector finds internal tokens, and is added with
+// detectors.Register rather than the engine configuration.
+type registeredDetector struct{}
+
+var _ detectors.Detector = registeredDetector{}
+
+var registeredTokenPat = regexp.MustCompile(`acmeinternal_[a-z0-9]{12}`)
+
+func (registeredDetector) FromData(_ aCtx.Context, _ bool, data []byte) ([]detectors.Result, error) {
+	var results []detectors.Result
+	for _, match := range registeredTokenPat.FindAll(data, -1) {
+		results = append(results, detectors.Result{
+			DetectorType: detectorspb.DetectorType(-2),
+			Raw:          match,
+		})
+	}
+	return results, nil
+}
+
+func (registeredDetector) Keywords() []string             { return []string{"acmeinternal_"} }
+func (registeredDetector) Type() detectorspb.DetectorType { return detectorspb.DetectorType(-2) }
+
+var registerDetectorOnce sync.Once
+
+func TestEngine_RegisteredDetector(t *testing.T) {
+	// Detectors are registered for the lifetime of the process.
+	registerDetectorOnce.Do(func() { detectors.Register(registeredDetector{}) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	dir := t.TempDir()
+	data := fakeDetectorKeyword + " token=acmeinternal_0123abcd4567"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "config.env"), []byte(data), 0644))
+
+	sourceManager := sources.NewManager(
+		sources.WithSourceUnits(),
+		sources.WithBufferedOutput(64),
+	)
+
+	dispatcher := new(recordingDispatcher)
+	conf := Config{
+		Concurrency:   1,
+		Decoders:      decoders.DefaultDecoders(),
+		Detectors:     []detectors.Detector{fakeDetectorV1{}},
+		SourceManager: sourceManager,
+		Dispatcher:    dispatcher,
+	}
+
+	e, err := NewEngine(ctx, &conf)
+	assert.NoError(t, err)
+
+	e.Start(ctx)
+	assert.NoError(t, e.ScanFileSystem(ctx, sources.FilesystemConfig{Paths: []string{dir}}))
+	assert.NoError(t, e.Finish(ctx))
+
+	// The registered detector is used alongside the configured one.
+	assert.Contains(t, dispatcher.raw, "acmeinternal_0123abcd4567")
+	assert.Contains(t, dispatcher.types, detectorspb.DetectorType(-1))
+	assert.Contains(t, dispatcher.types, detectorspb.DetectorType(-2))
+}
+
+func TestEngine_ScanIDAndDetectedAt(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	scan := func() (*Engine, *recordingDispatcher) {
+		dispatcher := new(recordingDispatcher)
+		conf := Config{
+			Concurrency:   1,
+			Decoders:      decoders.DefaultDecoders(),
+			Detectors:     []detectors.Detector{allowlistTestDetector{}},
+			SourceManager: sources.NewManager(sources.WithBufferedOutput(64)),
+			Dispatcher:    dispatcher,
+		}
+		e, err := NewEngine(ctx, &conf)
+		assert.NoError(t, err)
+		assert.Empty(t, e.ScanID())
+
+		e.Start(ctx)
+		source := &gcsChunksSource{}
+		for i := 0; i < 20; i++ {
+			source.objects = append(source.objects, [3]string{"bucket", fmt.Sprintf("object%d", i), fmt.Sprintf("allowlisted secret-%d", i)})
+		}
+		_, err = e.sourceManager.Run(ctx, "gcs", source)
+		assert.NoError(t, err)
+		assert.NoError(t, e.Finish(ctx))
+		return e, dispatcher
+	}
+
+	start := time.Now()
+	e, dispatcher := scan()
+	end := time.Now()
+
+	_, err := uuid.Parse(e.ScanID())
+	assert.NoError(t, err)
+	assert.Equal(t, e.ScanID(), e.GetMetrics().ScanID)
+
+	// All the results share the ID of the scan, and a single notifier worker
+	// reports them in the order of their times.
+	assert.Len(t, dispatcher.raw, 20)
+	for i, scanID := range dispatcher.scanIDs {
+		assert.Equal(t, e.ScanID(), scanID)
+		detectedAt := dispatcher.detectedAt[i]
+		assert.Equal(t, time.UTC, detectedAt.Location())
+		assert.False(t, detectedAt.Before(start) || detectedAt.After(end), "result %d detected at %s", i, detectedAt)
+		if i > 0 {
+			assert.False(t, detectedAt.Before(dispatcher.detectedAt[i-1]), "result %d detected before the previous one", i)
+		}
+	}
+
+	// Each scan has its own ID.
+	other, _ := scan()
+	assert.NotEqual(t, e.ScanID(), other.ScanID())
+}
@@ -15,9 +15,16 @@ import (
 // ScanFileSystem scans a given file system.
 func (e *Engine) ScanFileSystem(ctx context.Context, c sources.FilesystemConfig) error {
 	connection := &sourcespb.Filesystem{
-		Paths:            c.Paths,
-		IncludePathsFile: c.IncludePathsFile,
-		ExcludePathsFile: c.ExcludePathsFile,
+		Paths:                   c.Paths,
+		IncludePathsFile:        c.IncludePathsFile,
+		ExcludePathsFile:        c.ExcludePathsFile,
+		PathAllowlist:           c.PathAllowlist,
+		SkipBinaries:            c.SkipBinaries,
+		BinaryNullByteThreshold: c.BinaryThreshold,
+		ScanPaths:               c.ScanPaths,
+		JoinLineContinuations:   c.JoinLineContinuations,
+		UseIgnoreFiles:          c.UseIgnoreFiles,
+		MmapThreshold:           c.MmapThreshold,
 	}
 	var conn anypb.Any
 	err := anypb.MarshalFrom(&conn, connection, proto.MarshalOptions{})
@@ -0,0 +1,47 @@
+package engine
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/credentialspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/gcpsecretmanager"
+)
+
+// ScanGCPSecretManager with the provided options.
+func (e *Engine) ScanGCPSecretManager(ctx context.Context, c sources.GCPSecretManagerConfig) error {
+	if c.ProjectID == "" {
+		return fmt.Errorf("project ID is required")
+	}
+
+	connection := &sourcespb.GCPSecretManager{
+		ProjectId:      c.ProjectID,
+		IncludeSecrets: c.IncludeSecrets,
+		ExcludeSecrets: c.ExcludeSecrets,
+		Credential:     &sourcespb.GCPSecretManager_Adc{Adc: &credentialspb.CloudEnvironment{}},
+	}
+	if c.ServiceAccount != "" {
+		connection.Credential = &sourcespb.GCPSecretManager_ServiceAccountFile{ServiceAccountFile: c.ServiceAccount}
+	}
+
+	var conn anypb.Any
+	err := anypb.MarshalFrom(&conn, connection, proto.MarshalOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to marshal Secret Manager connection: %w", err)
+	}
+
+	sourceName := "trufflehog - gcp secret manager"
+	sourceID, jobID, _ := e.sourceManager.GetIDs(ctx, sourceName, gcpsecretmanager.SourceType)
+
+	smSource := &gcpsecretmanager.Source{}
+	if err := smSource.Init(ctx, sourceName, jobID, sourceID, true, &conn, c.Concurrency); err != nil {
+		return err
+	}
+	_, err = e.sourceManager.Run(ctx, sourceName, smSource)
+	return err
+}
@@ -14,8 +14,11 @@ import (
 
 // ScanGCS with the provided options.
 func (e *Engine) ScanGCS(ctx context.Context, c sources.GCSConfig) error {
-	// Project ID is required if using any authenticated access.
-	if c.ProjectID == "" && !c.WithoutAuth {
+	// Project ID is required if using any authenticated access. Signed URLs
+	// carry their own authentication, and the objects of an inventory report
+	// are read without listing the buckets of the project.
+	withSignedURLs := len(c.SignedURLs) > 0 || c.SignedURLsFile != ""
+	if c.ProjectID == "" && !c.WithoutAuth && !withSignedURLs && c.InventoryManifest == "" {
 		return fmt.Errorf("project ID is required")
 	}
 
@@ -26,11 +29,46 @@ func (e *Engine) ScanGCS(ctx context.Context, c sources.GCSConfig) error {
 	}
 
 	connection := &sourcespb.GCS{
-		ProjectId:      c.ProjectID,
-		IncludeBuckets: c.IncludeBuckets,
-		ExcludeBuckets: c.ExcludeBuckets,
-		IncludeObjects: c.IncludeObjects,
-		ExcludeObjects: c.ExcludeObjects,
+		ProjectId:                  c.ProjectID,
+		IncludeBuckets:             c.IncludeBuckets,
+		ExcludeBuckets:             c.ExcludeBuckets,
+		IncludeObjects:             c.IncludeObjects,
+		ExcludeObjects:             c.ExcludeObjects,
+		IncludeContentTypes:        c.IncludeContentTypes,
+		ExcludeContentTypes:        c.ExcludeContentTypes,
+		ExcludeUnknownContentTypes: c.ExcludeUnknownContentTypes,
+		SkipBinaries:               c.SkipBinaries,
+		BinaryNullByteThreshold:    c.BinaryThreshold,
+		SignedUrls:                 c.SignedURLs,
+		SignedUrlsFile:             c.SignedURLsFile,
+		DisableArchiveHandling:     c.DisableArchiveHandling,
+		Prefixes:                   c.Prefixes,
+		IncludeVersions:            c.IncludeVersions,
+		MaxObjectsPerBucket:        c.MaxObjectsPerBucket,
+		SampleObjects:              c.SampleObjects,
+		SampleRate:                 c.SampleRate,
+		SampleSeed:                 c.SampleSeed,
+		RangedReadThreshold:        c.RangedReadThreshold,
+		RangedReadConcurrency:      int32(c.RangedReadConcurrency),
+		PrefetchDepth:              int32(c.PrefetchDepth),
+		PrefetchMaxBytes:           c.PrefetchMaxBytes,
+		ContinueOnBucketError:      c.ContinueOnBucketError,
+		ReportPublicAccess:         c.ReportPublicAccess,
+		ScanPaths:                  c.ScanPaths,
+		Ndjson:                     c.NDJSON,
+		JoinLineContinuations:      c.JoinLineContinuations,
+		BucketIam:                  c.BucketIAM,
+		Proxy:                      c.Proxy,
+		PrivateEndpoint:            c.PrivateEndpoint,
+		SkipArchivedObjects:        c.SkipArchivedObjects,
+		DedupeObjects:              c.DedupeObjects,
+		SoftDeletedObjects:         c.SoftDeletedObjects,
+		InventoryManifest:          c.InventoryManifest,
+		MaxObjectSize:              c.MaxObjectSize,
+		MinObjectSize:              c.MinObjectSize,
+		TruncateLargeObjects:       c.TruncateLargeObjects,
+		ImpersonateServiceAccount:  c.ImpersonateServiceAccount,
+		ImpersonationDelegates:     c.ImpersonationDelegates,
 	}
 
 	// Make sure only one auth method is selected.
@@ -51,6 +89,7 @@ func (e *Engine) ScanGCS(ctx context.Context, c sources.GCSConfig) error {
 	if err := gcsSource.Init(ctx, sourceName, jobID, sourceID, true, &conn, int(c.Concurrency)); err != nil {
 		return err
 	}
+	e.summary.trackSource(sourceName, gcsSource)
 	_, err = e.sourceManager.Run(ctx, sourceName, gcsSource)
 	return err
 }
@@ -62,7 +101,9 @@ func isAuthValid(ctx context.Context, c sources.GCSConfig, connection *sourcespb
 		isAuthSelected = true
 		connection.Credential = &sourcespb.GCS_Unauthenticated{}
 	}
-	if c.CloudCred {
+	// Service accounts are impersonated with the application default
+	// credentials.
+	if c.CloudCred || c.ImpersonateServiceAccount != "" {
 		if isAuthSelected {
 			return false
 		}
@@ -78,6 +119,15 @@ func isAuthValid(ctx context.Context, c sources.GCSConfig, connection *sourcespb
 			ServiceAccountFile: c.ServiceAccount,
 		}
 	}
+	if c.WorkloadIdentityFederation != "" {
+		if isAuthSelected {
+			return false
+		}
+		isAuthSelected = true
+		connection.Credential = &sourcespb.GCS_WorkloadIdentityFederationFile{
+			WorkloadIdentityFederationFile: c.WorkloadIdentityFederation,
+		}
+	}
 	if c.ApiKey != "" {
 		if isAuthSelected {
 			return false
@@ -0,0 +1,209 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// ScanSummary is a rollup of a scan: the data scanned, by source, and the
+// results reported, by detector. See Engine.ScanSummary.
+type ScanSummary struct {
+	ChunksScanned uint64
+	BytesScanned  uint64
+	// Results are the counts of the reported results of all the detectors.
+	Results ResultCounts
+	// Detectors are the counts of the reported results, by detector name.
+	Detectors map[string]ResultCounts
+	// Sources are the sources scanned, by name.
+	Sources map[string]SourceSummary
+	// DetectorTimings are the time spent in each detector, by name, if
+	// Config.DetectorTiming is set.
+	DetectorTimings map[string]DetectorTiming
+}
+
+// ResultCounts are the counts of results by verification status. Unknown
+// results are those whose verification failed.
+type ResultCounts struct {
+	Verified   uint64
+	Unverified uint64
+	Unknown    uint64
+}
+
+// Total returns the number of results.
+func (c ResultCounts) Total() uint64 {
+	return c.Verified + c.Unverified + c.Unknown
+}
+
+func (c *ResultCounts) add(result detectors.ResultWithMetadata) {
+	switch {
+	case result.Verified:
+		c.Verified++
+	case result.VerificationError() != nil:
+		c.Unknown++
+	default:
+		c.Unverified++
+	}
+}
+
+// SourceSummary is the data scanned from a source. The objects are only
+// counted for sources that implement sources.ObjectCounter.
+type SourceSummary struct {
+	Type           sourcespb.SourceType
+	ChunksScanned  uint64
+	BytesScanned   uint64
+	ObjectsScanned uint64
+	ObjectBytes    uint64
+}
+
+// scanSummary aggregates the ScanSummary of a scan as chunks are scanned and
+// results reported.
+type scanSummary struct {
+	mu      sync.Mutex
+	summary ScanSummary
+	// counters are the sources that count the objects they scan, by name.
+	counters map[string]sources.Source
+}
+
+func newScanSummary() *scanSummary {
+	return &scanSummary{
+		summary: ScanSummary{
+			Detectors: make(map[string]ResultCounts),
+			Sources:   make(map[string]SourceSummary),
+		},
+		counters: make(map[string]sources.Source),
+	}
+}
+
+// trackSource adds the objects scanned by a source to the summary, if it
+// counts them.
+func (s *scanSummary) trackSource(name string, source sources.Source) {
+	if _, ok := source.(sources.ObjectCounter); !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counters[name] = source
+}
+
+func (s *scanSummary) addChunk(chunk *sources.Chunk) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	size := uint64(len(chunk.Data))
+	s.summary.ChunksScanned++
+	s.summary.BytesScanned += size
+
+	source := s.summary.Sources[chunk.SourceName]
+	source.Type = chunk.SourceType
+	source.ChunksScanned++
+	source.BytesScanned += size
+	s.summary.Sources[chunk.SourceName] = source
+}
+
+func (s *scanSummary) addResult(result detectors.ResultWithMetadata) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.summary.Results.add(result)
+	name := resultDetectorName(result)
+	counts := s.summary.Detectors[name]
+	counts.add(result)
+	s.summary.Detectors[name] = counts
+}
+
+// get returns a copy of the summary, with the objects scanned so far.
+func (s *scanSummary) get() ScanSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	summary := s.summary
+	summary.Detectors = make(map[string]ResultCounts, len(s.summary.Detectors))
+	for name, counts := range s.summary.Detectors {
+		summary.Detectors[name] = counts
+	}
+	summary.Sources = make(map[string]SourceSummary, len(s.summary.Sources))
+	for name, source := range s.summary.Sources {
+		summary.Sources[name] = source
+	}
+	for name, counter := range s.counters {
+		source := summary.Sources[name]
+		source.Type = counter.Type()
+		source.ObjectsScanned, source.ObjectBytes = counter.(sources.ObjectCounter).ObjectsScanned()
+		summary.Sources[name] = source
+	}
+	return summary
+}
+
+// resultDetectorName returns the name of the detector of a result. Custom
+// detectors are told apart by their name.
+func resultDetectorName(result detectors.ResultWithMetadata) string {
+	if result.DetectorName != "" {
+		return result.DetectorName
+	}
+	return result.DetectorType.String()
+}
+
+// Print writes the summary to w, with the detectors and sources sorted by name.
+func (s ScanSummary) Print(w io.Writer) error {
+	var err error
+	printf := func(format string, args ...any) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	printf("Scanned %d chunks (%d bytes)\n", s.ChunksScanned, s.BytesScanned)
+	printf("Found %d results: %d verified, %d unverified, %d unknown\n",
+		s.Results.Total(), s.Results.Verified, s.Results.Unverified, s.Results.Unknown)
+
+	if len(s.Detectors) > 0 {
+		printf("\nResults by detector:\n")
+		for _, name := range sortedKeys(s.Detectors) {
+			c := s.Detectors[name]
+			printf("  %s: %d verified, %d unverified, %d unknown\n", name, c.Verified, c.Unverified, c.Unknown)
+		}
+	}
+
+	if len(s.Sources) > 0 {
+		printf("\nSources:\n")
+		for _, name := range sortedKeys(s.Sources) {
+			source := s.Sources[name]
+			printf("  %s (%s): %d chunks (%d bytes)", name, source.Type, source.ChunksScanned, source.BytesScanned)
+			if source.ObjectsScanned > 0 {
+				printf(", %d objects (%d bytes)", source.ObjectsScanned, source.ObjectBytes)
+			}
+			printf("\n")
+		}
+	}
+
+	if len(s.DetectorTimings) > 0 {
+		printf("\nTime by detector:\n")
+		// The slowest detectors are printed first.
+		names := sortedKeys(s.DetectorTimings)
+		sort.SliceStable(names, func(i, j int) bool {
+			return s.DetectorTimings[names[i]].Total() > s.DetectorTimings[names[j]].Total()
+		})
+		for _, name := range names {
+			t := s.DetectorTimings[name]
+			printf("  %s: %s in %d calls (%s detecting, %s verifying)\n",
+				name, t.Total(), t.Calls, t.Detection, t.Verification)
+		}
+	}
+	return err
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
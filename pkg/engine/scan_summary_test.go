@@ -0,0 +1,173 @@
+package engine
+
+import (
+	aCtx "context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/decoders"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// statusDetector finds tokens whose verification status is part of the token,
+// e.g. tok_valid_1 is verified.
+type statusDetector struct{}
+
+var _ detectors.Detector = statusDetector{}
+
+var statusTokenPat = regexp.MustCompile(`tok_(valid|invalid|timeout)_\d+`)
+
+func (statusDetector) FromData(_ aCtx.Context, verify bool, data []byte) ([]detectors.Result, error) {
+	var results []detectors.Result
+	for _, match := range statusTokenPat.FindAllSubmatch(data, -1) {
+		result := detectors.Result{DetectorType: statusDetector{}.Type(), Raw: match[0]}
+		if verify {
+			switch string(match[1]) {
+			case "valid":
+				result.Verified = true
+			case "timeout":
+				result.SetVerificationError(fmt.Errorf("verification timed out"))
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (statusDetector) Keywords() []string             { return []string{"tok_"} }
+func (statusDetector) Type() detectorspb.DetectorType { return detectorspb.DetectorType(-3) }
+
+func TestEngine_ScanSummary(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	files := map[string]string{
+		"a.env": "API_TOKEN=tok_valid_1\nOLD_TOKEN=tok_invalid_1\n",
+		"b.env": "API_TOKEN=tok_valid_2\nSLOW_TOKEN=tok_timeout_1\n",
+		"c.env": "nothing to see here\n",
+	}
+	dir := t.TempDir()
+	var size uint64
+	for name, data := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(data), 0644))
+		size += uint64(len(data))
+	}
+
+	sourceManager := sources.NewManager(
+		sources.WithSourceUnits(),
+		sources.WithBufferedOutput(64),
+	)
+
+	dispatcher := new(recordingDispatcher)
+	conf := Config{
+		Concurrency:   1,
+		Decoders:      decoders.DefaultDecoders(),
+		Detectors:     []detectors.Detector{statusDetector{}},
+		Verify:        true,
+		SourceManager: sourceManager,
+		Dispatcher:    dispatcher,
+	}
+
+	e, err := NewEngine(ctx, &conf)
+	assert.NoError(t, err)
+
+	e.Start(ctx)
+	assert.NoError(t, e.ScanFileSystem(ctx, sources.FilesystemConfig{Paths: []string{dir}}))
+	assert.NoError(t, e.Finish(ctx))
+
+	summary := e.ScanSummary()
+	counts := ResultCounts{Verified: 2, Unverified: 1, Unknown: 1}
+	assert.Equal(t, ScanSummary{
+		ChunksScanned: 3,
+		BytesScanned:  size,
+		Results:       counts,
+		Detectors:     map[string]ResultCounts{detectorspb.DetectorType(-3).String(): counts},
+		Sources: map[string]SourceSummary{
+			"trufflehog - filesystem": {
+				Type:          sourcespb.SourceType_SOURCE_TYPE_FILESYSTEM,
+				ChunksScanned: 3,
+				BytesScanned:  size,
+			},
+		},
+	}, summary)
+
+	// The summary matches the results reported and the metrics.
+	assert.Equal(t, uint64(len(dispatcher.raw)), summary.Results.Total())
+	metrics := e.GetMetrics()
+	assert.Equal(t, metrics.ChunksScanned, summary.ChunksScanned)
+	assert.Equal(t, metrics.VerifiedSecretsFound, summary.Results.Verified)
+}
+
+// objectSource is a source that counts the objects it scans.
+type objectSource struct {
+	slowSource
+}
+
+var _ sources.ObjectCounter = (*objectSource)(nil)
+
+func (*objectSource) Type() sourcespb.SourceType              { return sourcespb.SourceType_SOURCE_TYPE_GCS }
+func (*objectSource) ObjectsScanned() (objects, bytes uint64) { return 2, 1024 }
+
+func TestScanSummary_ObjectCounter(t *testing.T) {
+	summary := newScanSummary()
+	summary.trackSource("objects", new(objectSource))
+	// Sources that don't count objects are only summarized by their chunks.
+	summary.trackSource("files", new(slowSource))
+
+	summary.addChunk(&sources.Chunk{SourceName: "objects", SourceType: sourcespb.SourceType_SOURCE_TYPE_GCS, Data: []byte("hello")})
+
+	assert.Equal(t, map[string]SourceSummary{
+		"objects": {
+			Type:           sourcespb.SourceType_SOURCE_TYPE_GCS,
+			ChunksScanned:  1,
+			BytesScanned:   5,
+			ObjectsScanned: 2,
+			ObjectBytes:    1024,
+		},
+	}, summary.get().Sources)
+}
+
+func TestScanSummary_Print(t *testing.T) {
+	summary := ScanSummary{
+		ChunksScanned: 3,
+		BytesScanned:  120,
+		Results:       ResultCounts{Verified: 2, Unverified: 1},
+		Detectors: map[string]ResultCounts{
+			"Github": {Unverified: 1},
+			"AWS":    {Verified: 2},
+		},
+		Sources: map[string]SourceSummary{
+			"trufflehog - gcs": {
+				Type:           sourcespb.SourceType_SOURCE_TYPE_GCS,
+				ChunksScanned:  3,
+				BytesScanned:   120,
+				ObjectsScanned: 2,
+				ObjectBytes:    100,
+			},
+		},
+	}
+
+	var out strings.Builder
+	assert.NoError(t, summary.Print(&out))
+	assert.Equal(t, `Scanned 3 chunks (120 bytes)
+Found 3 results: 2 verified, 1 unverified, 0 unknown
+
+Results by detector:
+  AWS: 2 verified, 0 unverified, 0 unknown
+  Github: 0 verified, 1 unverified, 0 unknown
+
+Sources:
+  trufflehog - gcs (SOURCE_TYPE_GCS): 3 chunks (120 bytes), 2 objects (100 bytes)
+`, out.String())
+}
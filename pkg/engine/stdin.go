@@ -0,0 +1,35 @@
+package engine
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources/stdin"
+)
+
+// ScanStdin scans the text read from standard input, or from the reader of
+// the config if set.
+func (e *Engine) ScanStdin(ctx context.Context, c sources.StdinConfig) error {
+	connection := &sourcespb.Stdin{Name: c.Name}
+
+	var conn anypb.Any
+	err := anypb.MarshalFrom(&conn, connection, proto.MarshalOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to marshal stdin connection: %w", err)
+	}
+
+	sourceName := "trufflehog - stdin"
+	sourceID, jobID, _ := e.sourceManager.GetIDs(ctx, sourceName, stdin.SourceType)
+
+	stdinSource := stdin.NewSource(c.Reader)
+	if err := stdinSource.Init(ctx, sourceName, jobID, sourceID, true, &conn, 1); err != nil {
+		return err
+	}
+	_, err = e.sourceManager.Run(ctx, sourceName, stdinSource)
+	return err
+}
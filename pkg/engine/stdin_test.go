@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/decoders"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+func TestEngine_ScanStdin(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	input := `# pasted config
+db_password = allowlisted pasted-secret
+`
+	tests := []struct {
+		name     string
+		config   sources.StdinConfig
+		wantName string
+	}{
+		{name: "default name", config: sources.StdinConfig{Reader: strings.NewReader(input)}, wantName: "stdin"},
+		{name: "named input", config: sources.StdinConfig{Name: "app.env", Reader: strings.NewReader(input)}, wantName: "app.env"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dispatcher := new(recordingDispatcher)
+			conf := Config{
+				Concurrency:   1,
+				Decoders:      decoders.DefaultDecoders(),
+				Detectors:     []detectors.Detector{allowlistTestDetector{}},
+				SourceManager: sources.NewManager(sources.WithBufferedOutput(64)),
+				Dispatcher:    dispatcher,
+			}
+			e, err := NewEngine(ctx, &conf)
+			require.NoError(t, err)
+
+			e.Start(ctx)
+			require.NoError(t, e.ScanStdin(ctx, tc.config))
+			require.NoError(t, e.Finish(ctx))
+
+			assert.Equal(t, []string{"pasted-secret"}, dispatcher.raw)
+			require.Len(t, dispatcher.metadata, 1)
+			assert.Equal(t, tc.wantName, dispatcher.metadata[0].GetStdin().GetName())
+			assert.Equal(t, &sources.Position{Offset: 42, Line: 2, Column: 27}, dispatcher.positions[0])
+			assert.Equal(t, uint64(1), e.GetMetrics().ChunksScanned)
+		})
+	}
+}
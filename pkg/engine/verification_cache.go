@@ -0,0 +1,227 @@
+package engine
+
+import (
+	"bytes"
+	aCtx "context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+const (
+	// verificationCacheSize is the number of responses the verification
+	// cache keeps, evicting the least recently used ones.
+	verificationCacheSize = 1024
+	// maxCachedResponseSize is the size of the largest response body cached.
+	maxCachedResponseSize = 64 * 1024
+)
+
+// verificationCache reuses the responses to the verification requests of a
+// secret for its later occurrences within a scan, so a secret that appears
+// many times, e.g. in a config templated across files, is only verified once
+// with its provider. It wraps the requests detectors make with the HTTP
+// clients of the common package, so the secrets found are still verified by
+// their detector, only without sending the requests again.
+//
+// Responses are keyed by the detector and the request, which includes the
+// secret, and expire after ttl, if set. Requests that differ every time, e.g.
+// signed with a timestamp, are never reused. Occurrences of a secret whose
+// request is being sent wait for its response instead of sending it again.
+type verificationCache struct {
+	ttl     time.Duration
+	clock   clock
+	entries *lru.Cache[verificationCacheKey, cachedResponse]
+
+	mu       sync.Mutex
+	inFlight map[verificationCacheKey]chan struct{}
+}
+
+// verificationCacheKey identifies a request sent by a detector. Detectors of
+// the same type but different versions may verify a secret differently.
+type verificationCacheKey struct {
+	detector ahocorasick.DetectorKey
+	request  [sha256.Size]byte
+}
+
+type cachedResponse struct {
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+func newVerificationCache(size int, ttl time.Duration, c clock) (*verificationCache, error) {
+	entries, err := lru.New[verificationCacheKey, cachedResponse](size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize verification cache: %w", err)
+	}
+	return &verificationCache{
+		ttl:      ttl,
+		clock:    c,
+		entries:  entries,
+		inFlight: make(map[verificationCacheKey]chan struct{}),
+	}, nil
+}
+
+// newVerificationCacheKey returns the key of the request, from its method,
+// URL, headers and body. The body is read, and replaced by a copy.
+func newVerificationCacheKey(detector ahocorasick.DetectorKey, req *http.Request) (verificationCacheKey, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", req.Method, req.URL)
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(h, "%s: %q\n", name, req.Header[name])
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return verificationCacheKey{}, fmt.Errorf("error reading request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		h.Write(body)
+	}
+
+	key := verificationCacheKey{detector: detector}
+	h.Sum(key.request[:0])
+	return key, nil
+}
+
+// middleware returns the middleware of the verification requests of the
+// detector, which returns the cached responses to the requests sent before.
+// The detection's timeout is paused while waiting for the response to the
+// same request to be received.
+func (c *verificationCache) middleware(detector ahocorasick.DetectorKey, timeout *pausableTimeout) common.RequestMiddleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return common.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			key, err := newVerificationCacheKey(detector, req)
+			if err != nil {
+				return nil, err
+			}
+
+			timeout.pause()
+			cached, ok, err := c.acquire(req.Context(), key)
+			timeout.resume()
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return cached.response(req), nil
+			}
+
+			res, err := next.RoundTrip(req)
+			return c.release(key, res, err)
+		})
+	}
+}
+
+// acquire returns the cached response of the key, if any. Otherwise the
+// caller must send the request, and call release once finished, even if the
+// request failed. If another caller is sending the request, acquire waits for
+// it to finish first. It returns the context's error if the context is done
+// before then.
+func (c *verificationCache) acquire(ctx aCtx.Context, key verificationCacheKey) (cachedResponse, bool, error) {
+	for {
+		c.mu.Lock()
+		wait, ok := c.inFlight[key]
+		if !ok {
+			cached, ok := c.lookup(key)
+			if !ok {
+				c.inFlight[key] = make(chan struct{})
+			}
+			c.mu.Unlock()
+			return cached, ok, nil
+		}
+		c.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return cachedResponse{}, false, ctx.Err()
+		case <-wait:
+		}
+	}
+}
+
+func (c *verificationCache) lookup(key verificationCacheKey) (cachedResponse, bool) {
+	cached, ok := c.entries.Get(key)
+	if ok && c.ttl > 0 && !c.clock.Now().Before(cached.expires) {
+		c.entries.Remove(key)
+		return cachedResponse{}, false
+	}
+	return cached, ok
+}
+
+// release caches the response to the request of the key, and wakes up the
+// callers waiting for it. Failed requests, rate limited or failed responses,
+// and large responses aren't cached, so the request is sent again next time.
+// The response is returned with its body replaced by the one read to cache it.
+func (c *verificationCache) release(key verificationCacheKey, res *http.Response, err error) (*http.Response, error) {
+	defer func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if wait, ok := c.inFlight[key]; ok {
+			close(wait)
+			delete(c.inFlight, key)
+		}
+	}()
+
+	if err != nil || res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError {
+		return res, err
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxCachedResponseSize+1))
+	if err != nil || len(body) > maxCachedResponseSize {
+		res.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), res.Body), Closer: res.Body}
+		return res, nil
+	}
+	_ = res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.entries.Add(key, cachedResponse{
+		status:  res.StatusCode,
+		header:  res.Header.Clone(),
+		body:    body,
+		expires: c.clock.Now().Add(c.ttl),
+	})
+	return res, nil
+}
+
+// response returns a copy of the cached response, to the request.
+func (r cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.status, http.StatusText(r.status)),
+		StatusCode:    r.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.body)),
+		ContentLength: int64(len(r.body)),
+		Request:       req,
+	}
+}
+
+// clear removes all the cached responses.
+func (c *verificationCache) clear() {
+	c.entries.Purge()
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
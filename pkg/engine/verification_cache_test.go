@@ -0,0 +1,243 @@
+package engine
+
+import (
+	aCtx "context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/decoders"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+func TestEngine_VerificationCache(t *testing.T) {
+	const numOccurrences = 20
+
+	tests := []struct {
+		name              string
+		verificationCache bool
+		wantRequests      int32
+	}{
+		{name: "cached", verificationCache: true, wantRequests: 1},
+		{name: "not cached", wantRequests: numOccurrences},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			// The same secret is templated across many files.
+			dir := t.TempDir()
+			for i := 0; i < numOccurrences; i++ {
+				path := filepath.Join(dir, fmt.Sprintf("config-%d.txt", i))
+				assert.NoError(t, os.WriteFile(path, []byte(fakeDetectorKeyword+" requested secret"), 0644))
+			}
+
+			sourceManager := sources.NewManager(
+				sources.WithSourceUnits(),
+				sources.WithBufferedOutput(64),
+			)
+
+			detector := &requestingDetector{requests: 1, roundTrip: okResponse}
+			dispatcher := new(recordingDispatcher)
+			conf := Config{
+				Concurrency:       4,
+				Decoders:          decoders.DefaultDecoders(),
+				Detectors:         []detectors.Detector{detector},
+				Verify:            true,
+				VerificationCache: tt.verificationCache,
+				SourceManager:     sourceManager,
+				Dispatcher:        dispatcher,
+			}
+
+			e, err := NewEngine(ctx, &conf)
+			assert.NoError(t, err)
+
+			e.Start(ctx)
+
+			cfg := sources.FilesystemConfig{Paths: []string{dir}}
+			assert.NoError(t, e.ScanFileSystem(ctx, cfg))
+
+			assert.Nil(t, e.Finish(ctx))
+			assert.Equal(t, tt.wantRequests, detector.sent.Load())
+
+			// Every occurrence is still reported as verified.
+			assert.Len(t, dispatcher.raw, numOccurrences)
+			assert.Equal(t, uint64(numOccurrences), e.GetMetrics().VerifiedSecretsFound)
+		})
+	}
+}
+
+// countingTransport responds with status to the requests, with the secret
+// header as the body, and counts them.
+type countingTransport struct {
+	status   atomic.Int32
+	requests atomic.Int32
+	// block, if set, blocks the requests until it's closed.
+	block chan struct{}
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests.Add(1)
+	if t.block != nil {
+		<-t.block
+	}
+	status := int(t.status.Load())
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return &http.Response{
+		Request:    req,
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"text/plain"}},
+		Body:       io.NopCloser(strings.NewReader("verified " + req.Header.Get("X-Secret"))),
+	}, nil
+}
+
+// sendVerification sends a request with the secret through the cache, and
+// returns the body of the response.
+func sendVerification(ctx aCtx.Context, cache *verificationCache, transport http.RoundTripper, secret string) (string, error) {
+	timeout := newPausableTimeout(context.AddLogger(ctx), time.Minute)
+	defer timeout.stop()
+	key := ahocorasick.CreateDetectorKey(new(requestingDetector))
+	reqCtx := common.WithRequestMiddleware(timeout, cache.middleware(key, timeout))
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, "https://verify.example.com", strings.NewReader("{}"))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Secret", secret)
+	client := &http.Client{Transport: common.NewCustomTransport(transport)}
+	res, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	return string(body), err
+}
+
+func TestVerificationCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reuses responses", func(t *testing.T) {
+		cache, err := newVerificationCache(verificationCacheSize, 0, realClock{})
+		require.NoError(t, err)
+		transport := new(countingTransport)
+
+		for i := 0; i < 3; i++ {
+			body, err := sendVerification(ctx, cache, transport, "secret")
+			require.NoError(t, err)
+			assert.Equal(t, "verified secret", body)
+		}
+		assert.Equal(t, int32(1), transport.requests.Load())
+
+		// A request with another secret is sent.
+		body, err := sendVerification(ctx, cache, transport, "other")
+		require.NoError(t, err)
+		assert.Equal(t, "verified other", body)
+		assert.Equal(t, int32(2), transport.requests.Load())
+
+		// Once cleared, the requests are sent again.
+		cache.clear()
+		_, err = sendVerification(ctx, cache, transport, "secret")
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), transport.requests.Load())
+	})
+
+	t.Run("failed responses are not cached", func(t *testing.T) {
+		cache, err := newVerificationCache(verificationCacheSize, 0, realClock{})
+		require.NoError(t, err)
+		transport := new(countingTransport)
+		transport.status.Store(http.StatusServiceUnavailable)
+
+		for i := 0; i < 2; i++ {
+			_, err := sendVerification(ctx, cache, transport, "secret")
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(2), transport.requests.Load())
+	})
+
+	t.Run("responses expire after the TTL", func(t *testing.T) {
+		clk := &fakeClock{now: time.Unix(0, 0)}
+		cache, err := newVerificationCache(verificationCacheSize, time.Minute, clk)
+		require.NoError(t, err)
+		transport := new(countingTransport)
+
+		_, err = sendVerification(ctx, cache, transport, "secret")
+		require.NoError(t, err)
+		<-clk.After(59 * time.Second)
+		_, err = sendVerification(ctx, cache, transport, "secret")
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), transport.requests.Load())
+
+		<-clk.After(time.Second)
+		_, err = sendVerification(ctx, cache, transport, "secret")
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), transport.requests.Load())
+	})
+
+	t.Run("keeps the most recently used responses", func(t *testing.T) {
+		cache, err := newVerificationCache(2, 0, realClock{})
+		require.NoError(t, err)
+		transport := new(countingTransport)
+
+		for _, secret := range []string{"a", "b", "c", "c", "b", "a"} {
+			_, err := sendVerification(ctx, cache, transport, secret)
+			require.NoError(t, err)
+		}
+		// a was evicted by c.
+		assert.Equal(t, int32(4), transport.requests.Load())
+	})
+
+	t.Run("waits for the request to be sent", func(t *testing.T) {
+		cache, err := newVerificationCache(verificationCacheSize, 0, realClock{})
+		require.NoError(t, err)
+		transport := &countingTransport{block: make(chan struct{})}
+
+		bodies := make(chan string, 2)
+		for i := 0; i < 2; i++ {
+			go func() {
+				body, err := sendVerification(ctx, cache, transport, "secret")
+				assert.NoError(t, err)
+				bodies <- body
+			}()
+		}
+
+		assert.Eventually(t, func() bool { return transport.requests.Load() == 1 }, time.Second, time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		close(transport.block)
+		assert.Equal(t, "verified secret", <-bodies)
+		assert.Equal(t, "verified secret", <-bodies)
+		assert.Equal(t, int32(1), transport.requests.Load())
+	})
+
+	t.Run("stops waiting when the context is done", func(t *testing.T) {
+		cache, err := newVerificationCache(verificationCacheSize, 0, realClock{})
+		require.NoError(t, err)
+		transport := &countingTransport{block: make(chan struct{})}
+		defer close(transport.block)
+
+		go func() { _, _ = sendVerification(ctx, cache, transport, "secret") }()
+		assert.Eventually(t, func() bool { return transport.requests.Load() == 1 }, time.Second, time.Millisecond)
+
+		cancelCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err = sendVerification(cancelCtx, cache, transport, "secret")
+		assert.ErrorIs(t, err, aCtx.Canceled)
+	})
+}
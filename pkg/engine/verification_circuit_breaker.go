@@ -0,0 +1,135 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+)
+
+// verificationCircuitBreaker stops verifying the results of a detector whose
+// service appears to be down, so a scan isn't slowed down by verification
+// requests that are bound to fail. Each detector has its own circuit:
+//
+//   - It is closed while verification works, and trips open after threshold
+//     consecutive verification requests failed.
+//   - While open, results are reported without being verified, with
+//     detectors.ErrProviderUnavailable as their verification error.
+//   - Once cooldown has elapsed, it is half-open: a single verification
+//     request probes whether the service recovered, which closes the circuit
+//     if it succeeds, or opens it again for another cooldown if it fails.
+type verificationCircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	clock     clock
+
+	mu       sync.Mutex
+	circuits map[ahocorasick.DetectorKey]*circuit
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuit is the state of the circuit breaker of a single detector.
+type circuit struct {
+	state circuitState
+	// failures is the number of consecutive failed verification requests.
+	failures int
+	openedAt time.Time
+}
+
+// defaultVerificationCircuitBreakerCooldown is how long a circuit stays open
+// if no cooldown is configured.
+const defaultVerificationCircuitBreakerCooldown = time.Minute
+
+func newVerificationCircuitBreaker(threshold int, cooldown time.Duration, c clock) *verificationCircuitBreaker {
+	if cooldown == 0 {
+		cooldown = defaultVerificationCircuitBreakerCooldown
+	}
+	return &verificationCircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		clock:     c,
+		circuits:  make(map[ahocorasick.DetectorKey]*circuit),
+	}
+}
+
+// allow reports whether a verification request for the detector may be made.
+// A request that is allowed must be followed by a call to record with its
+// results.
+func (b *verificationCircuitBreaker) allow(detector ahocorasick.DetectorKey) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[detector]
+	if !ok {
+		return true
+	}
+	switch c.state {
+	case circuitClosed:
+		return true
+	case circuitOpen:
+		if b.clock.Now().Sub(c.openedAt) < b.cooldown {
+			return false
+		}
+		c.state = circuitHalfOpen
+		return true
+	default:
+		// Another request is already probing the service.
+		return false
+	}
+}
+
+// record updates the circuit of the detector with the results of an allowed
+// verification request. The request failed if all its results have a
+// verification error, and succeeded if any result was verified or found not
+// to be valid. Requests without results tell nothing about the service, so an
+// interrupted probe is retried by the next request.
+func (b *verificationCircuitBreaker) record(ctx context.Context, detector ahocorasick.DetectorKey, results []detectors.Result) {
+	failed, succeeded := false, false
+	for _, result := range results {
+		if result.VerificationError() == nil {
+			succeeded = true
+			break
+		}
+		failed = true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[detector]
+	if !ok {
+		if !failed {
+			return
+		}
+		c = &circuit{}
+		b.circuits[detector] = c
+	}
+
+	switch {
+	case succeeded:
+		if c.state != circuitClosed {
+			ctx.Logger().Info("verification circuit breaker closed, the service recovered", "detector", detector.Type())
+		}
+		c.state, c.failures = circuitClosed, 0
+	case failed:
+		c.failures++
+		if c.state == circuitHalfOpen || (c.state == circuitClosed && c.failures >= b.threshold) {
+			if c.state == circuitClosed {
+				ctx.Logger().Info("verification circuit breaker opened, results will not be verified until the service recovers",
+					"detector", detector.Type(), "consecutive_failures", c.failures, "cooldown", b.cooldown)
+			}
+			c.state, c.openedAt = circuitOpen, b.clock.Now()
+		}
+	case c.state == circuitHalfOpen:
+		c.state = circuitOpen
+	}
+}
@@ -0,0 +1,174 @@
+package engine
+
+import (
+	aCtx "context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/engine/ahocorasick"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// providerDetector verifies its results against a fake service, which fails
+// every verification request while it is down.
+type providerDetector struct {
+	mu       sync.Mutex
+	down     bool
+	verified int // verified is the number of verification requests made.
+}
+
+var _ detectors.Detector = (*providerDetector)(nil)
+
+func (d *providerDetector) FromData(_ aCtx.Context, verify bool, data []byte) ([]detectors.Result, error) {
+	result := detectors.Result{DetectorType: d.Type(), Raw: data}
+	if !verify {
+		return []detectors.Result{result}, nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.verified++
+	if d.down {
+		result.SetVerificationError(fmt.Errorf("unexpected HTTP response status 503"))
+	} else {
+		result.Verified = true
+	}
+	return []detectors.Result{result}, nil
+}
+
+func (d *providerDetector) setDown(down bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.down = down
+}
+
+func (d *providerDetector) requests() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.verified
+}
+
+func (*providerDetector) Keywords() []string             { return []string{"provider_"} }
+func (*providerDetector) Type() detectorspb.DetectorType { return detectorspb.DetectorType(-4) }
+
+func TestEngine_VerificationCircuitBreaker(t *testing.T) {
+	const threshold = 3
+
+	ctx := context.Background()
+	e, err := NewEngine(ctx, &Config{
+		VerificationCircuitBreakerThreshold: threshold,
+		SourceManager:                       sources.NewManager(),
+		Dispatcher:                          new(recordingDispatcher),
+	})
+	assert.NoError(t, err)
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	e.verificationBreaker = newVerificationCircuitBreaker(threshold, time.Minute, clk)
+
+	detector := &providerDetector{down: true}
+	match := &ahocorasick.DetectorMatch{Key: ahocorasick.CreateDetectorKey(detector), Detector: detector}
+	verify := func() detectors.Result {
+		t.Helper()
+		results, err := e.fromData(ctx, match, true, []byte("provider_secret"))
+		assert.NoError(t, err)
+		if !assert.Len(t, results, 1) {
+			return detectors.Result{}
+		}
+		return results[0]
+	}
+
+	// The breaker trips after threshold consecutive failures.
+	for i := 0; i < threshold; i++ {
+		result := verify()
+		assert.Equal(t, detectors.VerificationErrorUnknown, result.VerificationErrorCategory())
+	}
+	assert.Equal(t, threshold, detector.requests())
+
+	// While open, results are reported without calling the service.
+	for i := 0; i < 5; i++ {
+		result := verify()
+		assert.False(t, result.Verified)
+		assert.Equal(t, detectors.VerificationErrorProviderUnavailable, result.VerificationErrorCategory())
+	}
+	assert.Equal(t, threshold, detector.requests())
+
+	// Once the cooldown has elapsed, a failed probe opens it again.
+	clk.After(time.Minute)
+	probe := verify()
+	assert.Equal(t, detectors.VerificationErrorUnknown, probe.VerificationErrorCategory())
+	assert.Equal(t, threshold+1, detector.requests())
+	skipped := verify()
+	assert.Equal(t, detectors.VerificationErrorProviderUnavailable, skipped.VerificationErrorCategory())
+	assert.Equal(t, threshold+1, detector.requests())
+
+	// A successful probe closes it.
+	clk.After(time.Minute)
+	detector.setDown(false)
+	assert.True(t, verify().Verified)
+	assert.True(t, verify().Verified)
+	assert.Equal(t, threshold+3, detector.requests())
+}
+
+func TestVerificationCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	breaker := newVerificationCircuitBreaker(2, time.Minute, clk)
+
+	provider := ahocorasick.CreateDetectorKey(&providerDetector{})
+	other := ahocorasick.CreateDetectorKey(statusDetector{})
+
+	failed := detectors.Result{}
+	failed.SetVerificationError(fmt.Errorf("connection refused"))
+	revoked := detectors.Result{Revoked: true}
+
+	// Successes and revoked secrets reset the consecutive failures.
+	breaker.record(ctx, provider, []detectors.Result{failed})
+	breaker.record(ctx, provider, []detectors.Result{{Verified: true}})
+	breaker.record(ctx, provider, []detectors.Result{failed})
+	breaker.record(ctx, provider, []detectors.Result{revoked})
+	breaker.record(ctx, provider, []detectors.Result{failed})
+	assert.True(t, breaker.allow(provider))
+
+	// A request succeeds if any of its results does.
+	breaker.record(ctx, provider, []detectors.Result{failed, {}})
+	breaker.record(ctx, provider, []detectors.Result{failed})
+	assert.True(t, breaker.allow(provider))
+
+	breaker.record(ctx, provider, []detectors.Result{failed, failed})
+	assert.False(t, breaker.allow(provider))
+	// Circuits are per detector.
+	assert.True(t, breaker.allow(other))
+
+	// A single probe is allowed once the cooldown has elapsed.
+	clk.After(time.Minute)
+	assert.True(t, breaker.allow(provider))
+	assert.False(t, breaker.allow(provider))
+
+	// A probe without results is retried.
+	breaker.record(ctx, provider, nil)
+	assert.True(t, breaker.allow(provider))
+	breaker.record(ctx, provider, []detectors.Result{{}})
+	assert.True(t, breaker.allow(provider))
+	assert.True(t, breaker.allow(provider))
+}
+
+func TestNewEngine_NegativeVerificationCircuitBreaker(t *testing.T) {
+	_, err := NewEngine(context.Background(), &Config{
+		VerificationCircuitBreakerThreshold: -1,
+		SourceManager:                       sources.NewManager(),
+	})
+	assert.Error(t, err)
+
+	_, err = NewEngine(context.Background(), &Config{
+		VerificationCircuitBreakerThreshold: 1,
+		VerificationCircuitBreakerCooldown:  -time.Second,
+		SourceManager:                       sources.NewManager(),
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// clock abstracts time so the rate limiter can be tested deterministically.
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the clock backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// verificationRateLimiter throttles verification requests using a token bucket
+// per detector type. This keeps the engine from tripping provider rate limits
+// when many candidate secrets for the same detector are verified in parallel.
+type verificationRateLimiter struct {
+	// rate is the number of verification requests allowed per second for each detector type.
+	rate  float64
+	clock clock
+
+	mu      sync.Mutex
+	buckets map[detectorspb.DetectorType]*tokenBucket
+}
+
+// tokenBucket tracks the available tokens for a single detector type.
+// A negative number of tokens indicates callers are queued waiting for a refill.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// verificationRateLimiterBurst is the maximum number of tokens a bucket can
+// accumulate. A burst of one spaces requests evenly at the configured rate.
+const verificationRateLimiterBurst = 1
+
+func newVerificationRateLimiter(rate float64, c clock) *verificationRateLimiter {
+	return &verificationRateLimiter{
+		rate:    rate,
+		clock:   c,
+		buckets: make(map[detectorspb.DetectorType]*tokenBucket),
+	}
+}
+
+// wait blocks until a verification request for the provided detector type is
+// permitted. Requests over the limit are queued rather than rejected.
+// It returns the context's error if the context is done before the request is permitted.
+func (l *verificationRateLimiter) wait(ctx context.Context, detectorType detectorspb.DetectorType) error {
+	delay, bucket := l.reserve(detectorType)
+	if delay <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		// Give the reserved token back so queued callers aren't delayed by
+		// a request that will never be made.
+		l.mu.Lock()
+		bucket.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-l.clock.After(delay):
+		return nil
+	}
+}
+
+// reserve takes a token from the detector type's bucket and returns how long
+// the caller must wait before the token is available.
+func (l *verificationRateLimiter) reserve(detectorType detectorspb.DetectorType) (time.Duration, *tokenBucket) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	bucket, ok := l.buckets[detectorType]
+	if !ok {
+		bucket = &tokenBucket{tokens: verificationRateLimiterBurst, last: now}
+		l.buckets[detectorType] = bucket
+	}
+
+	if elapsed := now.Sub(bucket.last); elapsed > 0 {
+		bucket.tokens = min(verificationRateLimiterBurst, bucket.tokens+elapsed.Seconds()*l.rate)
+		bucket.last = now
+	}
+
+	bucket.tokens--
+	if bucket.tokens >= 0 {
+		return 0, bucket
+	}
+
+	return time.Duration(-bucket.tokens / l.rate * float64(time.Second)), bucket
+}
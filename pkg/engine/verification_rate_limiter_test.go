@@ -0,0 +1,127 @@
+package engine
+
+import (
+	aCtx "context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+)
+
+// fakeClock is a clock whose time only moves when a caller waits on it.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After advances the clock by d and returns a channel that has already fired.
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+// stoppedClock is a clock that never fires.
+type stoppedClock struct{ now time.Time }
+
+func (c stoppedClock) Now() time.Time                     { return c.now }
+func (stoppedClock) After(time.Duration) <-chan time.Time { return nil }
+
+func TestVerificationRateLimiter_Wait(t *testing.T) {
+	tests := []struct {
+		name  string
+		rate  float64
+		calls int
+	}{
+		{name: "one per second", rate: 1, calls: 5},
+		{name: "four per second", rate: 4, calls: 20},
+		{name: "fractional rate", rate: 0.5, calls: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			clk := &fakeClock{now: time.Unix(0, 0)}
+			limiter := newVerificationRateLimiter(tt.rate, clk)
+
+			var permitted []time.Time
+			for i := 0; i < tt.calls; i++ {
+				assert.NoError(t, limiter.wait(ctx, detectorspb.DetectorType_AWS))
+				permitted = append(permitted, clk.Now())
+			}
+
+			// Consecutive verification calls must be spaced by at least 1/rate.
+			minInterval := time.Duration(float64(time.Second) / tt.rate)
+			for i := 1; i < len(permitted); i++ {
+				assert.GreaterOrEqual(t, permitted[i].Sub(permitted[i-1]), minInterval)
+			}
+
+			// No more than rate calls (plus the initial burst) may happen in the elapsed time.
+			elapsed := permitted[len(permitted)-1].Sub(permitted[0])
+			maxCalls := int(elapsed.Seconds()*tt.rate) + verificationRateLimiterBurst
+			assert.LessOrEqual(t, len(permitted), maxCalls)
+		})
+	}
+}
+
+func TestVerificationRateLimiter_Refill(t *testing.T) {
+	ctx := context.Background()
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	limiter := newVerificationRateLimiter(2, clk)
+
+	assert.NoError(t, limiter.wait(ctx, detectorspb.DetectorType_AWS))
+	start := clk.Now()
+
+	// Once enough time has passed to refill the bucket, the call should not wait.
+	clk.mu.Lock()
+	clk.now = clk.now.Add(time.Second)
+	clk.mu.Unlock()
+
+	assert.NoError(t, limiter.wait(ctx, detectorspb.DetectorType_AWS))
+	assert.Equal(t, time.Second, clk.Now().Sub(start))
+}
+
+func TestVerificationRateLimiter_IndependentDetectorTypes(t *testing.T) {
+	ctx := context.Background()
+	clk := &fakeClock{now: time.Unix(0, 0)}
+	limiter := newVerificationRateLimiter(1, clk)
+
+	start := clk.Now()
+	assert.NoError(t, limiter.wait(ctx, detectorspb.DetectorType_AWS))
+	assert.NoError(t, limiter.wait(ctx, detectorspb.DetectorType_Github))
+	assert.NoError(t, limiter.wait(ctx, detectorspb.DetectorType_Slack))
+
+	// Each detector type has its own bucket, so none of the calls should wait.
+	assert.Equal(t, start, clk.Now())
+
+	assert.NoError(t, limiter.wait(ctx, detectorspb.DetectorType_AWS))
+	assert.Equal(t, time.Second, clk.Now().Sub(start))
+}
+
+func TestVerificationRateLimiter_ContextCancellation(t *testing.T) {
+	limiter := newVerificationRateLimiter(1, stoppedClock{now: time.Unix(0, 0)})
+
+	assert.NoError(t, limiter.wait(context.Background(), detectorspb.DetectorType_AWS))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, limiter.wait(ctx, detectorspb.DetectorType_AWS), aCtx.Canceled)
+
+	// The cancelled caller's token is returned to the bucket.
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	assert.Equal(t, float64(0), limiter.buckets[detectorspb.DetectorType_AWS].tokens)
+}
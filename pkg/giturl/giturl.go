@@ -169,6 +169,57 @@ func GenerateLink(repo, commit, file string, line int64) string {
 	}
 }
 
+// WebRepoURL returns the URL of a repository in the form GenerateLink expects,
+// from the URL of one of its git remotes, e.g. https://github.com/org/repo.git
+// for git@github.com:org/repo.git. It supports https, ssh, and scp-like remote
+// URLs of the repositories of GitHub, GitLab, Bitbucket, and Azure Repos. It
+// returns false for other remotes, such as local paths.
+func WebRepoURL(remote string) (string, bool) {
+	var host, path string
+	if parsed, err := url.Parse(remote); err == nil && parsed.Host != "" {
+		switch parsed.Scheme {
+		case "http", "https", "ssh", "git":
+		default:
+			return "", false
+		}
+		host, path = parsed.Hostname(), parsed.Path
+	} else if !strings.Contains(remote, "://") {
+		// scp-like syntax: [user@]host:path
+		var ok bool
+		if host, path, ok = strings.Cut(remote, ":"); !ok {
+			return "", false
+		}
+		if _, h, ok := strings.Cut(host, "@"); ok {
+			host = h
+		}
+	} else {
+		return "", false
+	}
+	path = strings.Trim(path, "/")
+
+	// Azure Repos ssh remotes are ssh.dev.azure.com:v3/org/project/repo.
+	if host == "ssh.dev.azure.com" {
+		parts := strings.Split(path, "/")
+		if len(parts) != 4 || parts[0] != "v3" {
+			return "", false
+		}
+		return "https://dev.azure.com/" + parts[1] + "/" + parts[2] + "/_git/" + parts[3], true
+	}
+
+	repo := "https://" + host + "/" + path
+	switch determineProvider(repo) {
+	case "":
+		return "", false
+	case providerAzure:
+		return repo, true
+	default:
+		if !strings.HasSuffix(repo, ".git") {
+			repo += ".git"
+		}
+		return repo, true
+	}
+}
+
 var linePattern = regexp.MustCompile(`L\d+`)
 
 // UpdateLinkLineNumber updates the line number in a repository link.
@@ -308,3 +308,35 @@ func TestUpdateLinkLineNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestWebRepoURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		remote string
+		want   string
+	}{
+		{remote: "https://github.com/org/repo.git", want: "https://github.com/org/repo.git"},
+		{remote: "https://github.com/org/repo", want: "https://github.com/org/repo.git"},
+		{remote: "https://user@github.com/org/repo/", want: "https://github.com/org/repo.git"},
+		{remote: "git@github.com:org/repo.git", want: "https://github.com/org/repo.git"},
+		{remote: "ssh://git@gitlab.com/group/subgroup/repo.git", want: "https://gitlab.com/group/subgroup/repo.git"},
+		{remote: "git@bitbucket.org:org/repo.git", want: "https://bitbucket.org/org/repo.git"},
+		{remote: "https://dev.azure.com/org/project/_git/repo", want: "https://dev.azure.com/org/project/_git/repo"},
+		{remote: "git@ssh.dev.azure.com:v3/org/project/repo", want: "https://dev.azure.com/org/project/_git/repo"},
+		{remote: "https://git.example.com/org/repo.git"},
+		{remote: "git@git.example.com:org/repo.git"},
+		{remote: "file:///tmp/github.com/repo"},
+		{remote: "/tmp/github.com/repo"},
+		{remote: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remote, func(t *testing.T) {
+			got, ok := WebRepoURL(tt.remote)
+			if got != tt.want || ok != (tt.want != "") {
+				t.Errorf("WebRepoURL(%q) = %q, %t, want %q", tt.remote, got, ok, tt.want)
+			}
+		})
+	}
+}
@@ -21,8 +21,8 @@ func newARHandler() *arHandler {
 
 // HandleFile processes AR formatted files. This function needs to be implemented to extract or
 // manage data from AR files according to specific requirements.
-func (h *arHandler) HandleFile(ctx logContext.Context, input fileReader) (chan []byte, error) {
-	archiveChan := make(chan []byte, defaultBufferSize)
+func (h *arHandler) HandleFile(ctx logContext.Context, input fileReader) (chan fileChunk, error) {
+	archiveChan := make(chan fileChunk, defaultBufferSize)
 
 	go func() {
 		ctx, cancel := logContext.WithTimeout(ctx, maxTimeout)
@@ -65,7 +65,7 @@ func (h *arHandler) HandleFile(ctx logContext.Context, input fileReader) (chan [
 	return archiveChan, nil
 }
 
-func (h *arHandler) processARFiles(ctx logContext.Context, reader *deb.Ar, archiveChan chan []byte) error {
+func (h *arHandler) processARFiles(ctx logContext.Context, reader *deb.Ar, archiveChan chan fileChunk) error {
 	for {
 		select {
 		case <-ctx.Done():
@@ -82,6 +82,7 @@ func (h *arHandler) processARFiles(ctx logContext.Context, reader *deb.Ar, archi
 
 			fileSize := arEntry.Size
 			fileCtx := logContext.WithValues(ctx, "filename", arEntry.Name, "size", fileSize)
+			fileCtx = logContext.WithValue(fileCtx, archivePathKey, arEntry.Name)
 
 			if err := h.handleNonArchiveContent(fileCtx, arEntry.Data, archiveChan); err != nil {
 				fileCtx.Logger().Error(err, "error handling archive content in AR")
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"path"
 	"time"
 
 	"github.com/mholt/archiver/v4"
@@ -15,8 +17,9 @@ import (
 type ctxKey int
 
 const (
-	depthKey          ctxKey = iota
-	defaultBufferSize        = 512
+	depthKey ctxKey = iota
+	archivePathKey
+	defaultBufferSize = 512
 )
 
 var (
@@ -37,7 +40,18 @@ func SetArchiveMaxDepth(depth int) { maxDepth = depth }
 func SetArchiveMaxTimeout(timeout time.Duration) { maxTimeout = timeout }
 
 // archiveHandler is a handler for common archive files that are supported by the archiver library.
-type archiveHandler struct{ *defaultHandler }
+type archiveHandler struct {
+	*defaultHandler
+
+	// maxDepth overrides the package maxDepth when set.
+	maxDepth int
+	// maxDecompressedSize limits the total bytes decompressed or extracted from the archive when set.
+	// decompressedSize counts those bytes across all levels of nesting.
+	maxDecompressedSize int64
+	decompressedSize    int64
+	// checkpoint skips the entries already processed, and is sent the end of the others, when set.
+	checkpoint ArchiveCheckpoint
+}
 
 func newArchiveHandler() *archiveHandler {
 	return &archiveHandler{defaultHandler: newDefaultHandler(archiveHandlerType)}
@@ -46,9 +60,9 @@ func newArchiveHandler() *archiveHandler {
 // HandleFile processes the input as either an archive or non-archive based on its content,
 // utilizing a single output channel. It first tries to identify the input as an archive. If it is an archive,
 // it processes it accordingly; otherwise, it handles the input as non-archive content.
-// The function returns a channel that will receive the extracted data bytes and an error if the initial setup fails.
-func (h *archiveHandler) HandleFile(ctx logContext.Context, input fileReader) (chan []byte, error) {
-	dataChan := make(chan []byte, defaultBufferSize)
+// The function returns a channel that will receive the extracted file chunks and an error if the initial setup fails.
+func (h *archiveHandler) HandleFile(ctx logContext.Context, input fileReader) (chan fileChunk, error) {
+	dataChan := make(chan fileChunk, defaultBufferSize)
 
 	go func() {
 		ctx, cancel := logContext.WithTimeout(ctx, maxTimeout)
@@ -64,6 +78,10 @@ func (h *archiveHandler) HandleFile(ctx logContext.Context, input fileReader) (c
 		}()
 
 		if err = h.openArchive(ctx, 0, input, dataChan); err != nil {
+			if errors.Is(err, ErrMaxDepthReached) || errors.Is(err, ErrMaxDecompressedSizeReached) {
+				ctx.Logger().Info("WARNING: archive extraction limit exceeded, aborting", "error", err)
+				return
+			}
 			ctx.Logger().Error(err, "error unarchiving chunk.")
 		}
 	}()
@@ -71,18 +89,53 @@ func (h *archiveHandler) HandleFile(ctx logContext.Context, input fileReader) (c
 	return dataChan, nil
 }
 
-var ErrMaxDepthReached = errors.New("max archive depth reached")
+var (
+	ErrMaxDepthReached            = errors.New("max archive depth reached")
+	ErrMaxDecompressedSizeReached = errors.New("max archive decompressed size reached")
+)
+
+// depthLimit returns the maximum depth of nested archives to extract.
+func (h *archiveHandler) depthLimit() int {
+	if h.maxDepth > 0 {
+		return h.maxDepth
+	}
+	return maxDepth
+}
+
+// sizeLimitedReader counts the bytes read from a decompressed or extracted file towards the
+// decompressed size budget of the archive handler, failing once the budget is exceeded.
+type sizeLimitedReader struct {
+	io.Reader
+	h *archiveHandler
+}
+
+func (r sizeLimitedReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.h.decompressedSize += int64(n)
+	if r.h.maxDecompressedSize > 0 && r.h.decompressedSize > r.h.maxDecompressedSize {
+		return n, ErrMaxDecompressedSizeReached
+	}
+	return n, err
+}
+
+// limitReader wraps the reader with the decompressed size budget, if there is one.
+func (h *archiveHandler) limitReader(r io.Reader) io.Reader {
+	if h.maxDecompressedSize <= 0 {
+		return r
+	}
+	return sizeLimitedReader{Reader: r, h: h}
+}
 
 // openArchive recursively extracts content from an archive up to a maximum depth, handling nested archives if necessary.
 // It takes a reader from which it attempts to identify and process the archive format. Depending on the archive type,
 // it either decompresses or extracts the contents directly, sending data to the provided channel.
 // Returns an error if the archive cannot be processed due to issues like exceeding maximum depth or unsupported formats.
-func (h *archiveHandler) openArchive(ctx logContext.Context, depth int, reader fileReader, archiveChan chan []byte) error {
+func (h *archiveHandler) openArchive(ctx logContext.Context, depth int, reader fileReader, archiveChan chan fileChunk) error {
 	if common.IsDone(ctx) {
 		return ctx.Err()
 	}
 
-	if depth >= maxDepth {
+	if depth >= h.depthLimit() {
 		h.metrics.incMaxArchiveDepthCount()
 		return ErrMaxDepthReached
 	}
@@ -101,7 +154,7 @@ func (h *archiveHandler) openArchive(ctx logContext.Context, depth int, reader f
 		}
 		defer compReader.Close()
 
-		rdr, err := newFileReader(compReader)
+		rdr, err := newFileReader(io.NopCloser(h.limitReader(compReader)))
 		if err != nil {
 			if errors.Is(err, ErrEmptyReader) {
 				ctx.Logger().V(5).Info("empty reader, skipping file")
@@ -127,11 +180,16 @@ func (h *archiveHandler) openArchive(ctx logContext.Context, depth int, reader f
 // It logs the extraction, checks for cancellation, and decides whether to skip the file based on its name or type,
 // particularly for binary files if configured to skip. If the file is not skipped, it recursively calls openArchive
 // to handle nested archives or to continue processing based on the file's content and depth in the archive structure.
-func (h *archiveHandler) extractorHandler(archiveChan chan []byte) func(context.Context, archiver.File) error {
+func (h *archiveHandler) extractorHandler(archiveChan chan fileChunk) func(context.Context, archiver.File) error {
 	return func(ctx context.Context, file archiver.File) error {
+		// The path of the file within the archive, including the paths of any archives it is nested in.
+		parentPath, _ := ctx.Value(archivePathKey).(string)
+		archivePath := path.Join(parentPath, file.NameInArchive)
+
 		lCtx := logContext.WithValues(
 			logContext.AddLogger(ctx),
 			"filename", file.Name(),
+			"archive_path", archivePath,
 			"size", file.Size(),
 		)
 		lCtx.Logger().V(5).Info("Handling extracted file.")
@@ -145,6 +203,12 @@ func (h *archiveHandler) extractorHandler(archiveChan chan []byte) func(context.
 			return ctx.Err()
 		}
 
+		if h.checkpoint != nil && h.checkpoint.Processed(archivePath) {
+			lCtx.Logger().V(5).Info("skipping file, already processed")
+			h.metrics.incFilesSkipped()
+			return nil
+		}
+
 		depth := 0
 		if ctxDepth, ok := ctx.Value(depthKey).(int); ok {
 			depth = ctxDepth
@@ -185,7 +249,7 @@ func (h *archiveHandler) extractorHandler(archiveChan chan []byte) func(context.
 			}
 		}()
 
-		rdr, err := newFileReader(f)
+		rdr, err := newFileReader(io.NopCloser(h.limitReader(f)))
 		if err != nil {
 			if errors.Is(err, ErrEmptyReader) {
 				lCtx.Logger().V(5).Info("empty reader, skipping file")
@@ -198,6 +262,18 @@ func (h *archiveHandler) extractorHandler(archiveChan chan []byte) func(context.
 		h.metrics.incFilesProcessed()
 		h.metrics.observeFileSize(fileSize)
 
-		return h.openArchive(lCtx, depth, rdr, archiveChan)
+		if err := h.openArchive(logContext.WithValue(lCtx, archivePathKey, archivePath), depth, rdr, archiveChan); err != nil {
+			return err
+		}
+		return h.sendEntryDone(ctx, archivePath, archiveChan)
+	}
+}
+
+// sendEntryDone sends the end of the entry at archivePath once all its data was sent, so the entry is marked processed
+// in the checkpoint after its chunks are reported. Nothing is sent if there is no checkpoint.
+func (h *archiveHandler) sendEntryDone(ctx context.Context, archivePath string, archiveChan chan fileChunk) error {
+	if h.checkpoint == nil {
+		return nil
 	}
+	return common.CancellableWrite(ctx, archiveChan, fileChunk{entryPath: archivePath, entryDone: true})
 }
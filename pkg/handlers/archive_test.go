@@ -1,16 +1,23 @@
 package handlers
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"errors"
 	"io"
+	"maps"
 	"net/http"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
 )
 
 func TestArchiveHandler(t *testing.T) {
@@ -100,7 +107,7 @@ func TestArchiveHandler(t *testing.T) {
 			matched := false
 			for chunk := range archiveChan {
 				count++
-				if re.Match(chunk) {
+				if re.Match(chunk.data) {
 					matched = true
 				}
 			}
@@ -121,8 +128,115 @@ func TestOpenInvalidArchive(t *testing.T) {
 	assert.NoError(t, err)
 	defer rdr.Close()
 
-	archiveChan := make(chan []byte)
+	archiveChan := make(chan fileChunk)
 
 	err = handler.openArchive(ctx, 0, rdr, archiveChan)
 	assert.Error(t, err)
 }
+
+// zipArchive returns a zip archive of the files, in order.
+func zipArchive(t *testing.T, files ...[2][]byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, file := range files {
+		w, err := zw.Create(string(file[0]))
+		require.NoError(t, err)
+		_, err = w.Write(file[1])
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// testCheckpoint is an ArchiveCheckpoint that records the entries processed,
+// and those skipped because they were.
+type testCheckpoint struct {
+	mu        sync.Mutex
+	processed map[string]bool
+	skipped   []string
+}
+
+func (c *testCheckpoint) Processed(entryPath string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.processed[entryPath] {
+		c.skipped = append(c.skipped, entryPath)
+		return true
+	}
+	return false
+}
+
+func (c *testCheckpoint) MarkProcessed(entryPath string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.processed[entryPath] = true
+}
+
+// interruptingReporter reports chunks until limit chunks were reported, then
+// fails, like a scan interrupted midway.
+type interruptingReporter struct {
+	limit int
+	data  []string
+}
+
+var errInterrupted = errors.New("interrupted")
+
+func (r *interruptingReporter) ChunkOk(_ logContext.Context, chunk sources.Chunk) error {
+	if r.limit > 0 && len(r.data) == r.limit {
+		return errInterrupted
+	}
+	r.data = append(r.data, string(chunk.Data))
+	return nil
+}
+
+func (r *interruptingReporter) ChunkErr(_ logContext.Context, err error) error { return err }
+
+func TestHandleFile_ArchiveCheckpoint(t *testing.T) {
+	archive := zipArchive(t,
+		[2][]byte{[]byte("a.txt"), []byte("content a")},
+		[2][]byte{[]byte("nested.zip"), zipArchive(t,
+			[2][]byte{[]byte("b.txt"), []byte("content b")},
+			[2][]byte{[]byte("c.txt"), []byte("content c")},
+		)},
+		[2][]byte{[]byte("d.txt"), []byte("content d")},
+	)
+	checkpoint := &testCheckpoint{processed: make(map[string]bool)}
+
+	// The extraction is interrupted once the content of a.txt and b.txt is
+	// reported.
+	ctx, cancel := logContext.WithCancel(logContext.Background())
+	defer cancel()
+	reporter := &interruptingReporter{limit: 2}
+	err := HandleFile(ctx, io.NopCloser(bytes.NewReader(archive)), &sources.Chunk{}, reporter, WithArchiveCheckpoint(checkpoint))
+	assert.ErrorIs(t, err, errInterrupted)
+	cancel()
+	assert.Equal(t, []string{"content a", "content b"}, reporter.data)
+	assert.Equal(t, map[string]bool{"a.txt": true, "nested.zip/b.txt": true}, checkpoint.processed)
+
+	// Resuming only processes the remaining entries. The checkpoint is copied,
+	// like the resume info of a new scan, as the interrupted extraction may
+	// still be stopping.
+	checkpoint = &testCheckpoint{processed: maps.Clone(checkpoint.processed)}
+	reporter = &interruptingReporter{}
+	err = HandleFile(logContext.Background(), io.NopCloser(bytes.NewReader(archive)), &sources.Chunk{}, reporter, WithArchiveCheckpoint(checkpoint))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"content c", "content d"}, reporter.data)
+	assert.Equal(t, []string{"a.txt", "nested.zip/b.txt"}, checkpoint.skipped)
+	assert.Equal(t, map[string]bool{
+		"a.txt":            true,
+		"nested.zip":       true,
+		"nested.zip/b.txt": true,
+		"nested.zip/c.txt": true,
+		"d.txt":            true,
+	}, checkpoint.processed)
+
+	// Once the whole archive was processed, no entry is extracted again.
+	reporter = &interruptingReporter{}
+	checkpoint.skipped = nil
+	err = HandleFile(logContext.Background(), io.NopCloser(bytes.NewReader(archive)), &sources.Chunk{}, reporter, WithArchiveCheckpoint(checkpoint))
+	assert.NoError(t, err)
+	assert.Empty(t, reporter.data)
+	assert.Equal(t, []string{"a.txt", "nested.zip", "d.txt"}, checkpoint.skipped)
+}
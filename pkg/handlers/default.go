@@ -20,7 +20,11 @@ import (
 // once it has been extracted or decompressed by the specific handler.
 // This allows the specialized handlers to focus on their specific archive formats while leveraging
 // the common functionality provided by the defaultHandler for processing the extracted content.
-type defaultHandler struct{ metrics *metrics }
+type defaultHandler struct {
+	metrics *metrics
+	// joinLineContinuations joins the lines continued with a trailing backslash before the content is chunked.
+	joinLineContinuations bool
+}
 
 // newDefaultHandler creates a defaultHandler with metrics configured based on the provided handlerType.
 // The handlerType parameter is used to initialize the metrics instance with the appropriate handler type,
@@ -33,10 +37,10 @@ func newDefaultHandler(handlerType handlerType) *defaultHandler {
 // HandleFile processes the input as either an archive or non-archive based on its content,
 // utilizing a single output channel. It first tries to identify the input as an archive. If it is an archive,
 // it processes it accordingly; otherwise, it handles the input as non-archive content.
-// The function returns a channel that will receive the extracted data bytes and an error if the initial setup fails.
-func (h *defaultHandler) HandleFile(ctx logContext.Context, input fileReader) (chan []byte, error) {
+// The function returns a channel that will receive the extracted file chunks and an error if the initial setup fails.
+func (h *defaultHandler) HandleFile(ctx logContext.Context, input fileReader) (chan fileChunk, error) {
 	// Shared channel for both archive and non-archive content.
-	dataChan := make(chan []byte, defaultBufferSize)
+	dataChan := make(chan fileChunk, defaultBufferSize)
 
 	go func() {
 		defer close(dataChan)
@@ -76,7 +80,7 @@ func (h *defaultHandler) measureLatencyAndHandleErrors(start time.Time, err erro
 // on the type, particularly for binary files. It manages reading file chunks and writing them to the archive channel,
 // effectively collecting the final bytes for further processing. This function is a key component in ensuring that all
 // file content, regardless of being an archive or not, is handled appropriately.
-func (h *defaultHandler) handleNonArchiveContent(ctx logContext.Context, reader io.Reader, archiveChan chan []byte) error {
+func (h *defaultHandler) handleNonArchiveContent(ctx logContext.Context, reader io.Reader, archiveChan chan fileChunk) error {
 	bufReader := bufio.NewReaderSize(reader, defaultBufferSize)
 	// A buffer of 512 bytes is used since many file formats store their magic numbers within the first 512 bytes.
 	// If fewer bytes are read, MIME type detection may still succeed.
@@ -94,15 +98,28 @@ func (h *defaultHandler) handleNonArchiveContent(ctx logContext.Context, reader
 		return nil
 	}
 
-	chunkReader := sources.NewChunkReader()
+	// The path of the archive entry being read, if any, is attached to each chunk along with its position.
+	entryPath, _ := ctx.Value(archivePathKey).(string)
+	position := sources.StartPosition()
+
+	var chunkOpts []sources.ConfigOption
+	if h.joinLineContinuations {
+		chunkOpts = append(chunkOpts, sources.WithLineContinuations())
+	}
+	chunkReader := sources.NewChunkReader(chunkOpts...)
 	for data := range chunkReader(ctx, bufReader) {
+		// Chunks overlap by the peek size, so each one starts ChunkSize bytes after the previous one.
+		chunkPosition := position
+		position = position.Advance(data.Bytes(), sources.ChunkSize)
+
 		if err := data.Error(); err != nil {
 			ctx.Logger().Error(err, "error reading chunk")
 			h.metrics.incErrors()
 			continue
 		}
 
-		if err := common.CancellableWrite(ctx, archiveChan, data.Bytes()); err != nil {
+		chunk := fileChunk{data: data.Bytes(), entryPath: entryPath, position: chunkPosition}
+		if err := common.CancellableWrite(ctx, archiveChan, chunk); err != nil {
 			return err
 		}
 		h.metrics.incBytesProcessed(len(data.Bytes()))
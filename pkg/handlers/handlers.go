@@ -7,8 +7,10 @@ import (
 
 	"github.com/gabriel-vasile/mimetype"
 	"github.com/mholt/archiver/v4"
+	"google.golang.org/protobuf/proto"
 
 	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/readers"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
 )
@@ -64,6 +66,8 @@ func newFileReader(r io.ReadCloser) (fileReader, error) {
 		return reader, ErrEmptyReader
 	}
 
+	// Formats are identified by their magic bytes only, since no filename is given.
+	// Compressed archives, such as .tar.zst, are identified as a compression format wrapping an archival format.
 	format, arReader, err := archiver.Identify("", rdr)
 	switch {
 	case err == nil: // Archive detected
@@ -91,13 +95,48 @@ func newFileReader(r io.ReadCloser) (fileReader, error) {
 
 // FileHandler represents a handler for files.
 // It has a single method, HandleFile, which takes a context and a fileReader as input,
-// and returns a channel of byte slices and an error.
+// and returns a channel of file chunks and an error.
 type FileHandler interface {
-	HandleFile(ctx logContext.Context, reader fileReader) (chan []byte, error)
+	HandleFile(ctx logContext.Context, reader fileReader) (chan fileChunk, error)
+}
+
+// fileChunk is a piece of file content produced by a FileHandler, along with where it was found in the file.
+type fileChunk struct {
+	data []byte
+	// entryPath is the path of the entry within the archive the data was extracted from, if any.
+	entryPath string
+	// position is where the data starts within the entry, or within the file if there is no entry.
+	position sources.Position
+	// entryDone marks the end of the entry at entryPath, once all its data was sent, and carries no data.
+	// It is only sent for archives extracted with an ArchiveCheckpoint.
+	entryDone bool
+}
+
+// ArchiveCheckpoint records the entries of an archive whose content was reported, so that the extraction of an archive
+// interrupted midway can be resumed without decompressing those entries again. Entries are identified by their path
+// within the archive, including the paths of the archives they are nested in, as in "nested.zip/inner/path.txt".
+// A nested archive is marked processed once all its entries are.
+//
+// Processed and MarkProcessed may be called concurrently.
+type ArchiveCheckpoint interface {
+	// Processed returns true if the content of the entry was already reported.
+	Processed(entryPath string) bool
+	// MarkProcessed records that all the content of the entry was reported.
+	MarkProcessed(entryPath string)
 }
 
 // fileHandlingConfig encapsulates configuration settings that control the behavior of file processing.
-type fileHandlingConfig struct{ skipArchives bool }
+type fileHandlingConfig struct {
+	skipArchives bool
+	// maxArchiveDepth and maxArchiveDecompressedSize limit the extraction of archives.
+	// Zero values fall back to the package defaults.
+	maxArchiveDepth            int
+	maxArchiveDecompressedSize int64
+	// joinLineContinuations joins the lines continued with a trailing backslash before the content is chunked.
+	joinLineContinuations bool
+	// checkpoint records the archive entries processed, if set.
+	checkpoint ArchiveCheckpoint
+}
 
 // newFileHandlingConfig creates a default fileHandlingConfig with default settings.
 // Optional functional parameters can customize the configuration.
@@ -116,12 +155,40 @@ func WithSkipArchives(skip bool) func(*fileHandlingConfig) {
 	return func(c *fileHandlingConfig) { c.skipArchives = skip }
 }
 
+// WithMaxArchiveDepth sets the maxArchiveDepth field of the fileHandlingConfig.
+// It overrides the depth set with SetArchiveMaxDepth for nested archives within the file.
+func WithMaxArchiveDepth(depth int) func(*fileHandlingConfig) {
+	return func(c *fileHandlingConfig) { c.maxArchiveDepth = depth }
+}
+
+// WithMaxArchiveDecompressedSize sets the maxArchiveDecompressedSize field of the fileHandlingConfig.
+// It limits the total number of bytes decompressed or extracted from an archive, across all levels of nesting.
+// Extraction is aborted once the limit is exceeded.
+func WithMaxArchiveDecompressedSize(size int64) func(*fileHandlingConfig) {
+	return func(c *fileHandlingConfig) { c.maxArchiveDecompressedSize = size }
+}
+
+// WithLineContinuations sets the joinLineContinuations field of the fileHandlingConfig.
+// If join is true, lines continued with a trailing backslash are joined, so secrets wrapped across lines are found
+// whole. See sources.WithLineContinuations.
+func WithLineContinuations(join bool) func(*fileHandlingConfig) {
+	return func(c *fileHandlingConfig) { c.joinLineContinuations = join }
+}
+
+// WithArchiveCheckpoint sets the checkpoint field of the fileHandlingConfig.
+// Archive entries the checkpoint has as processed are skipped, and the others are marked processed once all their
+// content is reported, so an interrupted extraction can be resumed with the same checkpoint.
+func WithArchiveCheckpoint(checkpoint ArchiveCheckpoint) func(*fileHandlingConfig) {
+	return func(c *fileHandlingConfig) { c.checkpoint = checkpoint }
+}
+
 type handlerType string
 
 const (
 	archiveHandlerType handlerType = "archive"
 	arHandlerType      handlerType = "ar"
 	rpmHandlerType     handlerType = "rpm"
+	pdfHandlerType     handlerType = "pdf"
 	defaultHandlerType handlerType = "default"
 )
 
@@ -133,6 +200,7 @@ const (
 	unixArMime mimeType = "application/x-unix-archive"
 	arMime     mimeType = "application/x-archive"
 	debMime    mimeType = "application/vnd.debian.binary-package"
+	pdfMime    mimeType = "application/pdf"
 )
 
 // selectHandler dynamically selects and configures a FileHandler based on the provided fileReader.
@@ -140,20 +208,36 @@ const (
 // This method uses specialized handlers for specific file types:
 // - arHandler is used for Unix archives and Debian packages ('arMime', 'unixArMime', and 'debMime').
 // - rpmHandler is used for RPM and CPIO archives ('rpmMime' and 'cpioMime').
-// - archiveHandler is used for common archive formats supported by the archiver library (.zip, .tar, .gz, etc.).
+// - pdfHandler is used for PDF documents ('pdfMime'), extracting their text.
+// - archiveHandler is used for common archive formats supported by the archiver library (.zip, .tar, .gz, .zst, .7z, etc.).
 // - defaultHandler is used for non-archive files.
 // The selected handler is then returned, ready to handle the file according to its specific format and requirements.
-func selectHandler(file fileReader) FileHandler {
+func selectHandler(file fileReader, config fileHandlingConfig) FileHandler {
 	switch file.mimeType {
 	case arMime, unixArMime, debMime:
-		return newARHandler()
+		h := newARHandler()
+		h.joinLineContinuations = config.joinLineContinuations
+		return h
 	case rpmMime, cpioMime:
-		return newRPMHandler()
+		h := newRPMHandler()
+		h.joinLineContinuations = config.joinLineContinuations
+		return h
+	case pdfMime:
+		h := newPDFHandler()
+		h.joinLineContinuations = config.joinLineContinuations
+		return h
 	default:
 		if file.isGenericArchive {
-			return newArchiveHandler()
+			h := newArchiveHandler()
+			h.maxDepth = config.maxArchiveDepth
+			h.maxDecompressedSize = config.maxArchiveDecompressedSize
+			h.joinLineContinuations = config.joinLineContinuations
+			h.checkpoint = config.checkpoint
+			return h
 		}
-		return newDefaultHandler(defaultHandlerType)
+		h := newDefaultHandler(defaultHandlerType)
+		h.joinLineContinuations = config.joinLineContinuations
+		return h
 	}
 }
 
@@ -166,6 +250,12 @@ func selectHandler(file fileReader) FileHandler {
 //
 // If the skipArchives option is set to true and the detected MIME type is a known archive type,
 // the function will skip processing the file and return nil.
+//
+// Archives are extracted up to the depth and decompressed size limits given by the WithMaxArchiveDepth and
+// WithMaxArchiveDecompressedSize options. Extraction stops with a warning once a limit is exceeded.
+//
+// With the WithArchiveCheckpoint option, the entries of archives already processed are skipped, and the others are
+// marked processed as their content is reported.
 func HandleFile(
 	ctx logContext.Context,
 	reader io.ReadCloser,
@@ -193,24 +283,27 @@ func HandleFile(
 		return nil
 	}
 
-	handler := selectHandler(rdr)
+	handler := selectHandler(rdr, config)
 	archiveChan, err := handler.HandleFile(ctx, rdr) // Delegate to the specific handler to process the file.
 	if err != nil {
 		return fmt.Errorf("error handling file: %w", err)
 	}
 
-	return handleChunks(ctx, archiveChan, chunkSkel, reporter)
+	return handleChunks(ctx, archiveChan, chunkSkel, reporter, config.checkpoint)
 }
 
 // handleChunks reads data from the handlerChan and uses it to fill chunks according to a predefined skeleton (chunkSkel).
+// Chunks extracted from an archive entry have their source metadata location pointed at that entry.
 // Each filled chunk is reported using the provided reporter. This function manages the lifecycle of the channel,
 // handling the termination condition when the channel closes and ensuring the cancellation of the operation if the context
 // is done. It returns true if all chunks are processed successfully, otherwise returns false on errors or cancellation.
+// Archive entries whose chunks were all reported are marked processed in the checkpoint, if set.
 func handleChunks(
 	ctx logContext.Context,
-	handlerChan chan []byte,
+	handlerChan chan fileChunk,
 	chunkSkel *sources.Chunk,
 	reporter sources.ChunkReporter,
+	checkpoint ArchiveCheckpoint,
 ) error {
 	if handlerChan == nil {
 		return fmt.Errorf("handler channel is nil")
@@ -223,8 +316,19 @@ func handleChunks(
 				ctx.Logger().V(5).Info("handler channel closed, all chunks processed")
 				return nil
 			}
+			if data.entryDone {
+				if checkpoint != nil {
+					checkpoint.MarkProcessed(data.entryPath)
+				}
+				continue
+			}
 			chunk := *chunkSkel
-			chunk.Data = data
+			chunk.Data = data.data
+			position := data.position
+			chunk.Position = &position
+			if data.entryPath != "" {
+				chunk.SourceMetadata = withArchiveLocation(chunkSkel.SourceMetadata, data.entryPath, data.position.Offset)
+			}
 			if err := reporter.ChunkOk(ctx, chunk); err != nil {
 				return fmt.Errorf("error reporting chunk: %w", err)
 			}
@@ -233,3 +337,33 @@ func handleChunks(
 		}
 	}
 }
+
+// withArchiveLocation returns a copy of the metadata with the location of the data within the archive it was extracted
+// from: the path of its entry and its offset in it. Metadata of sources without archive location fields is returned as
+// is.
+func withArchiveLocation(metadata *source_metadatapb.MetaData, entryPath string, offset int64) *source_metadatapb.MetaData {
+	if metadata == nil {
+		return nil
+	}
+
+	md, ok := proto.Clone(metadata).(*source_metadatapb.MetaData)
+	if !ok {
+		return metadata
+	}
+
+	switch meta := md.GetData().(type) {
+	case *source_metadatapb.MetaData_Filesystem:
+		meta.Filesystem.ArchiveEntry, meta.Filesystem.ArchiveOffset = entryPath, offset
+	case *source_metadatapb.MetaData_Git:
+		meta.Git.ArchiveEntry, meta.Git.ArchiveOffset = entryPath, offset
+	case *source_metadatapb.MetaData_Gcs:
+		meta.Gcs.ArchiveEntry, meta.Gcs.ArchiveOffset = entryPath, offset
+	case *source_metadatapb.MetaData_S3:
+		meta.S3.ArchiveEntry, meta.S3.ArchiveOffset = entryPath, offset
+	case *source_metadatapb.MetaData_AzureBlob:
+		meta.AzureBlob.ArchiveEntry, meta.AzureBlob.ArchiveOffset = entryPath, offset
+	default:
+		return metadata
+	}
+	return md
+}
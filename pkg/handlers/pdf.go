@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+
+	logContext "github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// pdfHandler handles PDF files by extracting their text content.
+type pdfHandler struct{ *defaultHandler }
+
+// newPDFHandler creates a pdfHandler.
+func newPDFHandler() *pdfHandler {
+	return &pdfHandler{defaultHandler: newDefaultHandler(pdfHandlerType)}
+}
+
+// HandleFile extracts the text of all pages of a PDF file and sends it to the returned channel.
+// PDFs without any text, such as scanned documents, are skipped.
+func (h *pdfHandler) HandleFile(ctx logContext.Context, input fileReader) (chan fileChunk, error) {
+	pdfChan := make(chan fileChunk, defaultBufferSize)
+
+	go func() {
+		ctx, cancel := logContext.WithTimeout(ctx, maxTimeout)
+		defer cancel()
+		defer close(pdfChan)
+
+		// Update the metrics for the file processing.
+		start := time.Now()
+		var err error
+		defer func() {
+			h.measureLatencyAndHandleErrors(start, err)
+			h.metrics.incFilesProcessed()
+		}()
+
+		// Defer a panic recovery, the PDF reader panics on some malformed documents.
+		defer func() {
+			if r := recover(); r != nil {
+				// Return the panic as an error.
+				if e, ok := r.(error); ok {
+					err = e
+				} else {
+					err = fmt.Errorf("panic occurred: %v", r)
+				}
+				ctx.Logger().Error(err, "Panic occurred when reading PDF")
+			}
+		}()
+
+		var text string
+		text, err = extractPDFText(input)
+		if err != nil {
+			ctx.Logger().Error(err, "error extracting PDF text")
+			return
+		}
+
+		if strings.TrimSpace(text) == "" {
+			ctx.Logger().V(3).Info("skipping PDF without text content")
+			h.metrics.incFilesSkipped()
+			return
+		}
+
+		if err = h.handleNonArchiveContent(ctx, strings.NewReader(text), pdfChan); err != nil {
+			ctx.Logger().Error(err, "error handling PDF text")
+		}
+	}()
+
+	return pdfChan, nil
+}
+
+// extractPDFText returns the plain text of all pages of a PDF file.
+func extractPDFText(input fileReader) (string, error) {
+	reader, err := pdf.NewReader(input, int64(input.Size()))
+	if err != nil {
+		return "", fmt.Errorf("error reading PDF: %w", err)
+	}
+
+	var text strings.Builder
+	fonts := make(map[string]*pdf.Font)
+	for i := 1; i <= reader.NumPage(); i++ {
+		page := reader.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+		// Cache fonts across pages to avoid parsing their character maps for each page.
+		for _, name := range page.Fonts() {
+			if _, ok := fonts[name]; !ok {
+				font := page.Font(name)
+				fonts[name] = &font
+			}
+		}
+
+		pageText, err := page.GetPlainText(fonts)
+		if err != nil {
+			return "", fmt.Errorf("error extracting text from page %d: %w", i, err)
+		}
+		text.WriteString(pageText)
+		text.WriteString("\n")
+	}
+	return text.String(), nil
+}
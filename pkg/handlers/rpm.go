@@ -21,8 +21,8 @@ func newRPMHandler() *rpmHandler {
 
 // HandleFile processes RPM formatted files. Further implementation is required to appropriately
 // handle RPM specific archive operations.
-func (h *rpmHandler) HandleFile(ctx logContext.Context, input fileReader) (chan []byte, error) {
-	archiveChan := make(chan []byte, defaultBufferSize)
+func (h *rpmHandler) HandleFile(ctx logContext.Context, input fileReader) (chan fileChunk, error) {
+	archiveChan := make(chan fileChunk, defaultBufferSize)
 
 	go func() {
 		ctx, cancel := logContext.WithTimeout(ctx, maxTimeout)
@@ -72,7 +72,7 @@ func (h *rpmHandler) HandleFile(ctx logContext.Context, input fileReader) (chan
 	return archiveChan, nil
 }
 
-func (h *rpmHandler) processRPMFiles(ctx logContext.Context, reader rpmutils.PayloadReader, archiveChan chan []byte) error {
+func (h *rpmHandler) processRPMFiles(ctx logContext.Context, reader rpmutils.PayloadReader, archiveChan chan fileChunk) error {
 	for {
 		select {
 		case <-ctx.Done():
@@ -89,6 +89,7 @@ func (h *rpmHandler) processRPMFiles(ctx logContext.Context, reader rpmutils.Pay
 
 			fileSize := fileInfo.Size()
 			fileCtx := logContext.WithValues(ctx, "filename", fileInfo.Name, "size", fileSize)
+			fileCtx = logContext.WithValue(fileCtx, archivePathKey, fileInfo.Name())
 
 			if err := h.handleNonArchiveContent(fileCtx, reader, archiveChan); err != nil {
 				fileCtx.Logger().Error(err, "error handling archive content in RPM")
@@ -0,0 +1,48 @@
+package output
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+// CreateFile creates the file at path to write output to, such as results or
+// a scan report. If compress is set or the path ends in .gz, the output is
+// gzipped as it's written, rather than buffered, so large outputs take less
+// disk space without taking more memory. The file must be closed for the
+// gzip stream to be complete.
+func CreateFile(path string, compress bool) (io.WriteCloser, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if !compress && !strings.HasSuffix(path, ".gz") {
+		return f, nil
+	}
+	return &gzipFile{Writer: gzip.NewWriter(f), file: f}, nil
+}
+
+// gzipFile is a file written through a gzip stream.
+type gzipFile struct {
+	*gzip.Writer
+	file *os.File
+}
+
+// Name returns the name of the file, like os.File.
+func (f *gzipFile) Name() string { return f.file.Name() }
+
+// Close completes the gzip stream and closes the file.
+func (f *gzipFile) Close() error {
+	return errors.Join(f.Writer.Close(), f.file.Close())
+}
+
+// writerOrStdout returns w, or os.Stdout if w is nil, which printers write to
+// by default.
+func writerOrStdout(w io.Writer) io.Writer {
+	if w == nil {
+		return os.Stdout
+	}
+	return w
+}
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+)
+
+func TestCreateFile(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	tests := []struct {
+		name     string
+		path     string
+		compress bool
+		gzipped  bool
+	}{
+		{name: "plain", path: filepath.Join(dir, "results.json")},
+		{name: "gz extension", path: filepath.Join(dir, "results.json.gz"), gzipped: true},
+		{name: "compress", path: filepath.Join(dir, "results"), compress: true, gzipped: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := CreateFile(tt.path, tt.compress)
+			require.NoError(t, err)
+
+			printer := &JSONPrinter{Writer: f}
+			secrets := []string{"first-secret", "second-secret", "third-secret"}
+			for _, secret := range secrets {
+				require.NoError(t, printer.Print(ctx, &detectors.ResultWithMetadata{
+					SourceMetadata: &source_metadatapb.MetaData{Data: &source_metadatapb.MetaData_Gcs{
+						Gcs: &source_metadatapb.GCS{Bucket: "bucket", Filename: "config.env"},
+					}},
+					SourceType: sourcespb.SourceType_SOURCE_TYPE_GCS,
+					Result:     detectors.Result{DetectorType: detectorspb.DetectorType_AWS, Raw: []byte(secret)},
+				}))
+			}
+			require.NoError(t, f.Close())
+
+			file, err := os.Open(tt.path)
+			require.NoError(t, err)
+			defer file.Close()
+			var r io.Reader = file
+			if tt.gzipped {
+				gz, err := gzip.NewReader(file)
+				require.NoError(t, err)
+				defer gz.Close()
+				r = gz
+			}
+
+			var got []string
+			scanner := bufio.NewScanner(r)
+			for scanner.Scan() {
+				var res map[string]any
+				require.NoError(t, json.Unmarshal(scanner.Bytes(), &res))
+				assert.Equal(t, "AWS", res["DetectorName"])
+				got = append(got, res["Raw"].(string))
+			}
+			require.NoError(t, scanner.Err())
+			assert.Equal(t, secrets, got)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"sync"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
@@ -14,7 +15,12 @@ import (
 var dedupeCache = make(map[string]struct{})
 
 // GitHubActionsPrinter is a printer that prints results in GitHub Actions format.
-type GitHubActionsPrinter struct{ mu sync.Mutex }
+type GitHubActionsPrinter struct {
+	// Writer is where results are printed, os.Stdout if nil.
+	Writer io.Writer
+
+	mu sync.Mutex
+}
 
 func (p *GitHubActionsPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata) error {
 	out := gitHubActionsOutputFormat{
@@ -59,13 +65,15 @@ func (p *GitHubActionsPrinter) Print(_ context.Context, r *detectors.ResultWithM
 	}
 	dedupeCache[key] = struct{}{}
 
-	message := fmt.Sprintf("Found %s %s result 🐷🔑\n", verifiedStatus, out.DetectorType)
+	message := fmt.Sprintf("Found %s %s result with %s confidence 🐷🔑\n", verifiedStatus, out.DetectorType, r.Result.Confidence)
 	if r.Result.DecoderType != detectorspb.DecoderType_PLAIN {
-		message = fmt.Sprintf("Found %s %s result with %s encoding 🐷🔑\n", verifiedStatus, out.DetectorType, out.DecoderType)
+		message = fmt.Sprintf("Found %s %s result with %s encoding and %s confidence 🐷🔑\n", verifiedStatus, out.DetectorType, out.DecoderType, r.Result.Confidence)
 	}
 
-	fmt.Printf("::warning file=%s,line=%d,endLine=%d::%s",
-		out.Filename, out.StartLine, out.StartLine, message)
+	if _, err := fmt.Fprintf(writerOrStdout(p.Writer), "::warning file=%s,line=%d,endLine=%d::%s",
+		out.Filename, out.StartLine, out.StartLine, message); err != nil {
+		return fmt.Errorf("could not print result: %w", err)
+	}
 
 	return nil
 }
@@ -1,9 +1,15 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
@@ -14,9 +20,128 @@ import (
 )
 
 // JSONPrinter is a printer that prints results in JSON format.
-type JSONPrinter struct{ mu sync.Mutex }
+type JSONPrinter struct {
+	// Writer is where results are printed, os.Stdout if nil.
+	Writer io.Writer
+
+	mu sync.Mutex
+}
 
 func (p *JSONPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata) error {
+	out, err := json.Marshal(NewJSONResult(r))
+	if err != nil {
+		return fmt.Errorf("could not marshal result: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := fmt.Fprintln(writerOrStdout(p.Writer), string(out)); err != nil {
+		return fmt.Errorf("could not print result: %w", err)
+	}
+	return nil
+}
+
+// JSONResult is the JSON representation of a result, as printed by JSONPrinter.
+type JSONResult struct {
+	// SourceMetadata contains source-specific contextual information.
+	SourceMetadata *source_metadatapb.MetaData
+	// Position is where the result was found within the data of the source,
+	// if known.
+	Position *sources.Position `json:",omitempty"`
+	// SourceID is the ID of the source that the API uses to map secrets to specific sources.
+	SourceID sources.SourceID
+	// SourceType is the type of Source.
+	SourceType sourcespb.SourceType
+	// SourceName is the name of the Source.
+	SourceName string
+	// SourceLink is a web URL to view where the result was found, if any.
+	SourceLink string `json:",omitempty"`
+	// DetectorType is the type of Detector.
+	DetectorType detectorspb.DetectorType
+	// DetectorName is the string name of the DetectorType.
+	DetectorName string
+	// DecoderName is the string name of the DecoderType.
+	DecoderName string
+	Verified    bool
+	// Revoked is set for unverified results whose service reported the secret as revoked or expired.
+	Revoked           bool   `json:",omitempty"`
+	VerificationError string `json:",omitempty"`
+	// VerificationErrorCategory is the category of the verification error: network, rate_limited,
+	// provider_unavailable, or unknown.
+	VerificationErrorCategory detectors.VerificationErrorCategory `json:",omitempty"`
+	// Confidence is how likely the result is to be a real secret: low, medium, or high.
+	Confidence detectors.Confidence
+	// Raw contains the raw secret data.
+	Raw string
+	// RawV2 contains the raw secret identifier that is a combination of both the ID and the secret.
+	// This is used for secrets that are multi part and could have the same ID. Ex: AWS credentials
+	RawV2 string
+	// Redacted contains the redacted version of the raw secret identification data for display purposes.
+	// A secret ID should be used if available.
+	Redacted       string
+	ExtraData      map[string]string
+	StructuredData *detectorspb.StructuredData
+	// DetectedAt is when the result was reported, if known.
+	DetectedAt *time.Time `json:",omitempty"`
+	// ScanID is the ID of the scan that found the result, if known.
+	ScanID string `json:",omitempty"`
+	// Labels are the key/values attached to every result of the scan. They
+	// are encoded as additional fields, see MarshalJSON.
+	Labels map[string]string `json:"-"`
+}
+
+// jsonResultFields are the names of the fields of JSONResult, in lower case,
+// which labels can't overwrite.
+var jsonResultFields = func() map[string]struct{} {
+	fields := make(map[string]struct{})
+	t := reflect.TypeOf(JSONResult{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			name = t.Field(i).Name
+		}
+		fields[strings.ToLower(name)] = struct{}{}
+	}
+	return fields
+}()
+
+// MarshalJSON encodes the result with its labels merged in as additional
+// fields, sorted by name, so the systems results are sent to can index them
+// like the others. Labels named like a field of the result, ignoring case, are
+// left out rather than overwriting it.
+func (r JSONResult) MarshalJSON() ([]byte, error) {
+	// The alias doesn't have the method, so it's encoded as a plain struct.
+	type jsonResult JSONResult
+	out, err := json.Marshal(jsonResult(r))
+	if err != nil || len(r.Labels) == 0 {
+		return out, err
+	}
+
+	keys := make([]string, 0, len(r.Labels))
+	for key := range r.Labels {
+		if _, ok := jsonResultFields[strings.ToLower(key)]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.Write(out[:len(out)-1])
+	for _, key := range keys {
+		// Strings can always be encoded.
+		name, _ := json.Marshal(key)
+		value, _ := json.Marshal(r.Labels[key])
+		buf.WriteByte(',')
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// NewJSONResult returns the JSON representation of the result.
+func NewJSONResult(r *detectors.ResultWithMetadata) *JSONResult {
 	verificationErr := func(err error) string {
 		if err != nil {
 			return err.Error()
@@ -24,56 +149,33 @@ func (p *JSONPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata)
 		return ""
 	}(r.VerificationError())
 
-	v := &struct {
-		// SourceMetadata contains source-specific contextual information.
-		SourceMetadata *source_metadatapb.MetaData
-		// SourceID is the ID of the source that the API uses to map secrets to specific sources.
-		SourceID sources.SourceID
-		// SourceType is the type of Source.
-		SourceType sourcespb.SourceType
-		// SourceName is the name of the Source.
-		SourceName string
-		// DetectorType is the type of Detector.
-		DetectorType detectorspb.DetectorType
-		// DetectorName is the string name of the DetectorType.
-		DetectorName string
-		// DecoderName is the string name of the DecoderType.
-		DecoderName       string
-		Verified          bool
-		VerificationError string `json:",omitempty"`
-		// Raw contains the raw secret data.
-		Raw string
-		// RawV2 contains the raw secret identifier that is a combination of both the ID and the secret.
-		// This is used for secrets that are multi part and could have the same ID. Ex: AWS credentials
-		RawV2 string
-		// Redacted contains the redacted version of the raw secret identification data for display purposes.
-		// A secret ID should be used if available.
-		Redacted       string
-		ExtraData      map[string]string
-		StructuredData *detectorspb.StructuredData
-	}{
-		SourceMetadata:    r.SourceMetadata,
-		SourceID:          r.SourceID,
-		SourceType:        r.SourceType,
-		SourceName:        r.SourceName,
-		DetectorType:      r.DetectorType,
-		DetectorName:      r.DetectorType.String(),
-		DecoderName:       r.DecoderType.String(),
-		Verified:          r.Verified,
-		VerificationError: verificationErr,
-		Raw:               string(r.Raw),
-		RawV2:             string(r.RawV2),
-		Redacted:          r.Redacted,
-		ExtraData:         r.ExtraData,
-		StructuredData:    r.StructuredData,
-	}
-	out, err := json.Marshal(v)
-	if err != nil {
-		return fmt.Errorf("could not marshal result: %w", err)
+	var detectedAt *time.Time
+	if !r.DetectedAt.IsZero() {
+		detectedAt = &r.DetectedAt
 	}
 
-	p.mu.Lock()
-	fmt.Println(string(out))
-	p.mu.Unlock()
-	return nil
+	return &JSONResult{
+		SourceMetadata:            r.SourceMetadata,
+		Position:                  r.Position,
+		SourceID:                  r.SourceID,
+		SourceType:                r.SourceType,
+		SourceName:                r.SourceName,
+		SourceLink:                sources.SourceLink(r.SourceMetadata),
+		DetectorType:              r.DetectorType,
+		DetectorName:              r.DetectorType.String(),
+		DecoderName:               r.DecoderType.String(),
+		Verified:                  r.Verified,
+		Revoked:                   r.Revoked,
+		VerificationError:         verificationErr,
+		VerificationErrorCategory: r.VerificationErrorCategory(),
+		Confidence:                r.Confidence,
+		Raw:                       string(r.Raw),
+		RawV2:                     string(r.RawV2),
+		Redacted:                  r.Redacted,
+		ExtraData:                 r.ExtraData,
+		StructuredData:            r.StructuredData,
+		DetectedAt:                detectedAt,
+		ScanID:                    r.ScanID,
+		Labels:                    r.Labels,
+	}
 }
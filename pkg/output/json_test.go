@@ -0,0 +1,80 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+)
+
+func TestJSONResult_Labels(t *testing.T) {
+	r := &detectors.ResultWithMetadata{
+		SourceName: "test source",
+		SourceType: sourcespb.SourceType_SOURCE_TYPE_FILESYSTEM,
+		Labels: map[string]string{
+			"env":    "prod",
+			"ticket": "SEC-123",
+			// Labels can't overwrite the fields of the result, whatever
+			// their case, even those that are omitted.
+			"Raw":          "overwritten",
+			"detectorname": "overwritten",
+			"Position":     "overwritten",
+		},
+		Result: detectors.Result{
+			DetectorType: detectorspb.DetectorType_AWS,
+			Raw:          []byte("secret"),
+		},
+	}
+
+	out, err := json.Marshal(NewJSONResult(r))
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(out, &got))
+	assert.Equal(t, "prod", got["env"])
+	assert.Equal(t, "SEC-123", got["ticket"])
+	assert.Equal(t, "secret", got["Raw"])
+	assert.Equal(t, "AWS", got["DetectorName"])
+	assert.Equal(t, "test source", got["SourceName"])
+	assert.NotContains(t, got, "Position")
+	assert.NotContains(t, got, "detectorname")
+	assert.NotContains(t, got, "Labels")
+
+	// Without labels, the result is encoded as is.
+	r.Labels = nil
+	out, err = json.Marshal(NewJSONResult(r))
+	require.NoError(t, err)
+	type plainJSONResult JSONResult
+	want, err := json.Marshal(plainJSONResult(*NewJSONResult(r)))
+	require.NoError(t, err)
+	assert.JSONEq(t, string(want), string(out))
+}
+
+func TestJSONResult_DetectedAtAndScanID(t *testing.T) {
+	r := &detectors.ResultWithMetadata{
+		DetectedAt: time.Date(2024, 5, 1, 12, 30, 0, 500, time.UTC),
+		ScanID:     "0b5c8f6e-3f5d-4a8e-9f5e-1c2d3e4f5a6b",
+		Result:     detectors.Result{DetectorType: detectorspb.DetectorType_AWS},
+	}
+
+	out, err := json.Marshal(NewJSONResult(r))
+	require.NoError(t, err)
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(out, &got))
+	assert.Equal(t, "2024-05-01T12:30:00.0000005Z", got["DetectedAt"])
+	assert.Equal(t, r.ScanID, got["ScanID"])
+
+	// Results reported outside of a scan have neither.
+	out, err = json.Marshal(NewJSONResult(&detectors.ResultWithMetadata{}))
+	require.NoError(t, err)
+	got = nil
+	require.NoError(t, json.Unmarshal(out, &got))
+	assert.NotContains(t, got, "DetectedAt")
+	assert.NotContains(t, got, "ScanID")
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/url"
 	"os"
@@ -22,7 +23,12 @@ import (
 )
 
 // LegacyJSONPrinter is a printer that prints results in legacy JSON format for backwards compatibility.
-type LegacyJSONPrinter struct{ mu sync.Mutex }
+type LegacyJSONPrinter struct {
+	// Writer is where results are printed, os.Stdout if nil.
+	Writer io.Writer
+
+	mu sync.Mutex
+}
 
 func (p *LegacyJSONPrinter) Print(ctx context.Context, r *detectors.ResultWithMetadata) error {
 	var repo string
@@ -56,8 +62,10 @@ func (p *LegacyJSONPrinter) Print(ctx context.Context, r *detectors.ResultWithMe
 	}
 
 	p.mu.Lock()
-	fmt.Println(string(out))
-	p.mu.Unlock()
+	defer p.mu.Unlock()
+	if _, err := fmt.Fprintln(writerOrStdout(p.Writer), string(out)); err != nil {
+		return fmt.Errorf("could not print result: %w", err)
+	}
 	return nil
 }
 
@@ -111,6 +119,7 @@ func convertToLegacyJSON(r *detectors.ResultWithMetadata, repoPath string) (*Leg
 		PrintDiff:    printableDiff,
 		Reason:       r.Result.DetectorType.String(),
 		StringsFound: []string{foundString},
+		Confidence:   r.Result.Confidence.String(),
 	}
 	return output, nil
 }
@@ -236,6 +245,7 @@ type LegacyJSONOutput struct {
 	PrintDiff    string   `json:"printDiff"`
 	Reason       string   `json:"reason"`
 	StringsFound []string `json:"stringsFound"`
+	Confidence   string   `json:"confidence"`
 }
 
 type LegacyJSONCompatibleSource interface {
@@ -1,11 +1,14 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/fatih/color"
 	"golang.org/x/text/cases"
@@ -14,6 +17,7 @@ import (
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
 )
 
 var (
@@ -25,16 +29,24 @@ var (
 )
 
 // PlainPrinter is a printer that prints results in plain text format.
-type PlainPrinter struct{ mu sync.Mutex }
+type PlainPrinter struct {
+	// Writer is where results are printed, os.Stdout if nil.
+	Writer io.Writer
+
+	mu sync.Mutex
+}
 
 func (p *PlainPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata) error {
 	out := outputFormat{
-		DetectorType:      r.Result.DetectorType.String(),
-		DecoderType:       r.Result.DecoderType.String(),
-		Verified:          r.Result.Verified,
-		VerificationError: r.Result.VerificationError(),
-		MetaData:          r.SourceMetadata,
-		Raw:               strings.TrimSpace(string(r.Result.Raw)),
+		DetectorType:              r.Result.DetectorType.String(),
+		DecoderType:               r.Result.DecoderType.String(),
+		Verified:                  r.Result.Verified,
+		Revoked:                   r.Result.Revoked,
+		VerificationError:         r.Result.VerificationError(),
+		VerificationErrorCategory: r.Result.VerificationErrorCategory(),
+		Confidence:                r.Result.Confidence,
+		MetaData:                  r.SourceMetadata,
+		Raw:                       strings.TrimSpace(string(r.Result.Raw)),
 	}
 
 	meta, err := structToMap(out.MetaData.Data)
@@ -42,25 +54,31 @@ func (p *PlainPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata)
 		return fmt.Errorf("could not marshal result: %w", err)
 	}
 
+	// The result is printed at once, so results printed concurrently aren't
+	// interleaved.
+	var buf bytes.Buffer
 	printer := greenPrinter
-	p.mu.Lock()
-	defer p.mu.Unlock()
 
 	if out.Verified {
-		boldGreenPrinter.Print("✅ Found verified result 🐷🔑\n")
+		boldGreenPrinter.Fprint(&buf, "✅ Found verified result 🐷🔑\n")
 	} else {
 		printer = whitePrinter
-		boldWhitePrinter.Print("Found unverified result 🐷🔑❓\n")
+		boldWhitePrinter.Fprint(&buf, "Found unverified result 🐷🔑❓\n")
+		if out.Revoked {
+			whitePrinter.Fprint(&buf, "The secret was revoked or expired\n")
+		}
 		if out.VerificationError != nil {
-			yellowPrinter.Printf("Verification issue: %s\n", out.VerificationError)
+			yellowPrinter.Fprintf(&buf, "Verification issue (%s): %s\n", out.VerificationErrorCategory, out.VerificationError)
 		}
 	}
-	printer.Printf("Detector Type: %s\n", out.DetectorType)
-	printer.Printf("Decoder Type: %s\n", out.DecoderType)
-	printer.Printf("Raw result: %s\n", whitePrinter.Sprint(out.Raw))
+	printer.Fprintf(&buf, "Detector Type: %s\n", out.DetectorType)
+	printer.Fprintf(&buf, "Decoder Type: %s\n", out.DecoderType)
+	printer.Fprintf(&buf, "Confidence: %s\n", out.Confidence)
+	printer.Fprintf(&buf, "Raw result: %s\n", whitePrinter.Sprint(out.Raw))
 
 	for k, v := range r.Result.ExtraData {
-		printer.Printf(
+		printer.Fprintf(
+			&buf,
 			"%s: %v\n",
 			cases.Title(language.AmericanEnglish).String(k),
 			v)
@@ -68,17 +86,17 @@ func (p *PlainPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata)
 
 	if r.Result.StructuredData != nil {
 		for idx, v := range r.Result.StructuredData.GithubSshKey {
-			printer.Printf("GithubSshKey %d User: %s\n", idx, v.User)
+			printer.Fprintf(&buf, "GithubSshKey %d User: %s\n", idx, v.User)
 
 			if v.PublicKeyFingerprint != "" {
-				printer.Printf("GithubSshKey %d Fingerprint: %s\n", idx, v.PublicKeyFingerprint)
+				printer.Fprintf(&buf, "GithubSshKey %d Fingerprint: %s\n", idx, v.PublicKeyFingerprint)
 			}
 		}
 
 		for idx, v := range r.Result.StructuredData.TlsPrivateKey {
-			printer.Printf("TlsPrivateKey %d Fingerprint: %s\n", idx, v.CertificateFingerprint)
-			printer.Printf("TlsPrivateKey %d Verification URL: %s\n", idx, v.VerificationUrl)
-			printer.Printf("TlsPrivateKey %d Expiration: %d\n", idx, v.ExpirationTimestamp)
+			printer.Fprintf(&buf, "TlsPrivateKey %d Fingerprint: %s\n", idx, v.CertificateFingerprint)
+			printer.Fprintf(&buf, "TlsPrivateKey %d Verification URL: %s\n", idx, v.VerificationUrl)
+			printer.Fprintf(&buf, "TlsPrivateKey %d Expiration: %d\n", idx, v.ExpirationTimestamp)
 		}
 	}
 
@@ -93,9 +111,37 @@ func (p *PlainPrinter) Print(_ context.Context, r *detectors.ResultWithMetadata)
 	}
 	sort.Strings(aggregateDataKeys)
 	for _, k := range aggregateDataKeys {
-		printer.Printf("%s: %v\n", cases.Title(language.AmericanEnglish).String(k), aggregateData[k])
+		printer.Fprintf(&buf, "%s: %v\n", cases.Title(language.AmericanEnglish).String(k), aggregateData[k])
+	}
+	if pos := r.Position; pos != nil {
+		if pos.Line > 0 {
+			printer.Fprintf(&buf, "Position: offset %d, line %d, column %d\n", pos.Offset, pos.Line, pos.Column)
+		} else {
+			printer.Fprintf(&buf, "Position: offset %d\n", pos.Offset)
+		}
+	}
+	if !r.DetectedAt.IsZero() {
+		printer.Fprintf(&buf, "Detected At: %s\n", r.DetectedAt.Format(time.RFC3339))
+	}
+	if len(r.Labels) > 0 {
+		labels := make([]string, 0, len(r.Labels))
+		for k, v := range r.Labels {
+			labels = append(labels, k+"="+v)
+		}
+		sort.Strings(labels)
+		printer.Fprintf(&buf, "Labels: %s\n", strings.Join(labels, ", "))
+	}
+	// The source link is usually the link of the metadata, which was just printed.
+	if link := sources.SourceLink(r.SourceMetadata); link != "" && link != aggregateData["link"] {
+		printer.Fprintf(&buf, "Source Link: %s\n", link)
+	}
+	buf.WriteString("\n")
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := buf.WriteTo(writerOrStdout(p.Writer)); err != nil {
+		return fmt.Errorf("could not print result: %w", err)
 	}
-	fmt.Println("")
 	return nil
 }
 
@@ -111,8 +157,11 @@ func structToMap(obj any) (m map[string]map[string]any, err error) {
 type outputFormat struct {
 	DetectorType,
 	DecoderType string
-	Verified          bool
-	VerificationError error
-	Raw               string
+	Verified                  bool
+	Revoked                   bool
+	VerificationError         error
+	VerificationErrorCategory detectors.VerificationErrorCategory
+	Confidence                detectors.Confidence
+	Raw                       string
 	*source_metadatapb.MetaData
 }
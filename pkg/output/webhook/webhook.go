@@ -0,0 +1,211 @@
+// Package webhook sends results to an HTTP endpoint as they are found.
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/output"
+)
+
+// Policy is what the printer does with a result when its buffer is full,
+// because the endpoint is slow or down.
+type Policy string
+
+const (
+	// PolicyBlock waits for room in the buffer, which slows the scan down.
+	PolicyBlock Policy = "block"
+	// PolicyDrop drops the result, so the scan isn't slowed down.
+	PolicyDrop Policy = "drop"
+)
+
+const (
+	defaultBufferSize   = 100
+	defaultMaxRetries   = 3
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 30 * time.Second
+	defaultTimeout      = 10 * time.Second
+)
+
+// Config configures the printer. Zero values use the defaults.
+type Config struct {
+	// URL is the endpoint that results are POSTed to.
+	URL string
+	// Headers are added to every request, e.g. for authentication.
+	Headers map[string]string
+	// BufferSize is the number of results buffered while they wait to be sent.
+	BufferSize int
+	// Policy is what to do with a result when the buffer is full. It defaults to PolicyBlock.
+	Policy Policy
+	// MaxRetries is the number of times a request that failed with a network
+	// error, a rate limit, or a server error is retried.
+	MaxRetries int
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between retries.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	// Timeout is the time limit of each request.
+	Timeout time.Duration
+}
+
+// Printer is a printer that POSTs each result as JSON, in the format of
+// output.JSONPrinter, to an endpoint. Results are sent in the background, one
+// at a time, so a slow endpoint only delays the scan once the buffer is full.
+// Close must be called to send the buffered results.
+type Printer struct {
+	url     string
+	headers map[string]string
+	policy  Policy
+	client  *retryablehttp.Client
+
+	queue     chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+
+	sent    atomic.Uint64
+	failed  atomic.Uint64
+	dropped atomic.Uint64
+}
+
+// New returns a Printer configured with cfg, and starts sending results.
+func New(ctx context.Context, cfg Config) (*Printer, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid webhook URL %q, must be http or https", cfg.URL)
+	}
+
+	switch cfg.Policy {
+	case "":
+		cfg.Policy = PolicyBlock
+	case PolicyBlock, PolicyDrop:
+	default:
+		return nil, fmt.Errorf("invalid webhook policy %q, must be one of block or drop", cfg.Policy)
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = defaultBufferSize
+	}
+	if cfg.MaxRetries < 0 {
+		return nil, fmt.Errorf("webhook max retries must not be negative")
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = defaultMaxRetries
+	}
+	if cfg.RetryWaitMin <= 0 {
+		cfg.RetryWaitMin = defaultRetryWaitMin
+	}
+	if cfg.RetryWaitMax <= 0 {
+		cfg.RetryWaitMax = defaultRetryWaitMax
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	client := retryablehttp.NewClient()
+	client.Logger = nil
+	client.RetryMax = cfg.MaxRetries
+	client.RetryWaitMin = cfg.RetryWaitMin
+	client.RetryWaitMax = cfg.RetryWaitMax
+	client.HTTPClient.Timeout = cfg.Timeout
+
+	p := &Printer{
+		url:     cfg.URL,
+		headers: cfg.Headers,
+		policy:  cfg.Policy,
+		client:  client,
+		queue:   make(chan []byte, cfg.BufferSize),
+		done:    make(chan struct{}),
+	}
+	go p.run(ctx)
+	return p, nil
+}
+
+// Print buffers the result to be sent. If the buffer is full, it either waits
+// or drops the result, depending on the policy.
+func (p *Printer) Print(ctx context.Context, r *detectors.ResultWithMetadata) error {
+	payload, err := json.Marshal(output.NewJSONResult(r))
+	if err != nil {
+		return fmt.Errorf("could not marshal result: %w", err)
+	}
+
+	if p.policy == PolicyDrop {
+		select {
+		case p.queue <- payload:
+		default:
+			p.dropped.Add(1)
+			return fmt.Errorf("webhook buffer is full, dropped result")
+		}
+		return nil
+	}
+
+	select {
+	case p.queue <- payload:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close sends the buffered results and stops the printer. It returns an error
+// if any result couldn't be sent or was dropped.
+func (p *Printer) Close() error {
+	p.closeOnce.Do(func() { close(p.queue) })
+	<-p.done
+
+	failed, dropped := p.failed.Load(), p.dropped.Load()
+	if failed > 0 || dropped > 0 {
+		return fmt.Errorf("webhook sent %d results, %d failed and %d were dropped", p.sent.Load(), failed, dropped)
+	}
+	return nil
+}
+
+func (p *Printer) run(ctx context.Context) {
+	defer close(p.done)
+
+	for payload := range p.queue {
+		if err := p.send(ctx, payload); err != nil {
+			p.failed.Add(1)
+			ctx.Logger().Error(err, "error sending result to webhook")
+			continue
+		}
+		p.sent.Add(1)
+	}
+}
+
+func (p *Printer) send(ctx context.Context, payload []byte) error {
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodPost, p.url, payload)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range p.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected HTTP response status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	return nil
+}
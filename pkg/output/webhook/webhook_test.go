@@ -0,0 +1,223 @@
+package webhook
+
+import (
+	aCtx "context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/detectors"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/detectorspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+)
+
+func testResult(raw string) *detectors.ResultWithMetadata {
+	return &detectors.ResultWithMetadata{
+		SourceName: "test source",
+		SourceType: sourcespb.SourceType_SOURCE_TYPE_FILESYSTEM,
+		Result: detectors.Result{
+			DetectorType: detectorspb.DetectorType_AWS,
+			Verified:     true,
+			Confidence:   detectors.ConfidenceHigh,
+			Raw:          []byte(raw),
+			Redacted:     "AKIA...",
+		},
+	}
+}
+
+func TestPrinter_Payload(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		payloads []map[string]any
+		headers  []http.Header
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		var payload map[string]any
+		assert.NoError(t, json.Unmarshal(body, &payload))
+
+		mu.Lock()
+		payloads = append(payloads, payload)
+		headers = append(headers, r.Header.Clone())
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	p, err := New(ctx, Config{URL: server.URL, Headers: map[string]string{"Authorization": "Bearer token"}})
+	require.NoError(t, err)
+
+	require.NoError(t, p.Print(ctx, testResult("secret1")))
+	require.NoError(t, p.Print(ctx, testResult("secret2")))
+	require.NoError(t, p.Close())
+
+	require.Len(t, payloads, 2)
+	for i, payload := range payloads {
+		assert.Equal(t, "test source", payload["SourceName"])
+		assert.Equal(t, "AWS", payload["DetectorName"])
+		assert.Equal(t, true, payload["Verified"])
+		assert.Equal(t, "high", payload["Confidence"])
+		assert.Equal(t, "AKIA...", payload["Redacted"])
+		assert.Equal(t, "application/json", headers[i].Get("Content-Type"))
+		assert.Equal(t, "Bearer token", headers[i].Get("Authorization"))
+	}
+	assert.Equal(t, "secret1", payloads[0]["Raw"])
+	assert.Equal(t, "secret2", payloads[1]["Raw"])
+}
+
+func TestPrinter_Labels(t *testing.T) {
+	payloads := make(chan map[string]any, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		payloads <- payload
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	p, err := New(ctx, Config{URL: server.URL})
+	require.NoError(t, err)
+
+	result := testResult("secret")
+	result.Labels = map[string]string{"team": "security", "Raw": "overwritten"}
+	require.NoError(t, p.Print(ctx, result))
+	require.NoError(t, p.Close())
+
+	payload := <-payloads
+	assert.Equal(t, "security", payload["team"])
+	assert.Equal(t, "secret", payload["Raw"])
+}
+
+func TestPrinter_Retry(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	p, err := New(ctx, Config{URL: server.URL, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond})
+	require.NoError(t, err)
+
+	require.NoError(t, p.Print(ctx, testResult("secret")))
+	assert.NoError(t, p.Close())
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestPrinter_RetriesExhausted(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	p, err := New(ctx, Config{URL: server.URL, MaxRetries: 2, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond})
+	require.NoError(t, err)
+
+	require.NoError(t, p.Print(ctx, testResult("secret")))
+	assert.Error(t, p.Close())
+	assert.Equal(t, int32(3), attempts.Load())
+}
+
+func TestPrinter_ClientErrorNotRetried(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	p, err := New(ctx, Config{URL: server.URL, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond})
+	require.NoError(t, err)
+
+	require.NoError(t, p.Print(ctx, testResult("secret")))
+	assert.Error(t, p.Close())
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestPrinter_DropPolicy(t *testing.T) {
+	unblock := make(chan struct{})
+	var received atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+		received.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	p, err := New(ctx, Config{URL: server.URL, BufferSize: 1, Policy: PolicyDrop})
+	require.NoError(t, err)
+
+	// The first result is being sent and the second fills the buffer, so the
+	// rest are dropped instead of blocking while the endpoint hangs.
+	var dropped int
+	for i := 0; i < 10; i++ {
+		if err := p.Print(ctx, testResult("secret")); err != nil {
+			dropped++
+		}
+	}
+	assert.GreaterOrEqual(t, dropped, 8)
+
+	close(unblock)
+	assert.Error(t, p.Close())
+	assert.Equal(t, int32(10-dropped), received.Load())
+}
+
+func TestPrinter_BlockPolicy(t *testing.T) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	p, err := New(context.Background(), Config{URL: server.URL, BufferSize: 1})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// Printing blocks once the buffer is full, until the context is done.
+	var err2 error
+	for i := 0; i < 3 && err2 == nil; i++ {
+		err2 = p.Print(ctx, testResult("secret"))
+	}
+	assert.ErrorIs(t, err2, aCtx.DeadlineExceeded)
+
+	close(unblock)
+	assert.NoError(t, p.Close())
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := New(ctx, Config{URL: "ftp://example.com"})
+	assert.Error(t, err)
+
+	_, err = New(ctx, Config{URL: "https://example.com", Policy: "retry"})
+	assert.Error(t, err)
+
+	_, err = New(ctx, Config{URL: "https://example.com", MaxRetries: -1})
+	assert.Error(t, err)
+}
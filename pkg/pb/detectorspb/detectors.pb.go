@@ -1093,6 +1093,10 @@ const (
 	DetectorType_LarkSuite                               DetectorType = 991
 	DetectorType_LarkSuiteApiKey                         DetectorType = 992
 	DetectorType_EndorLabs                               DetectorType = 993
+	DetectorType_GenericEntropy                          DetectorType = 994
+	DetectorType_JWT                                     DetectorType = 995
+	DetectorType_GCSPublicAccess                         DetectorType = 996
+	DetectorType_DatabaseConnectionString                DetectorType = 998
 )
 
 // Enum value maps for DetectorType.
@@ -2088,6 +2092,10 @@ var (
 		991: "LarkSuite",
 		992: "LarkSuiteApiKey",
 		993: "EndorLabs",
+		994: "GenericEntropy",
+		995: "JWT",
+		996: "GCSPublicAccess",
+		998: "DatabaseConnectionString",
 	}
 	DetectorType_value = map[string]int32{
 		"Alibaba":                               0,
@@ -3080,6 +3088,10 @@ var (
 		"LarkSuite":                        991,
 		"LarkSuiteApiKey":                  992,
 		"EndorLabs":                        993,
+		"GenericEntropy":                   994,
+		"JWT":                              995,
+		"GCSPublicAccess":                  996,
+		"DatabaseConnectionString":         998,
 	}
 )
 
@@ -3533,7 +3545,7 @@ var file_detectors_proto_rawDesc = []byte{
 	0x4c, 0x41, 0x49, 0x4e, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x41, 0x53, 0x45, 0x36, 0x34,
 	0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x55, 0x54, 0x46, 0x31, 0x36, 0x10, 0x03, 0x12, 0x13, 0x0a,
 	0x0f, 0x45, 0x53, 0x43, 0x41, 0x50, 0x45, 0x44, 0x5f, 0x55, 0x4e, 0x49, 0x43, 0x4f, 0x44, 0x45,
-	0x10, 0x04, 0x2a, 0xf0, 0x7e, 0x0a, 0x0c, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x54,
+	0x10, 0x04, 0x2a, 0xc4, 0x7f, 0x0a, 0x0c, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x54,
 	0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x6c, 0x69, 0x62, 0x61, 0x62, 0x61, 0x10, 0x00,
 	0x12, 0x08, 0x0a, 0x04, 0x41, 0x4d, 0x51, 0x50, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x57,
 	0x53, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x10, 0x03, 0x12, 0x0a,
@@ -4548,11 +4560,16 @@ var file_detectors_proto_rawDesc = []byte{
 	0x0e, 0x0a, 0x09, 0x4c, 0x61, 0x72, 0x6b, 0x53, 0x75, 0x69, 0x74, 0x65, 0x10, 0xdf, 0x07, 0x12,
 	0x14, 0x0a, 0x0f, 0x4c, 0x61, 0x72, 0x6b, 0x53, 0x75, 0x69, 0x74, 0x65, 0x41, 0x70, 0x69, 0x4b,
 	0x65, 0x79, 0x10, 0xe0, 0x07, 0x12, 0x0e, 0x0a, 0x09, 0x45, 0x6e, 0x64, 0x6f, 0x72, 0x4c, 0x61,
-	0x62, 0x73, 0x10, 0xe1, 0x07, 0x42, 0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x72, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x73, 0x65, 0x63, 0x75, 0x72,
-	0x69, 0x74, 0x79, 0x2f, 0x74, 0x72, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x68, 0x6f, 0x67, 0x2f, 0x76,
-	0x33, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x2f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f,
-	0x72, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x62, 0x73, 0x10, 0xe1, 0x07, 0x12, 0x13, 0x0a, 0x0e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63,
+	0x45, 0x6e, 0x74, 0x72, 0x6f, 0x70, 0x79, 0x10, 0xe2, 0x07, 0x12, 0x08, 0x0a, 0x03, 0x4a, 0x57,
+	0x54, 0x10, 0xe3, 0x07, 0x12, 0x14, 0x0a, 0x0f, 0x47, 0x43, 0x53, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x10, 0xe4, 0x07, 0x12, 0x1d, 0x0a, 0x18, 0x44, 0x61,
+	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x10, 0xe6, 0x07, 0x42, 0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x72, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x73,
+	0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x2f, 0x74, 0x72, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x68,
+	0x6f, 0x67, 0x2f, 0x76, 0x33, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x2f, 0x64, 0x65, 0x74,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
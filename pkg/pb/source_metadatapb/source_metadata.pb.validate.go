@@ -960,6 +960,12 @@ func (m *Filesystem) validate(all bool) error {
 
 	// no validation rules for Line
 
+	// no validation rules for InPath
+
+	// no validation rules for ArchiveEntry
+
+	// no validation rules for ArchiveOffset
+
 	if len(errors) > 0 {
 		return FilesystemMultiError(errors)
 	}
@@ -1070,6 +1076,12 @@ func (m *Git) validate(all bool) error {
 
 	// no validation rules for Line
 
+	// no validation rules for Link
+
+	// no validation rules for ArchiveEntry
+
+	// no validation rules for ArchiveOffset
+
 	if len(errors) > 0 {
 		return GitMultiError(errors)
 	}
@@ -1416,6 +1428,30 @@ func (m *GCS) validate(all bool) error {
 
 	// no validation rules for ContentType
 
+	// no validation rules for Visibility
+
+	// no validation rules for InCustomMetadata
+
+	// no validation rules for Generation
+
+	// no validation rules for InPath
+
+	// no validation rules for Location
+
+	// no validation rules for LocationType
+
+	// no validation rules for Record
+
+	// no validation rules for RecordOffset
+
+	// no validation rules for ProjectNumber
+
+	// no validation rules for SoftDeleted
+
+	// no validation rules for ArchiveEntry
+
+	// no validation rules for ArchiveOffset
+
 	if len(errors) > 0 {
 		return GCSMultiError(errors)
 	}
@@ -1493,6 +1529,233 @@ var _ interface {
 	ErrorName() string
 } = GCSValidationError{}
 
+// Validate checks the field values on AzureBlob with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AzureBlob) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AzureBlob with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AzureBlobMultiError, or nil
+// if none found.
+func (m *AzureBlob) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AzureBlob) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Account
+
+	// no validation rules for Container
+
+	// no validation rules for Blob
+
+	// no validation rules for Link
+
+	// no validation rules for ContentType
+
+	// no validation rules for LastModified
+
+	// no validation rules for Etag
+
+	// no validation rules for ArchiveEntry
+
+	// no validation rules for ArchiveOffset
+
+	if len(errors) > 0 {
+		return AzureBlobMultiError(errors)
+	}
+
+	return nil
+}
+
+// AzureBlobMultiError is an error wrapping multiple validation errors returned
+// by AzureBlob.ValidateAll() if the designated constraints aren't met.
+type AzureBlobMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AzureBlobMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AzureBlobMultiError) AllErrors() []error { return m }
+
+// AzureBlobValidationError is the validation error returned by
+// AzureBlob.Validate if the designated constraints aren't met.
+type AzureBlobValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AzureBlobValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AzureBlobValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AzureBlobValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AzureBlobValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AzureBlobValidationError) ErrorName() string { return "AzureBlobValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AzureBlobValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAzureBlob.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AzureBlobValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AzureBlobValidationError{}
+
+// Validate checks the field values on GCPSecretManager with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GCPSecretManager) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GCPSecretManager with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GCPSecretManagerMultiError, or nil if none found.
+func (m *GCPSecretManager) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GCPSecretManager) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Project
+
+	// no validation rules for Secret
+
+	// no validation rules for Version
+
+	// no validation rules for Link
+
+	// no validation rules for CreateTime
+
+	if len(errors) > 0 {
+		return GCPSecretManagerMultiError(errors)
+	}
+
+	return nil
+}
+
+// GCPSecretManagerMultiError is an error wrapping multiple validation errors
+// returned by GCPSecretManager.ValidateAll() if the designated constraints
+// aren't met.
+type GCPSecretManagerMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GCPSecretManagerMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GCPSecretManagerMultiError) AllErrors() []error { return m }
+
+// GCPSecretManagerValidationError is the validation error returned by
+// GCPSecretManager.Validate if the designated constraints aren't met.
+type GCPSecretManagerValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GCPSecretManagerValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GCPSecretManagerValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GCPSecretManagerValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GCPSecretManagerValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GCPSecretManagerValidationError) ErrorName() string { return "GCPSecretManagerValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GCPSecretManagerValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGCPSecretManager.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GCPSecretManagerValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GCPSecretManagerValidationError{}
+
 // Validate checks the field values on Huggingface with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -1969,6 +2232,12 @@ func (m *S3) validate(all bool) error {
 
 	// no validation rules for Timestamp
 
+	// no validation rules for InPath
+
+	// no validation rules for ArchiveEntry
+
+	// no validation rules for ArchiveOffset
+
 	if len(errors) > 0 {
 		return S3MultiError(errors)
 	}
@@ -3899,6 +4168,106 @@ var _ interface {
 	ErrorName() string
 } = ElasticsearchValidationError{}
 
+// Validate checks the field values on Stdin with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Stdin) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Stdin with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is a
+// list of violation errors wrapped in StdinMultiError, or nil if none found.
+func (m *Stdin) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Stdin) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	if len(errors) > 0 {
+		return StdinMultiError(errors)
+	}
+
+	return nil
+}
+
+// StdinMultiError is an error wrapping multiple validation errors returned by
+// Stdin.ValidateAll() if the designated constraints aren't met.
+type StdinMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StdinMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StdinMultiError) AllErrors() []error { return m }
+
+// StdinValidationError is the validation error returned by Stdin.Validate if
+// the designated constraints aren't met.
+type StdinValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StdinValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StdinValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StdinValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StdinValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StdinValidationError) ErrorName() string { return "StdinValidationError" }
+
+// Error satisfies the builtin error interface
+func (e StdinValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStdin.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StdinValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StdinValidationError{}
+
 // Validate checks the field values on MetaData with the rules defined in the
 // proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -5234,6 +5603,129 @@ func (m *MetaData) validate(all bool) error {
 			}
 		}
 
+	case *MetaData_AzureBlob:
+		if v == nil {
+			err := MetaDataValidationError{
+				field:  "Data",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetAzureBlob()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MetaDataValidationError{
+						field:  "AzureBlob",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MetaDataValidationError{
+						field:  "AzureBlob",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetAzureBlob()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MetaDataValidationError{
+					field:  "AzureBlob",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MetaData_GcpSecretManager:
+		if v == nil {
+			err := MetaDataValidationError{
+				field:  "Data",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetGcpSecretManager()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MetaDataValidationError{
+						field:  "GcpSecretManager",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MetaDataValidationError{
+						field:  "GcpSecretManager",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetGcpSecretManager()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MetaDataValidationError{
+					field:  "GcpSecretManager",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *MetaData_Stdin:
+		if v == nil {
+			err := MetaDataValidationError{
+				field:  "Data",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetStdin()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, MetaDataValidationError{
+						field:  "Stdin",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, MetaDataValidationError{
+						field:  "Stdin",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetStdin()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return MetaDataValidationError{
+					field:  "Stdin",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	default:
 		_ = v // ensures v is used
 	}
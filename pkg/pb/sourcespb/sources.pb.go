@@ -65,6 +65,9 @@ const (
 	SourceType_SOURCE_TYPE_WEBHOOK                    SourceType = 34
 	SourceType_SOURCE_TYPE_ELASTICSEARCH              SourceType = 35
 	SourceType_SOURCE_TYPE_HUGGINGFACE                SourceType = 36
+	SourceType_SOURCE_TYPE_AZURE_BLOB                 SourceType = 37
+	SourceType_SOURCE_TYPE_GCP_SECRET_MANAGER         SourceType = 38
+	SourceType_SOURCE_TYPE_STDIN                      SourceType = 39
 )
 
 // Enum value maps for SourceType.
@@ -107,6 +110,9 @@ var (
 		34: "SOURCE_TYPE_WEBHOOK",
 		35: "SOURCE_TYPE_ELASTICSEARCH",
 		36: "SOURCE_TYPE_HUGGINGFACE",
+		37: "SOURCE_TYPE_AZURE_BLOB",
+		38: "SOURCE_TYPE_GCP_SECRET_MANAGER",
+		39: "SOURCE_TYPE_STDIN",
 	}
 	SourceType_value = map[string]int32{
 		"SOURCE_TYPE_AZURE_STORAGE":              0,
@@ -146,6 +152,9 @@ var (
 		"SOURCE_TYPE_WEBHOOK":                    34,
 		"SOURCE_TYPE_ELASTICSEARCH":              35,
 		"SOURCE_TYPE_HUGGINGFACE":                36,
+		"SOURCE_TYPE_AZURE_BLOB":                 37,
+		"SOURCE_TYPE_GCP_SECRET_MANAGER":         38,
+		"SOURCE_TYPE_STDIN":                      39,
 	}
 )
 
@@ -1201,10 +1210,18 @@ type Filesystem struct {
 
 	// DEPRECATED: directories is deprecated and can be removed / renamed to
 	// paths when we no longer depend on the name in enterprise configs.
-	Directories      []string `protobuf:"bytes,1,rep,name=directories,proto3" json:"directories,omitempty"`
-	Paths            []string `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"`
-	IncludePathsFile string   `protobuf:"bytes,3,opt,name=include_paths_file,json=includePathsFile,proto3" json:"include_paths_file,omitempty"` // path to file containing newline separated list of paths
-	ExcludePathsFile string   `protobuf:"bytes,4,opt,name=exclude_paths_file,json=excludePathsFile,proto3" json:"exclude_paths_file,omitempty"` // path to file containing newline separated list of paths
+	Directories             []string `protobuf:"bytes,1,rep,name=directories,proto3" json:"directories,omitempty"`
+	Paths                   []string `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"`
+	IncludePathsFile        string   `protobuf:"bytes,3,opt,name=include_paths_file,json=includePathsFile,proto3" json:"include_paths_file,omitempty"` // path to file containing newline separated list of paths
+	ExcludePathsFile        string   `protobuf:"bytes,4,opt,name=exclude_paths_file,json=excludePathsFile,proto3" json:"exclude_paths_file,omitempty"` // path to file containing newline separated list of paths
+	PathAllowlist           []string `protobuf:"bytes,5,rep,name=path_allowlist,json=pathAllowlist,proto3" json:"path_allowlist,omitempty"`            // globs of file paths to skip
+	SkipBinaries            bool     `protobuf:"varint,6,opt,name=skip_binaries,json=skipBinaries,proto3" json:"skip_binaries,omitempty"`
+	BinaryNullByteThreshold float64  `protobuf:"fixed64,7,opt,name=binary_null_byte_threshold,json=binaryNullByteThreshold,proto3" json:"binary_null_byte_threshold,omitempty"` // fraction of null bytes in the first 8KB above which a file is binary
+	CheckpointInterval      int64    `protobuf:"varint,8,opt,name=checkpoint_interval,json=checkpointInterval,proto3" json:"checkpoint_interval,omitempty"`                     // number of scanned files between persisting the resume info
+	ScanPaths               bool     `protobuf:"varint,9,opt,name=scan_paths,json=scanPaths,proto3" json:"scan_paths,omitempty"`                                                // also scan the paths of files for secrets
+	JoinLineContinuations   bool     `protobuf:"varint,10,opt,name=join_line_continuations,json=joinLineContinuations,proto3" json:"join_line_continuations,omitempty"`         // join lines continued with a trailing backslash, so secrets wrapped across lines are found
+	UseIgnoreFiles          bool     `protobuf:"varint,11,opt,name=use_ignore_files,json=useIgnoreFiles,proto3" json:"use_ignore_files,omitempty"`                              // skip the paths listed by .trufflehogignore files in the scanned directories
+	MmapThreshold           int64    `protobuf:"varint,12,opt,name=mmap_threshold,json=mmapThreshold,proto3" json:"mmap_threshold,omitempty"`                                   // file size in bytes above which files are memory-mapped rather than read with buffered IO; 0 disables memory-mapping
 }
 
 func (x *Filesystem) Reset() {
@@ -1267,6 +1284,62 @@ func (x *Filesystem) GetExcludePathsFile() string {
 	return ""
 }
 
+func (x *Filesystem) GetPathAllowlist() []string {
+	if x != nil {
+		return x.PathAllowlist
+	}
+	return nil
+}
+
+func (x *Filesystem) GetSkipBinaries() bool {
+	if x != nil {
+		return x.SkipBinaries
+	}
+	return false
+}
+
+func (x *Filesystem) GetBinaryNullByteThreshold() float64 {
+	if x != nil {
+		return x.BinaryNullByteThreshold
+	}
+	return 0
+}
+
+func (x *Filesystem) GetCheckpointInterval() int64 {
+	if x != nil {
+		return x.CheckpointInterval
+	}
+	return 0
+}
+
+func (x *Filesystem) GetScanPaths() bool {
+	if x != nil {
+		return x.ScanPaths
+	}
+	return false
+}
+
+func (x *Filesystem) GetJoinLineContinuations() bool {
+	if x != nil {
+		return x.JoinLineContinuations
+	}
+	return false
+}
+
+func (x *Filesystem) GetUseIgnoreFiles() bool {
+	if x != nil {
+		return x.UseIgnoreFiles
+	}
+	return false
+}
+
+func (x *Filesystem) GetMmapThreshold() int64 {
+	if x != nil {
+		return x.MmapThreshold
+	}
+	return 0
+}
+
 type GCS struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1280,13 +1353,60 @@ type GCS struct {
 	//	*GCS_Adc
 	//	*GCS_ServiceAccountFile
 	//	*GCS_Oauth
-	Credential     isGCS_Credential `protobuf_oneof:"credential"`
-	ProjectId      string           `protobuf:"bytes,5,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	IncludeBuckets []string         `protobuf:"bytes,6,rep,name=include_buckets,json=includeBuckets,proto3" json:"include_buckets,omitempty"`
-	ExcludeBuckets []string         `protobuf:"bytes,7,rep,name=exclude_buckets,json=excludeBuckets,proto3" json:"exclude_buckets,omitempty"`
-	IncludeObjects []string         `protobuf:"bytes,8,rep,name=include_objects,json=includeObjects,proto3" json:"include_objects,omitempty"`
-	ExcludeObjects []string         `protobuf:"bytes,9,rep,name=exclude_objects,json=excludeObjects,proto3" json:"exclude_objects,omitempty"`
-	MaxObjectSize  int64            `protobuf:"varint,10,opt,name=max_object_size,json=maxObjectSize,proto3" json:"max_object_size,omitempty"`
+	//	*GCS_WorkloadIdentityFederationFile
+	Credential              isGCS_Credential `protobuf_oneof:"credential"`
+	ProjectId               string           `protobuf:"bytes,5,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	IncludeBuckets          []string         `protobuf:"bytes,6,rep,name=include_buckets,json=includeBuckets,proto3" json:"include_buckets,omitempty"` // buckets, or @path of a file listing one bucket per line
+	ExcludeBuckets          []string         `protobuf:"bytes,7,rep,name=exclude_buckets,json=excludeBuckets,proto3" json:"exclude_buckets,omitempty"` // buckets, or @path of a file listing one bucket per line
+	IncludeObjects          []string         `protobuf:"bytes,8,rep,name=include_objects,json=includeObjects,proto3" json:"include_objects,omitempty"`
+	ExcludeObjects          []string         `protobuf:"bytes,9,rep,name=exclude_objects,json=excludeObjects,proto3" json:"exclude_objects,omitempty"`
+	MaxObjectSize           int64            `protobuf:"varint,10,opt,name=max_object_size,json=maxObjectSize,proto3" json:"max_object_size,omitempty"`
+	SkipBinaries            bool             `protobuf:"varint,13,opt,name=skip_binaries,json=skipBinaries,proto3" json:"skip_binaries,omitempty"`
+	BinaryNullByteThreshold float64          `protobuf:"fixed64,14,opt,name=binary_null_byte_threshold,json=binaryNullByteThreshold,proto3" json:"binary_null_byte_threshold,omitempty"` // fraction of null bytes in the first 8KB above which an object is binary
+	// Signed URLs of objects to fetch over HTTP instead of listing buckets
+	// through the GCS API. When set, the credential is not used.
+	SignedUrls                 []string             `protobuf:"bytes,15,rep,name=signed_urls,json=signedUrls,proto3" json:"signed_urls,omitempty"`
+	SignedUrlsFile             string               `protobuf:"bytes,16,opt,name=signed_urls_file,json=signedUrlsFile,proto3" json:"signed_urls_file,omitempty"`                                        // path to file containing newline separated list of signed URLs
+	DisableArchiveHandling     bool                 `protobuf:"varint,17,opt,name=disable_archive_handling,json=disableArchiveHandling,proto3" json:"disable_archive_handling,omitempty"`               // read objects as plain bytes without detecting or extracting archives
+	MaxArchiveDepth            int64                `protobuf:"varint,18,opt,name=max_archive_depth,json=maxArchiveDepth,proto3" json:"max_archive_depth,omitempty"`                                    // maximum depth of nested archives to extract
+	MaxArchiveDecompressedSize int64                `protobuf:"varint,19,opt,name=max_archive_decompressed_size,json=maxArchiveDecompressedSize,proto3" json:"max_archive_decompressed_size,omitempty"` // maximum total bytes to decompress from an archive
+	ObjectReadTimeout          *durationpb.Duration `protobuf:"bytes,20,opt,name=object_read_timeout,json=objectReadTimeout,proto3" json:"object_read_timeout,omitempty"`                               // maximum time to read a single object
+	Prefixes                   []string             `protobuf:"bytes,21,rep,name=prefixes,proto3" json:"prefixes,omitempty"`                                                                            // only scan objects whose names start with one of these prefixes
+	IncludeVersions            bool                 `protobuf:"varint,22,opt,name=include_versions,json=includeVersions,proto3" json:"include_versions,omitempty"`                                      // also scan noncurrent versions of objects in versioned buckets
+	MaxObjectsPerBucket        int64                `protobuf:"varint,23,opt,name=max_objects_per_bucket,json=maxObjectsPerBucket,proto3" json:"max_objects_per_bucket,omitempty"`                      // maximum number of objects to scan in each bucket, 0 for all
+	SampleObjects              bool                 `protobuf:"varint,24,opt,name=sample_objects,json=sampleObjects,proto3" json:"sample_objects,omitempty"`                                            // scan a random sample of max_objects_per_bucket objects instead of the first ones
+	RangedReadThreshold        int64                `protobuf:"varint,25,opt,name=ranged_read_threshold,json=rangedReadThreshold,proto3" json:"ranged_read_threshold,omitempty"`                        // objects of at least this many bytes are read in concurrent ranges, 0 to disable
+	RangedReadConcurrency      int32                `protobuf:"varint,26,opt,name=ranged_read_concurrency,json=rangedReadConcurrency,proto3" json:"ranged_read_concurrency,omitempty"`                  // maximum number of ranges of an object read at once
+	ContinueOnBucketError      bool                 `protobuf:"varint,27,opt,name=continue_on_bucket_error,json=continueOnBucketError,proto3" json:"continue_on_bucket_error,omitempty"`                // skip buckets that fail to be listed instead of stopping the scan
+	ReportPublicAccess         bool                 `protobuf:"varint,28,opt,name=report_public_access,json=reportPublicAccess,proto3" json:"report_public_access,omitempty"`                           // report the objects and buckets readable by allUsers as findings, even without secrets
+	Incremental                bool                 `protobuf:"varint,29,opt,name=incremental,proto3" json:"incremental,omitempty"`                                                                     // skip objects whose ETag is unchanged since the last scan, as kept in the resume info
+	ScanPaths                  bool                 `protobuf:"varint,30,opt,name=scan_paths,json=scanPaths,proto3" json:"scan_paths,omitempty"`                                                        // also scan the names of objects for secrets
+	Ndjson                     bool                 `protobuf:"varint,31,opt,name=ndjson,proto3" json:"ndjson,omitempty"`                                                                               // split objects into newline-delimited JSON records, reporting the record each secret is found in
+	BucketIam                  bool                 `protobuf:"varint,32,opt,name=bucket_iam,json=bucketIam,proto3" json:"bucket_iam,omitempty"`                                                        // report the principals that can read the objects of a bucket, from its IAM policy, with their findings; requires storage.buckets.getIamPolicy
+	Proxy                      string               `protobuf:"bytes,33,opt,name=proxy,proto3" json:"proxy,omitempty"`                                                                                  // URL of the HTTP, HTTPS or SOCKS5 proxy to request GCS through, except for the hosts in NO_PROXY
+	UpdatedSinceLastScan       bool                 `protobuf:"varint,34,opt,name=updated_since_last_scan,json=updatedSinceLastScan,proto3" json:"updated_since_last_scan,omitempty"`                   // only scan objects updated after the latest update time of the objects of the last completed scan, as kept in the resume info
+	LastScanOverlap            *durationpb.Duration `protobuf:"bytes,35,opt,name=last_scan_overlap,json=lastScanOverlap,proto3" json:"last_scan_overlap,omitempty"`                                     // also scan objects updated this long before the last completed scan, e.g. written while it was listing; defaults to 5m
+	PrivateEndpoint            bool                 `protobuf:"varint,36,opt,name=private_endpoint,json=privateEndpoint,proto3" json:"private_endpoint,omitempty"`                                      // connect to Google APIs through the restricted VIP, to scan from inside a VPC Service Controls perimeter
+	IncludeContentTypes        []string             `protobuf:"bytes,37,rep,name=include_content_types,json=includeContentTypes,proto3" json:"include_content_types,omitempty"`                         // only scan objects whose media type matches one of these, e.g. text/* or application/json
+	ExcludeContentTypes        []string             `protobuf:"bytes,38,rep,name=exclude_content_types,json=excludeContentTypes,proto3" json:"exclude_content_types,omitempty"`                         // skip objects whose media type matches one of these; takes precedence over include_content_types
+	ExcludeUnknownContentTypes bool                 `protobuf:"varint,39,opt,name=exclude_unknown_content_types,json=excludeUnknownContentTypes,proto3" json:"exclude_unknown_content_types,omitempty"` // skip objects without a valid content type when filtering by content type
+	// Buckets to scan with their own credential, e.g. the buckets of other
+	// projects. When set, the credential and include_buckets must not be.
+	CredentialScopes      []*GCSCredentialScope `protobuf:"bytes,40,rep,name=credential_scopes,json=credentialScopes,proto3" json:"credential_scopes,omitempty"`
+	JoinLineContinuations bool                  `protobuf:"varint,41,opt,name=join_line_continuations,json=joinLineContinuations,proto3" json:"join_line_continuations,omitempty"` // join lines continued with a trailing backslash, so secrets wrapped across lines are found
+
+	SkipArchivedObjects       bool     `protobuf:"varint,42,opt,name=skip_archived_objects,json=skipArchivedObjects,proto3" json:"skip_archived_objects,omitempty"`                  // skip objects in the COLDLINE and ARCHIVE storage classes, whose retrieval is billed; they don't need to be restored to be read
+	DedupeObjects             bool     `protobuf:"varint,43,opt,name=dedupe_objects,json=dedupeObjects,proto3" json:"dedupe_objects,omitempty"`                                      // skip the objects whose stored MD5 or CRC32C hash is that of an object already scanned, e.g. copies of a file in several buckets
+	SoftDeletedObjects        bool     `protobuf:"varint,44,opt,name=soft_deleted_objects,json=softDeletedObjects,proto3" json:"soft_deleted_objects,omitempty"`                     // also scan the objects that were soft-deleted but are still retained by the bucket's soft delete policy
+	SampleRate                float64  `protobuf:"fixed64,45,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`                                              // scan each object with this probability, between 0 and 1, for spot checks; 0 scans all objects
+	SampleSeed                int64    `protobuf:"varint,46,opt,name=sample_seed,json=sampleSeed,proto3" json:"sample_seed,omitempty"`                                               // seed of the random selection of sample_rate and sample_objects, so runs select the same objects; random if 0
+	TruncateLargeObjects      bool     `protobuf:"varint,47,opt,name=truncate_large_objects,json=truncateLargeObjects,proto3" json:"truncate_large_objects,omitempty"`               // scan the first max_object_size bytes of larger objects instead of skipping them
+	ImpersonateServiceAccount string   `protobuf:"bytes,48,opt,name=impersonate_service_account,json=impersonateServiceAccount,proto3" json:"impersonate_service_account,omitempty"` // service account to impersonate with the adc credential, e.g. to scan the buckets of another project
+	ImpersonationDelegates    []string `protobuf:"bytes,49,rep,name=impersonation_delegates,json=impersonationDelegates,proto3" json:"impersonation_delegates,omitempty"`            // service accounts in the delegation chain to impersonate_service_account, each granted roles/iam.serviceAccountTokenCreator on the next
+	InventoryManifest         string   `protobuf:"bytes,51,opt,name=inventory_manifest,json=inventoryManifest,proto3" json:"inventory_manifest,omitempty"`                           // gs:// URI of a Storage Insights inventory report, or of its manifest, listing the objects to scan instead of listing buckets
+	MinObjectSize             int64    `protobuf:"varint,52,opt,name=min_object_size,json=minObjectSize,proto3" json:"min_object_size,omitempty"`                                    // skip objects smaller than this many bytes, e.g. icons
+	PrefetchDepth             int32    `protobuf:"varint,53,opt,name=prefetch_depth,json=prefetchDepth,proto3" json:"prefetch_depth,omitempty"`                                      // number of objects downloaded into memory ahead of their detection, to overlap downloads with detection; 0 to disable
+	PrefetchMaxBytes          int64    `protobuf:"varint,54,opt,name=prefetch_max_bytes,json=prefetchMaxBytes,proto3" json:"prefetch_max_bytes,omitempty"`                           // memory the objects downloaded by prefetch_depth can use at once; larger objects are streamed; defaults to 64MB
 }
 
 func (x *GCS) Reset() {
@@ -1370,6 +1490,13 @@ func (x *GCS) GetOauth() *credentialspb.Oauth2 {
 	return nil
 }
 
+func (x *GCS) GetWorkloadIdentityFederationFile() string {
+	if x, ok := x.GetCredential().(*GCS_WorkloadIdentityFederationFile); ok {
+		return x.WorkloadIdentityFederationFile
+	}
+	return ""
+}
+
 func (x *GCS) GetProjectId() string {
 	if x != nil {
 		return x.ProjectId
@@ -1412,6 +1539,293 @@ func (x *GCS) GetMaxObjectSize() int64 {
 	return 0
 }
 
+func (x *GCS) GetSkipBinaries() bool {
+	if x != nil {
+		return x.SkipBinaries
+	}
+	return false
+}
+
+func (x *GCS) GetBinaryNullByteThreshold() float64 {
+	if x != nil {
+		return x.BinaryNullByteThreshold
+	}
+	return 0
+}
+
+func (x *GCS) GetSignedUrls() []string {
+	if x != nil {
+		return x.SignedUrls
+	}
+	return nil
+}
+
+func (x *GCS) GetSignedUrlsFile() string {
+	if x != nil {
+		return x.SignedUrlsFile
+	}
+	return ""
+}
+
+func (x *GCS) GetDisableArchiveHandling() bool {
+	if x != nil {
+		return x.DisableArchiveHandling
+	}
+	return false
+}
+
+func (x *GCS) GetMaxArchiveDepth() int64 {
+	if x != nil {
+		return x.MaxArchiveDepth
+	}
+	return 0
+}
+
+func (x *GCS) GetMaxArchiveDecompressedSize() int64 {
+	if x != nil {
+		return x.MaxArchiveDecompressedSize
+	}
+	return 0
+}
+
+func (x *GCS) GetObjectReadTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.ObjectReadTimeout
+	}
+	return nil
+}
+
+func (x *GCS) GetPrefixes() []string {
+	if x != nil {
+		return x.Prefixes
+	}
+	return nil
+}
+
+func (x *GCS) GetIncludeVersions() bool {
+	if x != nil {
+		return x.IncludeVersions
+	}
+	return false
+}
+
+func (x *GCS) GetMaxObjectsPerBucket() int64 {
+	if x != nil {
+		return x.MaxObjectsPerBucket
+	}
+	return 0
+}
+
+func (x *GCS) GetSampleObjects() bool {
+	if x != nil {
+		return x.SampleObjects
+	}
+	return false
+}
+
+func (x *GCS) GetRangedReadThreshold() int64 {
+	if x != nil {
+		return x.RangedReadThreshold
+	}
+	return 0
+}
+
+func (x *GCS) GetRangedReadConcurrency() int32 {
+	if x != nil {
+		return x.RangedReadConcurrency
+	}
+	return 0
+}
+
+func (x *GCS) GetContinueOnBucketError() bool {
+	if x != nil {
+		return x.ContinueOnBucketError
+	}
+	return false
+}
+
+func (x *GCS) GetReportPublicAccess() bool {
+	if x != nil {
+		return x.ReportPublicAccess
+	}
+	return false
+}
+
+func (x *GCS) GetIncremental() bool {
+	if x != nil {
+		return x.Incremental
+	}
+	return false
+}
+
+func (x *GCS) GetScanPaths() bool {
+	if x != nil {
+		return x.ScanPaths
+	}
+	return false
+}
+
+func (x *GCS) GetNdjson() bool {
+	if x != nil {
+		return x.Ndjson
+	}
+	return false
+}
+
+func (x *GCS) GetBucketIam() bool {
+	if x != nil {
+		return x.BucketIam
+	}
+	return false
+}
+
+func (x *GCS) GetProxy() string {
+	if x != nil {
+		return x.Proxy
+	}
+	return ""
+}
+
+func (x *GCS) GetUpdatedSinceLastScan() bool {
+	if x != nil {
+		return x.UpdatedSinceLastScan
+	}
+	return false
+}
+
+func (x *GCS) GetLastScanOverlap() *durationpb.Duration {
+	if x != nil {
+		return x.LastScanOverlap
+	}
+	return nil
+}
+
+func (x *GCS) GetPrivateEndpoint() bool {
+	if x != nil {
+		return x.PrivateEndpoint
+	}
+	return false
+}
+
+func (x *GCS) GetIncludeContentTypes() []string {
+	if x != nil {
+		return x.IncludeContentTypes
+	}
+	return nil
+}
+
+func (x *GCS) GetExcludeContentTypes() []string {
+	if x != nil {
+		return x.ExcludeContentTypes
+	}
+	return nil
+}
+
+func (x *GCS) GetExcludeUnknownContentTypes() bool {
+	if x != nil {
+		return x.ExcludeUnknownContentTypes
+	}
+	return false
+}
+
+func (x *GCS) GetCredentialScopes() []*GCSCredentialScope {
+	if x != nil {
+		return x.CredentialScopes
+	}
+	return nil
+}
+
+func (x *GCS) GetJoinLineContinuations() bool {
+	if x != nil {
+		return x.JoinLineContinuations
+	}
+	return false
+}
+
+func (x *GCS) GetSkipArchivedObjects() bool {
+	if x != nil {
+		return x.SkipArchivedObjects
+	}
+	return false
+}
+
+func (x *GCS) GetDedupeObjects() bool {
+	if x != nil {
+		return x.DedupeObjects
+	}
+	return false
+}
+
+func (x *GCS) GetSoftDeletedObjects() bool {
+	if x != nil {
+		return x.SoftDeletedObjects
+	}
+	return false
+}
+
+func (x *GCS) GetSampleRate() float64 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *GCS) GetSampleSeed() int64 {
+	if x != nil {
+		return x.SampleSeed
+	}
+	return 0
+}
+
+func (x *GCS) GetTruncateLargeObjects() bool {
+	if x != nil {
+		return x.TruncateLargeObjects
+	}
+	return false
+}
+
+func (x *GCS) GetImpersonateServiceAccount() string {
+	if x != nil {
+		return x.ImpersonateServiceAccount
+	}
+	return ""
+}
+
+func (x *GCS) GetImpersonationDelegates() []string {
+	if x != nil {
+		return x.ImpersonationDelegates
+	}
+	return nil
+}
+
+func (x *GCS) GetInventoryManifest() string {
+	if x != nil {
+		return x.InventoryManifest
+	}
+	return ""
+}
+
+func (x *GCS) GetMinObjectSize() int64 {
+	if x != nil {
+		return x.MinObjectSize
+	}
+	return 0
+}
+
+func (x *GCS) GetPrefetchDepth() int32 {
+	if x != nil {
+		return x.PrefetchDepth
+	}
+	return 0
+}
+
+func (x *GCS) GetPrefetchMaxBytes() int64 {
+	if x != nil {
+		return x.PrefetchMaxBytes
+	}
+	return 0
+}
+
 type isGCS_Credential interface {
 	isGCS_Credential()
 }
@@ -1440,6 +1854,10 @@ type GCS_Oauth struct {
 	Oauth *credentialspb.Oauth2 `protobuf:"bytes,12,opt,name=oauth,proto3,oneof"`
 }
 
+type GCS_WorkloadIdentityFederationFile struct {
+	WorkloadIdentityFederationFile string `protobuf:"bytes,50,opt,name=workload_identity_federation_file,json=workloadIdentityFederationFile,proto3,oneof"` // path to the external account configuration of a Workload Identity Federation pool
+}
+
 func (*GCS_JsonServiceAccount) isGCS_Credential() {}
 
 func (*GCS_ApiKey) isGCS_Credential() {}
@@ -1452,6 +1870,176 @@ func (*GCS_ServiceAccountFile) isGCS_Credential() {}
 
 func (*GCS_Oauth) isGCS_Credential() {}
 
+func (*GCS_WorkloadIdentityFederationFile) isGCS_Credential() {}
+
+// GCSCredentialScope is a credential and the buckets it scans.
+type GCSCredentialScope struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Credential:
+	//
+	//	*GCSCredentialScope_JsonServiceAccount
+	//	*GCSCredentialScope_ApiKey
+	//	*GCSCredentialScope_Unauthenticated
+	//	*GCSCredentialScope_Adc
+	//	*GCSCredentialScope_ServiceAccountFile
+	//	*GCSCredentialScope_Oauth
+	//	*GCSCredentialScope_WorkloadIdentityFederationFile
+	Credential     isGCSCredentialScope_Credential `protobuf_oneof:"credential"`
+	ProjectId      string                          `protobuf:"bytes,7,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`                // defaults to the project_id of the GCS source
+	IncludeBuckets []string                        `protobuf:"bytes,8,rep,name=include_buckets,json=includeBuckets,proto3" json:"include_buckets,omitempty"` // buckets, or @path of a file listing one bucket per line
+}
+
+func (x *GCSCredentialScope) Reset() {
+	*x = GCSCredentialScope{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sources_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GCSCredentialScope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GCSCredentialScope) ProtoMessage() {}
+
+func (x *GCSCredentialScope) ProtoReflect() protoreflect.Message {
+	mi := &file_sources_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GCSCredentialScope.ProtoReflect.Descriptor instead.
+func (*GCSCredentialScope) Descriptor() ([]byte, []int) {
+	return file_sources_proto_rawDescGZIP(), []int{11}
+}
+
+func (m *GCSCredentialScope) GetCredential() isGCSCredentialScope_Credential {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (x *GCSCredentialScope) GetJsonServiceAccount() string {
+	if x, ok := x.GetCredential().(*GCSCredentialScope_JsonServiceAccount); ok {
+		return x.JsonServiceAccount
+	}
+	return ""
+}
+
+func (x *GCSCredentialScope) GetApiKey() string {
+	if x, ok := x.GetCredential().(*GCSCredentialScope_ApiKey); ok {
+		return x.ApiKey
+	}
+	return ""
+}
+
+func (x *GCSCredentialScope) GetUnauthenticated() *credentialspb.Unauthenticated {
+	if x, ok := x.GetCredential().(*GCSCredentialScope_Unauthenticated); ok {
+		return x.Unauthenticated
+	}
+	return nil
+}
+
+func (x *GCSCredentialScope) GetAdc() *credentialspb.CloudEnvironment {
+	if x, ok := x.GetCredential().(*GCSCredentialScope_Adc); ok {
+		return x.Adc
+	}
+	return nil
+}
+
+func (x *GCSCredentialScope) GetServiceAccountFile() string {
+	if x, ok := x.GetCredential().(*GCSCredentialScope_ServiceAccountFile); ok {
+		return x.ServiceAccountFile
+	}
+	return ""
+}
+
+func (x *GCSCredentialScope) GetOauth() *credentialspb.Oauth2 {
+	if x, ok := x.GetCredential().(*GCSCredentialScope_Oauth); ok {
+		return x.Oauth
+	}
+	return nil
+}
+
+func (x *GCSCredentialScope) GetWorkloadIdentityFederationFile() string {
+	if x, ok := x.GetCredential().(*GCSCredentialScope_WorkloadIdentityFederationFile); ok {
+		return x.WorkloadIdentityFederationFile
+	}
+	return ""
+}
+
+func (x *GCSCredentialScope) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *GCSCredentialScope) GetIncludeBuckets() []string {
+	if x != nil {
+		return x.IncludeBuckets
+	}
+	return nil
+}
+
+type isGCSCredentialScope_Credential interface {
+	isGCSCredentialScope_Credential()
+}
+
+type GCSCredentialScope_JsonServiceAccount struct {
+	JsonServiceAccount string `protobuf:"bytes,1,opt,name=json_service_account,json=jsonServiceAccount,proto3,oneof"`
+}
+
+type GCSCredentialScope_ApiKey struct {
+	ApiKey string `protobuf:"bytes,2,opt,name=api_key,json=apiKey,proto3,oneof"`
+}
+
+type GCSCredentialScope_Unauthenticated struct {
+	Unauthenticated *credentialspb.Unauthenticated `protobuf:"bytes,3,opt,name=unauthenticated,proto3,oneof"`
+}
+
+type GCSCredentialScope_Adc struct {
+	Adc *credentialspb.CloudEnvironment `protobuf:"bytes,4,opt,name=adc,proto3,oneof"`
+}
+
+type GCSCredentialScope_ServiceAccountFile struct {
+	ServiceAccountFile string `protobuf:"bytes,5,opt,name=service_account_file,json=serviceAccountFile,proto3,oneof"`
+}
+
+type GCSCredentialScope_Oauth struct {
+	Oauth *credentialspb.Oauth2 `protobuf:"bytes,6,opt,name=oauth,proto3,oneof"`
+}
+
+type GCSCredentialScope_WorkloadIdentityFederationFile struct {
+	WorkloadIdentityFederationFile string `protobuf:"bytes,9,opt,name=workload_identity_federation_file,json=workloadIdentityFederationFile,proto3,oneof"` // path to the external account configuration of a Workload Identity Federation pool
+}
+
+func (*GCSCredentialScope_JsonServiceAccount) isGCSCredentialScope_Credential() {}
+
+func (*GCSCredentialScope_ApiKey) isGCSCredentialScope_Credential() {}
+
+func (*GCSCredentialScope_Unauthenticated) isGCSCredentialScope_Credential() {}
+
+func (*GCSCredentialScope_Adc) isGCSCredentialScope_Credential() {}
+
+func (*GCSCredentialScope_ServiceAccountFile) isGCSCredentialScope_Credential() {}
+
+func (*GCSCredentialScope_Oauth) isGCSCredentialScope_Credential() {}
+
+func (*GCSCredentialScope_WorkloadIdentityFederationFile) isGCSCredentialScope_Credential() {}
+
 type Git struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1476,15 +2064,20 @@ type Git struct {
 	// whereas the repositories field is used by the enterprise config to specify multiple repositories.
 	// Passing a single repository via the uri field also allows for additional options to be specified
 	// like head, base, bare, etc.
-	Uri          string `protobuf:"bytes,13,opt,name=uri,proto3" json:"uri,omitempty"` // repository URL. https://, file://, or ssh://
-	SkipBinaries bool   `protobuf:"varint,14,opt,name=skip_binaries,json=skipBinaries,proto3" json:"skip_binaries,omitempty"`
-	SkipArchives bool   `protobuf:"varint,15,opt,name=skip_archives,json=skipArchives,proto3" json:"skip_archives,omitempty"`
+	Uri                        string   `protobuf:"bytes,13,opt,name=uri,proto3" json:"uri,omitempty"` // repository URL. https://, file://, or ssh://
+	SkipBinaries               bool     `protobuf:"varint,14,opt,name=skip_binaries,json=skipBinaries,proto3" json:"skip_binaries,omitempty"`
+	SkipArchives               bool     `protobuf:"varint,15,opt,name=skip_archives,json=skipArchives,proto3" json:"skip_archives,omitempty"`
+	MaxDepthPerBranch          int64    `protobuf:"varint,16,opt,name=max_depth_per_branch,json=maxDepthPerBranch,proto3" json:"max_depth_per_branch,omitempty"`                            // maximum number of commits to scan for each branch
+	PathAllowlist              []string `protobuf:"bytes,17,rep,name=path_allowlist,json=pathAllowlist,proto3" json:"path_allowlist,omitempty"`                                             // globs of file paths to skip
+	MaxArchiveDepth            int64    `protobuf:"varint,18,opt,name=max_archive_depth,json=maxArchiveDepth,proto3" json:"max_archive_depth,omitempty"`                                    // maximum depth of nested archives to extract
+	MaxArchiveDecompressedSize int64    `protobuf:"varint,19,opt,name=max_archive_decompressed_size,json=maxArchiveDecompressedSize,proto3" json:"max_archive_decompressed_size,omitempty"` // maximum total bytes to decompress from an archive
+	UseIgnoreFiles             bool     `protobuf:"varint,20,opt,name=use_ignore_files,json=useIgnoreFiles,proto3" json:"use_ignore_files,omitempty"`                                       // skip the paths listed by the .trufflehogignore files of the scanned commit
 }
 
 func (x *Git) Reset() {
 	*x = Git{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[11]
+		mi := &file_sources_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1497,7 +2090,7 @@ func (x *Git) String() string {
 func (*Git) ProtoMessage() {}
 
 func (x *Git) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[11]
+	mi := &file_sources_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1510,7 +2103,7 @@ func (x *Git) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Git.ProtoReflect.Descriptor instead.
 func (*Git) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{11}
+	return file_sources_proto_rawDescGZIP(), []int{12}
 }
 
 func (m *Git) GetCredential() isGit_Credential {
@@ -1625,6 +2218,41 @@ func (x *Git) GetSkipArchives() bool {
 	return false
 }
 
+func (x *Git) GetMaxDepthPerBranch() int64 {
+	if x != nil {
+		return x.MaxDepthPerBranch
+	}
+	return 0
+}
+
+func (x *Git) GetPathAllowlist() []string {
+	if x != nil {
+		return x.PathAllowlist
+	}
+	return nil
+}
+
+func (x *Git) GetMaxArchiveDepth() int64 {
+	if x != nil {
+		return x.MaxArchiveDepth
+	}
+	return 0
+}
+
+func (x *Git) GetMaxArchiveDecompressedSize() int64 {
+	if x != nil {
+		return x.MaxArchiveDecompressedSize
+	}
+	return 0
+}
+
+func (x *Git) GetUseIgnoreFiles() bool {
+	if x != nil {
+		return x.UseIgnoreFiles
+	}
+	return false
+}
+
 type isGit_Credential interface {
 	isGit_Credential()
 }
@@ -1668,7 +2296,7 @@ type GitLab struct {
 func (x *GitLab) Reset() {
 	*x = GitLab{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[12]
+		mi := &file_sources_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1681,7 +2309,7 @@ func (x *GitLab) String() string {
 func (*GitLab) ProtoMessage() {}
 
 func (x *GitLab) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[12]
+	mi := &file_sources_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1694,7 +2322,7 @@ func (x *GitLab) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GitLab.ProtoReflect.Descriptor instead.
 func (*GitLab) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{12}
+	return file_sources_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GitLab) GetEndpoint() string {
@@ -1814,7 +2442,7 @@ type GitHub struct {
 func (x *GitHub) Reset() {
 	*x = GitHub{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[13]
+		mi := &file_sources_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1827,7 +2455,7 @@ func (x *GitHub) String() string {
 func (*GitHub) ProtoMessage() {}
 
 func (x *GitHub) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[13]
+	mi := &file_sources_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1840,7 +2468,7 @@ func (x *GitHub) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GitHub.ProtoReflect.Descriptor instead.
 func (*GitHub) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{13}
+	return file_sources_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GitHub) GetEndpoint() string {
@@ -2025,7 +2653,7 @@ type GoogleDrive struct {
 func (x *GoogleDrive) Reset() {
 	*x = GoogleDrive{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[14]
+		mi := &file_sources_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2038,7 +2666,7 @@ func (x *GoogleDrive) String() string {
 func (*GoogleDrive) ProtoMessage() {}
 
 func (x *GoogleDrive) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[14]
+	mi := &file_sources_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2051,7 +2679,7 @@ func (x *GoogleDrive) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GoogleDrive.ProtoReflect.Descriptor instead.
 func (*GoogleDrive) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{14}
+	return file_sources_proto_rawDescGZIP(), []int{15}
 }
 
 func (m *GoogleDrive) GetCredential() isGoogleDrive_Credential {
@@ -2110,7 +2738,7 @@ type Huggingface struct {
 func (x *Huggingface) Reset() {
 	*x = Huggingface{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[15]
+		mi := &file_sources_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2123,7 +2751,7 @@ func (x *Huggingface) String() string {
 func (*Huggingface) ProtoMessage() {}
 
 func (x *Huggingface) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[15]
+	mi := &file_sources_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2136,7 +2764,7 @@ func (x *Huggingface) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Huggingface.ProtoReflect.Descriptor instead.
 func (*Huggingface) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{15}
+	return file_sources_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *Huggingface) GetEndpoint() string {
@@ -2316,7 +2944,7 @@ type JIRA struct {
 func (x *JIRA) Reset() {
 	*x = JIRA{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[16]
+		mi := &file_sources_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2329,7 +2957,7 @@ func (x *JIRA) String() string {
 func (*JIRA) ProtoMessage() {}
 
 func (x *JIRA) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[16]
+	mi := &file_sources_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2342,7 +2970,7 @@ func (x *JIRA) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JIRA.ProtoReflect.Descriptor instead.
 func (*JIRA) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{16}
+	return file_sources_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *JIRA) GetEndpoint() string {
@@ -2450,7 +3078,7 @@ type NPMUnauthenticatedPackage struct {
 func (x *NPMUnauthenticatedPackage) Reset() {
 	*x = NPMUnauthenticatedPackage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[17]
+		mi := &file_sources_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2463,7 +3091,7 @@ func (x *NPMUnauthenticatedPackage) String() string {
 func (*NPMUnauthenticatedPackage) ProtoMessage() {}
 
 func (x *NPMUnauthenticatedPackage) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[17]
+	mi := &file_sources_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2476,7 +3104,7 @@ func (x *NPMUnauthenticatedPackage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NPMUnauthenticatedPackage.ProtoReflect.Descriptor instead.
 func (*NPMUnauthenticatedPackage) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{17}
+	return file_sources_proto_rawDescGZIP(), []int{18}
 }
 
 func (m *NPMUnauthenticatedPackage) GetCredential() isNPMUnauthenticatedPackage_Credential {
@@ -2517,7 +3145,7 @@ type PyPIUnauthenticatedPackage struct {
 func (x *PyPIUnauthenticatedPackage) Reset() {
 	*x = PyPIUnauthenticatedPackage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[18]
+		mi := &file_sources_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2530,7 +3158,7 @@ func (x *PyPIUnauthenticatedPackage) String() string {
 func (*PyPIUnauthenticatedPackage) ProtoMessage() {}
 
 func (x *PyPIUnauthenticatedPackage) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[18]
+	mi := &file_sources_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2543,7 +3171,7 @@ func (x *PyPIUnauthenticatedPackage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PyPIUnauthenticatedPackage.ProtoReflect.Descriptor instead.
 func (*PyPIUnauthenticatedPackage) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{18}
+	return file_sources_proto_rawDescGZIP(), []int{19}
 }
 
 func (m *PyPIUnauthenticatedPackage) GetCredential() isPyPIUnauthenticatedPackage_Credential {
@@ -2586,12 +3214,15 @@ type S3 struct {
 	MaxObjectSize int64           `protobuf:"varint,6,opt,name=max_object_size,json=maxObjectSize,proto3" json:"max_object_size,omitempty"`
 	Roles         []string        `protobuf:"bytes,7,rep,name=roles,proto3" json:"roles,omitempty"`
 	IgnoreBuckets []string        `protobuf:"bytes,8,rep,name=ignore_buckets,json=ignoreBuckets,proto3" json:"ignore_buckets,omitempty"`
+	ScanPaths     bool            `protobuf:"varint,9,opt,name=scan_paths,json=scanPaths,proto3" json:"scan_paths,omitempty"` // also scan the keys of objects for secrets
+
+	RestoreArchivedObjects bool `protobuf:"varint,10,opt,name=restore_archived_objects,json=restoreArchivedObjects,proto3" json:"restore_archived_objects,omitempty"` // request the restore of objects in the GLACIER and DEEP_ARCHIVE storage classes, and scan them once restored, instead of skipping them
 }
 
 func (x *S3) Reset() {
 	*x = S3{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[19]
+		mi := &file_sources_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2604,7 +3235,7 @@ func (x *S3) String() string {
 func (*S3) ProtoMessage() {}
 
 func (x *S3) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[19]
+	mi := &file_sources_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2617,7 +3248,7 @@ func (x *S3) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use S3.ProtoReflect.Descriptor instead.
 func (*S3) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{19}
+	return file_sources_proto_rawDescGZIP(), []int{20}
 }
 
 func (m *S3) GetCredential() isS3_Credential {
@@ -2683,6 +3314,20 @@ func (x *S3) GetIgnoreBuckets() []string {
 	return nil
 }
 
+func (x *S3) GetScanPaths() bool {
+	if x != nil {
+		return x.ScanPaths
+	}
+	return false
+}
+
+func (x *S3) GetRestoreArchivedObjects() bool {
+	if x != nil {
+		return x.RestoreArchivedObjects
+	}
+	return false
+}
+
 type isS3_Credential interface {
 	isS3_Credential()
 }
@@ -2729,7 +3374,7 @@ type Slack struct {
 func (x *Slack) Reset() {
 	*x = Slack{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[20]
+		mi := &file_sources_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2742,7 +3387,7 @@ func (x *Slack) String() string {
 func (*Slack) ProtoMessage() {}
 
 func (x *Slack) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[20]
+	mi := &file_sources_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2755,7 +3400,7 @@ func (x *Slack) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Slack.ProtoReflect.Descriptor instead.
 func (*Slack) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{20}
+	return file_sources_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *Slack) GetEndpoint() string {
@@ -2825,7 +3470,7 @@ type Test struct {
 func (x *Test) Reset() {
 	*x = Test{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[21]
+		mi := &file_sources_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2838,7 +3483,7 @@ func (x *Test) String() string {
 func (*Test) ProtoMessage() {}
 
 func (x *Test) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[21]
+	mi := &file_sources_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2851,7 +3496,7 @@ func (x *Test) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Test.ProtoReflect.Descriptor instead.
 func (*Test) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{21}
+	return file_sources_proto_rawDescGZIP(), []int{22}
 }
 
 type Buildkite struct {
@@ -2868,7 +3513,7 @@ type Buildkite struct {
 func (x *Buildkite) Reset() {
 	*x = Buildkite{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[22]
+		mi := &file_sources_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2881,7 +3526,7 @@ func (x *Buildkite) String() string {
 func (*Buildkite) ProtoMessage() {}
 
 func (x *Buildkite) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[22]
+	mi := &file_sources_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2894,7 +3539,7 @@ func (x *Buildkite) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Buildkite.ProtoReflect.Descriptor instead.
 func (*Buildkite) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{22}
+	return file_sources_proto_rawDescGZIP(), []int{23}
 }
 
 func (m *Buildkite) GetCredential() isBuildkite_Credential {
@@ -2940,7 +3585,7 @@ type Gerrit struct {
 func (x *Gerrit) Reset() {
 	*x = Gerrit{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[23]
+		mi := &file_sources_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2953,7 +3598,7 @@ func (x *Gerrit) String() string {
 func (*Gerrit) ProtoMessage() {}
 
 func (x *Gerrit) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[23]
+	mi := &file_sources_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2966,7 +3611,7 @@ func (x *Gerrit) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Gerrit.ProtoReflect.Descriptor instead.
 func (*Gerrit) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{23}
+	return file_sources_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *Gerrit) GetEndpoint() string {
@@ -3052,7 +3697,7 @@ type Jenkins struct {
 func (x *Jenkins) Reset() {
 	*x = Jenkins{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[24]
+		mi := &file_sources_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3065,7 +3710,7 @@ func (x *Jenkins) String() string {
 func (*Jenkins) ProtoMessage() {}
 
 func (x *Jenkins) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[24]
+	mi := &file_sources_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3078,7 +3723,7 @@ func (x *Jenkins) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Jenkins.ProtoReflect.Descriptor instead.
 func (*Jenkins) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{24}
+	return file_sources_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *Jenkins) GetEndpoint() string {
@@ -3165,7 +3810,7 @@ type Teams struct {
 func (x *Teams) Reset() {
 	*x = Teams{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[25]
+		mi := &file_sources_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3178,7 +3823,7 @@ func (x *Teams) String() string {
 func (*Teams) ProtoMessage() {}
 
 func (x *Teams) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[25]
+	mi := &file_sources_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3191,7 +3836,7 @@ func (x *Teams) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Teams.ProtoReflect.Descriptor instead.
 func (*Teams) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{25}
+	return file_sources_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *Teams) GetEndpoint() string {
@@ -3287,7 +3932,7 @@ type Syslog struct {
 func (x *Syslog) Reset() {
 	*x = Syslog{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[26]
+		mi := &file_sources_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3300,7 +3945,7 @@ func (x *Syslog) String() string {
 func (*Syslog) ProtoMessage() {}
 
 func (x *Syslog) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[26]
+	mi := &file_sources_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3313,7 +3958,7 @@ func (x *Syslog) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Syslog.ProtoReflect.Descriptor instead.
 func (*Syslog) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{26}
+	return file_sources_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *Syslog) GetProtocol() string {
@@ -3368,7 +4013,7 @@ type Forager struct {
 func (x *Forager) Reset() {
 	*x = Forager{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[27]
+		mi := &file_sources_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3381,7 +4026,7 @@ func (x *Forager) String() string {
 func (*Forager) ProtoMessage() {}
 
 func (x *Forager) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[27]
+	mi := &file_sources_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3394,7 +4039,7 @@ func (x *Forager) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Forager.ProtoReflect.Descriptor instead.
 func (*Forager) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{27}
+	return file_sources_proto_rawDescGZIP(), []int{28}
 }
 
 func (m *Forager) GetCredential() isForager_Credential {
@@ -3456,7 +4101,7 @@ type SlackRealtime struct {
 func (x *SlackRealtime) Reset() {
 	*x = SlackRealtime{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[28]
+		mi := &file_sources_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3469,7 +4114,7 @@ func (x *SlackRealtime) String() string {
 func (*SlackRealtime) ProtoMessage() {}
 
 func (x *SlackRealtime) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[28]
+	mi := &file_sources_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3482,7 +4127,7 @@ func (x *SlackRealtime) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SlackRealtime.ProtoReflect.Descriptor instead.
 func (*SlackRealtime) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{28}
+	return file_sources_proto_rawDescGZIP(), []int{29}
 }
 
 func (m *SlackRealtime) GetCredential() isSlackRealtime_Credential {
@@ -3524,7 +4169,7 @@ type Sharepoint struct {
 func (x *Sharepoint) Reset() {
 	*x = Sharepoint{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[29]
+		mi := &file_sources_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3537,7 +4182,7 @@ func (x *Sharepoint) String() string {
 func (*Sharepoint) ProtoMessage() {}
 
 func (x *Sharepoint) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[29]
+	mi := &file_sources_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3550,7 +4195,7 @@ func (x *Sharepoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Sharepoint.ProtoReflect.Descriptor instead.
 func (*Sharepoint) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{29}
+	return file_sources_proto_rawDescGZIP(), []int{30}
 }
 
 func (m *Sharepoint) GetCredential() isSharepoint_Credential {
@@ -3610,7 +4255,7 @@ type AzureRepos struct {
 func (x *AzureRepos) Reset() {
 	*x = AzureRepos{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[30]
+		mi := &file_sources_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3623,7 +4268,7 @@ func (x *AzureRepos) String() string {
 func (*AzureRepos) ProtoMessage() {}
 
 func (x *AzureRepos) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[30]
+	mi := &file_sources_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3636,7 +4281,7 @@ func (x *AzureRepos) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AzureRepos.ProtoReflect.Descriptor instead.
 func (*AzureRepos) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{30}
+	return file_sources_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *AzureRepos) GetEndpoint() string {
@@ -3779,7 +4424,7 @@ type Postman struct {
 func (x *Postman) Reset() {
 	*x = Postman{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[31]
+		mi := &file_sources_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3792,7 +4437,7 @@ func (x *Postman) String() string {
 func (*Postman) ProtoMessage() {}
 
 func (x *Postman) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[31]
+	mi := &file_sources_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3805,7 +4450,7 @@ func (x *Postman) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Postman.ProtoReflect.Descriptor instead.
 func (*Postman) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{31}
+	return file_sources_proto_rawDescGZIP(), []int{32}
 }
 
 func (m *Postman) GetCredential() isPostman_Credential {
@@ -3937,7 +4582,7 @@ type Webhook struct {
 func (x *Webhook) Reset() {
 	*x = Webhook{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[32]
+		mi := &file_sources_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3950,7 +4595,7 @@ func (x *Webhook) String() string {
 func (*Webhook) ProtoMessage() {}
 
 func (x *Webhook) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[32]
+	mi := &file_sources_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3963,7 +4608,7 @@ func (x *Webhook) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
 func (*Webhook) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{32}
+	return file_sources_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *Webhook) GetListenAddress() string {
@@ -4014,23 +4659,308 @@ type Elasticsearch struct {
 	BestEffortScan bool     `protobuf:"varint,10,opt,name=best_effort_scan,json=bestEffortScan,proto3" json:"best_effort_scan,omitempty"`
 }
 
-func (x *Elasticsearch) Reset() {
-	*x = Elasticsearch{}
+func (x *Elasticsearch) Reset() {
+	*x = Elasticsearch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sources_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Elasticsearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Elasticsearch) ProtoMessage() {}
+
+func (x *Elasticsearch) ProtoReflect() protoreflect.Message {
+	mi := &file_sources_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Elasticsearch.ProtoReflect.Descriptor instead.
+func (*Elasticsearch) Descriptor() ([]byte, []int) {
+	return file_sources_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Elasticsearch) GetNodes() []string {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *Elasticsearch) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Elasticsearch) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *Elasticsearch) GetCloudId() string {
+	if x != nil {
+		return x.CloudId
+	}
+	return ""
+}
+
+func (x *Elasticsearch) GetApiKey() string {
+	if x != nil {
+		return x.ApiKey
+	}
+	return ""
+}
+
+func (x *Elasticsearch) GetServiceToken() string {
+	if x != nil {
+		return x.ServiceToken
+	}
+	return ""
+}
+
+func (x *Elasticsearch) GetIndexPattern() string {
+	if x != nil {
+		return x.IndexPattern
+	}
+	return ""
+}
+
+func (x *Elasticsearch) GetQueryJson() string {
+	if x != nil {
+		return x.QueryJson
+	}
+	return ""
+}
+
+func (x *Elasticsearch) GetSinceTimestamp() string {
+	if x != nil {
+		return x.SinceTimestamp
+	}
+	return ""
+}
+
+func (x *Elasticsearch) GetBestEffortScan() bool {
+	if x != nil {
+		return x.BestEffortScan
+	}
+	return false
+}
+
+type AzureBlob struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Credential:
+	//
+	//	*AzureBlob_ConnectionString
+	//	*AzureBlob_SasToken
+	//	*AzureBlob_ManagedIdentity
+	Credential              isAzureBlob_Credential `protobuf_oneof:"credential"`
+	AccountUrl              string                 `protobuf:"bytes,4,opt,name=account_url,json=accountUrl,proto3" json:"account_url,omitempty"`                                            // blob service URL, e.g. https://account.blob.core.windows.net. Required unless a connection string is used.
+	ManagedIdentityClientId string                 `protobuf:"bytes,5,opt,name=managed_identity_client_id,json=managedIdentityClientId,proto3" json:"managed_identity_client_id,omitempty"` // client ID of a user-assigned managed identity, if any
+	IncludeContainers       []string               `protobuf:"bytes,6,rep,name=include_containers,json=includeContainers,proto3" json:"include_containers,omitempty"`
+	ExcludeContainers       []string               `protobuf:"bytes,7,rep,name=exclude_containers,json=excludeContainers,proto3" json:"exclude_containers,omitempty"`
+	IncludeBlobs            []string               `protobuf:"bytes,8,rep,name=include_blobs,json=includeBlobs,proto3" json:"include_blobs,omitempty"`
+	ExcludeBlobs            []string               `protobuf:"bytes,9,rep,name=exclude_blobs,json=excludeBlobs,proto3" json:"exclude_blobs,omitempty"`
+	MaxBlobSize             int64                  `protobuf:"varint,10,opt,name=max_blob_size,json=maxBlobSize,proto3" json:"max_blob_size,omitempty"`
+	SkipBinaries            bool                   `protobuf:"varint,11,opt,name=skip_binaries,json=skipBinaries,proto3" json:"skip_binaries,omitempty"`
+	DisableArchiveHandling  bool                   `protobuf:"varint,12,opt,name=disable_archive_handling,json=disableArchiveHandling,proto3" json:"disable_archive_handling,omitempty"` // read blobs as plain bytes without detecting or extracting archives
+}
+
+func (x *AzureBlob) Reset() {
+	*x = AzureBlob{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sources_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AzureBlob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AzureBlob) ProtoMessage() {}
+
+func (x *AzureBlob) ProtoReflect() protoreflect.Message {
+	mi := &file_sources_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AzureBlob.ProtoReflect.Descriptor instead.
+func (*AzureBlob) Descriptor() ([]byte, []int) {
+	return file_sources_proto_rawDescGZIP(), []int{35}
+}
+
+func (m *AzureBlob) GetCredential() isAzureBlob_Credential {
+	if m != nil {
+		return m.Credential
+	}
+	return nil
+}
+
+func (x *AzureBlob) GetConnectionString() string {
+	if x, ok := x.GetCredential().(*AzureBlob_ConnectionString); ok {
+		return x.ConnectionString
+	}
+	return ""
+}
+
+func (x *AzureBlob) GetSasToken() string {
+	if x, ok := x.GetCredential().(*AzureBlob_SasToken); ok {
+		return x.SasToken
+	}
+	return ""
+}
+
+func (x *AzureBlob) GetManagedIdentity() *credentialspb.CloudEnvironment {
+	if x, ok := x.GetCredential().(*AzureBlob_ManagedIdentity); ok {
+		return x.ManagedIdentity
+	}
+	return nil
+}
+
+func (x *AzureBlob) GetAccountUrl() string {
+	if x != nil {
+		return x.AccountUrl
+	}
+	return ""
+}
+
+func (x *AzureBlob) GetManagedIdentityClientId() string {
+	if x != nil {
+		return x.ManagedIdentityClientId
+	}
+	return ""
+}
+
+func (x *AzureBlob) GetIncludeContainers() []string {
+	if x != nil {
+		return x.IncludeContainers
+	}
+	return nil
+}
+
+func (x *AzureBlob) GetExcludeContainers() []string {
+	if x != nil {
+		return x.ExcludeContainers
+	}
+	return nil
+}
+
+func (x *AzureBlob) GetIncludeBlobs() []string {
+	if x != nil {
+		return x.IncludeBlobs
+	}
+	return nil
+}
+
+func (x *AzureBlob) GetExcludeBlobs() []string {
+	if x != nil {
+		return x.ExcludeBlobs
+	}
+	return nil
+}
+
+func (x *AzureBlob) GetMaxBlobSize() int64 {
+	if x != nil {
+		return x.MaxBlobSize
+	}
+	return 0
+}
+
+func (x *AzureBlob) GetSkipBinaries() bool {
+	if x != nil {
+		return x.SkipBinaries
+	}
+	return false
+}
+
+func (x *AzureBlob) GetDisableArchiveHandling() bool {
+	if x != nil {
+		return x.DisableArchiveHandling
+	}
+	return false
+}
+
+type isAzureBlob_Credential interface {
+	isAzureBlob_Credential()
+}
+
+type AzureBlob_ConnectionString struct {
+	ConnectionString string `protobuf:"bytes,1,opt,name=connection_string,json=connectionString,proto3,oneof"`
+}
+
+type AzureBlob_SasToken struct {
+	SasToken string `protobuf:"bytes,2,opt,name=sas_token,json=sasToken,proto3,oneof"`
+}
+
+type AzureBlob_ManagedIdentity struct {
+	ManagedIdentity *credentialspb.CloudEnvironment `protobuf:"bytes,3,opt,name=managed_identity,json=managedIdentity,proto3,oneof"`
+}
+
+func (*AzureBlob_ConnectionString) isAzureBlob_Credential() {}
+
+func (*AzureBlob_SasToken) isAzureBlob_Credential() {}
+
+func (*AzureBlob_ManagedIdentity) isAzureBlob_Credential() {}
+
+type GCPSecretManager struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Credential:
+	//
+	//	*GCPSecretManager_JsonServiceAccount
+	//	*GCPSecretManager_ServiceAccountFile
+	//	*GCPSecretManager_Adc
+	Credential     isGCPSecretManager_Credential `protobuf_oneof:"credential"`
+	ProjectId      string                        `protobuf:"bytes,4,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	IncludeSecrets []string                      `protobuf:"bytes,5,rep,name=include_secrets,json=includeSecrets,proto3" json:"include_secrets,omitempty"`
+	ExcludeSecrets []string                      `protobuf:"bytes,6,rep,name=exclude_secrets,json=excludeSecrets,proto3" json:"exclude_secrets,omitempty"`
+}
+
+func (x *GCPSecretManager) Reset() {
+	*x = GCPSecretManager{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sources_proto_msgTypes[33]
+		mi := &file_sources_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Elasticsearch) String() string {
+func (x *GCPSecretManager) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Elasticsearch) ProtoMessage() {}
+func (*GCPSecretManager) ProtoMessage() {}
 
-func (x *Elasticsearch) ProtoReflect() protoreflect.Message {
-	mi := &file_sources_proto_msgTypes[33]
+func (x *GCPSecretManager) ProtoReflect() protoreflect.Message {
+	mi := &file_sources_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4041,79 +4971,127 @@ func (x *Elasticsearch) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Elasticsearch.ProtoReflect.Descriptor instead.
-func (*Elasticsearch) Descriptor() ([]byte, []int) {
-	return file_sources_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use GCPSecretManager.ProtoReflect.Descriptor instead.
+func (*GCPSecretManager) Descriptor() ([]byte, []int) {
+	return file_sources_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *Elasticsearch) GetNodes() []string {
-	if x != nil {
-		return x.Nodes
+func (m *GCPSecretManager) GetCredential() isGCPSecretManager_Credential {
+	if m != nil {
+		return m.Credential
 	}
 	return nil
 }
 
-func (x *Elasticsearch) GetUsername() string {
-	if x != nil {
-		return x.Username
+func (x *GCPSecretManager) GetJsonServiceAccount() string {
+	if x, ok := x.GetCredential().(*GCPSecretManager_JsonServiceAccount); ok {
+		return x.JsonServiceAccount
 	}
 	return ""
 }
 
-func (x *Elasticsearch) GetPassword() string {
-	if x != nil {
-		return x.Password
+func (x *GCPSecretManager) GetServiceAccountFile() string {
+	if x, ok := x.GetCredential().(*GCPSecretManager_ServiceAccountFile); ok {
+		return x.ServiceAccountFile
 	}
 	return ""
 }
 
-func (x *Elasticsearch) GetCloudId() string {
-	if x != nil {
-		return x.CloudId
+func (x *GCPSecretManager) GetAdc() *credentialspb.CloudEnvironment {
+	if x, ok := x.GetCredential().(*GCPSecretManager_Adc); ok {
+		return x.Adc
 	}
-	return ""
+	return nil
 }
 
-func (x *Elasticsearch) GetApiKey() string {
+func (x *GCPSecretManager) GetProjectId() string {
 	if x != nil {
-		return x.ApiKey
+		return x.ProjectId
 	}
 	return ""
 }
 
-func (x *Elasticsearch) GetServiceToken() string {
+func (x *GCPSecretManager) GetIncludeSecrets() []string {
 	if x != nil {
-		return x.ServiceToken
+		return x.IncludeSecrets
 	}
-	return ""
+	return nil
 }
 
-func (x *Elasticsearch) GetIndexPattern() string {
+func (x *GCPSecretManager) GetExcludeSecrets() []string {
 	if x != nil {
-		return x.IndexPattern
+		return x.ExcludeSecrets
 	}
-	return ""
+	return nil
 }
 
-func (x *Elasticsearch) GetQueryJson() string {
-	if x != nil {
-		return x.QueryJson
+type isGCPSecretManager_Credential interface {
+	isGCPSecretManager_Credential()
+}
+
+type GCPSecretManager_JsonServiceAccount struct {
+	JsonServiceAccount string `protobuf:"bytes,1,opt,name=json_service_account,json=jsonServiceAccount,proto3,oneof"`
+}
+
+type GCPSecretManager_ServiceAccountFile struct {
+	ServiceAccountFile string `protobuf:"bytes,2,opt,name=service_account_file,json=serviceAccountFile,proto3,oneof"`
+}
+
+type GCPSecretManager_Adc struct {
+	Adc *credentialspb.CloudEnvironment `protobuf:"bytes,3,opt,name=adc,proto3,oneof"`
+}
+
+func (*GCPSecretManager_JsonServiceAccount) isGCPSecretManager_Credential() {}
+
+func (*GCPSecretManager_ServiceAccountFile) isGCPSecretManager_Credential() {}
+
+func (*GCPSecretManager_Adc) isGCPSecretManager_Credential() {}
+
+type Stdin struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // labels the input in the metadata of its findings, "stdin" if empty
+}
+
+func (x *Stdin) Reset() {
+	*x = Stdin{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sources_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *Elasticsearch) GetSinceTimestamp() string {
-	if x != nil {
-		return x.SinceTimestamp
+func (x *Stdin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stdin) ProtoMessage() {}
+
+func (x *Stdin) ProtoReflect() protoreflect.Message {
+	mi := &file_sources_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Elasticsearch) GetBestEffortScan() bool {
+// Deprecated: Use Stdin.ProtoReflect.Descriptor instead.
+func (*Stdin) Descriptor() ([]byte, []int) {
+	return file_sources_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Stdin) GetName() string {
 	if x != nil {
-		return x.BestEffortScan
+		return x.Name
 	}
-	return false
+	return ""
 }
 
 var File_sources_proto protoreflect.FileDescriptor
@@ -4274,7 +5252,7 @@ var file_sources_proto_rawDesc = []byte{
 	0x4b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x69, 0x65,
 	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
 	0x69, 0x65, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
-	0x6c, 0x22, 0xa0, 0x01, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x6c, 0x22, 0x82, 0x04, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
 	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18,
 	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69,
 	0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
@@ -4284,77 +5262,272 @@ var file_sources_proto_rawDesc = []byte{
 	0x68, 0x73, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64,
 	0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x10, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73,
-	0x46, 0x69, 0x6c, 0x65, 0x22, 0xab, 0x04, 0x0a, 0x03, 0x47, 0x43, 0x53, 0x12, 0x32, 0x0a, 0x14,
-	0x6a, 0x73, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x63, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x12, 0x6a, 0x73,
-	0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x12, 0x19, 0x0a, 0x07, 0x61, 0x70, 0x69, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x00, 0x52, 0x06, 0x61, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x12, 0x48, 0x0a, 0x0f, 0x75,
-	0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
-	0x6c, 0x73, 0x2e, 0x55, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74,
-	0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x75, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69,
-	0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x31, 0x0a, 0x03, 0x61, 0x64, 0x63, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73,
-	0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e,
-	0x74, 0x48, 0x00, 0x52, 0x03, 0x61, 0x64, 0x63, 0x12, 0x32, 0x0a, 0x14, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x66, 0x69, 0x6c, 0x65,
-	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x12, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x2b, 0x0a, 0x05,
-	0x6f, 0x61, 0x75, 0x74, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x72,
-	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x4f, 0x61, 0x75, 0x74, 0x68, 0x32,
-	0x48, 0x00, 0x52, 0x05, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f,
-	0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70,
-	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c,
-	0x75, 0x64, 0x65, 0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74,
-	0x73, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x62, 0x75, 0x63,
-	0x6b, 0x65, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x78, 0x63, 0x6c,
-	0x75, 0x64, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e,
-	0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x08, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4f, 0x62, 0x6a, 0x65,
-	0x63, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6f,
-	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x78,
-	0x63, 0x6c, 0x75, 0x64, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f,
-	0x6d, 0x61, 0x78, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
-	0x53, 0x69, 0x7a, 0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x61, 0x6c, 0x22, 0xc5, 0x04, 0x0a, 0x03, 0x47, 0x69, 0x74, 0x12, 0x37, 0x0a, 0x0a, 0x62, 0x61,
-	0x73, 0x69, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
-	0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x42, 0x61, 0x73,
-	0x69, 0x63, 0x41, 0x75, 0x74, 0x68, 0x48, 0x00, 0x52, 0x09, 0x62, 0x61, 0x73, 0x69, 0x63, 0x41,
-	0x75, 0x74, 0x68, 0x12, 0x48, 0x0a, 0x0f, 0x75, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
-	0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63,
-	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x55, 0x6e, 0x61, 0x75, 0x74,
-	0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x75, 0x6e,
-	0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x31, 0x0a,
-	0x08, 0x73, 0x73, 0x68, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x14, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x53, 0x53,
-	0x48, 0x41, 0x75, 0x74, 0x68, 0x48, 0x00, 0x52, 0x07, 0x73, 0x73, 0x68, 0x41, 0x75, 0x74, 0x68,
-	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18,
-	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69,
-	0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69,
-	0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69,
-	0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61,
-	0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x62, 0x61, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x62, 0x61,
-	0x72, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x70, 0x61,
-	0x74, 0x68, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
-	0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x46, 0x69, 0x6c, 0x65,
-	0x12, 0x2c, 0x0a, 0x12, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68,
-	0x73, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x65, 0x78,
-	0x63, 0x6c, 0x75, 0x64, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x23,
-	0x0a, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x67, 0x6c, 0x6f, 0x62, 0x73, 0x18,
-	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x47, 0x6c,
-	0x6f, 0x62, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68,
-	0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x44, 0x65, 0x70, 0x74, 0x68,
-	0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75,
-	0x72, 0x69, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72,
-	0x69, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x6b, 0x69, 0x70, 0x42,
-	0x69, 0x6e, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x5f,
-	0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
-	0x73, 0x6b, 0x69, 0x70, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x73, 0x42, 0x0c, 0x0a, 0x0a,
+	0x46, 0x69, 0x6c, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x61,
+	0x74, 0x68, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73,
+	0x6b, 0x69, 0x70, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x69, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0c, 0x73, 0x6b, 0x69, 0x70, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x69, 0x65, 0x73,
+	0x12, 0x3b, 0x0a, 0x1a, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x5f,
+	0x62, 0x79, 0x74, 0x65, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x17, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x4e, 0x75, 0x6c, 0x6c,
+	0x42, 0x79, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x2f, 0x0a,
+	0x13, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x1d,
+	0x0a, 0x0a, 0x73, 0x63, 0x61, 0x6e, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x09, 0x73, 0x63, 0x61, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x36, 0x0a,
+	0x17, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x69,
+	0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15,
+	0x6a, 0x6f, 0x69, 0x6e, 0x4c, 0x69, 0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x75, 0x73, 0x65, 0x5f, 0x69, 0x67, 0x6e,
+	0x6f, 0x72, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0e, 0x75, 0x73, 0x65, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12,
+	0x25, 0x0a, 0x0e, 0x6d, 0x6d, 0x61, 0x70, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c,
+	0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x6d, 0x61, 0x70, 0x54, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x22, 0xa1, 0x14, 0x0a, 0x03, 0x47, 0x43, 0x53, 0x12, 0x32,
+	0x0a, 0x14, 0x6a, 0x73, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x12,
+	0x6a, 0x73, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x19, 0x0a, 0x07, 0x61, 0x70, 0x69, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x61, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x12, 0x48, 0x0a,
+	0x0f, 0x75, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x73, 0x2e, 0x55, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x75, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e,
+	0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x31, 0x0a, 0x03, 0x61, 0x64, 0x63, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x73, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x03, 0x61, 0x64, 0x63, 0x12, 0x32, 0x0a, 0x14, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x66, 0x69,
+	0x6c, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x12, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x2b,
+	0x0a, 0x05, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x4f, 0x61, 0x75, 0x74,
+	0x68, 0x32, 0x48, 0x00, 0x52, 0x05, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x12, 0x4b, 0x0a, 0x21, 0x77,
+	0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x5f, 0x66, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x65,
+	0x18, 0x32, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x1e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f,
+	0x61, 0x64, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x62, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x78, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x78, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6d,
+	0x61, 0x78, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x62, 0x69, 0x6e, 0x61,
+	0x72, 0x69, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x6b, 0x69, 0x70,
+	0x42, 0x69, 0x6e, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x3b, 0x0a, 0x1a, 0x62, 0x69, 0x6e, 0x61,
+	0x72, 0x79, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x5f, 0x74, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x52, 0x17, 0x62, 0x69,
+	0x6e, 0x61, 0x72, 0x79, 0x4e, 0x75, 0x6c, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f,
+	0x75, 0x72, 0x6c, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x55, 0x72, 0x6c, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x5f, 0x75, 0x72, 0x6c, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x55, 0x72, 0x6c, 0x73, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x38, 0x0a, 0x18, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x61, 0x72, 0x63, 0x68,
+	0x69, 0x76, 0x65, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x16, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x41, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x61,
+	0x78, 0x5f, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18,
+	0x12, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76,
+	0x65, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x41, 0x0a, 0x1d, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x72,
+	0x63, 0x68, 0x69, 0x76, 0x65, 0x5f, 0x64, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x03, 0x52, 0x1a, 0x6d,
+	0x61, 0x78, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x49, 0x0a, 0x13, 0x6f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x11, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73,
+	0x18, 0x15, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73,
+	0x12, 0x29, 0x0a, 0x10, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x69, 0x6e, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x33, 0x0a, 0x16, 0x6d,
+	0x61, 0x78, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x62,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x6d, 0x61, 0x78,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x50, 0x65, 0x72, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74,
+	0x12, 0x25, 0x0a, 0x0e, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x73, 0x18, 0x18, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x72, 0x61, 0x6e, 0x67, 0x65,
+	0x64, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x18, 0x19, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x52, 0x65,
+	0x61, 0x64, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x36, 0x0a, 0x17, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x15, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x64, 0x52, 0x65, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x12, 0x37, 0x0a, 0x18, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x65, 0x5f,
+	0x6f, 0x6e, 0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x1b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x65, 0x4f,
+	0x6e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x30, 0x0a, 0x14,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x61, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x20,
+	0x0a, 0x0b, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x18, 0x1d, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x6c,
+	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x63, 0x61, 0x6e, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x1e,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x63, 0x61, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x6e, 0x64, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x6e, 0x64, 0x6a, 0x73, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x5f, 0x69, 0x61, 0x6d, 0x18, 0x20, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x62, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x49, 0x61, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x18,
+	0x21, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x12, 0x35, 0x0a, 0x17,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x73, 0x63, 0x61, 0x6e, 0x18, 0x22, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x4c, 0x61, 0x73, 0x74, 0x53,
+	0x63, 0x61, 0x6e, 0x12, 0x45, 0x0a, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x63, 0x61, 0x6e,
+	0x5f, 0x6f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x70, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x53,
+	0x63, 0x61, 0x6e, 0x4f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x70, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x72,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x24,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x64,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x25,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x65, 0x78, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x18, 0x26, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x41, 0x0a,
+	0x1d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e,
+	0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x27,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x1a, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x55, 0x6e, 0x6b,
+	0x6e, 0x6f, 0x77, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73,
+	0x12, 0x48, 0x0a, 0x11, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x73,
+	0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x28, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x47, 0x43, 0x53, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x10, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x61, 0x6c, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x6a, 0x6f,
+	0x69, 0x6e, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x29, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x6a, 0x6f, 0x69,
+	0x6e, 0x4c, 0x69, 0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x61, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x64, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x2a, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x13, 0x73, 0x6b, 0x69, 0x70, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x64, 0x75, 0x70, 0x65,
+	0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d,
+	0x64, 0x65, 0x64, 0x75, 0x70, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x30, 0x0a,
+	0x14, 0x73, 0x6f, 0x66, 0x74, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x6f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x2c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x73, 0x6f, 0x66,
+	0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12,
+	0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x2d,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x73, 0x65, 0x65, 0x64, 0x18,
+	0x2e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x53, 0x65, 0x65,
+	0x64, 0x12, 0x34, 0x0a, 0x16, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x61,
+	0x72, 0x67, 0x65, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x2f, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x14, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x4c, 0x61, 0x72, 0x67, 0x65,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x3e, 0x0a, 0x1b, 0x69, 0x6d, 0x70, 0x65, 0x72,
+	0x73, 0x6f, 0x6e, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x30, 0x20, 0x01, 0x28, 0x09, 0x52, 0x19, 0x69, 0x6d,
+	0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x37, 0x0a, 0x17, 0x69, 0x6d, 0x70, 0x65, 0x72,
+	0x73, 0x6f, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x73, 0x18, 0x31, 0x20, 0x03, 0x28, 0x09, 0x52, 0x16, 0x69, 0x6d, 0x70, 0x65, 0x72, 0x73,
+	0x6f, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x73,
+	0x12, 0x2d, 0x0a, 0x12, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x6d, 0x61,
+	0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x18, 0x33, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x69, 0x6e,
+	0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x12,
+	0x26, 0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x34, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x4f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x65, 0x66, 0x65,
+	0x74, 0x63, 0x68, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x35, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0d, 0x70, 0x72, 0x65, 0x66, 0x65, 0x74, 0x63, 0x68, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x2c,
+	0x0a, 0x12, 0x70, 0x72, 0x65, 0x66, 0x65, 0x74, 0x63, 0x68, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x36, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x70, 0x72, 0x65, 0x66,
+	0x65, 0x74, 0x63, 0x68, 0x4d, 0x61, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x42, 0x0c, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0xe4, 0x03, 0x0a, 0x12, 0x47,
+	0x43, 0x53, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x63, 0x6f, 0x70,
+	0x65, 0x12, 0x32, 0x0a, 0x14, 0x6a, 0x73, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x00, 0x52, 0x12, 0x6a, 0x73, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x07, 0x61, 0x70, 0x69, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x61, 0x70, 0x69, 0x4b, 0x65, 0x79,
+	0x12, 0x48, 0x0a, 0x0f, 0x75, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x55, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e,
+	0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x75, 0x6e, 0x61, 0x75, 0x74,
+	0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x31, 0x0a, 0x03, 0x61, 0x64,
+	0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x45, 0x6e, 0x76, 0x69, 0x72,
+	0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x03, 0x61, 0x64, 0x63, 0x12, 0x32, 0x0a,
+	0x14, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x12, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x46, 0x69, 0x6c,
+	0x65, 0x12, 0x2b, 0x0a, 0x05, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x4f,
+	0x61, 0x75, 0x74, 0x68, 0x32, 0x48, 0x00, 0x52, 0x05, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x12, 0x4b,
+	0x0a, 0x21, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x5f, 0x66, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66,
+	0x69, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x1e, 0x77, 0x6f, 0x72,
+	0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x46, 0x65, 0x64,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x42, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x22, 0xb6, 0x06, 0x0a, 0x03, 0x47, 0x69, 0x74, 0x12, 0x37, 0x0a, 0x0a, 0x62, 0x61, 0x73,
+	0x69, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x42, 0x61, 0x73, 0x69,
+	0x63, 0x41, 0x75, 0x74, 0x68, 0x48, 0x00, 0x52, 0x09, 0x62, 0x61, 0x73, 0x69, 0x63, 0x41, 0x75,
+	0x74, 0x68, 0x12, 0x48, 0x0a, 0x0f, 0x75, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x72,
+	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x55, 0x6e, 0x61, 0x75, 0x74, 0x68,
+	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x75, 0x6e, 0x61,
+	0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x31, 0x0a, 0x08,
+	0x73, 0x73, 0x68, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x53, 0x53, 0x48,
+	0x41, 0x75, 0x74, 0x68, 0x48, 0x00, 0x52, 0x07, 0x73, 0x73, 0x68, 0x41, 0x75, 0x74, 0x68, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x73,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x62, 0x61, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x62, 0x61, 0x72,
+	0x65, 0x12, 0x2c, 0x0a, 0x12, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x70, 0x61, 0x74,
+	0x68, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x69,
+	0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x46, 0x69, 0x6c, 0x65, 0x12,
+	0x2c, 0x0a, 0x12, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73,
+	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x65, 0x78, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x23, 0x0a,
+	0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x67, 0x6c, 0x6f, 0x62, 0x73, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x47, 0x6c, 0x6f,
+	0x62, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72,
+	0x69, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x69,
+	0x65, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x6b, 0x69, 0x70, 0x42, 0x69,
+	0x6e, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x61,
+	0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73,
+	0x6b, 0x69, 0x70, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x14, 0x6d,
+	0x61, 0x78, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x62, 0x72, 0x61,
+	0x6e, 0x63, 0x68, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x44, 0x65,
+	0x70, 0x74, 0x68, 0x50, 0x65, 0x72, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x25, 0x0a, 0x0e,
+	0x70, 0x61, 0x74, 0x68, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x11,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x61, 0x74, 0x68, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x6c,
+	0x69, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x12, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f,
+	0x6d, 0x61, 0x78, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12,
+	0x41, 0x0a, 0x1d, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x5f, 0x64,
+	0x65, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x13, 0x20, 0x01, 0x28, 0x03, 0x52, 0x1a, 0x6d, 0x61, 0x78, 0x41, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x75, 0x73, 0x65, 0x5f, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65,
+	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x75, 0x73,
+	0x65, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x42, 0x0c, 0x0a, 0x0a,
 	0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0xcb, 0x02, 0x0a, 0x06, 0x47,
 	0x69, 0x74, 0x4c, 0x61, 0x62, 0x12, 0x24, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
 	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0x90, 0x01,
@@ -4516,7 +5689,7 @@ var file_sources_proto_rawDesc = []byte{
 	0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x55, 0x6e, 0x61,
 	0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f,
 	0x75, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x42,
-	0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0xad, 0x03,
+	0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0x86, 0x04,
 	0x0a, 0x02, 0x53, 0x33, 0x12, 0x37, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6b,
 	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65,
 	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x4b, 0x65, 0x79, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
@@ -4542,280 +5715,348 @@ var file_sources_proto_rawDesc = []byte{
 	0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
 	0x09, 0x52, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x67, 0x6e, 0x6f,
 	0x72, 0x65, 0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x0d, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x42,
-	0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0xc4, 0x01,
-	0x0a, 0x05, 0x53, 0x6c, 0x61, 0x63, 0x6b, 0x12, 0x24, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f,
-	0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03,
-	0x90, 0x01, 0x01, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a,
-	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05,
-	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x32, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x61, 0x6c, 0x73, 0x2e, 0x53, 0x6c, 0x61, 0x63, 0x6b, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x48,
-	0x00, 0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61,
-	0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x61,
-	0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f,
-	0x6c, 0x69, 0x73, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x67, 0x6e, 0x6f,
-	0x72, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e,
-	0x74, 0x69, 0x61, 0x6c, 0x22, 0x06, 0x0a, 0x04, 0x54, 0x65, 0x73, 0x74, 0x22, 0x31, 0x0a, 0x09,
-	0x42, 0x75, 0x69, 0x6c, 0x64, 0x6b, 0x69, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x05, 0x74, 0x6f, 0x6b,
-	0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65,
-	0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22,
-	0xa5, 0x02, 0x0a, 0x06, 0x47, 0x65, 0x72, 0x72, 0x69, 0x74, 0x12, 0x24, 0x0a, 0x08, 0x65, 0x6e,
-	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42,
-	0x05, 0x72, 0x03, 0x90, 0x01, 0x01, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
-	0x12, 0x37, 0x0a, 0x0a, 0x62, 0x61, 0x73, 0x69, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
-	0x6c, 0x73, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x41, 0x75, 0x74, 0x68, 0x48, 0x00, 0x52, 0x09,
-	0x62, 0x61, 0x73, 0x69, 0x63, 0x41, 0x75, 0x74, 0x68, 0x12, 0x48, 0x0a, 0x0f, 0x75, 0x6e, 0x61,
-	0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73,
-	0x2e, 0x55, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64,
-	0x48, 0x00, 0x52, 0x0f, 0x75, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
-	0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18,
-	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12,
-	0x23, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x69, 0x65, 0x73,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x6b, 0x69, 0x70, 0x42, 0x69, 0x6e, 0x61,
-	0x72, 0x69, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x61, 0x72, 0x63,
-	0x68, 0x69, 0x76, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x6b, 0x69,
-	0x70, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65,
-	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0xa8, 0x02, 0x0a, 0x07, 0x4a, 0x65, 0x6e, 0x6b,
-	0x69, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18,
+	0x52, 0x0d, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x63, 0x61, 0x6e, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x63, 0x61, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x38,
+	0x0a, 0x18, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76,
+	0x65, 0x64, 0x5f, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x16, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65,
+	0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0xc4, 0x01, 0x0a, 0x05, 0x53, 0x6c, 0x61, 0x63, 0x6b,
+	0x12, 0x24, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0x90, 0x01, 0x01, 0x52, 0x08, 0x65, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x32,
+	0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x53, 0x6c, 0x61,
+	0x63, 0x6b, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x48, 0x00, 0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x12, 0x1f,
+	0x0a, 0x0b, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x42,
+	0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0x06, 0x0a,
+	0x04, 0x54, 0x65, 0x73, 0x74, 0x22, 0x31, 0x0a, 0x09, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x6b, 0x69,
+	0x74, 0x65, 0x12, 0x16, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72,
+	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0xa5, 0x02, 0x0a, 0x06, 0x47, 0x65, 0x72,
+	0x72, 0x69, 0x74, 0x12, 0x24, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0x90, 0x01, 0x01, 0x52,
 	0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x37, 0x0a, 0x0a, 0x62, 0x61, 0x73,
 	0x69, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
 	0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x42, 0x61, 0x73, 0x69,
 	0x63, 0x41, 0x75, 0x74, 0x68, 0x48, 0x00, 0x52, 0x09, 0x62, 0x61, 0x73, 0x69, 0x63, 0x41, 0x75,
-	0x74, 0x68, 0x12, 0x2d, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73,
-	0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x12, 0x48, 0x0a, 0x0f, 0x75, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63,
-	0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x72, 0x65,
-	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x55, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65,
-	0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x75, 0x6e, 0x61, 0x75,
-	0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x37, 0x0a, 0x18, 0x69,
-	0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x5f, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x76, 0x65, 0x72,
-	0x69, 0x66, 0x79, 0x5f, 0x74, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x69,
-	0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x53, 0x6b, 0x69, 0x70, 0x56, 0x65, 0x72, 0x69, 0x66,
-	0x79, 0x54, 0x6c, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x61, 0x6c, 0x22, 0xa0, 0x02, 0x0a, 0x05, 0x54, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x24, 0x0a, 0x08,
+	0x74, 0x68, 0x12, 0x48, 0x0a, 0x0f, 0x75, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x72,
+	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x55, 0x6e, 0x61, 0x75, 0x74, 0x68,
+	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x75, 0x6e, 0x61,
+	0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70,
+	0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x73, 0x6b, 0x69, 0x70, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x23, 0x0a,
+	0x0d, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x6b, 0x69, 0x70, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76,
+	0x65, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x22, 0xa8, 0x02, 0x0a, 0x07, 0x4a, 0x65, 0x6e, 0x6b, 0x69, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x08,
 	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08,
 	0xfa, 0x42, 0x05, 0x72, 0x03, 0x90, 0x01, 0x01, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69,
-	0x6e, 0x74, 0x12, 0x16, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x00, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x46, 0x0a, 0x0d, 0x61, 0x75,
-	0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e,
-	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
-	0x73, 0x48, 0x00, 0x52, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74,
-	0x65, 0x64, 0x12, 0x2b, 0x0a, 0x05, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x13, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e,
-	0x4f, 0x61, 0x75, 0x74, 0x68, 0x32, 0x48, 0x00, 0x52, 0x05, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x12,
-	0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x69,
-	0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x0a, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08,
-	0x74, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
-	0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65,
-	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0x95, 0x01, 0x0a, 0x06, 0x53, 0x79, 0x73, 0x6c, 0x6f, 0x67,
-	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x25, 0x0a, 0x0e,
-	0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x41, 0x64, 0x64, 0x72,
-	0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x6c, 0x73, 0x43, 0x65, 0x72, 0x74, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x6c, 0x73, 0x43, 0x65, 0x72, 0x74, 0x12, 0x16, 0x0a,
-	0x06, 0x74, 0x6c, 0x73, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74,
-	0x6c, 0x73, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0xca, 0x01,
-	0x0a, 0x07, 0x46, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x72, 0x12, 0x48, 0x0a, 0x0f, 0x75, 0x6e, 0x61,
-	0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73,
-	0x2e, 0x55, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64,
-	0x48, 0x00, 0x52, 0x0f, 0x75, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
-	0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x1b, 0x0a,
-	0x09, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x08, 0x6d, 0x61, 0x78, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x69,
-	0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x42, 0x0c, 0x0a, 0x0a,
-	0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0x51, 0x0a, 0x0d, 0x53, 0x6c,
-	0x61, 0x63, 0x6b, 0x52, 0x65, 0x61, 0x6c, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x32, 0x0a, 0x06, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x72,
-	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x53, 0x6c, 0x61, 0x63, 0x6b, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x48, 0x00, 0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x42,
-	0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0x62, 0x0a,
-	0x0a, 0x53, 0x68, 0x61, 0x72, 0x65, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x05, 0x6f,
-	0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x72, 0x65,
+	0x6e, 0x74, 0x12, 0x37, 0x0a, 0x0a, 0x62, 0x61, 0x73, 0x69, 0x63, 0x5f, 0x61, 0x75, 0x74, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x73, 0x2e, 0x42, 0x61, 0x73, 0x69, 0x63, 0x41, 0x75, 0x74, 0x68, 0x48, 0x00,
+	0x52, 0x09, 0x62, 0x61, 0x73, 0x69, 0x63, 0x41, 0x75, 0x74, 0x68, 0x12, 0x2d, 0x0a, 0x06, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x72,
+	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x48, 0x00, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x48, 0x0a, 0x0f, 0x75, 0x6e,
+	0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x73, 0x2e, 0x55, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x64, 0x48, 0x00, 0x52, 0x0f, 0x75, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x37, 0x0a, 0x18, 0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65,
+	0x5f, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x5f, 0x74, 0x6c, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65,
+	0x53, 0x6b, 0x69, 0x70, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x54, 0x6c, 0x73, 0x42, 0x0c, 0x0a,
+	0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0xa0, 0x02, 0x0a, 0x05,
+	0x54, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x24, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0x90, 0x01,
+	0x01, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x05, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x46, 0x0a, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x72, 0x65,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43,
+	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x48, 0x00, 0x52, 0x0d, 0x61, 0x75,
+	0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x05, 0x6f,
+	0x61, 0x75, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x72, 0x65,
 	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x4f, 0x61, 0x75, 0x74, 0x68, 0x32, 0x48,
-	0x00, 0x52, 0x05, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x69, 0x74, 0x65,
-	0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x69, 0x74, 0x65,
-	0x55, 0x72, 0x6c, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
-	0x6c, 0x22, 0xf6, 0x03, 0x0a, 0x0a, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73,
-	0x12, 0x24, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0x90, 0x01, 0x01, 0x52, 0x08, 0x65, 0x6e,
-	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2b,
-	0x0a, 0x05, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x4f, 0x61, 0x75, 0x74,
-	0x68, 0x32, 0x48, 0x00, 0x52, 0x05, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x72,
-	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12,
-	0x24, 0x0a, 0x0d, 0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
-	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
-	0x73, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x66, 0x6f, 0x72,
-	0x6b, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64,
-	0x65, 0x46, 0x6f, 0x72, 0x6b, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65,
-	0x5f, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x67,
-	0x6e, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x63,
-	0x6c, 0x75, 0x64, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x12, 0x29,
-	0x0a, 0x10, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
-	0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64,
-	0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x67, 0x6e,
-	0x6f, 0x72, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x0e, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63,
-	0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72,
-	0x69, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x6b, 0x69, 0x70, 0x42,
-	0x69, 0x6e, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x5f,
-	0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
-	0x73, 0x6b, 0x69, 0x70, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x73, 0x42, 0x0c, 0x0a, 0x0a,
-	0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0xd5, 0x04, 0x0a, 0x07, 0x50,
-	0x6f, 0x73, 0x74, 0x6d, 0x61, 0x6e, 0x12, 0x48, 0x0a, 0x0f, 0x75, 0x6e, 0x61, 0x75, 0x74, 0x68,
-	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1c, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x55, 0x6e,
-	0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52,
-	0x0f, 0x75, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64,
-	0x12, 0x16, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48,
-	0x00, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b,
-	0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f,
-	0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63,
-	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x6e,
-	0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x0c, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2f,
-	0x0a, 0x13, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x65, 0x78, 0x63,
-	0x6c, 0x75, 0x64, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
-	0x31, 0x0a, 0x14, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x65, 0x6e, 0x76, 0x69, 0x72,
-	0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x65,
-	0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e,
-	0x74, 0x73, 0x12, 0x2f, 0x0a, 0x13, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x63, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x12, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x31, 0x0a, 0x14, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x65,
-	0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x13, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f,
-	0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74,
-	0x6f, 0x72, 0x5f, 0x6b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x10, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x4b, 0x65, 0x79, 0x77, 0x6f,
-	0x72, 0x64, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65,
-	0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x77, 0x6f,
-	0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x29, 0x0a, 0x10,
-	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73,
-	0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x6e, 0x76, 0x69, 0x72,
-	0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x0d, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x10, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x50,
-	0x61, 0x74, 0x68, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x61, 0x6c, 0x22, 0x76, 0x0a, 0x07, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x12, 0x2e, 0x0a,
-	0x0e, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x68, 0x01, 0x52, 0x0d,
-	0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2d, 0x0a,
-	0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
-	0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x48, 0x65, 0x61, 0x64,
-	0x65, 0x72, 0x48, 0x00, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x42, 0x0c, 0x0a, 0x0a,
-	0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0xcd, 0x02, 0x0a, 0x0d, 0x45,
-	0x6c, 0x61, 0x73, 0x74, 0x69, 0x63, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05,
-	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x64,
-	0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a,
-	0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x6c,
-	0x6f, 0x75, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6c,
-	0x6f, 0x75, 0x64, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x70, 0x69, 0x5f, 0x6b, 0x65, 0x79,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x12, 0x23,
-	0x0a, 0x0d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x70, 0x61, 0x74,
-	0x74, 0x65, 0x72, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x64, 0x65,
-	0x78, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x65, 0x72,
-	0x79, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75,
-	0x65, 0x72, 0x79, 0x4a, 0x73, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x69, 0x6e, 0x63, 0x65,
-	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0e, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x12, 0x28, 0x0a, 0x10, 0x62, 0x65, 0x73, 0x74, 0x5f, 0x65, 0x66, 0x66, 0x6f, 0x72, 0x74, 0x5f,
-	0x73, 0x63, 0x61, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x62, 0x65, 0x73, 0x74,
-	0x45, 0x66, 0x66, 0x6f, 0x72, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x2a, 0x8c, 0x08, 0x0a, 0x0a, 0x53,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x4f, 0x55,
-	0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x5a, 0x55, 0x52, 0x45, 0x5f, 0x53,
-	0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x4f, 0x55, 0x52,
-	0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x42, 0x49, 0x54, 0x42, 0x55, 0x43, 0x4b, 0x45,
-	0x54, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59,
-	0x50, 0x45, 0x5f, 0x43, 0x49, 0x52, 0x43, 0x4c, 0x45, 0x43, 0x49, 0x10, 0x02, 0x12, 0x1a, 0x0a,
-	0x16, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x4e,
-	0x46, 0x4c, 0x55, 0x45, 0x4e, 0x43, 0x45, 0x10, 0x03, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x4f, 0x55,
-	0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x4f, 0x43, 0x4b, 0x45, 0x52, 0x10,
-	0x04, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x45, 0x43, 0x52, 0x10, 0x05, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45,
-	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x47, 0x43, 0x53, 0x10, 0x06, 0x12, 0x16, 0x0a, 0x12, 0x53,
-	0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x47, 0x49, 0x54, 0x48, 0x55,
-	0x42, 0x10, 0x07, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59,
-	0x50, 0x45, 0x5f, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x43, 0x5f, 0x47, 0x49, 0x54, 0x10, 0x08, 0x12,
+	0x00, 0x52, 0x05, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x6c,
+	0x69, 0x73, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x67, 0x6e, 0x6f, 0x72,
+	0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x74, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x73,
+	0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0x95,
+	0x01, 0x0a, 0x06, 0x53, 0x79, 0x73, 0x6c, 0x6f, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x5f,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x74, 0x6c, 0x73, 0x43, 0x65, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74,
+	0x6c, 0x73, 0x43, 0x65, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6c, 0x73, 0x4b, 0x65, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6c, 0x73, 0x4b, 0x65, 0x79, 0x12, 0x16,
+	0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0xca, 0x01, 0x0a, 0x07, 0x46, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x72, 0x12, 0x48, 0x0a, 0x0f, 0x75, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x72,
+	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x55, 0x6e, 0x61, 0x75, 0x74, 0x68,
+	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x75, 0x6e, 0x61,
+	0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x65,
+	0x70, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x44, 0x65,
+	0x70, 0x74, 0x68, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05,
+	0x73, 0x69, 0x6e, 0x63, 0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x22, 0x51, 0x0a, 0x0d, 0x53, 0x6c, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x61, 0x6c,
+	0x74, 0x69, 0x6d, 0x65, 0x12, 0x32, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x73, 0x2e, 0x53, 0x6c, 0x61, 0x63, 0x6b, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x48, 0x00,
+	0x52, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0x62, 0x0a, 0x0a, 0x53, 0x68, 0x61, 0x72, 0x65, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x05, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x73, 0x2e, 0x4f, 0x61, 0x75, 0x74, 0x68, 0x32, 0x48, 0x00, 0x52, 0x05, 0x6f, 0x61, 0x75, 0x74,
+	0x68, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x69, 0x74, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x69, 0x74, 0x65, 0x55, 0x72, 0x6c, 0x42, 0x0c, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0xf6, 0x03, 0x0a, 0x0a, 0x41,
+	0x7a, 0x75, 0x72, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x12, 0x24, 0x0a, 0x08, 0x65, 0x6e, 0x64,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05,
+	0x72, 0x03, 0x90, 0x01, 0x01, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12,
+	0x16, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
+	0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2b, 0x0a, 0x05, 0x6f, 0x61, 0x75, 0x74, 0x68,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x73, 0x2e, 0x4f, 0x61, 0x75, 0x74, 0x68, 0x32, 0x48, 0x00, 0x52, 0x05, 0x6f,
+	0x61, 0x75, 0x74, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f,
+	0x72, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6f, 0x72, 0x67, 0x61,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0d, 0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6b, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x46, 0x6f, 0x72, 0x6b, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x70,
+	0x6f, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x72, 0x65,
+	0x70, 0x6f, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x70, 0x72, 0x6f,
+	0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x67, 0x6e,
+	0x6f, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73,
+	0x6b, 0x69, 0x70, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x69, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0c, 0x73, 0x6b, 0x69, 0x70, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x69, 0x65, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65,
+	0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x6b, 0x69, 0x70, 0x41, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x22, 0xd5, 0x04, 0x0a, 0x07, 0x50, 0x6f, 0x73, 0x74, 0x6d, 0x61, 0x6e, 0x12,
+	0x48, 0x0a, 0x0f, 0x75, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x55, 0x6e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0f, 0x75, 0x6e, 0x61, 0x75, 0x74, 0x68,
+	0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x73, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x6e, 0x76, 0x69, 0x72,
+	0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x13, 0x65, 0x78, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x31, 0x0a, 0x14, 0x65, 0x78, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x5f, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x45,
+	0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x13, 0x69,
+	0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x31, 0x0a, 0x14,
+	0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x69, 0x6e, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x2b, 0x0a, 0x11, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x6b, 0x65, 0x79, 0x77,
+	0x6f, 0x72, 0x64, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x64, 0x65, 0x74, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x4b, 0x65, 0x79, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x27, 0x0a, 0x0f,
+	0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18,
+	0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x73,
+	0x12, 0x2b, 0x0a, 0x11, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
+	0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x65, 0x6e, 0x76,
+	0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x61, 0x74, 0x68, 0x73, 0x42, 0x0c, 0x0a,
+	0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0x76, 0x0a, 0x07, 0x57,
+	0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x12, 0x2e, 0x0a, 0x0e, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07,
+	0xfa, 0x42, 0x04, 0x72, 0x02, 0x68, 0x01, 0x52, 0x0d, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2d, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x73, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x22, 0xcd, 0x02, 0x0a, 0x0d, 0x45, 0x6c, 0x61, 0x73, 0x74, 0x69, 0x63, 0x73,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x69, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x49, 0x64, 0x12, 0x17,
+	0x0a, 0x07, 0x61, 0x70, 0x69, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x61, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x23, 0x0a, 0x0d,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x71, 0x75, 0x65, 0x72, 0x79, 0x4a, 0x73, 0x6f, 0x6e,
+	0x12, 0x27, 0x0a, 0x0f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x69, 0x6e, 0x63, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x28, 0x0a, 0x10, 0x62, 0x65, 0x73,
+	0x74, 0x5f, 0x65, 0x66, 0x66, 0x6f, 0x72, 0x74, 0x5f, 0x73, 0x63, 0x61, 0x6e, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0e, 0x62, 0x65, 0x73, 0x74, 0x45, 0x66, 0x66, 0x6f, 0x72, 0x74, 0x53,
+	0x63, 0x61, 0x6e, 0x22, 0xbc, 0x04, 0x0a, 0x09, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x42, 0x6c, 0x6f,
+	0x62, 0x12, 0x2d, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x10,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x12, 0x1d, 0x0a, 0x09, 0x73, 0x61, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x73, 0x61, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x4a, 0x0a, 0x10, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x45, 0x6e, 0x76,
+	0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x64, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x72, 0x6c, 0x12, 0x3b, 0x0a, 0x1a,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x17, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x64, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x65, 0x78, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x5f, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c,
+	0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x23, 0x0a, 0x0d,
+	0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x42, 0x6c, 0x6f, 0x62,
+	0x73, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x42, 0x6c, 0x6f,
+	0x62, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x62, 0x69,
+	0x6e, 0x61, 0x72, 0x69, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x6b,
+	0x69, 0x70, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x18, 0x64, 0x69,
+	0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x5f, 0x68, 0x61,
+	0x6e, 0x64, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x64, 0x69,
+	0x73, 0x61, 0x62, 0x6c, 0x65, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x48, 0x61, 0x6e, 0x64,
+	0x6c, 0x69, 0x6e, 0x67, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x61, 0x6c, 0x22, 0xac, 0x02, 0x0a, 0x10, 0x47, 0x43, 0x50, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x14, 0x6a, 0x73, 0x6f, 0x6e, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x12, 0x6a, 0x73, 0x6f, 0x6e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x32, 0x0a, 0x14, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x66,
+	0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x12, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12,
+	0x31, 0x0a, 0x03, 0x61, 0x64, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63,
+	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64,
+	0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x03, 0x61,
+	0x64, 0x63, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49,
+	0x64, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x53, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x22, 0x1b, 0x0a, 0x05, 0x53, 0x74, 0x64, 0x69, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x2a, 0xe3,
+	0x08, 0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a,
+	0x19, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x5a, 0x55,
+	0x52, 0x45, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x41, 0x47, 0x45, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15,
+	0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x42, 0x49, 0x54, 0x42,
+	0x55, 0x43, 0x4b, 0x45, 0x54, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x4f, 0x55, 0x52, 0x43,
+	0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x49, 0x52, 0x43, 0x4c, 0x45, 0x43, 0x49, 0x10,
+	0x02, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x4c, 0x55, 0x45, 0x4e, 0x43, 0x45, 0x10, 0x03, 0x12, 0x16, 0x0a,
+	0x12, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x4f, 0x43,
+	0x4b, 0x45, 0x52, 0x10, 0x04, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x45, 0x43, 0x52, 0x10, 0x05, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x4f,
+	0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x47, 0x43, 0x53, 0x10, 0x06, 0x12,
 	0x16, 0x0a, 0x12, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x47,
-	0x49, 0x54, 0x4c, 0x41, 0x42, 0x10, 0x09, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x4f, 0x55, 0x52, 0x43,
-	0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4a, 0x49, 0x52, 0x41, 0x10, 0x0a, 0x12, 0x24, 0x0a,
-	0x20, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x50, 0x4d,
-	0x5f, 0x55, 0x4e, 0x41, 0x55, 0x54, 0x48, 0x44, 0x5f, 0x50, 0x41, 0x43, 0x4b, 0x41, 0x47, 0x45,
-	0x53, 0x10, 0x0b, 0x12, 0x25, 0x0a, 0x21, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59,
-	0x50, 0x45, 0x5f, 0x50, 0x59, 0x50, 0x49, 0x5f, 0x55, 0x4e, 0x41, 0x55, 0x54, 0x48, 0x44, 0x5f,
-	0x50, 0x41, 0x43, 0x4b, 0x41, 0x47, 0x45, 0x53, 0x10, 0x0c, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x4f,
-	0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x33, 0x10, 0x0d, 0x12, 0x15,
-	0x0a, 0x11, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x4c,
-	0x41, 0x43, 0x4b, 0x10, 0x0e, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f,
-	0x54, 0x59, 0x50, 0x45, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x10,
-	0x0f, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x47, 0x49, 0x54, 0x10, 0x10, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45,
-	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x45, 0x53, 0x54, 0x10, 0x11, 0x12, 0x1b, 0x0a, 0x17,
-	0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x33, 0x5f, 0x55,
-	0x4e, 0x41, 0x55, 0x54, 0x48, 0x45, 0x44, 0x10, 0x12, 0x12, 0x2a, 0x0a, 0x26, 0x53, 0x4f, 0x55,
-	0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x47, 0x49, 0x54, 0x48, 0x55, 0x42, 0x5f,
-	0x55, 0x4e, 0x41, 0x55, 0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41, 0x54, 0x45, 0x44, 0x5f,
-	0x4f, 0x52, 0x47, 0x10, 0x13, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f,
-	0x54, 0x59, 0x50, 0x45, 0x5f, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x4b, 0x49, 0x54, 0x45, 0x10, 0x14,
-	0x12, 0x16, 0x0a, 0x12, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
-	0x47, 0x45, 0x52, 0x52, 0x49, 0x54, 0x10, 0x15, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x4f, 0x55, 0x52,
-	0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4a, 0x45, 0x4e, 0x4b, 0x49, 0x4e, 0x53, 0x10,
-	0x16, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x54, 0x45, 0x41, 0x4d, 0x53, 0x10, 0x17, 0x12, 0x21, 0x0a, 0x1d, 0x53, 0x4f, 0x55, 0x52,
-	0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4a, 0x46, 0x52, 0x4f, 0x47, 0x5f, 0x41, 0x52,
-	0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x4f, 0x52, 0x59, 0x10, 0x18, 0x12, 0x16, 0x0a, 0x12, 0x53,
-	0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x59, 0x53, 0x4c, 0x4f,
-	0x47, 0x10, 0x19, 0x12, 0x27, 0x0a, 0x23, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59,
-	0x50, 0x45, 0x5f, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x43, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f,
-	0x4d, 0x4f, 0x4e, 0x49, 0x54, 0x4f, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x1a, 0x12, 0x1e, 0x0a, 0x1a,
-	0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x4c, 0x41, 0x43,
-	0x4b, 0x5f, 0x52, 0x45, 0x41, 0x4c, 0x54, 0x49, 0x4d, 0x45, 0x10, 0x1b, 0x12, 0x1c, 0x0a, 0x18,
-	0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x47, 0x4f, 0x4f, 0x47,
-	0x4c, 0x45, 0x5f, 0x44, 0x52, 0x49, 0x56, 0x45, 0x10, 0x1c, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x4f,
-	0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x48, 0x41, 0x52, 0x45, 0x50,
-	0x4f, 0x49, 0x4e, 0x54, 0x10, 0x1d, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45,
-	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x47, 0x43, 0x53, 0x5f, 0x55, 0x4e, 0x41, 0x55, 0x54, 0x48,
-	0x45, 0x44, 0x10, 0x1e, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54,
-	0x59, 0x50, 0x45, 0x5f, 0x41, 0x5a, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x53, 0x10,
-	0x1f, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x54, 0x52, 0x41, 0x56, 0x49, 0x53, 0x43, 0x49, 0x10, 0x20, 0x12, 0x17, 0x0a, 0x13, 0x53,
-	0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x4f, 0x53, 0x54, 0x4d,
-	0x41, 0x4e, 0x10, 0x21, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54,
-	0x59, 0x50, 0x45, 0x5f, 0x57, 0x45, 0x42, 0x48, 0x4f, 0x4f, 0x4b, 0x10, 0x22, 0x12, 0x1d, 0x0a,
-	0x19, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x45, 0x4c, 0x41,
-	0x53, 0x54, 0x49, 0x43, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x10, 0x23, 0x12, 0x1b, 0x0a, 0x17,
-	0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x48, 0x55, 0x47, 0x47,
-	0x49, 0x4e, 0x47, 0x46, 0x41, 0x43, 0x45, 0x10, 0x24, 0x42, 0x3b, 0x5a, 0x39, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x72, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x73,
-	0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x2f, 0x74, 0x72, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x68,
-	0x6f, 0x67, 0x2f, 0x76, 0x33, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x2f, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x49, 0x54, 0x48, 0x55, 0x42, 0x10, 0x07, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x4f, 0x55, 0x52, 0x43,
+	0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x43, 0x5f, 0x47, 0x49,
+	0x54, 0x10, 0x08, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x47, 0x49, 0x54, 0x4c, 0x41, 0x42, 0x10, 0x09, 0x12, 0x14, 0x0a, 0x10, 0x53,
+	0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4a, 0x49, 0x52, 0x41, 0x10,
+	0x0a, 0x12, 0x24, 0x0a, 0x20, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x4e, 0x50, 0x4d, 0x5f, 0x55, 0x4e, 0x41, 0x55, 0x54, 0x48, 0x44, 0x5f, 0x50, 0x41, 0x43,
+	0x4b, 0x41, 0x47, 0x45, 0x53, 0x10, 0x0b, 0x12, 0x25, 0x0a, 0x21, 0x53, 0x4f, 0x55, 0x52, 0x43,
+	0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x59, 0x50, 0x49, 0x5f, 0x55, 0x4e, 0x41, 0x55,
+	0x54, 0x48, 0x44, 0x5f, 0x50, 0x41, 0x43, 0x4b, 0x41, 0x47, 0x45, 0x53, 0x10, 0x0c, 0x12, 0x12,
+	0x0a, 0x0e, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x33,
+	0x10, 0x0d, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x53, 0x4c, 0x41, 0x43, 0x4b, 0x10, 0x0e, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x4f, 0x55,
+	0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x53, 0x59, 0x53,
+	0x54, 0x45, 0x4d, 0x10, 0x0f, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x47, 0x49, 0x54, 0x10, 0x10, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x4f,
+	0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x45, 0x53, 0x54, 0x10, 0x11,
+	0x12, 0x1b, 0x0a, 0x17, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x53, 0x33, 0x5f, 0x55, 0x4e, 0x41, 0x55, 0x54, 0x48, 0x45, 0x44, 0x10, 0x12, 0x12, 0x2a, 0x0a,
+	0x26, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x47, 0x49, 0x54,
+	0x48, 0x55, 0x42, 0x5f, 0x55, 0x4e, 0x41, 0x55, 0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41,
+	0x54, 0x45, 0x44, 0x5f, 0x4f, 0x52, 0x47, 0x10, 0x13, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x4f, 0x55,
+	0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x4b, 0x49,
+	0x54, 0x45, 0x10, 0x14, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x47, 0x45, 0x52, 0x52, 0x49, 0x54, 0x10, 0x15, 0x12, 0x17, 0x0a, 0x13,
+	0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4a, 0x45, 0x4e, 0x4b,
+	0x49, 0x4e, 0x53, 0x10, 0x16, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x45, 0x41, 0x4d, 0x53, 0x10, 0x17, 0x12, 0x21, 0x0a, 0x1d,
+	0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4a, 0x46, 0x52, 0x4f,
+	0x47, 0x5f, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x4f, 0x52, 0x59, 0x10, 0x18, 0x12,
+	0x16, 0x0a, 0x12, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53,
+	0x59, 0x53, 0x4c, 0x4f, 0x47, 0x10, 0x19, 0x12, 0x27, 0x0a, 0x23, 0x53, 0x4f, 0x55, 0x52, 0x43,
+	0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x43, 0x5f, 0x45, 0x56,
+	0x45, 0x4e, 0x54, 0x5f, 0x4d, 0x4f, 0x4e, 0x49, 0x54, 0x4f, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x1a,
+	0x12, 0x1e, 0x0a, 0x1a, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x53, 0x4c, 0x41, 0x43, 0x4b, 0x5f, 0x52, 0x45, 0x41, 0x4c, 0x54, 0x49, 0x4d, 0x45, 0x10, 0x1b,
+	0x12, 0x1c, 0x0a, 0x18, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x47, 0x4f, 0x4f, 0x47, 0x4c, 0x45, 0x5f, 0x44, 0x52, 0x49, 0x56, 0x45, 0x10, 0x1c, 0x12, 0x1a,
+	0x0a, 0x16, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x48,
+	0x41, 0x52, 0x45, 0x50, 0x4f, 0x49, 0x4e, 0x54, 0x10, 0x1d, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x4f,
+	0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x47, 0x43, 0x53, 0x5f, 0x55, 0x4e,
+	0x41, 0x55, 0x54, 0x48, 0x45, 0x44, 0x10, 0x1e, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x4f, 0x55, 0x52,
+	0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x5a, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45,
+	0x50, 0x4f, 0x53, 0x10, 0x1f, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x52, 0x41, 0x56, 0x49, 0x53, 0x43, 0x49, 0x10, 0x20, 0x12,
+	0x17, 0x0a, 0x13, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50,
+	0x4f, 0x53, 0x54, 0x4d, 0x41, 0x4e, 0x10, 0x21, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x4f, 0x55, 0x52,
+	0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x57, 0x45, 0x42, 0x48, 0x4f, 0x4f, 0x4b, 0x10,
+	0x22, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x45, 0x4c, 0x41, 0x53, 0x54, 0x49, 0x43, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x10, 0x23,
+	0x12, 0x1b, 0x0a, 0x17, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x48, 0x55, 0x47, 0x47, 0x49, 0x4e, 0x47, 0x46, 0x41, 0x43, 0x45, 0x10, 0x24, 0x12, 0x1a, 0x0a,
+	0x16, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x5a, 0x55,
+	0x52, 0x45, 0x5f, 0x42, 0x4c, 0x4f, 0x42, 0x10, 0x25, 0x12, 0x22, 0x0a, 0x1e, 0x53, 0x4f, 0x55,
+	0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x47, 0x43, 0x50, 0x5f, 0x53, 0x45, 0x43,
+	0x52, 0x45, 0x54, 0x5f, 0x4d, 0x41, 0x4e, 0x41, 0x47, 0x45, 0x52, 0x10, 0x26, 0x12, 0x15, 0x0a,
+	0x11, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x54, 0x44,
+	0x49, 0x4e, 0x10, 0x27, 0x42, 0x3b, 0x5a, 0x39, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x74, 0x72, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69,
+	0x74, 0x79, 0x2f, 0x74, 0x72, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x68, 0x6f, 0x67, 0x2f, 0x76, 0x33,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x70,
+	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -4831,7 +6072,7 @@ func file_sources_proto_rawDescGZIP() []byte {
 }
 
 var file_sources_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_sources_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_sources_proto_msgTypes = make([]protoimpl.MessageInfo, 38)
 var file_sources_proto_goTypes = []interface{}{
 	(SourceType)(0),                             // 0: sources.SourceType
 	(Confluence_GetAllSpacesScope)(0),           // 1: sources.Confluence.GetAllSpacesScope
@@ -4846,100 +6087,112 @@ var file_sources_proto_goTypes = []interface{}{
 	(*ECR)(nil),                                 // 10: sources.ECR
 	(*Filesystem)(nil),                          // 11: sources.Filesystem
 	(*GCS)(nil),                                 // 12: sources.GCS
-	(*Git)(nil),                                 // 13: sources.Git
-	(*GitLab)(nil),                              // 14: sources.GitLab
-	(*GitHub)(nil),                              // 15: sources.GitHub
-	(*GoogleDrive)(nil),                         // 16: sources.GoogleDrive
-	(*Huggingface)(nil),                         // 17: sources.Huggingface
-	(*JIRA)(nil),                                // 18: sources.JIRA
-	(*NPMUnauthenticatedPackage)(nil),           // 19: sources.NPMUnauthenticatedPackage
-	(*PyPIUnauthenticatedPackage)(nil),          // 20: sources.PyPIUnauthenticatedPackage
-	(*S3)(nil),                                  // 21: sources.S3
-	(*Slack)(nil),                               // 22: sources.Slack
-	(*Test)(nil),                                // 23: sources.Test
-	(*Buildkite)(nil),                           // 24: sources.Buildkite
-	(*Gerrit)(nil),                              // 25: sources.Gerrit
-	(*Jenkins)(nil),                             // 26: sources.Jenkins
-	(*Teams)(nil),                               // 27: sources.Teams
-	(*Syslog)(nil),                              // 28: sources.Syslog
-	(*Forager)(nil),                             // 29: sources.Forager
-	(*SlackRealtime)(nil),                       // 30: sources.SlackRealtime
-	(*Sharepoint)(nil),                          // 31: sources.Sharepoint
-	(*AzureRepos)(nil),                          // 32: sources.AzureRepos
-	(*Postman)(nil),                             // 33: sources.Postman
-	(*Webhook)(nil),                             // 34: sources.Webhook
-	(*Elasticsearch)(nil),                       // 35: sources.Elasticsearch
-	(*durationpb.Duration)(nil),                 // 36: google.protobuf.Duration
-	(*anypb.Any)(nil),                           // 37: google.protobuf.Any
-	(*credentialspb.BasicAuth)(nil),             // 38: credentials.BasicAuth
-	(*credentialspb.Unauthenticated)(nil),       // 39: credentials.Unauthenticated
-	(*credentialspb.Oauth2)(nil),                // 40: credentials.Oauth2
-	(*credentialspb.KeySecret)(nil),             // 41: credentials.KeySecret
-	(*credentialspb.CloudEnvironment)(nil),      // 42: credentials.CloudEnvironment
-	(*credentialspb.SSHAuth)(nil),               // 43: credentials.SSHAuth
-	(*credentialspb.GitHubApp)(nil),             // 44: credentials.GitHubApp
-	(*credentialspb.AWSSessionTokenSecret)(nil), // 45: credentials.AWSSessionTokenSecret
-	(*credentialspb.SlackTokens)(nil),           // 46: credentials.SlackTokens
-	(*credentialspb.Header)(nil),                // 47: credentials.Header
-	(*credentialspb.ClientCredentials)(nil),     // 48: credentials.ClientCredentials
-	(*timestamppb.Timestamp)(nil),               // 49: google.protobuf.Timestamp
+	(*GCSCredentialScope)(nil),                  // 13: sources.GCSCredentialScope
+	(*Git)(nil),                                 // 14: sources.Git
+	(*GitLab)(nil),                              // 15: sources.GitLab
+	(*GitHub)(nil),                              // 16: sources.GitHub
+	(*GoogleDrive)(nil),                         // 17: sources.GoogleDrive
+	(*Huggingface)(nil),                         // 18: sources.Huggingface
+	(*JIRA)(nil),                                // 19: sources.JIRA
+	(*NPMUnauthenticatedPackage)(nil),           // 20: sources.NPMUnauthenticatedPackage
+	(*PyPIUnauthenticatedPackage)(nil),          // 21: sources.PyPIUnauthenticatedPackage
+	(*S3)(nil),                                  // 22: sources.S3
+	(*Slack)(nil),                               // 23: sources.Slack
+	(*Test)(nil),                                // 24: sources.Test
+	(*Buildkite)(nil),                           // 25: sources.Buildkite
+	(*Gerrit)(nil),                              // 26: sources.Gerrit
+	(*Jenkins)(nil),                             // 27: sources.Jenkins
+	(*Teams)(nil),                               // 28: sources.Teams
+	(*Syslog)(nil),                              // 29: sources.Syslog
+	(*Forager)(nil),                             // 30: sources.Forager
+	(*SlackRealtime)(nil),                       // 31: sources.SlackRealtime
+	(*Sharepoint)(nil),                          // 32: sources.Sharepoint
+	(*AzureRepos)(nil),                          // 33: sources.AzureRepos
+	(*Postman)(nil),                             // 34: sources.Postman
+	(*Webhook)(nil),                             // 35: sources.Webhook
+	(*Elasticsearch)(nil),                       // 36: sources.Elasticsearch
+	(*AzureBlob)(nil),                           // 37: sources.AzureBlob
+	(*GCPSecretManager)(nil),                    // 38: sources.GCPSecretManager
+	(*Stdin)(nil),                               // 39: sources.Stdin
+	(*durationpb.Duration)(nil),                 // 40: google.protobuf.Duration
+	(*anypb.Any)(nil),                           // 41: google.protobuf.Any
+	(*credentialspb.BasicAuth)(nil),             // 42: credentials.BasicAuth
+	(*credentialspb.Unauthenticated)(nil),       // 43: credentials.Unauthenticated
+	(*credentialspb.Oauth2)(nil),                // 44: credentials.Oauth2
+	(*credentialspb.KeySecret)(nil),             // 45: credentials.KeySecret
+	(*credentialspb.CloudEnvironment)(nil),      // 46: credentials.CloudEnvironment
+	(*credentialspb.SSHAuth)(nil),               // 47: credentials.SSHAuth
+	(*credentialspb.GitHubApp)(nil),             // 48: credentials.GitHubApp
+	(*credentialspb.AWSSessionTokenSecret)(nil), // 49: credentials.AWSSessionTokenSecret
+	(*credentialspb.SlackTokens)(nil),           // 50: credentials.SlackTokens
+	(*credentialspb.Header)(nil),                // 51: credentials.Header
+	(*credentialspb.ClientCredentials)(nil),     // 52: credentials.ClientCredentials
+	(*timestamppb.Timestamp)(nil),               // 53: google.protobuf.Timestamp
 }
 var file_sources_proto_depIdxs = []int32{
-	36, // 0: sources.LocalSource.scan_interval:type_name -> google.protobuf.Duration
-	37, // 1: sources.LocalSource.connection:type_name -> google.protobuf.Any
-	38, // 2: sources.Artifactory.basic_auth:type_name -> credentials.BasicAuth
-	39, // 3: sources.Artifactory.unauthenticated:type_name -> credentials.Unauthenticated
-	38, // 4: sources.AzureStorage.basic_auth:type_name -> credentials.BasicAuth
-	39, // 5: sources.AzureStorage.unauthenticated:type_name -> credentials.Unauthenticated
-	40, // 6: sources.Bitbucket.oauth:type_name -> credentials.Oauth2
-	38, // 7: sources.Bitbucket.basic_auth:type_name -> credentials.BasicAuth
-	39, // 8: sources.Confluence.unauthenticated:type_name -> credentials.Unauthenticated
-	38, // 9: sources.Confluence.basic_auth:type_name -> credentials.BasicAuth
+	40, // 0: sources.LocalSource.scan_interval:type_name -> google.protobuf.Duration
+	41, // 1: sources.LocalSource.connection:type_name -> google.protobuf.Any
+	42, // 2: sources.Artifactory.basic_auth:type_name -> credentials.BasicAuth
+	43, // 3: sources.Artifactory.unauthenticated:type_name -> credentials.Unauthenticated
+	42, // 4: sources.AzureStorage.basic_auth:type_name -> credentials.BasicAuth
+	43, // 5: sources.AzureStorage.unauthenticated:type_name -> credentials.Unauthenticated
+	44, // 6: sources.Bitbucket.oauth:type_name -> credentials.Oauth2
+	42, // 7: sources.Bitbucket.basic_auth:type_name -> credentials.BasicAuth
+	43, // 8: sources.Confluence.unauthenticated:type_name -> credentials.Unauthenticated
+	42, // 9: sources.Confluence.basic_auth:type_name -> credentials.BasicAuth
 	1,  // 10: sources.Confluence.spaces_scope:type_name -> sources.Confluence.GetAllSpacesScope
-	39, // 11: sources.Docker.unauthenticated:type_name -> credentials.Unauthenticated
-	38, // 12: sources.Docker.basic_auth:type_name -> credentials.BasicAuth
-	41, // 13: sources.ECR.access_key:type_name -> credentials.KeySecret
-	39, // 14: sources.GCS.unauthenticated:type_name -> credentials.Unauthenticated
-	42, // 15: sources.GCS.adc:type_name -> credentials.CloudEnvironment
-	40, // 16: sources.GCS.oauth:type_name -> credentials.Oauth2
-	38, // 17: sources.Git.basic_auth:type_name -> credentials.BasicAuth
-	39, // 18: sources.Git.unauthenticated:type_name -> credentials.Unauthenticated
-	43, // 19: sources.Git.ssh_auth:type_name -> credentials.SSHAuth
-	40, // 20: sources.GitLab.oauth:type_name -> credentials.Oauth2
-	38, // 21: sources.GitLab.basic_auth:type_name -> credentials.BasicAuth
-	44, // 22: sources.GitHub.github_app:type_name -> credentials.GitHubApp
-	39, // 23: sources.GitHub.unauthenticated:type_name -> credentials.Unauthenticated
-	38, // 24: sources.GitHub.basic_auth:type_name -> credentials.BasicAuth
-	39, // 25: sources.Huggingface.unauthenticated:type_name -> credentials.Unauthenticated
-	38, // 26: sources.JIRA.basic_auth:type_name -> credentials.BasicAuth
-	39, // 27: sources.JIRA.unauthenticated:type_name -> credentials.Unauthenticated
-	40, // 28: sources.JIRA.oauth:type_name -> credentials.Oauth2
-	39, // 29: sources.NPMUnauthenticatedPackage.unauthenticated:type_name -> credentials.Unauthenticated
-	39, // 30: sources.PyPIUnauthenticatedPackage.unauthenticated:type_name -> credentials.Unauthenticated
-	41, // 31: sources.S3.access_key:type_name -> credentials.KeySecret
-	39, // 32: sources.S3.unauthenticated:type_name -> credentials.Unauthenticated
-	42, // 33: sources.S3.cloud_environment:type_name -> credentials.CloudEnvironment
-	45, // 34: sources.S3.session_token:type_name -> credentials.AWSSessionTokenSecret
-	46, // 35: sources.Slack.tokens:type_name -> credentials.SlackTokens
-	38, // 36: sources.Gerrit.basic_auth:type_name -> credentials.BasicAuth
-	39, // 37: sources.Gerrit.unauthenticated:type_name -> credentials.Unauthenticated
-	38, // 38: sources.Jenkins.basic_auth:type_name -> credentials.BasicAuth
-	47, // 39: sources.Jenkins.header:type_name -> credentials.Header
-	39, // 40: sources.Jenkins.unauthenticated:type_name -> credentials.Unauthenticated
-	48, // 41: sources.Teams.authenticated:type_name -> credentials.ClientCredentials
-	40, // 42: sources.Teams.oauth:type_name -> credentials.Oauth2
-	39, // 43: sources.Forager.unauthenticated:type_name -> credentials.Unauthenticated
-	49, // 44: sources.Forager.since:type_name -> google.protobuf.Timestamp
-	46, // 45: sources.SlackRealtime.tokens:type_name -> credentials.SlackTokens
-	40, // 46: sources.Sharepoint.oauth:type_name -> credentials.Oauth2
-	40, // 47: sources.AzureRepos.oauth:type_name -> credentials.Oauth2
-	39, // 48: sources.Postman.unauthenticated:type_name -> credentials.Unauthenticated
-	47, // 49: sources.Webhook.header:type_name -> credentials.Header
-	50, // [50:50] is the sub-list for method output_type
-	50, // [50:50] is the sub-list for method input_type
-	50, // [50:50] is the sub-list for extension type_name
-	50, // [50:50] is the sub-list for extension extendee
-	0,  // [0:50] is the sub-list for field type_name
+	43, // 11: sources.Docker.unauthenticated:type_name -> credentials.Unauthenticated
+	42, // 12: sources.Docker.basic_auth:type_name -> credentials.BasicAuth
+	45, // 13: sources.ECR.access_key:type_name -> credentials.KeySecret
+	43, // 14: sources.GCS.unauthenticated:type_name -> credentials.Unauthenticated
+	46, // 15: sources.GCS.adc:type_name -> credentials.CloudEnvironment
+	44, // 16: sources.GCS.oauth:type_name -> credentials.Oauth2
+	40, // 17: sources.GCS.object_read_timeout:type_name -> google.protobuf.Duration
+	40, // 18: sources.GCS.last_scan_overlap:type_name -> google.protobuf.Duration
+	13, // 19: sources.GCS.credential_scopes:type_name -> sources.GCSCredentialScope
+	43, // 20: sources.GCSCredentialScope.unauthenticated:type_name -> credentials.Unauthenticated
+	46, // 21: sources.GCSCredentialScope.adc:type_name -> credentials.CloudEnvironment
+	44, // 22: sources.GCSCredentialScope.oauth:type_name -> credentials.Oauth2
+	42, // 23: sources.Git.basic_auth:type_name -> credentials.BasicAuth
+	43, // 24: sources.Git.unauthenticated:type_name -> credentials.Unauthenticated
+	47, // 25: sources.Git.ssh_auth:type_name -> credentials.SSHAuth
+	44, // 26: sources.GitLab.oauth:type_name -> credentials.Oauth2
+	42, // 27: sources.GitLab.basic_auth:type_name -> credentials.BasicAuth
+	48, // 28: sources.GitHub.github_app:type_name -> credentials.GitHubApp
+	43, // 29: sources.GitHub.unauthenticated:type_name -> credentials.Unauthenticated
+	42, // 30: sources.GitHub.basic_auth:type_name -> credentials.BasicAuth
+	43, // 31: sources.Huggingface.unauthenticated:type_name -> credentials.Unauthenticated
+	42, // 32: sources.JIRA.basic_auth:type_name -> credentials.BasicAuth
+	43, // 33: sources.JIRA.unauthenticated:type_name -> credentials.Unauthenticated
+	44, // 34: sources.JIRA.oauth:type_name -> credentials.Oauth2
+	43, // 35: sources.NPMUnauthenticatedPackage.unauthenticated:type_name -> credentials.Unauthenticated
+	43, // 36: sources.PyPIUnauthenticatedPackage.unauthenticated:type_name -> credentials.Unauthenticated
+	45, // 37: sources.S3.access_key:type_name -> credentials.KeySecret
+	43, // 38: sources.S3.unauthenticated:type_name -> credentials.Unauthenticated
+	46, // 39: sources.S3.cloud_environment:type_name -> credentials.CloudEnvironment
+	49, // 40: sources.S3.session_token:type_name -> credentials.AWSSessionTokenSecret
+	50, // 41: sources.Slack.tokens:type_name -> credentials.SlackTokens
+	42, // 42: sources.Gerrit.basic_auth:type_name -> credentials.BasicAuth
+	43, // 43: sources.Gerrit.unauthenticated:type_name -> credentials.Unauthenticated
+	42, // 44: sources.Jenkins.basic_auth:type_name -> credentials.BasicAuth
+	51, // 45: sources.Jenkins.header:type_name -> credentials.Header
+	43, // 46: sources.Jenkins.unauthenticated:type_name -> credentials.Unauthenticated
+	52, // 47: sources.Teams.authenticated:type_name -> credentials.ClientCredentials
+	44, // 48: sources.Teams.oauth:type_name -> credentials.Oauth2
+	43, // 49: sources.Forager.unauthenticated:type_name -> credentials.Unauthenticated
+	53, // 50: sources.Forager.since:type_name -> google.protobuf.Timestamp
+	50, // 51: sources.SlackRealtime.tokens:type_name -> credentials.SlackTokens
+	44, // 52: sources.Sharepoint.oauth:type_name -> credentials.Oauth2
+	44, // 53: sources.AzureRepos.oauth:type_name -> credentials.Oauth2
+	43, // 54: sources.Postman.unauthenticated:type_name -> credentials.Unauthenticated
+	51, // 55: sources.Webhook.header:type_name -> credentials.Header
+	46, // 56: sources.AzureBlob.managed_identity:type_name -> credentials.CloudEnvironment
+	46, // 57: sources.GCPSecretManager.adc:type_name -> credentials.CloudEnvironment
+	58, // [58:58] is the sub-list for method output_type
+	58, // [58:58] is the sub-list for method input_type
+	58, // [58:58] is the sub-list for extension type_name
+	58, // [58:58] is the sub-list for extension extendee
+	0,  // [0:58] is the sub-list for field type_name
 }
 
 func init() { file_sources_proto_init() }
@@ -5081,7 +6334,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Git); i {
+			switch v := v.(*GCSCredentialScope); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5093,7 +6346,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GitLab); i {
+			switch v := v.(*Git); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5105,7 +6358,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GitHub); i {
+			switch v := v.(*GitLab); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5117,7 +6370,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GoogleDrive); i {
+			switch v := v.(*GitHub); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5129,7 +6382,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Huggingface); i {
+			switch v := v.(*GoogleDrive); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5141,7 +6394,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JIRA); i {
+			switch v := v.(*Huggingface); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5153,7 +6406,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NPMUnauthenticatedPackage); i {
+			switch v := v.(*JIRA); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5165,7 +6418,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PyPIUnauthenticatedPackage); i {
+			switch v := v.(*NPMUnauthenticatedPackage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5177,7 +6430,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*S3); i {
+			switch v := v.(*PyPIUnauthenticatedPackage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5189,7 +6442,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Slack); i {
+			switch v := v.(*S3); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5201,7 +6454,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Test); i {
+			switch v := v.(*Slack); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5213,7 +6466,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Buildkite); i {
+			switch v := v.(*Test); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5225,7 +6478,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Gerrit); i {
+			switch v := v.(*Buildkite); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5237,7 +6490,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Jenkins); i {
+			switch v := v.(*Gerrit); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5249,7 +6502,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Teams); i {
+			switch v := v.(*Jenkins); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5261,7 +6514,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Syslog); i {
+			switch v := v.(*Teams); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5273,7 +6526,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Forager); i {
+			switch v := v.(*Syslog); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5285,7 +6538,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SlackRealtime); i {
+			switch v := v.(*Forager); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5297,7 +6550,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Sharepoint); i {
+			switch v := v.(*SlackRealtime); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5309,7 +6562,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AzureRepos); i {
+			switch v := v.(*Sharepoint); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5321,7 +6574,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Postman); i {
+			switch v := v.(*AzureRepos); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5333,7 +6586,7 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Webhook); i {
+			switch v := v.(*Postman); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -5345,6 +6598,18 @@ func file_sources_proto_init() {
 			}
 		}
 		file_sources_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Webhook); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sources_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Elasticsearch); i {
 			case 0:
 				return &v.state
@@ -5356,6 +6621,42 @@ func file_sources_proto_init() {
 				return nil
 			}
 		}
+		file_sources_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AzureBlob); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sources_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GCPSecretManager); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sources_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Stdin); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_sources_proto_msgTypes[1].OneofWrappers = []interface{}{
 		(*Artifactory_BasicAuth)(nil),
@@ -5400,96 +6701,116 @@ func file_sources_proto_init() {
 		(*GCS_Adc)(nil),
 		(*GCS_ServiceAccountFile)(nil),
 		(*GCS_Oauth)(nil),
+		(*GCS_WorkloadIdentityFederationFile)(nil),
 	}
 	file_sources_proto_msgTypes[11].OneofWrappers = []interface{}{
+		(*GCSCredentialScope_JsonServiceAccount)(nil),
+		(*GCSCredentialScope_ApiKey)(nil),
+		(*GCSCredentialScope_Unauthenticated)(nil),
+		(*GCSCredentialScope_Adc)(nil),
+		(*GCSCredentialScope_ServiceAccountFile)(nil),
+		(*GCSCredentialScope_Oauth)(nil),
+		(*GCSCredentialScope_WorkloadIdentityFederationFile)(nil),
+	}
+	file_sources_proto_msgTypes[12].OneofWrappers = []interface{}{
 		(*Git_BasicAuth)(nil),
 		(*Git_Unauthenticated)(nil),
 		(*Git_SshAuth)(nil),
 	}
-	file_sources_proto_msgTypes[12].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[13].OneofWrappers = []interface{}{
 		(*GitLab_Token)(nil),
 		(*GitLab_Oauth)(nil),
 		(*GitLab_BasicAuth)(nil),
 	}
-	file_sources_proto_msgTypes[13].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[14].OneofWrappers = []interface{}{
 		(*GitHub_GithubApp)(nil),
 		(*GitHub_Token)(nil),
 		(*GitHub_Unauthenticated)(nil),
 		(*GitHub_BasicAuth)(nil),
 	}
-	file_sources_proto_msgTypes[14].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[15].OneofWrappers = []interface{}{
 		(*GoogleDrive_RefreshToken)(nil),
 	}
-	file_sources_proto_msgTypes[15].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[16].OneofWrappers = []interface{}{
 		(*Huggingface_Token)(nil),
 		(*Huggingface_Unauthenticated)(nil),
 	}
-	file_sources_proto_msgTypes[16].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[17].OneofWrappers = []interface{}{
 		(*JIRA_BasicAuth)(nil),
 		(*JIRA_Unauthenticated)(nil),
 		(*JIRA_Oauth)(nil),
 		(*JIRA_Token)(nil),
 	}
-	file_sources_proto_msgTypes[17].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[18].OneofWrappers = []interface{}{
 		(*NPMUnauthenticatedPackage_Unauthenticated)(nil),
 	}
-	file_sources_proto_msgTypes[18].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[19].OneofWrappers = []interface{}{
 		(*PyPIUnauthenticatedPackage_Unauthenticated)(nil),
 	}
-	file_sources_proto_msgTypes[19].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[20].OneofWrappers = []interface{}{
 		(*S3_AccessKey)(nil),
 		(*S3_Unauthenticated)(nil),
 		(*S3_CloudEnvironment)(nil),
 		(*S3_SessionToken)(nil),
 	}
-	file_sources_proto_msgTypes[20].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[21].OneofWrappers = []interface{}{
 		(*Slack_Token)(nil),
 		(*Slack_Tokens)(nil),
 	}
-	file_sources_proto_msgTypes[22].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[23].OneofWrappers = []interface{}{
 		(*Buildkite_Token)(nil),
 	}
-	file_sources_proto_msgTypes[23].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[24].OneofWrappers = []interface{}{
 		(*Gerrit_BasicAuth)(nil),
 		(*Gerrit_Unauthenticated)(nil),
 	}
-	file_sources_proto_msgTypes[24].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[25].OneofWrappers = []interface{}{
 		(*Jenkins_BasicAuth)(nil),
 		(*Jenkins_Header)(nil),
 		(*Jenkins_Unauthenticated)(nil),
 	}
-	file_sources_proto_msgTypes[25].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[26].OneofWrappers = []interface{}{
 		(*Teams_Token)(nil),
 		(*Teams_Authenticated)(nil),
 		(*Teams_Oauth)(nil),
 	}
-	file_sources_proto_msgTypes[27].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[28].OneofWrappers = []interface{}{
 		(*Forager_Unauthenticated)(nil),
 	}
-	file_sources_proto_msgTypes[28].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[29].OneofWrappers = []interface{}{
 		(*SlackRealtime_Tokens)(nil),
 	}
-	file_sources_proto_msgTypes[29].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[30].OneofWrappers = []interface{}{
 		(*Sharepoint_Oauth)(nil),
 	}
-	file_sources_proto_msgTypes[30].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[31].OneofWrappers = []interface{}{
 		(*AzureRepos_Token)(nil),
 		(*AzureRepos_Oauth)(nil),
 	}
-	file_sources_proto_msgTypes[31].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[32].OneofWrappers = []interface{}{
 		(*Postman_Unauthenticated)(nil),
 		(*Postman_Token)(nil),
 	}
-	file_sources_proto_msgTypes[32].OneofWrappers = []interface{}{
+	file_sources_proto_msgTypes[33].OneofWrappers = []interface{}{
 		(*Webhook_Header)(nil),
 	}
+	file_sources_proto_msgTypes[35].OneofWrappers = []interface{}{
+		(*AzureBlob_ConnectionString)(nil),
+		(*AzureBlob_SasToken)(nil),
+		(*AzureBlob_ManagedIdentity)(nil),
+	}
+	file_sources_proto_msgTypes[36].OneofWrappers = []interface{}{
+		(*GCPSecretManager_JsonServiceAccount)(nil),
+		(*GCPSecretManager_ServiceAccountFile)(nil),
+		(*GCPSecretManager_Adc)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_sources_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   34,
+			NumMessages:   38,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
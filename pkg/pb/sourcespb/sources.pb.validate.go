@@ -1687,6 +1687,20 @@ func (m *Filesystem) validate(all bool) error {
 
 	// no validation rules for ExcludePathsFile
 
+	// no validation rules for SkipBinaries
+
+	// no validation rules for BinaryNullByteThreshold
+
+	// no validation rules for CheckpointInterval
+
+	// no validation rules for ScanPaths
+
+	// no validation rules for JoinLineContinuations
+
+	// no validation rules for UseIgnoreFiles
+
+	// no validation rules for MmapThreshold
+
 	if len(errors) > 0 {
 		return FilesystemMultiError(errors)
 	}
@@ -1789,6 +1803,164 @@ func (m *GCS) validate(all bool) error {
 
 	// no validation rules for MaxObjectSize
 
+	// no validation rules for SkipBinaries
+
+	// no validation rules for BinaryNullByteThreshold
+
+	// no validation rules for SignedUrlsFile
+
+	// no validation rules for DisableArchiveHandling
+
+	// no validation rules for MaxArchiveDepth
+
+	// no validation rules for MaxArchiveDecompressedSize
+
+	if all {
+		switch v := interface{}(m.GetObjectReadTimeout()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GCSValidationError{
+					field:  "ObjectReadTimeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GCSValidationError{
+					field:  "ObjectReadTimeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetObjectReadTimeout()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GCSValidationError{
+				field:  "ObjectReadTimeout",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for IncludeVersions
+
+	// no validation rules for MaxObjectsPerBucket
+
+	// no validation rules for SampleObjects
+
+	// no validation rules for RangedReadThreshold
+
+	// no validation rules for RangedReadConcurrency
+
+	// no validation rules for ContinueOnBucketError
+
+	// no validation rules for ReportPublicAccess
+
+	// no validation rules for Incremental
+
+	// no validation rules for ScanPaths
+
+	// no validation rules for Ndjson
+
+	// no validation rules for BucketIam
+
+	// no validation rules for Proxy
+
+	// no validation rules for UpdatedSinceLastScan
+
+	if all {
+		switch v := interface{}(m.GetLastScanOverlap()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GCSValidationError{
+					field:  "LastScanOverlap",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GCSValidationError{
+					field:  "LastScanOverlap",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLastScanOverlap()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GCSValidationError{
+				field:  "LastScanOverlap",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for PrivateEndpoint
+
+	// no validation rules for ExcludeUnknownContentTypes
+
+	for idx, item := range m.GetCredentialScopes() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GCSValidationError{
+						field:  fmt.Sprintf("CredentialScopes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GCSValidationError{
+						field:  fmt.Sprintf("CredentialScopes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GCSValidationError{
+					field:  fmt.Sprintf("CredentialScopes[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for JoinLineContinuations
+
+	// no validation rules for SkipArchivedObjects
+
+	// no validation rules for DedupeObjects
+
+	// no validation rules for SoftDeletedObjects
+
+	// no validation rules for SampleRate
+
+	// no validation rules for SampleSeed
+
+	// no validation rules for TruncateLargeObjects
+
+	// no validation rules for ImpersonateServiceAccount
+
+	// no validation rules for InventoryManifest
+
+	// no validation rules for MinObjectSize
+
+	// no validation rules for PrefetchDepth
+
+	// no validation rules for PrefetchMaxBytes
+
 	switch v := m.Credential.(type) {
 	case *GCS_JsonServiceAccount:
 		if v == nil {
@@ -1949,6 +2121,18 @@ func (m *GCS) validate(all bool) error {
 			}
 		}
 
+	case *GCS_WorkloadIdentityFederationFile:
+		if v == nil {
+			err := GCSValidationError{
+				field:  "Credential",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		// no validation rules for WorkloadIdentityFederationFile
 	default:
 		_ = v // ensures v is used
 	}
@@ -2030,51 +2214,58 @@ var _ interface {
 	ErrorName() string
 } = GCSValidationError{}
 
-// Validate checks the field values on Git with the rules defined in the proto
-// definition for this message. If any rules are violated, the first error
-// encountered is returned, or nil if there are no violations.
-func (m *Git) Validate() error {
+// Validate checks the field values on GCSCredentialScope with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GCSCredentialScope) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on Git with the rules defined in the
-// proto definition for this message. If any rules are violated, the result is
-// a list of violation errors wrapped in GitMultiError, or nil if none found.
-func (m *Git) ValidateAll() error {
+// ValidateAll checks the field values on GCSCredentialScope with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GCSCredentialScopeMultiError, or nil if none found.
+func (m *GCSCredentialScope) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *Git) validate(all bool) error {
+func (m *GCSCredentialScope) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Head
-
-	// no validation rules for Base
-
-	// no validation rules for Bare
-
-	// no validation rules for IncludePathsFile
-
-	// no validation rules for ExcludePathsFile
-
-	// no validation rules for ExcludeGlobs
-
-	// no validation rules for MaxDepth
-
-	// no validation rules for Uri
-
-	// no validation rules for SkipBinaries
-
-	// no validation rules for SkipArchives
+	// no validation rules for ProjectId
 
 	switch v := m.Credential.(type) {
-	case *Git_BasicAuth:
+	case *GCSCredentialScope_JsonServiceAccount:
 		if v == nil {
-			err := GitValidationError{
+			err := GCSCredentialScopeValidationError{
+				field:  "Credential",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		// no validation rules for JsonServiceAccount
+	case *GCSCredentialScope_ApiKey:
+		if v == nil {
+			err := GCSCredentialScopeValidationError{
+				field:  "Credential",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		// no validation rules for ApiKey
+	case *GCSCredentialScope_Unauthenticated:
+		if v == nil {
+			err := GCSCredentialScopeValidationError{
 				field:  "Credential",
 				reason: "oneof value cannot be a typed-nil",
 			}
@@ -2085,37 +2276,37 @@ func (m *Git) validate(all bool) error {
 		}
 
 		if all {
-			switch v := interface{}(m.GetBasicAuth()).(type) {
+			switch v := interface{}(m.GetUnauthenticated()).(type) {
 			case interface{ ValidateAll() error }:
 				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, GitValidationError{
-						field:  "BasicAuth",
+					errors = append(errors, GCSCredentialScopeValidationError{
+						field:  "Unauthenticated",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			case interface{ Validate() error }:
 				if err := v.Validate(); err != nil {
-					errors = append(errors, GitValidationError{
-						field:  "BasicAuth",
+					errors = append(errors, GCSCredentialScopeValidationError{
+						field:  "Unauthenticated",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			}
-		} else if v, ok := interface{}(m.GetBasicAuth()).(interface{ Validate() error }); ok {
+		} else if v, ok := interface{}(m.GetUnauthenticated()).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				return GitValidationError{
-					field:  "BasicAuth",
+				return GCSCredentialScopeValidationError{
+					field:  "Unauthenticated",
 					reason: "embedded message failed validation",
 					cause:  err,
 				}
 			}
 		}
 
-	case *Git_Unauthenticated:
+	case *GCSCredentialScope_Adc:
 		if v == nil {
-			err := GitValidationError{
+			err := GCSCredentialScopeValidationError{
 				field:  "Credential",
 				reason: "oneof value cannot be a typed-nil",
 			}
@@ -2126,37 +2317,49 @@ func (m *Git) validate(all bool) error {
 		}
 
 		if all {
-			switch v := interface{}(m.GetUnauthenticated()).(type) {
+			switch v := interface{}(m.GetAdc()).(type) {
 			case interface{ ValidateAll() error }:
 				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, GitValidationError{
-						field:  "Unauthenticated",
+					errors = append(errors, GCSCredentialScopeValidationError{
+						field:  "Adc",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			case interface{ Validate() error }:
 				if err := v.Validate(); err != nil {
-					errors = append(errors, GitValidationError{
-						field:  "Unauthenticated",
+					errors = append(errors, GCSCredentialScopeValidationError{
+						field:  "Adc",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			}
-		} else if v, ok := interface{}(m.GetUnauthenticated()).(interface{ Validate() error }); ok {
+		} else if v, ok := interface{}(m.GetAdc()).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				return GitValidationError{
-					field:  "Unauthenticated",
+				return GCSCredentialScopeValidationError{
+					field:  "Adc",
 					reason: "embedded message failed validation",
 					cause:  err,
 				}
 			}
 		}
 
-	case *Git_SshAuth:
+	case *GCSCredentialScope_ServiceAccountFile:
 		if v == nil {
-			err := GitValidationError{
+			err := GCSCredentialScopeValidationError{
+				field:  "Credential",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		// no validation rules for ServiceAccountFile
+	case *GCSCredentialScope_Oauth:
+		if v == nil {
+			err := GCSCredentialScopeValidationError{
 				field:  "Credential",
 				reason: "oneof value cannot be a typed-nil",
 			}
@@ -2167,51 +2370,64 @@ func (m *Git) validate(all bool) error {
 		}
 
 		if all {
-			switch v := interface{}(m.GetSshAuth()).(type) {
+			switch v := interface{}(m.GetOauth()).(type) {
 			case interface{ ValidateAll() error }:
 				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, GitValidationError{
-						field:  "SshAuth",
+					errors = append(errors, GCSCredentialScopeValidationError{
+						field:  "Oauth",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			case interface{ Validate() error }:
 				if err := v.Validate(); err != nil {
-					errors = append(errors, GitValidationError{
-						field:  "SshAuth",
+					errors = append(errors, GCSCredentialScopeValidationError{
+						field:  "Oauth",
 						reason: "embedded message failed validation",
 						cause:  err,
 					})
 				}
 			}
-		} else if v, ok := interface{}(m.GetSshAuth()).(interface{ Validate() error }); ok {
+		} else if v, ok := interface{}(m.GetOauth()).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				return GitValidationError{
-					field:  "SshAuth",
+				return GCSCredentialScopeValidationError{
+					field:  "Oauth",
 					reason: "embedded message failed validation",
 					cause:  err,
 				}
 			}
 		}
 
+	case *GCSCredentialScope_WorkloadIdentityFederationFile:
+		if v == nil {
+			err := GCSCredentialScopeValidationError{
+				field:  "Credential",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		// no validation rules for WorkloadIdentityFederationFile
 	default:
 		_ = v // ensures v is used
 	}
 
 	if len(errors) > 0 {
-		return GitMultiError(errors)
+		return GCSCredentialScopeMultiError(errors)
 	}
 
 	return nil
 }
 
-// GitMultiError is an error wrapping multiple validation errors returned by
-// Git.ValidateAll() if the designated constraints aren't met.
-type GitMultiError []error
+// GCSCredentialScopeMultiError is an error wrapping multiple validation errors
+// returned by GCSCredentialScope.ValidateAll() if the designated constraints
+// aren't met.
+type GCSCredentialScopeMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GitMultiError) Error() string {
+func (m GCSCredentialScopeMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -2220,11 +2436,11 @@ func (m GitMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GitMultiError) AllErrors() []error { return m }
+func (m GCSCredentialScopeMultiError) AllErrors() []error { return m }
 
-// GitValidationError is the validation error returned by Git.Validate if the
-// designated constraints aren't met.
-type GitValidationError struct {
+// GCSCredentialScopeValidationError is the validation error returned by
+// GCSCredentialScope.Validate if the designated constraints aren't met.
+type GCSCredentialScopeValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -2232,22 +2448,24 @@ type GitValidationError struct {
 }
 
 // Field function returns field value.
-func (e GitValidationError) Field() string { return e.field }
+func (e GCSCredentialScopeValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GitValidationError) Reason() string { return e.reason }
+func (e GCSCredentialScopeValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GitValidationError) Cause() error { return e.cause }
+func (e GCSCredentialScopeValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GitValidationError) Key() bool { return e.key }
+func (e GCSCredentialScopeValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GitValidationError) ErrorName() string { return "GitValidationError" }
+func (e GCSCredentialScopeValidationError) ErrorName() string {
+	return "GCSCredentialScopeValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e GitValidationError) Error() string {
+func (e GCSCredentialScopeValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2259,7 +2477,261 @@ func (e GitValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGit.%s: %s%s",
+		"invalid %sGCSCredentialScope.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GCSCredentialScopeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GCSCredentialScopeValidationError{}
+
+// Validate checks the field values on Git with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Git) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Git with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in GitMultiError, or nil if none found.
+func (m *Git) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Git) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Head
+
+	// no validation rules for Base
+
+	// no validation rules for Bare
+
+	// no validation rules for IncludePathsFile
+
+	// no validation rules for ExcludePathsFile
+
+	// no validation rules for ExcludeGlobs
+
+	// no validation rules for MaxDepth
+
+	// no validation rules for Uri
+
+	// no validation rules for SkipBinaries
+
+	// no validation rules for SkipArchives
+
+	// no validation rules for MaxDepthPerBranch
+
+	// no validation rules for MaxArchiveDepth
+
+	// no validation rules for MaxArchiveDecompressedSize
+
+	// no validation rules for UseIgnoreFiles
+
+	switch v := m.Credential.(type) {
+	case *Git_BasicAuth:
+		if v == nil {
+			err := GitValidationError{
+				field:  "Credential",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetBasicAuth()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GitValidationError{
+						field:  "BasicAuth",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GitValidationError{
+						field:  "BasicAuth",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetBasicAuth()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GitValidationError{
+					field:  "BasicAuth",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Git_Unauthenticated:
+		if v == nil {
+			err := GitValidationError{
+				field:  "Credential",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetUnauthenticated()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GitValidationError{
+						field:  "Unauthenticated",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GitValidationError{
+						field:  "Unauthenticated",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetUnauthenticated()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GitValidationError{
+					field:  "Unauthenticated",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Git_SshAuth:
+		if v == nil {
+			err := GitValidationError{
+				field:  "Credential",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetSshAuth()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GitValidationError{
+						field:  "SshAuth",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GitValidationError{
+						field:  "SshAuth",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetSshAuth()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GitValidationError{
+					field:  "SshAuth",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+
+	if len(errors) > 0 {
+		return GitMultiError(errors)
+	}
+
+	return nil
+}
+
+// GitMultiError is an error wrapping multiple validation errors returned by
+// Git.ValidateAll() if the designated constraints aren't met.
+type GitMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GitMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GitMultiError) AllErrors() []error { return m }
+
+// GitValidationError is the validation error returned by Git.Validate if the
+// designated constraints aren't met.
+type GitValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GitValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GitValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GitValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GitValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GitValidationError) ErrorName() string { return "GitValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GitValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGit.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
@@ -3625,6 +4097,10 @@ func (m *S3) validate(all bool) error {
 
 	// no validation rules for MaxObjectSize
 
+	// no validation rules for ScanPaths
+
+	// no validation rules for RestoreArchivedObjects
+
 	switch v := m.Credential.(type) {
 	case *S3_AccessKey:
 		if v == nil {
@@ -6113,3 +6589,454 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = ElasticsearchValidationError{}
+
+// Validate checks the field values on AzureBlob with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AzureBlob) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AzureBlob with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AzureBlobMultiError, or nil
+// if none found.
+func (m *AzureBlob) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AzureBlob) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for AccountUrl
+
+	// no validation rules for ManagedIdentityClientId
+
+	// no validation rules for MaxBlobSize
+
+	// no validation rules for SkipBinaries
+
+	// no validation rules for DisableArchiveHandling
+
+	switch v := m.Credential.(type) {
+	case *AzureBlob_ConnectionString:
+		if v == nil {
+			err := AzureBlobValidationError{
+				field:  "Credential",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		// no validation rules for ConnectionString
+	case *AzureBlob_SasToken:
+		if v == nil {
+			err := AzureBlobValidationError{
+				field:  "Credential",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		// no validation rules for SasToken
+	case *AzureBlob_ManagedIdentity:
+		if v == nil {
+			err := AzureBlobValidationError{
+				field:  "Credential",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetManagedIdentity()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, AzureBlobValidationError{
+						field:  "ManagedIdentity",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, AzureBlobValidationError{
+						field:  "ManagedIdentity",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetManagedIdentity()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return AzureBlobValidationError{
+					field:  "ManagedIdentity",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+
+	if len(errors) > 0 {
+		return AzureBlobMultiError(errors)
+	}
+
+	return nil
+}
+
+// AzureBlobMultiError is an error wrapping multiple validation errors returned
+// by AzureBlob.ValidateAll() if the designated constraints aren't met.
+type AzureBlobMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AzureBlobMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AzureBlobMultiError) AllErrors() []error { return m }
+
+// AzureBlobValidationError is the validation error returned by
+// AzureBlob.Validate if the designated constraints aren't met.
+type AzureBlobValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AzureBlobValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AzureBlobValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AzureBlobValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AzureBlobValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AzureBlobValidationError) ErrorName() string { return "AzureBlobValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AzureBlobValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAzureBlob.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AzureBlobValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AzureBlobValidationError{}
+
+// Validate checks the field values on GCPSecretManager with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GCPSecretManager) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GCPSecretManager with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GCPSecretManagerMultiError, or nil if none found.
+func (m *GCPSecretManager) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GCPSecretManager) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for ProjectId
+
+	switch v := m.Credential.(type) {
+	case *GCPSecretManager_JsonServiceAccount:
+		if v == nil {
+			err := GCPSecretManagerValidationError{
+				field:  "Credential",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		// no validation rules for JsonServiceAccount
+	case *GCPSecretManager_ServiceAccountFile:
+		if v == nil {
+			err := GCPSecretManagerValidationError{
+				field:  "Credential",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		// no validation rules for ServiceAccountFile
+	case *GCPSecretManager_Adc:
+		if v == nil {
+			err := GCPSecretManagerValidationError{
+				field:  "Credential",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetAdc()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GCPSecretManagerValidationError{
+						field:  "Adc",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GCPSecretManagerValidationError{
+						field:  "Adc",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetAdc()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GCPSecretManagerValidationError{
+					field:  "Adc",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+
+	if len(errors) > 0 {
+		return GCPSecretManagerMultiError(errors)
+	}
+
+	return nil
+}
+
+// GCPSecretManagerMultiError is an error wrapping multiple validation errors
+// returned by GCPSecretManager.ValidateAll() if the designated constraints
+// aren't met.
+type GCPSecretManagerMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GCPSecretManagerMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GCPSecretManagerMultiError) AllErrors() []error { return m }
+
+// GCPSecretManagerValidationError is the validation error returned by
+// GCPSecretManager.Validate if the designated constraints aren't met.
+type GCPSecretManagerValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GCPSecretManagerValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GCPSecretManagerValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GCPSecretManagerValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GCPSecretManagerValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GCPSecretManagerValidationError) ErrorName() string { return "GCPSecretManagerValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GCPSecretManagerValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGCPSecretManager.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GCPSecretManagerValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GCPSecretManagerValidationError{}
+
+// Validate checks the field values on Stdin with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Stdin) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Stdin with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is a
+// list of violation errors wrapped in StdinMultiError, or nil if none found.
+func (m *Stdin) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Stdin) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	if len(errors) > 0 {
+		return StdinMultiError(errors)
+	}
+
+	return nil
+}
+
+// StdinMultiError is an error wrapping multiple validation errors returned by
+// Stdin.ValidateAll() if the designated constraints aren't met.
+type StdinMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StdinMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StdinMultiError) AllErrors() []error { return m }
+
+// StdinValidationError is the validation error returned by Stdin.Validate if
+// the designated constraints aren't met.
+type StdinValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StdinValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StdinValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StdinValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StdinValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StdinValidationError) ErrorName() string { return "StdinValidationError" }
+
+// Error satisfies the builtin error interface
+func (e StdinValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStdin.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StdinValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StdinValidationError{}
@@ -0,0 +1,455 @@
+package azureblob
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/gobwas/glob"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/cache"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/cache/memory"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/handlers"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+const (
+	SourceType = sourcespb.SourceType_SOURCE_TYPE_AZURE_BLOB
+
+	defaultMaxBlobSize           = 10 * 1024 * 1024 // 10MB
+	defaultCachePersistIncrement = 2500
+)
+
+// Ensure the Source satisfies the interfaces at compile time.
+var _ sources.Source = (*Source)(nil)
+var _ sources.SourceUnitUnmarshaller = (*Source)(nil)
+
+// Source scans the blobs of the containers of an Azure Blob Storage account.
+type Source struct {
+	name        string
+	jobId       sources.JobID
+	sourceId    sources.SourceID
+	concurrency int
+	verify      bool
+
+	client  *blobClient
+	account string
+
+	// containerNames are the containers to scan when they are all named
+	// literally, so they don't need to be listed.
+	containerNames []string
+	includeContainers,
+	excludeContainers,
+	includeBlobs,
+	excludeBlobs []glob.Glob
+
+	maxBlobSize int64
+	// skipBinaries skips blobs whose content is binary, as determined by
+	// their content type or content. See common.IsBinaryContent.
+	skipBinaries bool
+	// disableArchiveHandling reads blobs as plain bytes instead of passing
+	// them through handlers.HandleFile, which detects and extracts archives.
+	disableArchiveHandling bool
+
+	log      logr.Logger
+	chunksCh chan *sources.Chunk
+
+	mu               sync.Mutex
+	sources.Progress // progress is not thread safe
+	sources.CommonSourceUnitUnmarshaller
+}
+
+// Type returns the type of source.
+// It is used for matching source types in configuration and job input.
+func (s *Source) Type() sourcespb.SourceType {
+	return SourceType
+}
+
+// SourceID number for the Azure Blob Storage source.
+func (s *Source) SourceID() sources.SourceID {
+	return s.sourceId
+}
+
+// JobID number for the Azure Blob Storage source.
+func (s *Source) JobID() sources.JobID {
+	return s.jobId
+}
+
+// Init returns an initialized Azure Blob Storage source.
+func (s *Source) Init(aCtx context.Context, name string, id sources.JobID, sourceID sources.SourceID, verify bool, connection *anypb.Any, concurrency int) error {
+	s.log = aCtx.Logger()
+
+	s.name = name
+	s.verify = verify
+	s.sourceId = sourceID
+	s.jobId = id
+	s.concurrency = max(concurrency, 1)
+
+	var conn sourcespb.AzureBlob
+	if err := anypb.UnmarshalTo(connection, &conn, proto.UnmarshalOptions{}); err != nil {
+		return fmt.Errorf("error unmarshalling connection: %w", err)
+	}
+
+	endpoint := conn.GetAccountUrl()
+	var auth authorizer
+	switch cred := conn.GetCredential().(type) {
+	case *sourcespb.AzureBlob_ConnectionString:
+		cs, err := parseConnectionString(cred.ConnectionString)
+		if err != nil {
+			return fmt.Errorf("error parsing connection string: %w", err)
+		}
+		endpoint, auth = cs.blobEndpoint, cs.auth
+	case *sourcespb.AzureBlob_SasToken:
+		sas, err := newSASAuthorizer(cred.SasToken)
+		if err != nil {
+			return err
+		}
+		auth = sas
+	case *sourcespb.AzureBlob_ManagedIdentity:
+		mi, err := newManagedIdentityAuthorizer(conn.GetManagedIdentityClientId())
+		if err != nil {
+			return err
+		}
+		auth = mi
+	default:
+		return fmt.Errorf("unknown Azure Blob Storage authentication type: %T", cred)
+	}
+	if endpoint == "" {
+		return fmt.Errorf("account URL is required, unless a connection string is used")
+	}
+
+	client, err := newBlobClient(endpoint, auth)
+	if err != nil {
+		return err
+	}
+	s.client = client
+	s.account = accountName(client.endpoint)
+
+	if s.includeContainers, err = compileGlobs(conn.GetIncludeContainers()); err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(conn.GetIncludeContainers(), isGlob) {
+		s.containerNames = conn.GetIncludeContainers()
+	}
+	if s.excludeContainers, err = compileGlobs(conn.GetExcludeContainers()); err != nil {
+		return err
+	}
+	if s.includeBlobs, err = compileGlobs(conn.GetIncludeBlobs()); err != nil {
+		return err
+	}
+	if s.excludeBlobs, err = compileGlobs(conn.GetExcludeBlobs()); err != nil {
+		return err
+	}
+
+	s.maxBlobSize = conn.GetMaxBlobSize()
+	if s.maxBlobSize <= 0 {
+		s.maxBlobSize = defaultMaxBlobSize
+	}
+	s.skipBinaries = conn.GetSkipBinaries()
+	s.disableArchiveHandling = conn.GetDisableArchiveHandling()
+
+	return nil
+}
+
+// accountName returns the name of the storage account of the blob service
+// endpoint, either from its host, e.g. account.blob.core.windows.net, or from
+// its path, as used by emulators, e.g. 127.0.0.1:10000/devstoreaccount1.
+func accountName(endpoint *url.URL) string {
+	if path := strings.Trim(endpoint.Path, "/"); path != "" {
+		return path
+	}
+	account, _, _ := strings.Cut(endpoint.Hostname(), ".")
+	return account
+}
+
+// isGlob returns true if the pattern has glob syntax, as opposed to being a literal name.
+func isGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, `*?[]{}\!`)
+}
+
+func compileGlobs(patterns []string) ([]glob.Glob, error) {
+	globs := make([]glob.Glob, 0, len(patterns))
+	for _, pattern := range patterns {
+		g, err := glob.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+		globs = append(globs, g)
+	}
+	return globs, nil
+}
+
+// shouldScan returns true if the name matches one of the include globs, if
+// any, and none of the exclude globs.
+func shouldScan(name string, include, exclude []glob.Glob) bool {
+	if len(include) > 0 && !matchesAny(name, include) {
+		return false
+	}
+	return !matchesAny(name, exclude)
+}
+
+func matchesAny(name string, globs []glob.Glob) bool {
+	for _, g := range globs {
+		if g.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Close is a no-op, the source holds no resources.
+func (s *Source) Close() error { return nil }
+
+// Chunks emits chunks of bytes over a channel.
+func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ ...sources.ChunkingTarget) error {
+	s.chunksCh = chunksChan
+	scanned := s.setupCache(ctx)
+
+	blobs, err := s.listBlobs(ctx)
+	if err != nil {
+		return err
+	}
+	s.Progress.Message = "starting to process blobs..."
+
+	var workers errgroup.Group
+	workers.SetLimit(s.concurrency)
+	for _, b := range blobs {
+		if scanned.Exists(b.Properties.Etag) {
+			ctx.Logger().V(5).Info("skipping blob, blob already processed", "container", b.container, "name", b.Name)
+			s.setProgress(ctx, b, scanned, len(blobs))
+			continue
+		}
+
+		release, err := sources.AcquireWorker(ctx)
+		if err != nil {
+			_ = workers.Wait()
+			return fmt.Errorf("error acquiring worker: %w", err)
+		}
+
+		b := b
+		workers.Go(func() error {
+			defer release()
+
+			if err := s.processBlob(ctx, b); err != nil {
+				ctx.Logger().V(1).Info("error processing blob", "container", b.container, "name", b.Name, "error", err)
+				return nil
+			}
+			s.setProgress(ctx, b, scanned, len(blobs))
+			return nil
+		})
+	}
+	_ = workers.Wait()
+
+	msg := fmt.Sprintf("Azure Blob Storage source finished processing %d blobs", len(blobs))
+	ctx.Logger().Info(msg)
+	s.SetProgressComplete(int(s.SectionsCompleted), len(blobs), msg, s.Progress.EncodedResumeInfo)
+	return nil
+}
+
+// listBlobs lists the blobs to scan in the containers to scan, listing
+// containers concurrently. Containers that fail to be listed don't stop the
+// others from being scanned.
+func (s *Source) listBlobs(ctx context.Context) ([]blob, error) {
+	containers, err := s.containers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu    sync.Mutex
+		blobs []blob
+	)
+	listErrs := sources.NewScanErrors()
+	var listers errgroup.Group
+	listers.SetLimit(s.concurrency)
+	for _, name := range containers {
+		name := name
+		listers.Go(func() error {
+			err := s.client.listBlobs(ctx, name, func(page []blob) error {
+				mu.Lock()
+				defer mu.Unlock()
+				for _, b := range page {
+					if s.shouldScanBlob(ctx, b) {
+						blobs = append(blobs, b)
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				listErrs.Add(err)
+			}
+			return nil
+		})
+	}
+	_ = listers.Wait()
+
+	if listErrs.Count() > 0 {
+		ctx.Logger().Error(listErrs.Errors(), "failed to list the blobs of some containers")
+	}
+	ctx.Logger().V(2).Info("listed blobs", "num_containers", len(containers), "num_blobs", len(blobs))
+	return blobs, nil
+}
+
+// containers returns the names of the containers to scan. If the included
+// containers are all named literally, they aren't listed, so a SAS token
+// scoped to the containers is enough.
+func (s *Source) containers(ctx context.Context) ([]string, error) {
+	if len(s.containerNames) > 0 {
+		var containers []string
+		for _, name := range s.containerNames {
+			if shouldScan(name, nil, s.excludeContainers) {
+				containers = append(containers, name)
+			}
+		}
+		return containers, nil
+	}
+
+	listed, err := s.client.listContainers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var containers []string
+	for _, c := range listed {
+		if shouldScan(c.Name, s.includeContainers, s.excludeContainers) {
+			containers = append(containers, c.Name)
+		}
+	}
+	return containers, nil
+}
+
+func (s *Source) shouldScanBlob(ctx context.Context, b blob) bool {
+	if !shouldScan(b.Name, s.includeBlobs, s.excludeBlobs) {
+		return false
+	}
+	if b.Properties.ContentLength <= 0 || b.Properties.ContentLength > s.maxBlobSize {
+		ctx.Logger().V(5).Info("skipping blob, invalid size", "container", b.container, "name", b.Name, "size", b.Properties.ContentLength)
+		return false
+	}
+	return true
+}
+
+func (s *Source) setupCache(ctx context.Context) *persistableCache {
+	c, err := memory.NewFromContents(s.Progress.EncodedResumeInfo)
+	if err != nil {
+		ctx.Logger().Error(err, "ignoring resume info, all blobs will be scanned")
+		c = memory.New[string]()
+	}
+	if c.Count() > 0 {
+		ctx.Logger().V(3).Info("Loaded cache", "num_entries", c.Count())
+	}
+
+	return &persistableCache{persistIncrement: defaultCachePersistIncrement, Cache: c, Progress: &s.Progress}
+}
+
+// persistableCache is a cache of the ETags of the scanned blobs, persisted in
+// the progress of the source at given increments, so an interrupted scan can
+// be resumed.
+type persistableCache struct {
+	persistIncrement int
+	cache.Cache[string]
+	*sources.Progress
+}
+
+// Set overrides the cache Set method of the cache to enable the persistence
+// of the cache contents the Progress of the source at given increments.
+func (c *persistableCache) Set(key string, val string) {
+	c.Cache.Set(key, val)
+	if c.Count()%c.persistIncrement == 0 {
+		c.Progress.EncodedResumeInfo = c.Contents()
+	}
+}
+
+func (s *Source) setProgress(ctx context.Context, b blob, cache cache.Cache[string], total int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx.Logger().V(5).Info("setting progress for blob", "container", b.container, "name", b.Name)
+	s.SectionsCompleted++
+
+	// ETags can contain commas, which separate the persisted keys.
+	if etag := b.Properties.Etag; etag != "" && !strings.Contains(etag, ",") {
+		cache.Set(etag, etag)
+	}
+	s.SetProgressComplete(int(s.SectionsCompleted), total, s.Progress.Message, s.Progress.EncodedResumeInfo)
+}
+
+func (s *Source) processBlob(ctx context.Context, b blob) error {
+	rc, err := s.client.getBlob(ctx, b)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	chunkSkel := &sources.Chunk{
+		SourceName: s.name,
+		SourceType: s.Type(),
+		JobID:      s.JobID(),
+		SourceID:   s.sourceId,
+		Verify:     s.verify,
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_AzureBlob{
+				AzureBlob: &source_metadatapb.AzureBlob{
+					Account:      s.account,
+					Container:    b.container,
+					Blob:         b.Name,
+					Link:         s.client.blobURL(b),
+					ContentType:  b.Properties.ContentType,
+					LastModified: b.Properties.LastModified,
+					Etag:         b.Properties.Etag,
+				},
+			},
+		},
+	}
+	reporter := sources.ChanReporter{Ch: s.chunksCh}
+
+	var reader io.Reader = rc
+	if s.skipBinaries {
+		binary, sniffedReader, err := isBinaryBlob(b, reader)
+		if err != nil {
+			return fmt.Errorf("error reading blob: %w", err)
+		}
+		if binary {
+			ctx.Logger().V(3).Info("skipping binary blob", "container", b.container, "name", b.Name, "content_type", b.Properties.ContentType)
+			return nil
+		}
+		reader = sniffedReader
+	}
+
+	if s.disableArchiveHandling {
+		chunkReader := sources.NewChunkReader()
+		for data := range chunkReader(ctx, reader) {
+			if err := data.Error(); err != nil {
+				return fmt.Errorf("error reading blob: %w", err)
+			}
+			chunk := *chunkSkel
+			chunk.Data = data.Bytes()
+			if err := reporter.ChunkOk(ctx, chunk); err != nil {
+				return fmt.Errorf("error reporting chunk: %w", err)
+			}
+		}
+		return nil
+	}
+	return handlers.HandleFile(ctx, io.NopCloser(reader), chunkSkel, reporter)
+}
+
+// isBinaryBlob determines whether the blob's content is binary from its
+// content type, falling back to sniffing its content when the content type
+// doesn't tell. The returned reader yields the entire content of the blob
+// read from r.
+func isBinaryBlob(b blob, r io.Reader) (bool, io.Reader, error) {
+	if binary, ok := common.IsBinaryContentType(b.Properties.ContentType); ok {
+		return binary, r, nil
+	}
+	return common.SniffBinary(r, 0)
+}
@@ -0,0 +1,371 @@
+package azureblob
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/credentialspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+const (
+	testAccount = "devstoreaccount1"
+	testSAS     = "sv=2021-08-06&ss=b&srt=sco&sp=rl&sig=c2lnbmF0dXJl"
+)
+
+var testKey = base64.StdEncoding.EncodeToString([]byte("test-account-key"))
+
+// fakeBlobService serves the containers and blobs of a storage account, in
+// the path style of the Azurite emulator. Requests must be authorized with
+// either testSAS or a valid shared key signature of testKey. Containers are
+// listed one per page. If listContainers is false, listing containers is
+// forbidden, like with a SAS token scoped to containers.
+func fakeBlobService(t *testing.T, containers map[string]map[string]string, listContainers bool) *httptest.Server {
+	t.Helper()
+
+	names := make([]string, 0, len(containers))
+	for name := range containers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isAuthorized(r) {
+			http.Error(w, "AuthenticationFailed", http.StatusForbidden)
+			return
+		}
+
+		path, ok := strings.CutPrefix(r.URL.Path, "/"+testAccount+"/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		containerName, blobName, _ := strings.Cut(path, "/")
+		query := r.URL.Query()
+
+		switch {
+		case containerName == "" && query.Get("comp") == "list":
+			if !listContainers {
+				http.Error(w, "AuthorizationPermissionMismatch", http.StatusForbidden)
+				return
+			}
+			i := 0
+			if marker := query.Get("marker"); marker != "" {
+				i = sort.SearchStrings(names, marker)
+			}
+			next := ""
+			if i+1 < len(names) {
+				next = names[i+1]
+			}
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?><EnumerationResults><Containers><Container><Name>%s</Name></Container></Containers><NextMarker>%s</NextMarker></EnumerationResults>`, names[i], next)
+		case blobName == "" && query.Get("restype") == "container" && query.Get("comp") == "list":
+			blobs, ok := containers[containerName]
+			if !ok {
+				http.Error(w, "ContainerNotFound", http.StatusNotFound)
+				return
+			}
+			var items strings.Builder
+			for name, content := range blobs {
+				fmt.Fprintf(&items, `<Blob><Name>%s</Name><Properties><Last-Modified>Mon, 01 Jul 2024 00:00:00 GMT</Last-Modified><Etag>%s</Etag><Content-Length>%d</Content-Length><Content-Type>text/plain</Content-Type><BlobType>BlockBlob</BlobType></Properties></Blob>`, name, etag(containerName, name), len(content))
+			}
+			fmt.Fprintf(w, `<?xml version="1.0" encoding="utf-8"?><EnumerationResults><Blobs>%s</Blobs><NextMarker/></EnumerationResults>`, items.String())
+		default:
+			content, ok := containers[containerName][blobName]
+			if !ok {
+				http.Error(w, "BlobNotFound", http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(content))
+		}
+	}))
+}
+
+func etag(container, blob string) string {
+	return fmt.Sprintf("0x%X", sha256.Sum256([]byte(container+"/"+blob)))[:18]
+}
+
+func isAuthorized(r *http.Request) bool {
+	if r.URL.Query().Get("sig") == "c2lnbmF0dXJl" {
+		return true
+	}
+
+	key, _ := base64.StdEncoding.DecodeString(testKey)
+	auth := sharedKeyAuthorizer{account: testAccount, key: key}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(auth.stringToSign(r)))
+	want := fmt.Sprintf("SharedKey %s:%s", testAccount, base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	return r.Header.Get("Authorization") == want
+}
+
+func testConnection(t *testing.T, conn *sourcespb.AzureBlob) *anypb.Any {
+	t.Helper()
+
+	a, err := anypb.New(conn)
+	require.NoError(t, err)
+	return a
+}
+
+// scan runs the source and returns the data of its chunks by blob.
+func scan(t *testing.T, s *Source) map[string]string {
+	t.Helper()
+
+	chunksCh := make(chan *sources.Chunk, 1)
+	go func() {
+		defer close(chunksCh)
+		assert.NoError(t, s.Chunks(context.Background(), chunksCh))
+	}()
+
+	got := make(map[string]string)
+	for chunk := range chunksCh {
+		md := chunk.SourceMetadata.GetAzureBlob()
+		assert.Equal(t, testAccount, md.GetAccount())
+		assert.Equal(t, etag(md.GetContainer(), md.GetBlob()), md.GetEtag())
+		assert.True(t, strings.HasSuffix(md.GetLink(), "/"+testAccount+"/"+md.GetContainer()+"/"+md.GetBlob()))
+		got[md.GetContainer()+"/"+md.GetBlob()] += string(chunk.Data)
+	}
+	return got
+}
+
+var testContainers = map[string]map[string]string{
+	"config": {
+		"app/prod.env": "AWS_SECRET_ACCESS_KEY=secret",
+		"app/dev.env":  "AWS_SECRET_ACCESS_KEY=dev",
+		"readme.md":    "nothing to see",
+	},
+	"logs": {
+		"2024/07/01.log": "token=abc",
+	},
+	"backups": {
+		"db.sql": "password=hunter2",
+	},
+}
+
+func TestSource_Chunks(t *testing.T) {
+	server := fakeBlobService(t, testContainers, true)
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		conn *sourcespb.AzureBlob
+		want map[string]string
+	}{
+		{
+			name: "SAS token",
+			conn: &sourcespb.AzureBlob{
+				AccountUrl: server.URL + "/" + testAccount,
+				Credential: &sourcespb.AzureBlob_SasToken{SasToken: "?" + testSAS},
+			},
+			want: map[string]string{
+				"backups/db.sql":      "password=hunter2",
+				"config/app/dev.env":  "AWS_SECRET_ACCESS_KEY=dev",
+				"config/app/prod.env": "AWS_SECRET_ACCESS_KEY=secret",
+				"config/readme.md":    "nothing to see",
+				"logs/2024/07/01.log": "token=abc",
+			},
+		},
+		{
+			name: "connection string with an account key",
+			conn: &sourcespb.AzureBlob{
+				Credential: &sourcespb.AzureBlob_ConnectionString{
+					ConnectionString: fmt.Sprintf("DefaultEndpointsProtocol=http;AccountName=%s;AccountKey=%s;BlobEndpoint=%s/%s;", testAccount, testKey, server.URL, testAccount),
+				},
+				IncludeContainers: []string{"c*", "logs"},
+				ExcludeBlobs:      []string{"*.md"},
+			},
+			want: map[string]string{
+				"config/app/dev.env":  "AWS_SECRET_ACCESS_KEY=dev",
+				"config/app/prod.env": "AWS_SECRET_ACCESS_KEY=secret",
+				"logs/2024/07/01.log": "token=abc",
+			},
+		},
+		{
+			name: "included and excluded blobs",
+			conn: &sourcespb.AzureBlob{
+				AccountUrl:        server.URL + "/" + testAccount,
+				Credential:        &sourcespb.AzureBlob_SasToken{SasToken: testSAS},
+				ExcludeContainers: []string{"backups"},
+				IncludeBlobs:      []string{"app/*.env", "**.log"},
+				ExcludeBlobs:      []string{"app/dev.env"},
+			},
+			want: map[string]string{
+				"config/app/prod.env": "AWS_SECRET_ACCESS_KEY=secret",
+				"logs/2024/07/01.log": "token=abc",
+			},
+		},
+		{
+			name: "maximum blob size",
+			conn: &sourcespb.AzureBlob{
+				AccountUrl:  server.URL + "/" + testAccount,
+				Credential:  &sourcespb.AzureBlob_SasToken{SasToken: testSAS},
+				MaxBlobSize: 10,
+			},
+			want: map[string]string{
+				"logs/2024/07/01.log": "token=abc",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Source{}
+			require.NoError(t, s.Init(context.Background(), "test", 0, 0, false, testConnection(t, tt.conn), 2))
+
+			assert.Equal(t, tt.want, scan(t, s))
+			assert.Equal(t, int32(len(tt.want)), s.Progress.SectionsCompleted)
+			assert.Equal(t, int64(100), s.Progress.PercentComplete)
+		})
+	}
+}
+
+func TestSource_Chunks_LiteralContainers(t *testing.T) {
+	// Listing containers is forbidden, so the included containers must be
+	// scanned without listing them.
+	server := fakeBlobService(t, testContainers, false)
+	defer server.Close()
+
+	s := &Source{}
+	conn := &sourcespb.AzureBlob{
+		AccountUrl:        server.URL + "/" + testAccount,
+		Credential:        &sourcespb.AzureBlob_SasToken{SasToken: testSAS},
+		IncludeContainers: []string{"logs", "backups"},
+		ExcludeContainers: []string{"backups"},
+	}
+	require.NoError(t, s.Init(context.Background(), "test", 0, 0, false, testConnection(t, conn), 1))
+
+	assert.Equal(t, map[string]string{"logs/2024/07/01.log": "token=abc"}, scan(t, s))
+}
+
+func TestSource_Chunks_Resume(t *testing.T) {
+	server := fakeBlobService(t, testContainers, true)
+	defer server.Close()
+
+	s := &Source{}
+	conn := &sourcespb.AzureBlob{
+		AccountUrl:        server.URL + "/" + testAccount,
+		Credential:        &sourcespb.AzureBlob_SasToken{SasToken: testSAS},
+		IncludeContainers: []string{"config"},
+	}
+	require.NoError(t, s.Init(context.Background(), "test", 0, 0, false, testConnection(t, conn), 1))
+
+	// The blobs scanned before the scan was interrupted are skipped, but
+	// still counted as processed.
+	s.Progress.EncodedResumeInfo = etag("config", "app/prod.env") + "," + etag("config", "readme.md")
+	assert.Equal(t, map[string]string{"config/app/dev.env": "AWS_SECRET_ACCESS_KEY=dev"}, scan(t, s))
+	assert.Equal(t, int32(3), s.Progress.SectionsCompleted)
+}
+
+func TestSource_Init_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		conn *sourcespb.AzureBlob
+	}{
+		{
+			name: "no credential",
+			conn: &sourcespb.AzureBlob{AccountUrl: "https://account.blob.core.windows.net"},
+		},
+		{
+			name: "no account URL",
+			conn: &sourcespb.AzureBlob{Credential: &sourcespb.AzureBlob_SasToken{SasToken: testSAS}},
+		},
+		{
+			name: "SAS token without signature",
+			conn: &sourcespb.AzureBlob{
+				AccountUrl: "https://account.blob.core.windows.net",
+				Credential: &sourcespb.AzureBlob_SasToken{SasToken: "sv=2021-08-06&sp=rl"},
+			},
+		},
+		{
+			name: "invalid glob",
+			conn: &sourcespb.AzureBlob{
+				AccountUrl:   "https://account.blob.core.windows.net",
+				Credential:   &sourcespb.AzureBlob_SasToken{SasToken: testSAS},
+				IncludeBlobs: []string{"[a-"},
+			},
+		},
+		{
+			name: "managed identity with an invalid account URL",
+			conn: &sourcespb.AzureBlob{
+				AccountUrl: "account.blob.core.windows.net",
+				Credential: &sourcespb.AzureBlob_ManagedIdentity{ManagedIdentity: &credentialspb.CloudEnvironment{}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Source{}
+			assert.Error(t, s.Init(context.Background(), "test", 0, 0, false, testConnection(t, tt.conn), 1))
+		})
+	}
+}
+
+func TestParseConnectionString(t *testing.T) {
+	tests := []struct {
+		name         string
+		s            string
+		wantEndpoint string
+		wantAuth     authorizer
+		wantErr      bool
+	}{
+		{
+			name:         "account key",
+			s:            "DefaultEndpointsProtocol=https;AccountName=account;AccountKey=" + testKey + ";EndpointSuffix=core.windows.net",
+			wantEndpoint: "https://account.blob.core.windows.net",
+			wantAuth:     sharedKeyAuthorizer{account: "account", key: []byte("test-account-key")},
+		},
+		{
+			name:         "default protocol and suffix",
+			s:            "AccountName=account;AccountKey=" + testKey,
+			wantEndpoint: "https://account.blob.core.windows.net",
+			wantAuth:     sharedKeyAuthorizer{account: "account", key: []byte("test-account-key")},
+		},
+		{
+			name:         "SAS with blob endpoint",
+			s:            "BlobEndpoint=https://account.blob.core.windows.net/;SharedAccessSignature=" + testSAS,
+			wantEndpoint: "https://account.blob.core.windows.net/",
+			wantAuth:     sasAuthorizer{token: map[string][]string{"sv": {"2021-08-06"}, "ss": {"b"}, "srt": {"sco"}, "sp": {"rl"}, "sig": {"c2lnbmF0dXJl"}}},
+		},
+		{
+			name:    "no credential",
+			s:       "AccountName=account",
+			wantErr: true,
+		},
+		{
+			name:    "invalid account key",
+			s:       "AccountName=account;AccountKey=not base64",
+			wantErr: true,
+		},
+		{
+			name:    "no account",
+			s:       "AccountKey=" + testKey,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseConnectionString(tt.s)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantEndpoint, got.blobEndpoint)
+			assert.Equal(t, tt.wantAuth, got.auth)
+		})
+	}
+}
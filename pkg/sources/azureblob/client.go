@@ -0,0 +1,365 @@
+package azureblob
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/adal"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// apiVersion is the version of the Blob service REST API used. Authorizing
+// with a bearer token requires at least 2017-11-09.
+const apiVersion = "2021-08-06"
+
+// storageResource is the resource that managed identity tokens are requested for.
+const storageResource = "https://storage.azure.com/"
+
+// authorizer adds credentials to requests to the Blob service.
+type authorizer interface {
+	authorize(ctx context.Context, req *http.Request) error
+}
+
+// sharedKeyAuthorizer signs requests with the account key.
+// https://learn.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key
+type sharedKeyAuthorizer struct {
+	account string
+	key     []byte
+}
+
+func (a sharedKeyAuthorizer) authorize(_ context.Context, req *http.Request) error {
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(a.stringToSign(req)))
+	req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", a.account, base64.StdEncoding.EncodeToString(mac.Sum(nil))))
+	return nil
+}
+
+func (a sharedKeyAuthorizer) stringToSign(req *http.Request) string {
+	contentLength := req.Header.Get("Content-Length")
+	if contentLength == "0" {
+		contentLength = ""
+	}
+
+	var msHeaders []string
+	for name := range req.Header {
+		if name := strings.ToLower(name); strings.HasPrefix(name, "x-ms-") {
+			msHeaders = append(msHeaders, name)
+		}
+	}
+	sort.Strings(msHeaders)
+	var canonicalHeaders strings.Builder
+	for _, name := range msHeaders {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, strings.TrimSpace(req.Header.Get(name)))
+	}
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalResource := "/" + a.account + path
+	query := req.URL.Query()
+	params := make([]string, 0, len(query))
+	for name := range query {
+		params = append(params, name)
+	}
+	sort.Strings(params)
+	for _, name := range params {
+		values := query[name]
+		sort.Strings(values)
+		canonicalResource += fmt.Sprintf("\n%s:%s", strings.ToLower(name), strings.Join(values, ","))
+	}
+
+	return strings.Join([]string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"", // Date, x-ms-date is used instead.
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+		canonicalHeaders.String() + canonicalResource,
+	}, "\n")
+}
+
+// sasAuthorizer appends a shared access signature to the query of requests.
+type sasAuthorizer struct{ token url.Values }
+
+func newSASAuthorizer(token string) (sasAuthorizer, error) {
+	values, err := url.ParseQuery(strings.TrimPrefix(token, "?"))
+	if err != nil {
+		return sasAuthorizer{}, fmt.Errorf("invalid SAS token: %w", err)
+	}
+	if values.Get("sig") == "" {
+		return sasAuthorizer{}, fmt.Errorf("invalid SAS token: missing signature")
+	}
+	return sasAuthorizer{token: values}, nil
+}
+
+func (a sasAuthorizer) authorize(_ context.Context, req *http.Request) error {
+	query := req.URL.Query()
+	for name, values := range a.token {
+		query[name] = values
+	}
+	req.URL.RawQuery = query.Encode()
+	return nil
+}
+
+// tokenAuthorizer authorizes requests with an OAuth token of a managed identity.
+type tokenAuthorizer struct{ token *adal.ServicePrincipalToken }
+
+func newManagedIdentityAuthorizer(clientID string) (tokenAuthorizer, error) {
+	token, err := adal.NewServicePrincipalTokenFromManagedIdentity(storageResource, &adal.ManagedIdentityOptions{ClientID: clientID})
+	if err != nil {
+		return tokenAuthorizer{}, fmt.Errorf("error creating managed identity token: %w", err)
+	}
+	return tokenAuthorizer{token: token}, nil
+}
+
+func (a tokenAuthorizer) authorize(ctx context.Context, req *http.Request) error {
+	if err := a.token.EnsureFreshWithContext(ctx); err != nil {
+		return fmt.Errorf("error refreshing managed identity token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+a.token.OAuthToken())
+	return nil
+}
+
+// connectionString is the parsed connection string of a storage account.
+type connectionString struct {
+	blobEndpoint string
+	auth         authorizer
+}
+
+// parseConnectionString parses a storage account connection string, which
+// authorizes with either an account key or a shared access signature.
+// https://learn.microsoft.com/en-us/azure/storage/common/storage-configure-connection-string
+func parseConnectionString(s string) (connectionString, error) {
+	settings := make(map[string]string)
+	for _, part := range strings.Split(s, ";") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return connectionString{}, fmt.Errorf("invalid connection string setting %q", key)
+		}
+		settings[strings.ToLower(key)] = value
+	}
+
+	var cs connectionString
+	account := settings["accountname"]
+	cs.blobEndpoint = settings["blobendpoint"]
+	if cs.blobEndpoint == "" {
+		if account == "" {
+			return connectionString{}, fmt.Errorf("connection string must have an AccountName or a BlobEndpoint")
+		}
+		protocol, suffix := settings["defaultendpointsprotocol"], settings["endpointsuffix"]
+		if protocol == "" {
+			protocol = "https"
+		}
+		if suffix == "" {
+			suffix = "core.windows.net"
+		}
+		cs.blobEndpoint = fmt.Sprintf("%s://%s.blob.%s", protocol, account, suffix)
+	}
+
+	switch {
+	case settings["sharedaccesssignature"] != "":
+		auth, err := newSASAuthorizer(settings["sharedaccesssignature"])
+		if err != nil {
+			return connectionString{}, err
+		}
+		cs.auth = auth
+	case settings["accountkey"] != "":
+		if account == "" {
+			return connectionString{}, fmt.Errorf("connection string with an AccountKey must have an AccountName")
+		}
+		key, err := base64.StdEncoding.DecodeString(settings["accountkey"])
+		if err != nil {
+			return connectionString{}, fmt.Errorf("invalid account key: %w", err)
+		}
+		cs.auth = sharedKeyAuthorizer{account: account, key: key}
+	default:
+		return connectionString{}, fmt.Errorf("connection string must have an AccountKey or a SharedAccessSignature")
+	}
+	return cs, nil
+}
+
+// blobClient is a minimal client of the Blob service REST API.
+// https://learn.microsoft.com/en-us/rest/api/storageservices/blob-service-rest-api
+type blobClient struct {
+	endpoint   *url.URL
+	auth       authorizer
+	httpClient *http.Client
+}
+
+func newBlobClient(endpoint string, auth authorizer) (*blobClient, error) {
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid blob service URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid blob service URL %q", endpoint)
+	}
+	return &blobClient{endpoint: u, auth: auth, httpClient: common.RetryableHTTPClient()}, nil
+}
+
+// container is a container of blobs.
+type container struct {
+	Name string `xml:"Name"`
+}
+
+// blob is a blob, as listed in a container.
+type blob struct {
+	Name       string `xml:"Name"`
+	Properties struct {
+		LastModified  string `xml:"Last-Modified"`
+		Etag          string `xml:"Etag"`
+		ContentLength int64  `xml:"Content-Length"`
+		ContentType   string `xml:"Content-Type"`
+		BlobType      string `xml:"BlobType"`
+	} `xml:"Properties"`
+
+	container string
+}
+
+type listContainersResponse struct {
+	Containers []container `xml:"Containers>Container"`
+	NextMarker string      `xml:"NextMarker"`
+}
+
+type listBlobsResponse struct {
+	Blobs      []blob `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+// listContainers returns the containers of the account.
+func (c *blobClient) listContainers(ctx context.Context) ([]container, error) {
+	var containers []container
+	marker := ""
+	for {
+		query := url.Values{"comp": {"list"}}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+
+		var resp listContainersResponse
+		if err := c.getXML(ctx, "", query, &resp); err != nil {
+			return nil, fmt.Errorf("failed to list containers: %w", err)
+		}
+		containers = append(containers, resp.Containers...)
+		if marker = resp.NextMarker; marker == "" {
+			return containers, nil
+		}
+	}
+}
+
+// listBlobs calls fn with each page of the blobs of the container.
+func (c *blobClient) listBlobs(ctx context.Context, name string, fn func([]blob) error) error {
+	marker := ""
+	for {
+		query := url.Values{"restype": {"container"}, "comp": {"list"}}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+
+		var resp listBlobsResponse
+		if err := c.getXML(ctx, url.PathEscape(name), query, &resp); err != nil {
+			return fmt.Errorf("failed to list blobs of container %s: %w", name, err)
+		}
+		for i := range resp.Blobs {
+			resp.Blobs[i].container = name
+		}
+		if err := fn(resp.Blobs); err != nil {
+			return err
+		}
+		if marker = resp.NextMarker; marker == "" {
+			return nil
+		}
+	}
+}
+
+// getBlob returns the content of the blob. The caller must close it.
+func (c *blobClient) getBlob(ctx context.Context, b blob) (io.ReadCloser, error) {
+	resp, err := c.do(ctx, url.PathEscape(b.container)+"/"+escapeBlobName(b.Name), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob %s/%s: %w", b.container, b.Name, err)
+	}
+	return resp.Body, nil
+}
+
+// blobURL returns the URL of the blob, without credentials.
+func (c *blobClient) blobURL(b blob) string {
+	return c.endpoint.String() + "/" + url.PathEscape(b.container) + "/" + escapeBlobName(b.Name)
+}
+
+// escapeBlobName escapes the segments of the blob name, keeping the slashes
+// that blob names commonly use as directory separators.
+func escapeBlobName(name string) string {
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func (c *blobClient) getXML(ctx context.Context, path string, query url.Values, v any) error {
+	resp, err := c.do(ctx, path, query)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := xml.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// do sends an authorized GET request for the path, relative to the endpoint,
+// and returns the response if it succeeded.
+func (c *blobClient) do(ctx context.Context, path string, query url.Values) (*http.Response, error) {
+	u := *c.endpoint
+	u.RawPath = c.endpoint.EscapedPath() + "/" + path
+	u.Path, _ = url.PathUnescape(u.RawPath)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-ms-version", apiVersion)
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	if c.auth != nil {
+		if err := c.auth.authorize(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected HTTP response status %d: %s", resp.StatusCode, strconv.Quote(strings.TrimSpace(string(body))))
+	}
+	return resp, nil
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
@@ -18,6 +19,27 @@ const (
 	TotalChunkSize = ChunkSize + PeekSize
 )
 
+// ValidateChunkOverlap returns an error if chunks of chunkSize bytes can't
+// overlap by overlap bytes. The overlap must be shorter than the chunks, or
+// each chunk would be entirely contained in the previous one.
+func ValidateChunkOverlap(overlap, chunkSize int) error {
+	if overlap < 0 {
+		return fmt.Errorf("chunk overlap must not be negative, got %d", overlap)
+	}
+	if overlap >= chunkSize {
+		return fmt.Errorf("chunk overlap of %d bytes must be smaller than the chunk size of %d bytes", overlap, chunkSize)
+	}
+	return nil
+}
+
+type chunkOverlapKey struct{}
+
+// withChunkOverlap returns a context in which the chunk readers that don't set
+// their own peek size overlap chunks by overlap bytes rather than PeekSize.
+func withChunkOverlap(ctx context.Context, overlap int) context.Context {
+	return context.WithValue(ctx, chunkOverlapKey{}, overlap)
+}
+
 // Chunker takes a chunk and splits it into chunks of ChunkSize.
 func Chunker(originalChunk *Chunk) chan *Chunk {
 	chunkChan := make(chan *Chunk, 1)
@@ -51,8 +73,12 @@ func Chunker(originalChunk *Chunk) chan *Chunk {
 
 type chunkReaderConfig struct {
 	chunkSize int
-	totalSize int
-	peekSize  int
+	// peekSize is the overlap of the chunks, or -1 for the one of the context
+	// the chunks are read with, see withChunkOverlap.
+	peekSize int
+	// joinLineContinuations joins the lines continued with a trailing
+	// backslash before the data is chunked.
+	joinLineContinuations bool
 }
 
 // ConfigOption is a function that configures a chunker.
@@ -65,13 +91,45 @@ func WithChunkSize(size int) ConfigOption {
 	}
 }
 
-// WithPeekSize sets the peek size.
+// WithPeekSize sets the peek size, the number of bytes of the next chunk each
+// chunk is followed by, overriding the chunk overlap the SourceManager runs the
+// source with, see WithChunkOverlap.
 func WithPeekSize(size int) ConfigOption {
 	return func(c *chunkReaderConfig) {
 		c.peekSize = size
 	}
 }
 
+// WithLineContinuations joins the lines continued with a trailing backslash,
+// so secrets wrapped across lines are found whole. The joined data keeps the
+// size and line count of the original data, see lineContinuationReader.
+func WithLineContinuations() ConfigOption {
+	return func(c *chunkReaderConfig) {
+		c.joinLineContinuations = true
+	}
+}
+
+// ChunkSplitter splits the data read from a reader into chunks to scan.
+// Sources that benefit from a different chunking strategy than the default
+// one, e.g. small chunks for config files or large ones for logs, can use
+// their own.
+// Consecutive chunks should overlap, so secrets that straddle the boundary
+// between two chunks are still found whole in one of them.
+type ChunkSplitter interface {
+	// Chunk returns a channel of the chunks of the data read from reader,
+	// which is closed once the reader is exhausted, an error is returned, or
+	// the context is done.
+	Chunk(ctx context.Context, reader io.Reader) <-chan ChunkResult
+}
+
+// DefaultChunkSplitter returns the ChunkSplitter used by sources unless they
+// provide their own, which reads chunks of ChunkSize bytes, each followed by
+// the first bytes of the next one, PeekSize unless the SourceManager runs the
+// source with another overlap, see WithChunkOverlap.
+func DefaultChunkSplitter() ChunkSplitter {
+	return NewChunkReader()
+}
+
 // ChunkResult is the output unit of a ChunkReader,
 // it contains the data and error of a chunk.
 type ChunkResult struct {
@@ -79,6 +137,12 @@ type ChunkResult struct {
 	err  error
 }
 
+// NewChunkResult returns a ChunkResult of the data, or of the error that
+// occurred reading it, for use by ChunkSplitter implementations.
+func NewChunkResult(data []byte, err error) ChunkResult {
+	return ChunkResult{data: data, err: err}
+}
+
 // Bytes for a ChunkResult.
 func (cr ChunkResult) Bytes() []byte {
 	return cr.data
@@ -95,6 +159,14 @@ func (cr ChunkResult) Error() error {
 // Ex: reading attachments, archives, etc.
 type ChunkReader func(ctx context.Context, reader io.Reader) <-chan ChunkResult
 
+// Ensure ChunkReader satisfies the interface at compile time.
+var _ ChunkSplitter = ChunkReader(nil)
+
+// Chunk calls the ChunkReader, so it can be used as a ChunkSplitter.
+func (r ChunkReader) Chunk(ctx context.Context, reader io.Reader) <-chan ChunkResult {
+	return r(ctx, reader)
+}
+
 // NewChunkReader returns a ChunkReader with the given options.
 func NewChunkReader(opts ...ConfigOption) ChunkReader {
 	config := applyOptions(opts)
@@ -105,15 +177,13 @@ func applyOptions(opts []ConfigOption) *chunkReaderConfig {
 	// Set defaults.
 	config := &chunkReaderConfig{
 		chunkSize: ChunkSize, // default
-		peekSize:  PeekSize,  // default
+		peekSize:  -1,        // default
 	}
 
 	for _, opt := range opts {
 		opt(config)
 	}
 
-	config.totalSize = config.chunkSize + config.peekSize
-
 	return config
 }
 
@@ -125,19 +195,36 @@ func createReaderFn(config *chunkReaderConfig) ChunkReader {
 
 func readInChunks(ctx context.Context, reader io.Reader, config *chunkReaderConfig) <-chan ChunkResult {
 	const channelSize = 1
+	if config.joinLineContinuations {
+		reader = newLineContinuationReader(reader)
+	}
 	chunkReader := bufio.NewReaderSize(reader, config.chunkSize)
 	chunkResultChan := make(chan ChunkResult, channelSize)
 
+	peekSize := config.peekSize
+	if peekSize < 0 {
+		peekSize = PeekSize
+		if overlap, ok := ctx.Value(chunkOverlapKey{}).(int); ok {
+			if err := ValidateChunkOverlap(overlap, config.chunkSize); err != nil {
+				chunkResultChan <- ChunkResult{err: err}
+				close(chunkResultChan)
+				return chunkResultChan
+			}
+			peekSize = overlap
+		}
+	}
+	totalSize := config.chunkSize + peekSize
+
 	go func() {
 		defer close(chunkResultChan)
 
 		for {
 			chunkRes := ChunkResult{}
-			chunkBytes := make([]byte, config.totalSize)
+			chunkBytes := make([]byte, totalSize)
 			chunkBytes = chunkBytes[:config.chunkSize]
 			n, err := io.ReadFull(chunkReader, chunkBytes)
 			if n > 0 {
-				peekData, _ := chunkReader.Peek(config.totalSize - n)
+				peekData, _ := chunkReader.Peek(totalSize - n)
 				chunkBytes = append(chunkBytes[:n], peekData...)
 				chunkRes.data = chunkBytes
 			}
@@ -35,6 +35,7 @@ type Source struct {
 	sources.Progress
 	client *http.Client
 	sources.CommonSourceUnitUnmarshaller
+	sources.NoopCloser
 }
 
 // Ensure the Source satisfies the interfaces at compile time.
@@ -91,6 +92,12 @@ func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ .
 	for _, proj := range projects {
 		proj := proj
 		s.jobPool.Go(func() error {
+			release, err := sources.AcquireWorker(ctx)
+			if err != nil {
+				return nil
+			}
+			defer release()
+
 			builds, err := s.buildsForProject(ctx, proj)
 			if err != nil {
 				scanErrs.Add(fmt.Errorf("error getting builds for project %s: %w", proj.RepoName, err))
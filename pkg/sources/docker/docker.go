@@ -2,15 +2,16 @@ package docker
 
 import (
 	"archive/tar"
-	"compress/gzip"
 	"errors"
 	"fmt"
 	"io"
+	"path"
 	"strings"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/go-containerregistry/pkg/v1/tarball"
 	"golang.org/x/sync/errgroup"
@@ -19,6 +20,7 @@ import (
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/handlers"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
@@ -35,6 +37,7 @@ type Source struct {
 	conn        sourcespb.Docker
 	sources.Progress
 	sources.CommonSourceUnitUnmarshaller
+	sources.NoopCloser
 }
 
 // Ensure the Source satisfies the interfaces at compile time.
@@ -109,6 +112,12 @@ func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ .
 				return nil
 			}
 
+			release, err := sources.AcquireWorker(ctx)
+			if err != nil {
+				return nil
+			}
+			defer release()
+
 			imgInfo, err := s.processImage(ctx, image)
 			if err != nil {
 				scanErrs.Add(err)
@@ -175,15 +184,25 @@ func (s *Source) processImage(ctx context.Context, image string) (imageInfo, err
 		return imgInfo, err
 	}
 
-	const filePrefix = "file://"
-	if strings.HasPrefix(image, filePrefix) {
+	const (
+		filePrefix = "file://"
+		ociPrefix  = "oci://"
+	)
+	switch {
+	case strings.HasPrefix(image, filePrefix):
 		image = strings.TrimPrefix(image, filePrefix)
 		imgInfo.base = image
 		imgInfo.image, err = tarball.ImageFromPath(image, nil)
 		if err != nil {
 			return imgInfo, err
 		}
-	} else {
+	case strings.HasPrefix(image, ociPrefix):
+		imgInfo.base, imgInfo.tag = splitLayoutRef(strings.TrimPrefix(image, ociPrefix))
+		imgInfo.image, err = layoutImage(imgInfo.base, imgInfo.tag)
+		if err != nil {
+			return imgInfo, err
+		}
+	default:
 		imgInfo.base, imgInfo.tag, hasDigest = baseAndTagFromImage(image)
 
 		if hasDigest {
@@ -206,6 +225,66 @@ func (s *Source) processImage(ctx context.Context, image string) (imageInfo, err
 	return imgInfo, nil
 }
 
+// ociRefNameAnnotation is the annotation of the images of an OCI image layout
+// that holds their reference name, usually their tag.
+const ociRefNameAnnotation = "org.opencontainers.image.ref.name"
+
+// splitLayoutRef splits the directory of an OCI image layout from the digest
+// or reference name of the image to scan in it, as in "dir@sha256:..." or
+// "dir:tag".
+func splitLayoutRef(image string) (dir, ref string) {
+	if dir, digest, ok := strings.Cut(image, "@"); ok {
+		return dir, digest
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i], image[i+1:]
+	}
+	return image, ""
+}
+
+// layoutImage returns the image of the OCI image layout in dir whose digest
+// or reference name is ref, or its only image if ref is empty.
+func layoutImage(dir, ref string) (v1.Image, error) {
+	index, err := layout.ImageIndexFromPath(dir)
+	if err != nil {
+		return nil, err
+	}
+	return imageFromIndex(index, ref)
+}
+
+// imageFromIndex returns the image of index whose digest or reference name is
+// ref, or its only image if ref is empty. Nested indexes must hold a single
+// image.
+func imageFromIndex(index v1.ImageIndex, ref string) (v1.Image, error) {
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []v1.Descriptor
+	for _, desc := range manifest.Manifests {
+		if ref == "" || desc.Digest.String() == ref || desc.Annotations[ociRefNameAnnotation] == ref {
+			matches = append(matches, desc)
+		}
+	}
+	switch {
+	case len(matches) == 0:
+		return nil, fmt.Errorf("no image %q in the OCI image layout", ref)
+	case len(matches) > 1:
+		return nil, fmt.Errorf("%d images in the OCI image layout, select one by its digest or reference name", len(matches))
+	}
+
+	desc := matches[0]
+	if desc.MediaType.IsIndex() {
+		nested, err := index.ImageIndex(desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+		return imageFromIndex(nested, "")
+	}
+	return index.Image(desc.Digest)
+}
+
 // getHistoryEntries collates an image's configuration history together with the
 // corresponding layer digests for any non-empty layers.
 func getHistoryEntries(ctx context.Context, imgInfo imageInfo) ([]historyEntryInfo, error) {
@@ -286,6 +365,10 @@ func (s *Source) processHistoryEntry(ctx context.Context, historyInfo historyEnt
 	return common.CancellableWrite(ctx, chunksChan, chunk)
 }
 
+// whiteoutPrefix is the prefix of the names of whiteout files, see
+// https://github.com/opencontainers/image-spec/blob/main/layer.md#whiteouts.
+const whiteoutPrefix = ".wh."
+
 // processLayer processes an individual layer of an image.
 func (s *Source) processLayer(ctx context.Context, layer v1.Layer, imgInfo imageInfo, chunksChan chan *sources.Chunk) error {
 	layerInfo := layerInfo{
@@ -301,19 +384,15 @@ func (s *Source) processLayer(ctx context.Context, layer v1.Layer, imgInfo image
 
 	ctx.Logger().WithValues("layer", layerInfo.digest.String()).V(2).Info("scanning layer")
 
-	rc, err := layer.Compressed()
+	// Layers may be compressed with gzip or zstd, or not at all, e.g. in OCI
+	// image layouts.
+	rc, err := layer.Uncompressed()
 	if err != nil {
 		return err
 	}
 	defer rc.Close()
 
-	gzipReader, err := gzip.NewReader(rc)
-	if err != nil {
-		return err
-	}
-	defer gzipReader.Close()
-
-	tarReader := tar.NewReader(gzipReader)
+	tarReader := tar.NewReader(rc)
 	for {
 		header, err := tarReader.Next()
 		if errors.Is(err, io.EOF) {
@@ -323,6 +402,12 @@ func (s *Source) processLayer(ctx context.Context, layer v1.Layer, imgInfo image
 			return err
 		}
 
+		// Only regular files have content. Whiteout files mark the files
+		// deleted from the layers below, and are empty.
+		if header.Typeflag != tar.TypeReg || strings.HasPrefix(path.Base(header.Name), whiteoutPrefix) {
+			continue
+		}
+
 		info := chunkProcessingInfo{size: header.Size, name: header.Name, reader: tarReader, layer: layerInfo}
 		if err := s.processChunk(ctx, info, chunksChan); err != nil {
 			return err
@@ -347,36 +432,30 @@ func (s *Source) processChunk(ctx context.Context, info chunkProcessingInfo, chu
 		return nil
 	}
 
-	chunkReader := sources.NewChunkReader()
-	chunkResChan := chunkReader(ctx, info.reader)
-
-	for data := range chunkResChan {
-		if err := data.Error(); err != nil {
-			ctx.Logger().Error(err, "error reading chunk.")
-			continue
-		}
-
-		chunk := &sources.Chunk{
-			SourceType: s.Type(),
-			SourceName: s.name,
-			SourceID:   s.SourceID(),
-			SourceMetadata: &source_metadatapb.MetaData{
-				Data: &source_metadatapb.MetaData_Docker{
-					Docker: &source_metadatapb.Docker{
-						File:  "/" + info.name,
-						Image: info.layer.base,
-						Tag:   info.layer.tag,
-						Layer: info.layer.digest.String(),
-					},
+	chunkSkel := &sources.Chunk{
+		SourceType: s.Type(),
+		SourceName: s.name,
+		SourceID:   s.SourceID(),
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Docker{
+				Docker: &source_metadatapb.Docker{
+					File:  "/" + info.name,
+					Image: info.layer.base,
+					Tag:   info.layer.tag,
+					Layer: info.layer.digest.String(),
 				},
 			},
-			Verify: s.verify,
-		}
-		chunk.Data = data.Bytes()
+		},
+		Verify: s.verify,
+	}
 
-		if err := common.CancellableWrite(ctx, chunksChan, chunk); err != nil {
-			return err
+	// Archives in the layer, e.g. jars or tarballs, are extracted by the
+	// handlers and their files scanned too.
+	if err := handlers.HandleFile(ctx, io.NopCloser(info.reader), chunkSkel, sources.ChanReporter{Ch: chunksChan}); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
 		}
+		ctx.Logger().Error(err, "error handling file", "file", info.name)
 	}
 
 	return nil
@@ -33,6 +33,7 @@ type Source struct {
 	client         *es.TypedClient
 	log            logr.Logger
 	sources.Progress
+	sources.NoopCloser
 }
 
 // Init returns an initialized Elasticsearch source
@@ -166,6 +167,12 @@ func (s *Source) Chunks(
 			uow := outerUOW
 
 			workerPool.Go(func() error {
+				release, err := sources.AcquireWorker(ctx)
+				if err != nil {
+					return nil
+				}
+				defer release()
+
 				// Give each worker its own client
 				client, err := es.NewTypedClient(s.esConfig)
 				if err != nil {
@@ -0,0 +1,179 @@
+package filesystem
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"strconv"
+	"sync"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/cache"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/cache/memory"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/handlers"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// defaultCheckpointInterval is the number of scanned files between persisting
+// the resume info, if the connection doesn't set one.
+const defaultCheckpointInterval = 1000
+
+// scannedFiles tracks the files that were fully scanned, so an interrupted
+// scan can be resumed without scanning them again. The files are persisted in
+// the EncodedResumeInfo of the source progress every interval files.
+type scannedFiles struct {
+	mu       sync.Mutex
+	interval int
+	cache    cache.Cache[string]
+	progress *sources.Progress
+}
+
+// loadScannedFiles returns the files scanned before the scan was interrupted,
+// as persisted in the progress of the source.
+func (s *Source) loadScannedFiles(ctx context.Context) *scannedFiles {
+	c, err := memory.NewFromContents(s.Progress.EncodedResumeInfo)
+	if err != nil {
+		ctx.Logger().Error(err, "ignoring resume info, all files will be scanned")
+		c = memory.New[string]()
+	}
+	if c.Count() > 0 {
+		ctx.Logger().V(3).Info("loaded scanned files", "num_files", c.Count())
+	}
+
+	return &scannedFiles{interval: s.checkpointInterval, cache: c, progress: &s.Progress}
+}
+
+// scannedFileKey identifies a file by its path, size, and modification time,
+// so a file that changed since it was scanned is scanned again. The key is a
+// hash because paths can contain the separator of the persisted keys.
+func scannedFileKey(path string, info fs.FileInfo) string {
+	h := sha256.New()
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(info.Size(), 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(info.ModTime().UnixNano(), 10)))
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// has returns true if the file with the key was already scanned.
+func (f *scannedFiles) has(key string) bool {
+	if f == nil {
+		return false
+	}
+	return f.cache.Exists(key)
+}
+
+// add records the file with the key as scanned, and persists the scanned
+// files every interval files.
+func (f *scannedFiles) add(key string) {
+	if f == nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.cache.Set(key, key)
+	if f.cache.Count()%f.interval == 0 {
+		f.progress.EncodedResumeInfo = f.cache.Contents()
+	}
+}
+
+// remove forgets the keys, without persisting the scanned files.
+func (f *scannedFiles) remove(keys []string) {
+	if f == nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, key := range keys {
+		f.cache.Delete(key)
+	}
+}
+
+// persist stores all the scanned files in the progress of the source.
+func (f *scannedFiles) persist() {
+	if f == nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.progress.EncodedResumeInfo = f.cache.Contents()
+}
+
+// encoded returns the scanned files as last persisted.
+func (f *scannedFiles) encoded() string {
+	if f == nil {
+		return ""
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.progress.EncodedResumeInfo
+}
+
+// archiveEntries are the entries of an archive file whose content was
+// reported, kept with the scanned files so that the extraction of an archive
+// interrupted midway resumes after them. Their keys are removed once the whole
+// file is scanned, as the key of the file then covers them.
+type archiveEntries struct {
+	files   *scannedFiles
+	fileKey string
+
+	// keys are the keys of the entries processed, by this scan or an earlier
+	// one. They're guarded by mu, as entries are checked while extracting and
+	// marked while reporting.
+	mu   sync.Mutex
+	keys []string
+}
+
+var _ handlers.ArchiveCheckpoint = (*archiveEntries)(nil)
+
+// archiveEntries returns the checkpoint of the entries of the archive file
+// with the key.
+func (f *scannedFiles) archiveEntries(fileKey string) *archiveEntries {
+	return &archiveEntries{files: f, fileKey: fileKey}
+}
+
+// archiveEntryKey identifies an entry by the key of its archive file and its
+// path within it. The path is hashed, like that of the file.
+func archiveEntryKey(fileKey, entryPath string) string {
+	h := sha256.Sum256([]byte(entryPath))
+	return fileKey + "-" + hex.EncodeToString(h[:8])
+}
+
+// Processed returns true if the entry was processed before the scan was
+// interrupted.
+func (e *archiveEntries) Processed(entryPath string) bool {
+	key := archiveEntryKey(e.fileKey, entryPath)
+	if !e.files.has(key) {
+		return false
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.keys = append(e.keys, key)
+	return true
+}
+
+// MarkProcessed records the entry as processed, persisted with the scanned
+// files.
+func (e *archiveEntries) MarkProcessed(entryPath string) {
+	key := archiveEntryKey(e.fileKey, entryPath)
+	e.files.add(key)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.keys = append(e.keys, key)
+}
+
+// done forgets the entries once the whole archive file was scanned.
+func (e *archiveEntries) done() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.files.remove(e.keys)
+	e.keys = nil
+}
@@ -14,6 +14,8 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/glob"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/ignore"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/handlers"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
@@ -33,8 +35,32 @@ type Source struct {
 	paths       []string
 	log         logr.Logger
 	filter      *common.Filter
+	// pathAllowlist skips files whose path matches one of its globs.
+	pathAllowlist *glob.Filter
+	// skipBinaries skips files whose content is binary, as determined by
+	// binaryThreshold. See common.IsBinaryContent.
+	skipBinaries    bool
+	binaryThreshold float64
+	// mmapThreshold is the file size above which files are memory-mapped
+	// rather than read with buffered IO. Zero disables memory-mapping.
+	mmapThreshold int64
+	// checkpointInterval is the number of scanned files between persisting
+	// the scanned files in the resume info.
+	checkpointInterval int
+	// scanPaths also scans the paths of the files, see scanPath.
+	scanPaths bool
+	// joinLineContinuations joins the lines continued with a trailing
+	// backslash before files are chunked, see handlers.WithLineContinuations.
+	joinLineContinuations bool
+	// useIgnoreFiles skips the paths listed by the .trufflehogignore files
+	// of scanned directories, see ignore.Matcher.
+	useIgnoreFiles bool
+	// scanned are the files already scanned, skipped when resuming a scan.
+	// It is only used by Chunks.
+	scanned *scannedFiles
 	sources.Progress
 	sources.CommonSourceUnitUnmarshaller
+	sources.NoopCloser
 }
 
 // Ensure the Source satisfies the interfaces at compile time
@@ -78,17 +104,41 @@ func (s *Source) Init(aCtx context.Context, name string, jobId sources.JobID, so
 	}
 	s.filter = filter
 
+	s.mmapThreshold = conn.GetMmapThreshold()
+	s.skipBinaries = conn.GetSkipBinaries()
+	s.binaryThreshold = conn.GetBinaryNullByteThreshold()
+
+	s.scanPaths = conn.GetScanPaths()
+	s.joinLineContinuations = conn.GetJoinLineContinuations()
+	s.useIgnoreFiles = conn.GetUseIgnoreFiles()
+	s.checkpointInterval = int(conn.GetCheckpointInterval())
+	if s.checkpointInterval <= 0 {
+		s.checkpointInterval = defaultCheckpointInterval
+	}
+
+	if globs := conn.GetPathAllowlist(); len(globs) > 0 {
+		allowlist, err := glob.NewGlobFilter(glob.WithExcludePathGlobs(globs...))
+		if err != nil {
+			return fmt.Errorf("unable to create path allowlist: %w", err)
+		}
+		s.pathAllowlist = allowlist
+	}
+
 	return nil
 }
 
-// Chunks emits chunks of bytes over a channel.
+// Chunks emits chunks of bytes over a channel. Files scanned before the
+// resume info of the progress was persisted are skipped, unless they changed.
 func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ ...sources.ChunkingTarget) error {
+	s.scanned = s.loadScannedFiles(ctx)
+	defer s.scanned.persist()
+
 	for i, path := range s.paths {
 		logger := ctx.Logger().WithValues("path", path)
 		if common.IsDone(ctx) {
 			return nil
 		}
-		s.SetProgressComplete(i, len(s.paths), fmt.Sprintf("Path: %s", path), "")
+		s.SetProgressComplete(i, len(s.paths), fmt.Sprintf("Path: %s", path), s.scanned.encoded())
 
 		cleanPath := filepath.Clean(path)
 		fileInfo, err := os.Lstat(cleanPath)
@@ -105,6 +155,10 @@ func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ .
 		if fileInfo.IsDir() {
 			err = s.scanDir(ctx, cleanPath, chunksChan)
 		} else {
+			if s.isAllowlisted(cleanPath) {
+				logger.V(3).Info("skipping, path is allowlisted", "path", cleanPath)
+				continue
+			}
 			err = s.scanFile(ctx, cleanPath, chunksChan)
 		}
 
@@ -122,13 +176,19 @@ func (s *Source) scanDir(ctx context.Context, path string, chunksChan chan *sour
 	workerPool.SetLimit(s.concurrency)
 	defer func() { _ = workerPool.Wait() }()
 
-	return fs.WalkDir(os.DirFS(path), ".", func(relativePath string, d fs.DirEntry, err error) error {
+	fsys := os.DirFS(path)
+	ignored := s.newIgnoreMatcher()
+	return fs.WalkDir(fsys, ".", func(relativePath string, d fs.DirEntry, err error) error {
 		if err != nil {
 			ctx.Logger().Error(err, "error walking directory")
 			return nil
 		}
 		fullPath := filepath.Join(path, relativePath)
 
+		if d.IsDir() {
+			return s.enterDir(ctx, fsys, relativePath, ignored)
+		}
+
 		// Skip over non-regular files. We do this check here to suppress noisy
 		// logs for trying to scan directories and other non-regular files in
 		// our traversal.
@@ -138,8 +198,17 @@ func (s *Source) scanDir(ctx context.Context, path string, chunksChan chan *sour
 		if s.filter != nil && !s.filter.Pass(fullPath) {
 			return nil
 		}
+		if s.isAllowlisted(relativePath) || ignored.Match(relativePath, false) {
+			return nil
+		}
 
 		workerPool.Go(func() error {
+			release, err := sources.AcquireWorker(ctx)
+			if err != nil {
+				return nil
+			}
+			defer release()
+
 			if err = s.scanFile(ctx, fullPath, chunksChan); err != nil {
 				ctx.Logger().Error(err, "error scanning file", "path", fullPath, "error", err)
 			}
@@ -150,6 +219,44 @@ func (s *Source) scanDir(ctx context.Context, path string, chunksChan chan *sour
 	})
 }
 
+// newIgnoreMatcher returns the matcher the ignore files of a walked directory
+// are added to, or nil if they aren't used.
+func (s *Source) newIgnoreMatcher() *ignore.Matcher {
+	if !s.useIgnoreFiles {
+		return nil
+	}
+	return new(ignore.Matcher)
+}
+
+// enterDir returns fs.SkipDir if the directory at relativePath within the
+// walked directory fsys is allowlisted or ignored. Otherwise, its ignore
+// file, if any, is added to ignored. Directories are walked before their
+// contents, so the ignore files of parent directories are added first.
+func (s *Source) enterDir(ctx context.Context, fsys fs.FS, relativePath string, ignored *ignore.Matcher) error {
+	if relativePath != "." {
+		// Don't descend into directories whose contents are all allowlisted.
+		if s.isAllowlisted(relativePath + "/") {
+			return fs.SkipDir
+		}
+		if ignored.Match(relativePath, true) {
+			ctx.Logger().V(3).Info("skipping, directory is ignored", "path", relativePath)
+			return fs.SkipDir
+		}
+	}
+	if ignored != nil {
+		if err := ignored.AddDir(fsys, relativePath); err != nil {
+			ctx.Logger().Error(err, "error reading ignore file", "path", relativePath)
+		}
+	}
+	return nil
+}
+
+// isAllowlisted returns true if the path matches the path allowlist and should not be scanned.
+// Paths of files within a scanned directory are relative to that directory.
+func (s *Source) isAllowlisted(path string) bool {
+	return !s.pathAllowlist.ShouldInclude(filepath.ToSlash(path))
+}
+
 var skipSymlinkErr = errors.New("skipping symlink")
 
 func (s *Source) scanFile(ctx context.Context, path string, chunksChan chan *sources.Chunk) error {
@@ -162,11 +269,39 @@ func (s *Source) scanFile(ctx context.Context, path string, chunksChan chan *sou
 		return skipSymlinkErr
 	}
 
-	inputFile, err := os.Open(path)
+	key := scannedFileKey(path, fileStat)
+	if s.scanned.has(key) {
+		logger.V(3).Info("skipping, file already scanned")
+		return nil
+	}
+
+	if s.scanPaths {
+		if err := s.scanPath(ctx, path, chunksChan); err != nil {
+			return err
+		}
+	}
+
+	inputFile, err := s.openFile(ctx, path, fileStat.Size())
 	if err != nil {
 		return fmt.Errorf("unable to open file: %w", err)
 	}
 
+	reader := inputFile
+	if s.skipBinaries {
+		binary, sniffedReader, err := common.SniffBinary(inputFile, s.binaryThreshold)
+		if err != nil {
+			_ = inputFile.Close()
+			return fmt.Errorf("unable to read file: %w", err)
+		}
+		if binary {
+			_ = inputFile.Close()
+			logger.V(3).Info("skipping binary file")
+			s.scanned.add(key)
+			return nil
+		}
+		reader = readCloser{Reader: sniffedReader, Closer: inputFile}
+	}
+
 	logger.V(3).Info("scanning file")
 
 	chunkSkel := &sources.Chunk{
@@ -184,7 +319,47 @@ func (s *Source) scanFile(ctx context.Context, path string, chunksChan chan *sou
 		Verify: s.verify,
 	}
 
-	return handlers.HandleFile(ctx, inputFile, chunkSkel, sources.ChanReporter{Ch: chunksChan})
+	// The entries of archives are checkpointed too, so an interrupted scan
+	// resumes the extraction of the archive it was in.
+	entries := s.scanned.archiveEntries(key)
+	reporter := &lineNumberReporter{ChunkReporter: sources.ChanReporter{Ch: chunksChan}, line: 1}
+	if err := handlers.HandleFile(ctx, reader, chunkSkel, reporter,
+		handlers.WithLineContinuations(s.joinLineContinuations),
+		handlers.WithArchiveCheckpoint(entries),
+	); err != nil {
+		return err
+	}
+	s.scanned.add(key)
+	entries.done()
+	return nil
+}
+
+// scanPath reports the path of a file as a chunk, so secrets in the names of
+// files and directories are found. Its metadata has InPath set.
+func (s *Source) scanPath(ctx context.Context, path string, chunksChan chan *sources.Chunk) error {
+	chunk := sources.Chunk{
+		SourceType: s.Type(),
+		SourceName: s.name,
+		SourceID:   s.SourceID(),
+		JobID:      s.JobID(),
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Filesystem{
+				Filesystem: &source_metadatapb.Filesystem{
+					File:   sanitizer.UTF8(path),
+					InPath: true,
+				},
+			},
+		},
+		Data:   []byte(path),
+		Verify: s.verify,
+	}
+	return sources.ChanReporter{Ch: chunksChan}.ChunkOk(ctx, chunk)
+}
+
+// readCloser reads from a Reader that wraps the file to be closed by Closer.
+type readCloser struct {
+	io.Reader
+	io.Closer
 }
 
 // Enumerate implements SourceUnitEnumerator interface. This implementation simply
@@ -200,23 +375,31 @@ func (s *Source) Enumerate(ctx context.Context, reporter sources.UnitReporter) e
 			continue
 		}
 		if !fileInfo.IsDir() {
+			if s.isAllowlisted(filepath.Clean(path)) {
+				continue
+			}
 			item := sources.CommonSourceUnit{ID: path}
 			if err := reporter.UnitOk(ctx, item); err != nil {
 				return err
 			}
 			continue
 		}
-		err = fs.WalkDir(os.DirFS(path), ".", func(relativePath string, d fs.DirEntry, err error) error {
+		fsys := os.DirFS(path)
+		ignored := s.newIgnoreMatcher()
+		err = fs.WalkDir(fsys, ".", func(relativePath string, d fs.DirEntry, err error) error {
 			if err != nil {
 				return reporter.UnitErr(ctx, err)
 			}
 			if d.IsDir() {
-				return nil
+				return s.enterDir(ctx, fsys, relativePath, ignored)
 			}
 			fullPath := filepath.Join(path, relativePath)
 			if s.filter != nil && !s.filter.Pass(fullPath) {
 				return nil
 			}
+			if s.isAllowlisted(relativePath) || ignored.Match(relativePath, false) {
+				return nil
+			}
 			item := sources.CommonSourceUnit{ID: fullPath}
 			return reporter.UnitOk(ctx, item)
 		})
@@ -0,0 +1,41 @@
+package filesystem
+
+import (
+	"bytes"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// lineNumberReporter is a ChunkReporter that records the line of the file each
+// chunk starts on, so the engine can compute the line of a finding by adding
+// the line offset of the match within the chunk.
+type lineNumberReporter struct {
+	sources.ChunkReporter
+	// line is the line number the next chunk starts on.
+	line int64
+}
+
+func (r *lineNumberReporter) ChunkOk(ctx context.Context, chunk sources.Chunk) error {
+	// Chunks are reported from a shared skeleton, so each chunk needs its own
+	// metadata to hold its line.
+	if fsMetadata := chunk.SourceMetadata.GetFilesystem(); fsMetadata != nil {
+		chunk.SourceMetadata = &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Filesystem{
+				Filesystem: &source_metadatapb.Filesystem{
+					File:  fsMetadata.GetFile(),
+					Link:  fsMetadata.GetLink(),
+					Email: fsMetadata.GetEmail(),
+					Line:  r.line,
+				},
+			},
+		}
+	}
+
+	// Each chunk includes a peek into the start of the next one. Only the
+	// data before the peek is new, so only it moves the next chunk's line.
+	r.line += int64(bytes.Count(chunk.Data[:min(len(chunk.Data), sources.ChunkSize)], []byte("\n")))
+
+	return r.ChunkReporter.ChunkOk(ctx, chunk)
+}
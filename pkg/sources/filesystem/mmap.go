@@ -0,0 +1,31 @@
+package filesystem
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/exp/mmap"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// openFile opens the file at path for reading. Files larger than the mmap
+// threshold are memory-mapped, falling back to buffered IO if the platform or
+// filesystem doesn't support it, or if the file shrank since its size was
+// read, e.g. because it's being truncated or rewritten.
+func (s *Source) openFile(ctx context.Context, path string, size int64) (io.ReadCloser, error) {
+	if s.mmapThreshold > 0 && size > s.mmapThreshold {
+		mmapReader, err := mmap.Open(path)
+		switch {
+		case err != nil:
+			ctx.Logger().V(3).Info("unable to memory-map file, falling back to buffered IO", "path", path, "error", err)
+		case int64(mmapReader.Len()) < size:
+			_ = mmapReader.Close()
+			ctx.Logger().V(3).Info("file shrank since it was listed, falling back to buffered IO",
+				"path", path, "size", size, "mapped_size", mmapReader.Len())
+		default:
+			return readCloser{Reader: io.NewSectionReader(mmapReader, 0, int64(mmapReader.Len())), Closer: mmapReader}, nil
+		}
+	}
+	return os.Open(path)
+}
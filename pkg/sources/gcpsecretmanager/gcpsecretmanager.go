@@ -0,0 +1,298 @@
+package gcpsecretmanager
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	secretmanager "cloud.google.com/go/secretmanager/apiv1"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"github.com/gobwas/glob"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+const SourceType = sourcespb.SourceType_SOURCE_TYPE_GCP_SECRET_MANAGER
+
+// Ensure the Source satisfies the interfaces at compile time.
+var _ sources.Source = (*Source)(nil)
+var _ sources.SourceUnitUnmarshaller = (*Source)(nil)
+
+// Source scans the payloads of the enabled versions of the secrets of a
+// Google Cloud Secret Manager project.
+type Source struct {
+	name        string
+	jobId       sources.JobID
+	sourceId    sources.SourceID
+	concurrency int
+	verify      bool
+
+	projectID string
+	client    *secretmanager.Client
+	// clientOptions are added to the options the client is created with.
+	clientOptions []option.ClientOption
+
+	includeSecrets,
+	excludeSecrets []glob.Glob
+
+	chunksCh chan *sources.Chunk
+
+	mu               sync.Mutex
+	sources.Progress // progress is not thread safe
+	sources.CommonSourceUnitUnmarshaller
+}
+
+// Type returns the type of source.
+// It is used for matching source types in configuration and job input.
+func (s *Source) Type() sourcespb.SourceType {
+	return SourceType
+}
+
+// SourceID number for the Secret Manager source.
+func (s *Source) SourceID() sources.SourceID {
+	return s.sourceId
+}
+
+// JobID number for the Secret Manager source.
+func (s *Source) JobID() sources.JobID {
+	return s.jobId
+}
+
+// Init returns an initialized Secret Manager source.
+func (s *Source) Init(aCtx context.Context, name string, id sources.JobID, sourceID sources.SourceID, verify bool, connection *anypb.Any, concurrency int) error {
+	s.name = name
+	s.verify = verify
+	s.sourceId = sourceID
+	s.jobId = id
+	s.concurrency = max(concurrency, 1)
+
+	var conn sourcespb.GCPSecretManager
+	if err := anypb.UnmarshalTo(connection, &conn, proto.UnmarshalOptions{}); err != nil {
+		return fmt.Errorf("error unmarshalling connection: %w", err)
+	}
+
+	s.projectID = conn.GetProjectId()
+	if s.projectID == "" {
+		return fmt.Errorf("project ID is required")
+	}
+
+	var opts []option.ClientOption
+	switch cred := conn.GetCredential().(type) {
+	case *sourcespb.GCPSecretManager_JsonServiceAccount:
+		opts = append(opts, option.WithCredentialsJSON([]byte(cred.JsonServiceAccount)))
+	case *sourcespb.GCPSecretManager_ServiceAccountFile:
+		opts = append(opts, option.WithCredentialsFile(cred.ServiceAccountFile))
+	case *sourcespb.GCPSecretManager_Adc:
+		// The client uses Application Default Credentials by default.
+	default:
+		return fmt.Errorf("unknown Secret Manager authentication type: %T", cred)
+	}
+
+	var err error
+	if s.includeSecrets, err = compileGlobs(conn.GetIncludeSecrets()); err != nil {
+		return err
+	}
+	if s.excludeSecrets, err = compileGlobs(conn.GetExcludeSecrets()); err != nil {
+		return err
+	}
+
+	client, err := secretmanager.NewRESTClient(aCtx, append(opts, s.clientOptions...)...)
+	if err != nil {
+		return fmt.Errorf("error creating Secret Manager client: %w", err)
+	}
+	s.client = client
+
+	return nil
+}
+
+func compileGlobs(patterns []string) ([]glob.Glob, error) {
+	globs := make([]glob.Glob, 0, len(patterns))
+	for _, pattern := range patterns {
+		g, err := glob.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+		globs = append(globs, g)
+	}
+	return globs, nil
+}
+
+// shouldScanSecret returns true if the ID of the secret matches one of the
+// include globs, if any, and none of the exclude globs.
+func (s *Source) shouldScanSecret(secretID string) bool {
+	if len(s.includeSecrets) > 0 && !matchesAny(secretID, s.includeSecrets) {
+		return false
+	}
+	return !matchesAny(secretID, s.excludeSecrets)
+}
+
+func matchesAny(name string, globs []glob.Glob) bool {
+	for _, g := range globs {
+		if g.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Close closes the Secret Manager client.
+func (s *Source) Close() error {
+	if s.client == nil {
+		return nil
+	}
+	return s.client.Close()
+}
+
+// Chunks emits chunks of bytes over a channel.
+func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ ...sources.ChunkingTarget) error {
+	s.chunksCh = chunksChan
+
+	secrets, err := s.listSecrets(ctx)
+	if err != nil {
+		return err
+	}
+	s.Progress.Message = "starting to process secrets..."
+
+	var workers errgroup.Group
+	workers.SetLimit(s.concurrency)
+	for _, secret := range secrets {
+		release, err := sources.AcquireWorker(ctx)
+		if err != nil {
+			_ = workers.Wait()
+			return fmt.Errorf("error acquiring worker: %w", err)
+		}
+
+		secret := secret
+		workers.Go(func() error {
+			defer release()
+
+			if err := s.processSecret(ctx, secret); err != nil {
+				ctx.Logger().V(1).Info("error processing secret", "secret", secret, "error", err)
+			}
+			s.setProgress(ctx, secret, len(secrets))
+			return nil
+		})
+	}
+	_ = workers.Wait()
+
+	msg := fmt.Sprintf("Secret Manager source finished processing %d secrets", len(secrets))
+	ctx.Logger().Info(msg)
+	s.SetProgressComplete(int(s.SectionsCompleted), len(secrets), msg, s.Progress.EncodedResumeInfo)
+	return nil
+}
+
+// listSecrets returns the resource names of the secrets to scan, e.g.
+// projects/my-project/secrets/my-secret.
+func (s *Source) listSecrets(ctx context.Context) ([]string, error) {
+	var secrets []string
+	it := s.client.ListSecrets(ctx, &secretmanagerpb.ListSecretsRequest{Parent: "projects/" + s.projectID})
+	for {
+		secret, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list secrets: %w", err)
+		}
+		if !s.shouldScanSecret(path.Base(secret.GetName())) {
+			ctx.Logger().V(5).Info("skipping secret", "secret", secret.GetName())
+			continue
+		}
+		secrets = append(secrets, secret.GetName())
+	}
+	ctx.Logger().V(2).Info("listed secrets", "num_secrets", len(secrets))
+	return secrets, nil
+}
+
+func (s *Source) setProgress(ctx context.Context, secret string, total int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx.Logger().V(5).Info("setting progress for secret", "secret", secret)
+	s.SectionsCompleted++
+	s.SetProgressComplete(int(s.SectionsCompleted), total, s.Progress.Message, s.Progress.EncodedResumeInfo)
+}
+
+// processSecret reports the payloads of the enabled versions of the secret.
+// Disabled and destroyed versions can't be accessed.
+func (s *Source) processSecret(ctx context.Context, secret string) error {
+	it := s.client.ListSecretVersions(ctx, &secretmanagerpb.ListSecretVersionsRequest{
+		Parent: secret,
+		Filter: "state:ENABLED",
+	})
+	for {
+		version, err := it.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list secret versions: %w", err)
+		}
+		if err := s.processVersion(ctx, version); err != nil {
+			ctx.Logger().V(1).Info("error processing secret version", "version", version.GetName(), "error", err)
+		}
+	}
+}
+
+func (s *Source) processVersion(ctx context.Context, version *secretmanagerpb.SecretVersion) error {
+	resp, err := s.client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: version.GetName()})
+	if err != nil {
+		return fmt.Errorf("failed to access secret version: %w", err)
+	}
+
+	// Version names are projects/<project>/secrets/<secret>/versions/<version>.
+	secret := path.Base(path.Dir(path.Dir(version.GetName())))
+	versionID := path.Base(version.GetName())
+	var createTime string
+	if version.GetCreateTime() != nil {
+		createTime = version.GetCreateTime().AsTime().UTC().Format(time.RFC3339)
+	}
+
+	chunkSkel := sources.Chunk{
+		SourceName: s.name,
+		SourceType: s.Type(),
+		JobID:      s.JobID(),
+		SourceID:   s.sourceId,
+		Verify:     s.verify,
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_GcpSecretManager{
+				GcpSecretManager: &source_metadatapb.GCPSecretManager{
+					Project:    s.projectID,
+					Secret:     secret,
+					Version:    versionID,
+					Link:       versionLink(s.projectID, secret, versionID),
+					CreateTime: createTime,
+				},
+			},
+		},
+	}
+	reporter := sources.ChanReporter{Ch: s.chunksCh}
+
+	chunkReader := sources.NewChunkReader()
+	for data := range chunkReader(ctx, bytes.NewReader(resp.GetPayload().GetData())) {
+		if err := data.Error(); err != nil {
+			return fmt.Errorf("error reading secret payload: %w", err)
+		}
+		chunk := chunkSkel
+		chunk.Data = data.Bytes()
+		if err := reporter.ChunkOk(ctx, chunk); err != nil {
+			return fmt.Errorf("error reporting chunk: %w", err)
+		}
+	}
+	return nil
+}
+
+// versionLink returns the link to the secret version in the Cloud Console.
+func versionLink(project, secret, version string) string {
+	return fmt.Sprintf("https://console.cloud.google.com/security/secret-manager/secret/%s/versions/%s?project=%s", secret, version, project)
+}
@@ -0,0 +1,53 @@
+package gcs
+
+import "fmt"
+
+// contentHash returns the hash of the stored content of the object, from its
+// MD5 hash or, for composite objects which don't have one, its CRC32C
+// checksum and size. Objects without either have no content hash.
+func contentHash(o object) string {
+	switch {
+	case o.md5 != "":
+		return "md5:" + o.md5
+	case o.crc32c != 0:
+		// CRC32C collides far more often than MD5, so the size has to match
+		// as well.
+		return fmt.Sprintf("crc32c:%08x:%d", o.crc32c, o.size)
+	default:
+		return ""
+	}
+}
+
+// claimContentHash returns the object whose content is that of o, if one was
+// already claimed by this scan, and claims the content for o otherwise.
+// Objects without a content hash are never duplicates.
+func (s *Source) claimContentHash(o object) (string, bool) {
+	hash := contentHash(o)
+	if hash == "" {
+		return "", false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if first, ok := s.contentHashes[hash]; ok {
+		return first, true
+	}
+	if s.contentHashes == nil {
+		s.contentHashes = make(map[string]string)
+	}
+	s.contentHashes[hash] = "gs://" + o.bucket + "/" + o.name
+	return "", false
+}
+
+// releaseContentHash releases the content of o when it failed to be scanned,
+// so the next object with the same content is scanned instead.
+func (s *Source) releaseContentHash(o object) {
+	hash := contentHash(o)
+	if hash == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.contentHashes, hash)
+}
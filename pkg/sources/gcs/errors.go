@@ -0,0 +1,89 @@
+package gcs
+
+import (
+	"errors"
+	"net/http"
+
+	"cloud.google.com/go/storage"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+)
+
+// The errors of the failure modes of the GCS source, which the errors it
+// returns can be matched against with errors.Is, e.g. to tell missing
+// permissions from a missing bucket. The errors still wrap the error of the
+// GCS client, which can be retrieved with errors.As.
+var (
+	// ErrAuth is returned when the credentials are missing, invalid, or
+	// expired.
+	ErrAuth = errors.New("gcs: authentication failed")
+	// ErrPermission is returned when the credentials lack the permission to
+	// access a bucket or object, including when the request is denied by a
+	// VPC Service Controls perimeter.
+	ErrPermission = errors.New("gcs: permission denied")
+	// ErrBucketNotFound is returned when a bucket doesn't exist.
+	ErrBucketNotFound = errors.New("gcs: bucket not found")
+	// ErrObjectNotFound is returned when an object doesn't exist, e.g. when
+	// it was deleted after it was listed.
+	ErrObjectNotFound = errors.New("gcs: object not found")
+)
+
+// gcsError is an error of the GCS client with the failure mode it matches.
+// Its message is that of the client's error.
+type gcsError struct {
+	kind error
+	err  error
+}
+
+func (e *gcsError) Error() string { return e.err.Error() }
+
+func (e *gcsError) Unwrap() []error { return []error{e.kind, e.err} }
+
+// wrapError returns err matching the kind failure mode.
+func wrapError(kind, err error) error {
+	if err == nil || errors.Is(err, kind) {
+		return err
+	}
+	return &gcsError{kind: kind, err: err}
+}
+
+// classifyError returns err matching its failure mode, if it's known.
+// notFound is the failure mode of a 404 response of the API, which is
+// returned for both missing buckets and missing objects, so it depends on the
+// resource requested.
+func classifyError(err, notFound error) error {
+	if err == nil {
+		return nil
+	}
+	for _, kind := range []error{ErrAuth, ErrPermission, ErrBucketNotFound, ErrObjectNotFound} {
+		if errors.Is(err, kind) {
+			return err
+		}
+	}
+
+	var (
+		apiErr      *googleapi.Error
+		retrieveErr *oauth2.RetrieveError
+	)
+	switch {
+	case errors.Is(err, storage.ErrBucketNotExist):
+		return wrapError(ErrBucketNotFound, err)
+	case errors.Is(err, storage.ErrObjectNotExist):
+		return wrapError(ErrObjectNotFound, err)
+	case errors.As(err, &retrieveErr):
+		// The token of the credentials couldn't be fetched.
+		return wrapError(ErrAuth, err)
+	case errors.As(err, &apiErr):
+		switch apiErr.Code {
+		case http.StatusUnauthorized:
+			return wrapError(ErrAuth, err)
+		case http.StatusForbidden:
+			return wrapError(ErrPermission, err)
+		case http.StatusNotFound:
+			if notFound != nil {
+				return wrapError(notFound, err)
+			}
+		}
+	}
+	return err
+}
@@ -0,0 +1,446 @@
+package gcs
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// fakeGCS is a fake GCS server. It serves the buckets of a project, and the
+// metadata of their objects, through the GCS JSON API, and the content of the
+// objects through the XML API. Each test configures the buckets and objects it
+// needs. Requests for any other bucket are forbidden.
+type fakeGCS struct {
+	// buckets are the buckets of the project, by name.
+	buckets map[string]fakeBucket
+	// apiKeys scopes the buckets to the API keys that can access them, by
+	// API key, if set. Requests for the buckets of another API key are
+	// forbidden.
+	apiKeys map[string][]string
+	// unlistable forbids listing buckets and objects, like for credentials
+	// that can only read the objects they are given.
+	unlistable bool
+	// listings counts the requests listing buckets or objects, if set.
+	listings *atomic.Int32
+	// signed rejects the requests for the content of objects that aren't
+	// signed, like GCS does for signed URLs.
+	signed bool
+	// onRequest is called with each request before it's served, if set.
+	onRequest func(r *http.Request)
+}
+
+// fakeBucket is a bucket of fakeGCS.
+type fakeBucket struct {
+	objects map[string]fakeObject
+	// attrs are the attributes of the bucket's JSON resource besides its
+	// name, e.g. its labels or location. The attributes of buckets without
+	// any can't be read, although the buckets can still be listed.
+	attrs map[string]any
+	// acl is the ACL of the bucket. The ACL of buckets without one can't be
+	// read, like that of buckets with uniform bucket-level access.
+	acl []storage.ACLRule
+	// iamPolicy is the IAM policy of the bucket, in JSON. The policy of
+	// buckets without one can't be read.
+	iamPolicy string
+	// softDeletedReadable serves the content of soft-deleted objects, which
+	// GCS may refuse to serve until they are restored.
+	softDeletedReadable bool
+}
+
+// fakeObject is an object of a fakeBucket.
+type fakeObject struct {
+	// versions are the contents of the versions of the object, from the
+	// oldest to the live one. Noncurrent versions are only listed if
+	// requested.
+	versions []string
+	// softDeleted are the contents of the soft-deleted versions of the
+	// object, whose generations follow those of its versions. They are only
+	// listed when soft-deleted objects are requested.
+	softDeleted []string
+	// generation is the generation of the first version, 1 if unset. The
+	// generations of the other versions follow.
+	generation int64
+	// deleted objects are listed, but not found when they are read, like
+	// objects deleted after they were listed.
+	deleted bool
+	// attrs are the attributes of the object's JSON resource, e.g. its
+	// contentType, updated time or custom metadata. They override the
+	// attributes derived from its content, and nil values remove them.
+	attrs map[string]any
+	// acl is the ACL of the object.
+	acl []storage.ACLRule
+}
+
+// fakeObjects returns the objects with the given contents of their versions,
+// by name.
+func fakeObjects(versions map[string][]string) map[string]fakeObject {
+	objects := make(map[string]fakeObject, len(versions))
+	for name, contents := range versions {
+		objects[name] = fakeObject{versions: contents}
+	}
+	return objects
+}
+
+// fakeBuckets returns the buckets with the given objects, by name, whose
+// contents are those of their versions.
+func fakeBuckets(buckets map[string]map[string][]string) map[string]fakeBucket {
+	fakes := make(map[string]fakeBucket, len(buckets))
+	for name, objects := range buckets {
+		fakes[name] = fakeBucket{objects: fakeObjects(objects)}
+	}
+	return fakes
+}
+
+// fakeSizedObjects returns objects of the given sizes, named object0,
+// object1, and so on.
+func fakeSizedObjects(sizes ...int64) map[string]fakeObject {
+	objects := make(map[string]fakeObject, len(sizes))
+	for i, size := range sizes {
+		objects[fmt.Sprintf("object%d", i)] = fakeObject{versions: []string{strings.Repeat("x", int(size))}}
+	}
+	return objects
+}
+
+// fakeGCSServer starts a server serving gcs.
+func fakeGCSServer(t *testing.T, gcs *fakeGCS) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(gcs.handler())
+}
+
+// fakeClient returns a GCS client that talks to the given fake server.
+func fakeClient(t *testing.T, server *httptest.Server) *storage.Client {
+	t.Helper()
+
+	client, err := storage.NewClient(context.Background(), option.WithEndpoint(server.URL+"/storage/v1/"), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("failed to create fake client: %v", err)
+	}
+	return client
+}
+
+// fakeVersion is a version of a fakeObject.
+type fakeVersion struct {
+	content     string
+	generation  int64
+	softDeleted bool
+}
+
+// live returns the live version of the object.
+func (o fakeObject) live() (fakeVersion, bool) {
+	if o.deleted || len(o.versions) == 0 {
+		return fakeVersion{}, false
+	}
+	return o.all()[len(o.versions)-1], true
+}
+
+// all returns the versions of the object, from the oldest to the live one,
+// followed by its soft-deleted versions.
+func (o fakeObject) all() []fakeVersion {
+	gen := max(o.generation, 1)
+	versions := make([]fakeVersion, 0, len(o.versions)+len(o.softDeleted))
+	for _, content := range o.versions {
+		versions = append(versions, fakeVersion{content: content, generation: gen})
+		gen++
+	}
+	for _, content := range o.softDeleted {
+		versions = append(versions, fakeVersion{content: content, generation: gen, softDeleted: true})
+		gen++
+	}
+	return versions
+}
+
+// version returns the version of the object with the generation, or its live
+// version if generation is empty.
+func (o fakeObject) version(generation string, softDeleted bool) (fakeVersion, bool) {
+	if generation == "" {
+		if softDeleted {
+			return fakeVersion{}, false
+		}
+		return o.live()
+	}
+	gen, err := strconv.ParseInt(generation, 10, 64)
+	if err != nil {
+		return fakeVersion{}, false
+	}
+	for _, v := range o.all() {
+		if v.generation != gen || v.softDeleted != softDeleted {
+			continue
+		}
+		if o.deleted && !v.softDeleted {
+			return fakeVersion{}, false
+		}
+		return v, true
+	}
+	return fakeVersion{}, false
+}
+
+// resource returns the JSON resource of the version of the object.
+func (o fakeObject) resource(bkt, name string, v fakeVersion) map[string]any {
+	sum := md5.Sum([]byte(v.content))
+	res := map[string]any{
+		"kind":        "storage#object",
+		"name":        name,
+		"bucket":      bkt,
+		"generation":  strconv.FormatInt(v.generation, 10),
+		"size":        strconv.Itoa(len(v.content)),
+		"contentType": "text/plain",
+		"md5Hash":     base64.StdEncoding.EncodeToString(sum[:]),
+		"crc32c":      fakeCRC32C(v.content),
+		"etag":        fmt.Sprintf("%x/%d", sum[:4], v.generation),
+	}
+	if v.softDeleted {
+		res["softDeleteTime"] = "2024-05-01T00:00:00Z"
+		res["hardDeleteTime"] = "2024-05-08T00:00:00Z"
+	}
+	if o.acl != nil {
+		res["acl"] = fakeACL(o.acl)
+	}
+	for k, val := range o.attrs {
+		if val == nil {
+			delete(res, k)
+			continue
+		}
+		res[k] = val
+	}
+	return res
+}
+
+// fakeCRC32C returns the CRC32C checksum of the content, as GCS encodes it.
+func fakeCRC32C(content string) string {
+	sum := crc32.Checksum([]byte(content), crc32.MakeTable(crc32.Castagnoli))
+	return base64.StdEncoding.EncodeToString(binary.BigEndian.AppendUint32(nil, sum))
+}
+
+func fakeACL(acl []storage.ACLRule) []map[string]string {
+	items := make([]map[string]string, 0, len(acl))
+	for _, rule := range acl {
+		items = append(items, map[string]string{"entity": string(rule.Entity), "role": string(rule.Role)})
+	}
+	return items
+}
+
+// fakeError writes a JSON API error with the status code.
+func fakeError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = fmt.Fprintf(w, `{"error": {"code": %d, "message": %q}}`, code, msg)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// handler returns the handler serving the requests of gcs.
+func (gcs *fakeGCS) handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if gcs.onRequest != nil {
+			gcs.onRequest(r)
+		}
+
+		// Metadata requests are under /storage/v1/b/<bucket>, and media
+		// downloads under /<bucket>.
+		rest, isMetadata := strings.CutPrefix(r.URL.Path, "/storage/v1/b")
+		if isMetadata && rest == "" {
+			gcs.listBuckets(w, r)
+			return
+		}
+		if isMetadata {
+			rest = strings.TrimPrefix(rest, "/")
+		} else {
+			rest = strings.TrimPrefix(r.URL.Path, "/")
+		}
+		name, rest, _ := strings.Cut(rest, "/")
+		bkt, ok := gcs.buckets[name]
+		if !ok || !gcs.authorized(r, name) {
+			fakeError(w, http.StatusForbidden, "forbidden")
+			return
+		}
+		if !isMetadata {
+			gcs.download(w, r, bkt, rest)
+			return
+		}
+
+		query := r.URL.Query()
+		switch {
+		case rest == "":
+			if bkt.attrs == nil {
+				fakeError(w, http.StatusForbidden, "forbidden")
+				return
+			}
+			res := map[string]any{"kind": "storage#bucket", "name": name}
+			for k, v := range bkt.attrs {
+				res[k] = v
+			}
+			writeJSON(w, res)
+		case rest == "acl":
+			if bkt.acl == nil {
+				fakeError(w, http.StatusBadRequest, "uniform bucket-level access is enabled")
+				return
+			}
+			writeJSON(w, map[string]any{"items": fakeACL(bkt.acl)})
+		case rest == "iam":
+			if bkt.iamPolicy == "" {
+				fakeError(w, http.StatusForbidden, "forbidden")
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(bkt.iamPolicy))
+		case rest == "o":
+			if gcs.listed() {
+				fakeError(w, http.StatusForbidden, "forbidden")
+				return
+			}
+			writeJSON(w, map[string]any{"kind": "storage#objects", "items": bkt.list(name, query)})
+		case strings.HasPrefix(rest, "o/"):
+			objName := strings.TrimPrefix(rest, "o/")
+			obj, ok := bkt.objects[objName]
+			if !ok {
+				fakeError(w, http.StatusNotFound, "not found")
+				return
+			}
+			v, ok := obj.version(query.Get("generation"), query.Get("softDeleted") == "true")
+			if !ok {
+				fakeError(w, http.StatusNotFound, "not found")
+				return
+			}
+			writeJSON(w, obj.resource(name, objName, v))
+		default:
+			fakeError(w, http.StatusNotFound, "not found")
+		}
+	})
+}
+
+// listed counts a listing request, and reports whether listing is forbidden.
+func (gcs *fakeGCS) listed() bool {
+	if gcs.listings != nil {
+		gcs.listings.Add(1)
+	}
+	return gcs.unlistable
+}
+
+// authorized reports whether the request can access the bucket.
+func (gcs *fakeGCS) authorized(r *http.Request, bkt string) bool {
+	if gcs.apiKeys == nil {
+		return true
+	}
+	return slices.Contains(gcs.apiKeys[r.URL.Query().Get("key")], bkt)
+}
+
+func (gcs *fakeGCS) listBuckets(w http.ResponseWriter, r *http.Request) {
+	if gcs.listed() {
+		fakeError(w, http.StatusForbidden, "forbidden")
+		return
+	}
+
+	names := make([]string, 0, len(gcs.buckets))
+	for name := range gcs.buckets {
+		if gcs.authorized(r, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	items := make([]map[string]any, 0, len(names))
+	for _, name := range names {
+		item := map[string]any{"name": name}
+		for k, v := range gcs.buckets[name].attrs {
+			item[k] = v
+		}
+		items = append(items, item)
+	}
+	writeJSON(w, map[string]any{"kind": "storage#buckets", "items": items})
+}
+
+// list returns the JSON resources of the objects of the bucket matching the
+// query, in lexicographic order like GCS lists them.
+func (bkt fakeBucket) list(name string, query url.Values) []map[string]any {
+	prefix := query.Get("prefix")
+	versions, softDeleted := query.Get("versions") == "true", query.Get("softDeleted") == "true"
+
+	names := make([]string, 0, len(bkt.objects))
+	for objName := range bkt.objects {
+		if strings.HasPrefix(objName, prefix) {
+			names = append(names, objName)
+		}
+	}
+	sort.Strings(names)
+
+	items := make([]map[string]any, 0, len(names))
+	for _, objName := range names {
+		obj := bkt.objects[objName]
+		all := obj.all()
+		for i, v := range all {
+			switch {
+			case softDeleted != v.softDeleted:
+				continue
+			case !softDeleted && !versions && i != len(obj.versions)-1:
+				continue
+			}
+			items = append(items, obj.resource(name, objName, v))
+		}
+	}
+	return items
+}
+
+// download serves the content of the requested version of the object, which
+// can be read in ranges.
+func (gcs *fakeGCS) download(w http.ResponseWriter, r *http.Request, bkt fakeBucket, name string) {
+	if gcs.signed && r.URL.Query().Get("X-Goog-Signature") == "" {
+		fakeError(w, http.StatusForbidden, "forbidden")
+		return
+	}
+
+	obj, ok := bkt.objects[name]
+	if !ok {
+		fakeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	generation := r.URL.Query().Get("generation")
+	v, ok := obj.version(generation, false)
+	if !ok && bkt.softDeletedReadable {
+		v, ok = obj.version(generation, true)
+	}
+	if !ok {
+		fakeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	res := obj.resource("", name, v)
+	if contentType, _ := res["contentType"].(string); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	if md5Hash, ok := res["md5Hash"].(string); ok {
+		w.Header().Add("X-Goog-Hash", "md5="+md5Hash)
+	}
+	w.Header().Add("X-Goog-Hash", "crc32c="+fakeCRC32C(v.content))
+	w.Header().Set("X-Goog-Generation", strconv.FormatInt(v.generation, 10))
+	if metadata, ok := res["metadata"].(map[string]string); ok {
+		for k, val := range metadata {
+			w.Header().Set("X-Goog-Meta-"+k, val)
+		}
+	}
+	var updated time.Time
+	if s, ok := res["updated"].(string); ok {
+		updated, _ = time.Parse(time.RFC3339Nano, s)
+	}
+	http.ServeContent(w, r, name, updated, strings.NewReader(v.content))
+}
@@ -1,13 +1,17 @@
 package gcs
 
 import (
+	"compress/gzip"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"cloud.google.com/go/storage"
 	"github.com/go-errors/errors"
@@ -19,6 +23,7 @@ import (
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/cache"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/cache/memory"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/handlers"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/credentialspb"
@@ -30,12 +35,26 @@ import (
 const (
 	SourceType = sourcespb.SourceType_SOURCE_TYPE_GCS
 
-	defaultCachePersistIncrement = 2500
+	// defaultPersistInterval is how often the processed objects are persisted
+	// as the resume info while scanning.
+	defaultPersistInterval = 5 * time.Second
+	// defaultMaxResumeInfoSize caps the size of the resume info persisted
+	// while scanning, about 500k objects.
+	defaultMaxResumeInfoSize = 16 * 1024 * 1024 // 16MB
+
+	// defaultCompressResumeInfoSize is the size from which the processed
+	// objects persisted as the resume info are compressed, about 30k objects.
+	defaultCompressResumeInfoSize = 1024 * 1024 // 1MB
+
+	// defaultLastScanOverlap is how long before the high-water mark of the
+	// last scan objects are scanned again, if no overlap is configured.
+	defaultLastScanOverlap = 5 * time.Minute
 )
 
 // Ensure the Source satisfies the interfaces at compile time.
 var _ sources.Source = (*Source)(nil)
 var _ sources.SourceUnitUnmarshaller = (*Source)(nil)
+var _ sources.ObjectCounter = (*Source)(nil)
 
 // Type returns the type of source.
 // It is used for matching source types in configuration and job input.
@@ -56,6 +75,14 @@ func (s *Source) JobID() sources.JobID {
 type objectManager interface {
 	ListObjects(context.Context) (chan io.Reader, error)
 	Attributes(ctx context.Context) (*attributes, error)
+	// BucketErrors returns the errors of the buckets that failed to be
+	// listed, once all the objects of ListObjects have been received.
+	BucketErrors() error
+	// PublicBuckets returns the ACL entries granting access to allUsers of
+	// the listed buckets, by bucket, once all the objects of ListObjects have
+	// been received.
+	PublicBuckets() map[string][]string
+	Close() error
 }
 
 // Source represents a GCS source.
@@ -65,6 +92,75 @@ type Source struct {
 	sourceId    sources.SourceID
 	concurrency int
 	verify      bool
+	// skipBinaries skips objects whose content is binary, as determined by
+	// their content type or binaryThreshold. See common.IsBinaryContent.
+	skipBinaries    bool
+	binaryThreshold float64
+	// disableArchiveHandling reads objects as plain bytes instead of passing
+	// them through handlers.HandleFile, which detects and extracts archives.
+	disableArchiveHandling bool
+	// maxArchiveDepth and maxArchiveSize limit the extraction of archives.
+	// Zero values use the handlers defaults.
+	maxArchiveDepth int
+	maxArchiveSize  int64
+	// chunker splits the content of objects read as plain bytes into chunks.
+	// If nil, sources.DefaultChunkSplitter is used.
+	chunker sources.ChunkSplitter
+	// reportPublicAccess reports the objects and buckets that anyone on the
+	// internet can read, whether or not they contain secrets, as chunks
+	// found by the gcspublicaccess detector.
+	reportPublicAccess bool
+	// incremental skips the objects whose ETag is unchanged since the last
+	// scan, as kept in the resume info. etags are the ETags of the objects
+	// scanned by this scan and the previous ones, and scannedETags those of
+	// this scan only. See incrementalResumeInfo.
+	incremental  bool
+	etags        map[string]string
+	scannedETags map[string]string
+	// updatedSinceLastScan only scans the objects updated after the
+	// high-water mark of the last completed scan, minus lastScanOverlap. See
+	// lastScanCutoff. highWaterMark is the latest update time of the objects
+	// scanned by this scan and lastHighWaterMark that of the last completed
+	// scan. objectFailed is set if an object of this scan failed.
+	updatedSinceLastScan bool
+	lastScanOverlap      time.Duration
+	highWaterMark        time.Time
+	lastHighWaterMark    time.Time
+	objectFailed         bool
+	// scanPaths also scans the names of the objects, see processObjectName.
+	scanPaths bool
+	// ndjson splits the content of objects into records, see
+	// processNDJSONObject.
+	ndjson bool
+	// joinLineContinuations joins the lines continued with a trailing
+	// backslash before objects are chunked, see sources.WithLineContinuations.
+	// It doesn't apply to the records of ndjson objects or a custom chunker.
+	joinLineContinuations bool
+	// dedupeObjects skips the objects whose content hash, see contentHash, is
+	// that of an object already scanned by this scan, e.g. copies of a file
+	// in several buckets, without reading them. contentHashes are the
+	// content hashes claimed by the objects scanned, to the object that
+	// claimed them. It is guarded by mu.
+	dedupeObjects bool
+	contentHashes map[string]string
+	// processed are the MD5 hashes of the objects processed, by bucket, as
+	// exported by ExportProgress. It is guarded by mu.
+	processed map[string]map[string]struct{}
+	// objectsScanned and bytesScanned count the objects processed and their
+	// size, see ObjectsScanned.
+	objectsScanned atomic.Uint64
+	bytesScanned   atomic.Uint64
+	// bytesCompleted is the size of the objects whose progress is set, see
+	// updateProgress. It is guarded by mu.
+	bytesCompleted uint64
+	// prefetchDepth is the number of objects downloaded into memory ahead of
+	// being scanned, using at most prefetchMaxBytes, see prefetcher. If 0,
+	// objects are streamed as they're scanned.
+	prefetchDepth    int
+	prefetchMaxBytes int64
+	// persistInterval is how often the processed objects are persisted as the
+	// resume info, see persistPeriodically. Defaults to defaultPersistInterval.
+	persistInterval time.Duration
 
 	gcsManager objectManager
 	stats      *attributes
@@ -76,37 +172,87 @@ type Source struct {
 	sources.CommonSourceUnitUnmarshaller
 }
 
-// persistableCache is a wrapper around cache.Cache that allows
-// for the persistence of the cache contents in the Progress of the source
-// at given increments.
+// persistableCache is a wrapper around cache.Cache that allows for the
+// persistence of the cache contents in the Progress of the source. Setting
+// keys doesn't persist them, which would encode the contents of the cache for
+// each object; persist does, if keys were set since it last did, so updates
+// are coalesced. It is guarded by the mutex of the source.
 type persistableCache struct {
-	persistIncrement int
 	cache.Cache[string]
 	*sources.Progress
+	// encode encodes the contents of the cache as the resume info, if set.
+	encode func(contents string) string
+
+	// dirty is set if keys were set since the contents were last persisted.
+	// keySize is the size of the last key set.
+	dirty   bool
+	keySize int
+	// persists is the number of times the contents were persisted.
+	persists int
+
+	// maxSize caps the size of the persisted resume info, if set. Once the
+	// resume info would exceed it, it is no longer updated, so a resumed
+	// scan processes the objects processed since again, rather than the
+	// contents of the cache of millions of objects being encoded over and
+	// over. capped is set once it happened.
+	maxSize int
+	capped  bool
+	// compressed is set if the contents of the cache are compressed once they
+	// are large, in which case their size can't be estimated from the keys.
+	compressed bool
+	log        logr.Logger
 }
 
-func newPersistableCache(increment int, cache cache.Cache[string], p *sources.Progress) *persistableCache {
+func newPersistableCache(cache cache.Cache[string], p *sources.Progress) *persistableCache {
 	return &persistableCache{
-		persistIncrement: increment,
-		Cache:            cache,
-		Progress:         p,
+		Cache:    cache,
+		Progress: p,
+		log:      logr.Discard(),
 	}
 }
 
-// Set overrides the cache Set method of the cache to enable the persistence
-// of the cache contents the Progress of the source at given increments.
+// Set overrides the cache Set method to record that the contents of the
+// cache changed since they were last persisted.
 func (c *persistableCache) Set(key string, val string) {
 	c.Cache.Set(key, val)
-	if ok, contents := c.shouldPersist(); ok {
-		c.Progress.EncodedResumeInfo = contents
+	c.dirty = true
+	c.keySize = len(key)
+}
+
+// persist persists the contents of the cache as the resume info, if they
+// changed since they were last persisted, and returns whether it did.
+func (c *persistableCache) persist() bool {
+	if !c.dirty || c.capped {
+		return false
 	}
+	c.dirty = false
+	// The size of the contents is estimated from the size of the keys before
+	// they are encoded, as all the keys are MD5 hashes separated by commas.
+	if !c.compressed && c.exceedsMaxSize(c.Count()*(c.keySize+1)-1) {
+		return false
+	}
+	contents := c.Contents()
+	if c.encode != nil {
+		contents = c.encode(contents)
+	}
+	if c.exceedsMaxSize(len(contents)) {
+		return false
+	}
+	c.Progress.EncodedResumeInfo = contents
+	c.persists++
+	return true
 }
 
-func (c *persistableCache) shouldPersist() (bool, string) {
-	if c.Count()%c.persistIncrement != 0 {
-		return false, ""
+// exceedsMaxSize returns true, and stops persisting the resume info, if size
+// exceeds maxSize.
+func (c *persistableCache) exceedsMaxSize(size int) bool {
+	if c.maxSize <= 0 || size <= c.maxSize {
+		return false
 	}
-	return true, c.Contents()
+	c.capped = true
+	c.log.Info("resume info exceeds its maximum size, it will no longer be updated; a resumed scan will process the objects processed since again",
+		"size", size, "max_size", c.maxSize, "objects", c.Count())
+	return true
 }
 
 // Init returns an initialized GCS source.
@@ -125,11 +271,57 @@ func (s *Source) Init(aCtx context.Context, name string, id sources.JobID, sourc
 		return errors.WrapPrefix(err, "error unmarshalling connection", 0)
 	}
 
-	gcsManager, err := configureGCSManager(aCtx, &conn, concurrency)
+	s.skipBinaries = conn.GetSkipBinaries()
+	s.binaryThreshold = conn.GetBinaryNullByteThreshold()
+	s.disableArchiveHandling = conn.GetDisableArchiveHandling()
+	s.maxArchiveDepth = int(conn.GetMaxArchiveDepth())
+	s.maxArchiveSize = conn.GetMaxArchiveDecompressedSize()
+	s.reportPublicAccess = conn.GetReportPublicAccess()
+	s.incremental = conn.GetIncremental()
+	s.updatedSinceLastScan = conn.GetUpdatedSinceLastScan()
+	s.lastScanOverlap = defaultLastScanOverlap
+	if conn.LastScanOverlap != nil {
+		s.lastScanOverlap = conn.GetLastScanOverlap().AsDuration()
+	}
+	if s.lastScanOverlap < 0 {
+		return fmt.Errorf("last scan overlap must not be negative")
+	}
+	s.scanPaths = conn.GetScanPaths()
+	s.ndjson = conn.GetNdjson()
+	s.joinLineContinuations = conn.GetJoinLineContinuations()
+	s.dedupeObjects = conn.GetDedupeObjects()
+	s.prefetchDepth = int(conn.GetPrefetchDepth())
+	s.prefetchMaxBytes = conn.GetPrefetchMaxBytes()
+	if s.prefetchDepth < 0 || s.prefetchMaxBytes < 0 {
+		return fmt.Errorf("prefetch depth and max bytes must not be negative")
+	}
+
+	urls, err := signedURLs(&conn)
 	if err != nil {
 		return err
 	}
-	s.gcsManager = gcsManager
+	switch {
+	case len(urls) > 0:
+		s.gcsManager = newSignedURLManager(urls, conn.GetMaxObjectSize())
+	case len(conn.GetCredentialScopes()) > 0:
+		if conn.GetInventoryManifest() != "" {
+			return fmt.Errorf("an inventory manifest can't be scanned with credential scopes")
+		}
+		multiManager, err := configureCredentialScopes(aCtx, &conn, concurrency, withUpdatedAfter(s.lastScanCutoff(aCtx)))
+		if err != nil {
+			return err
+		}
+		s.gcsManager = multiManager
+	default:
+		gcsManager, err := configureGCSManager(aCtx, &conn, concurrency, withUpdatedAfter(s.lastScanCutoff(aCtx)))
+		if err != nil {
+			return err
+		}
+		if err := gcsManager.validateCredentials(aCtx); err != nil {
+			return err
+		}
+		s.gcsManager = gcsManager
+	}
 
 	s.log.V(2).Info("enumerating buckets and objects")
 	if err := s.enumerate(aCtx); err != nil {
@@ -139,13 +331,20 @@ func (s *Source) Init(aCtx context.Context, name string, id sources.JobID, sourc
 	return nil
 }
 
-func configureGCSManager(aCtx context.Context, conn *sourcespb.GCS, concurrency int) (*gcsManager, error) {
+func configureGCSManager(aCtx context.Context, conn *sourcespb.GCS, concurrency int, opts ...gcsManagerOption) (*gcsManager, error) {
 	if conn == nil {
 		return nil, fmt.Errorf("GCS connection is nil, cannot configure GCS manager")
 	}
 
 	var gcsManagerAuthOption gcsManagerOption
 
+	// Service accounts are impersonated with the application default
+	// credentials, so other credentials can't be used to impersonate them.
+	_, isADC := conn.Credential.(*sourcespb.GCS_Adc)
+	if conn.GetImpersonateServiceAccount() != "" && !isADC {
+		return nil, fmt.Errorf("impersonating a service account requires the adc credential")
+	}
+
 	switch conn.Credential.(type) {
 	case *sourcespb.GCS_ApiKey:
 		gcsManagerAuthOption = withAPIKey(aCtx, conn.GetApiKey())
@@ -157,12 +356,28 @@ func configureGCSManager(aCtx context.Context, conn *sourcespb.GCS, concurrency
 		gcsManagerAuthOption = withJSONServiceAccount(aCtx, b)
 	case *sourcespb.GCS_JsonServiceAccount:
 		gcsManagerAuthOption = withJSONServiceAccount(aCtx, []byte(conn.GetJsonServiceAccount()))
+	case *sourcespb.GCS_WorkloadIdentityFederationFile:
+		b, err := os.ReadFile(conn.GetWorkloadIdentityFederationFile())
+		if err != nil {
+			return nil, fmt.Errorf("error reading GCS workload identity federation configuration file: %w", err)
+		}
+		gcsManagerAuthOption = withWorkloadIdentityFederation(aCtx, b)
 	case *sourcespb.GCS_Adc:
 		gcsManagerAuthOption = withDefaultADC(aCtx)
+		if conn.GetImpersonateServiceAccount() != "" {
+			gcsManagerAuthOption = withImpersonation(aCtx, conn.GetImpersonateServiceAccount(), conn.GetImpersonationDelegates())
+		}
 	case *sourcespb.GCS_Unauthenticated:
 		gcsManagerAuthOption = withoutAuthentication()
 	case *sourcespb.GCS_Oauth:
-		client, err := oauth2Client(aCtx, conn.GetOauth())
+		route := clientRoute{private: conn.GetPrivateEndpoint()}
+		if conn.GetProxy() != "" {
+			var err error
+			if route.proxy, err = common.ParseProxyURL(conn.GetProxy()); err != nil {
+				return nil, err
+			}
+		}
+		client, err := oauth2Client(aCtx, conn.GetOauth(), route)
 		if err != nil {
 			return nil, fmt.Errorf("error creating oauth2 client: %w", err)
 		}
@@ -175,10 +390,44 @@ func configureGCSManager(aCtx context.Context, conn *sourcespb.GCS, concurrency
 	gcsManagerOpts := []gcsManagerOption{
 		withConcurrency(concurrency),
 		withMaxObjectSize(conn.MaxObjectSize),
+		withMinObjectSize(conn.GetMinObjectSize()),
+		withTruncateLargeObjects(conn.GetTruncateLargeObjects()),
+		withObjectReadTimeout(conn.GetObjectReadTimeout().AsDuration()),
+		withPrefixes(conn.GetPrefixes()),
+		withVersions(conn.GetIncludeVersions()),
+		withMaxObjectsPerBucket(int(conn.GetMaxObjectsPerBucket())),
+		withRangedReads(conn.GetRangedReadThreshold(), int(conn.GetRangedReadConcurrency())),
+		withContinueOnBucketError(conn.GetContinueOnBucketError()),
+		withPublicAccess(conn.GetReportPublicAccess()),
+		withBucketIAM(conn.GetBucketIam()),
+		withProxy(conn.GetProxy()),
+		withPrivateEndpoint(conn.GetPrivateEndpoint()),
+		withIncludeContentTypes(conn.GetIncludeContentTypes()),
+		withExcludeContentTypes(conn.GetExcludeContentTypes()),
+		withExcludeUnknownContentTypes(conn.GetExcludeUnknownContentTypes()),
+		withSkipArchivedObjects(conn.GetSkipArchivedObjects()),
+		withSoftDeleted(conn.GetSoftDeletedObjects()),
+		withInventoryManifest(conn.GetInventoryManifest()),
 		gcsManagerAuthOption,
 	}
-	if setGCSManagerBucketOptions(conn) != nil {
-		gcsManagerOpts = append(gcsManagerOpts, setGCSManagerBucketOptions(conn))
+	gcsManagerOpts = append(gcsManagerOpts, opts...)
+	// Without a seed, each run samples different objects.
+	sampleSeed := conn.GetSampleSeed()
+	if sampleSeed == 0 {
+		sampleSeed = time.Now().UnixNano()
+	}
+	if conn.GetSampleObjects() {
+		gcsManagerOpts = append(gcsManagerOpts, withObjectSampling(sampleSeed))
+	}
+	if conn.GetSampleRate() != 0 {
+		gcsManagerOpts = append(gcsManagerOpts, withSampleRate(conn.GetSampleRate()), withSampleSeed(sampleSeed))
+	}
+	bucketOpts, err := setGCSManagerBucketOptions(conn)
+	if err != nil {
+		return nil, err
+	}
+	if bucketOpts != nil {
+		gcsManagerOpts = append(gcsManagerOpts, bucketOpts)
 	}
 	if setGCSManagerObjectOptions(conn) != nil {
 		gcsManagerOpts = append(gcsManagerOpts, setGCSManagerObjectOptions(conn))
@@ -192,7 +441,9 @@ func configureGCSManager(aCtx context.Context, conn *sourcespb.GCS, concurrency
 	return gcsManager, nil
 }
 
-func oauth2Client(ctx context.Context, creds *credentialspb.Oauth2) (*http.Client, error) {
+// oauth2Client returns an HTTP client authenticated with the OAuth2
+// credentials, which sends its requests following route.
+func oauth2Client(ctx context.Context, creds *credentialspb.Oauth2, route clientRoute) (*http.Client, error) {
 	if creds == nil {
 		return nil, fmt.Errorf("oauth2 credentials are required")
 	}
@@ -214,11 +465,43 @@ func oauth2Client(ctx context.Context, creds *credentialspb.Oauth2) (*http.Clien
 		RefreshToken: creds.GetRefreshToken(),
 	}
 
-	return conf.Client(ctx, tok), nil
+	return conf.Client(routeContext(ctx, route), tok), nil
+}
+
+func setGCSManagerBucketOptions(conn *sourcespb.GCS) (gcsManagerOption, error) {
+	include, err := expandBucketFiles(conn.GetIncludeBuckets())
+	if err != nil {
+		return nil, fmt.Errorf("error reading include buckets: %w", err)
+	}
+	exclude, err := expandBucketFiles(conn.GetExcludeBuckets())
+	if err != nil {
+		return nil, fmt.Errorf("error reading exclude buckets: %w", err)
+	}
+	return setGCSManagerOptions(include, exclude, withIncludeBuckets, withExcludeBuckets), nil
 }
 
-func setGCSManagerBucketOptions(conn *sourcespb.GCS) gcsManagerOption {
-	return setGCSManagerOptions(conn.GetIncludeBuckets(), conn.GetExcludeBuckets(), withIncludeBuckets, withExcludeBuckets)
+// bucketFilePrefix marks the bucket values that are the path of a file
+// listing buckets, one per line, rather than a bucket, e.g. @buckets.txt.
+const bucketFilePrefix = "@"
+
+// expandBucketFiles replaces the bucket values referencing a file with the
+// buckets the file lists. See readListFile for the format of the file.
+func expandBucketFiles(values []string) ([]string, error) {
+	var buckets []string
+	for _, value := range values {
+		path, ok := strings.CutPrefix(value, bucketFilePrefix)
+		if !ok {
+			buckets = append(buckets, value)
+			continue
+		}
+
+		listed, err := readListFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading bucket file: %w", err)
+		}
+		buckets = append(buckets, listed...)
+	}
+	return buckets, nil
 }
 
 func setGCSManagerObjectOptions(conn *sourcespb.GCS) gcsManagerOption {
@@ -242,22 +525,47 @@ func setGCSManagerOptions(include, exclude []string, includeFn, excludeFn func([
 // This will be used to calculate progress.
 func (s *Source) enumerate(ctx context.Context) error {
 	stats, err := s.gcsManager.Attributes(ctx)
-	if err != nil {
+	if stats == nil {
 		return fmt.Errorf("error getting attributes during enumeration: %w", err)
 	}
+	// Some buckets failed to be enumerated, but continueOnBucketError is set.
+	// They are still attempted while scanning, and skipped if they fail again.
+	if err != nil {
+		ctx.Logger().Error(err, "failed to enumerate some buckets")
+	}
 	s.stats = stats
 
 	return nil
 }
 
+// ObjectsScanned returns the number of objects processed by the scan, and
+// their size in bytes. It implements sources.ObjectCounter.
+func (s *Source) ObjectsScanned() (objects, bytes uint64) {
+	return s.objectsScanned.Load(), s.bytesScanned.Load()
+}
+
+// Close closes the GCS client used by the source. It is safe to call more
+// than once.
+func (s *Source) Close() error {
+	if s.gcsManager == nil {
+		return nil
+	}
+	return s.gcsManager.Close()
+}
+
 // Chunks emits chunks of bytes over a channel.
 func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ ...sources.ChunkingTarget) error {
 	persistableCache := s.setupCache(ctx)
+	stopPersisting := s.persistPeriodically(persistableCache)
+	defer stopPersisting()
 
 	objectCh, err := s.gcsManager.ListObjects(ctx)
 	if err != nil {
 		return fmt.Errorf("error listing objects: %w", err)
 	}
+	if s.prefetchDepth > 0 {
+		objectCh = newPrefetcher(s.prefetchDepth, s.prefetchMaxBytes).prefetch(ctx, objectCh)
+	}
 	s.chunksCh = chunksChan
 	s.Progress.Message = "starting to process objects..."
 
@@ -272,65 +580,238 @@ func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ .
 
 		if persistableCache.Exists(o.md5) {
 			ctx.Logger().V(5).Info("skipping object, object already processed", "name", o.name)
+			if closer, ok := o.Reader.(io.Closer); ok {
+				_ = closer.Close()
+			}
+			s.mu.Lock()
+			s.addProcessed(o.bucket, o.md5)
+			s.mu.Unlock()
+			s.recordScanned(o)
+			continue
+		}
+		if o.deleted {
+			s.setProgress(ctx, o, persistableCache)
+			continue
+		}
+		if s.incremental && s.unchanged(o) {
+			ctx.Logger().V(5).Info("skipping object, object unchanged since the last scan", "name", o.name)
+			if s.updatedSinceLastScan {
+				s.recordUpdated(o)
+			}
+			if closer, ok := o.Reader.(io.Closer); ok {
+				_ = closer.Close()
+			}
+			s.setProgress(ctx, o, persistableCache)
 			continue
 		}
+		if s.dedupeObjects {
+			if first, ok := s.claimContentHash(o); ok {
+				ctx.Logger().V(3).Info("skipping object, same content as an object already scanned", "name", o.name, "bucket", o.bucket, "duplicate_of", first)
+				if closer, ok := o.Reader.(io.Closer); ok {
+					_ = closer.Close()
+				}
+				s.recordScanned(o)
+				s.setProgress(ctx, o, persistableCache)
+				continue
+			}
+		}
+
+		release, err := sources.AcquireWorker(ctx)
+		if err != nil {
+			wg.Wait()
+			return fmt.Errorf("error acquiring worker: %w", err)
+		}
 
 		wg.Add(1)
 		go func(obj object) {
 			defer wg.Done()
+			defer release()
 
 			if err := s.processObject(ctx, o); err != nil {
+				// The object was deleted while it was read. Retrying it
+				// can only fail again.
+				if errors.Is(err, ErrObjectNotFound) {
+					ctx.Logger().V(3).Info("object was deleted before it could be read", "name", o.name)
+					s.setProgress(ctx, o, persistableCache)
+					return
+				}
 				ctx.Logger().V(1).Info("error setting start progress progress", "name", o.name, "error", err)
+				if s.dedupeObjects {
+					s.releaseContentHash(o)
+				}
+				s.mu.Lock()
+				s.objectFailed = true
+				s.mu.Unlock()
 				return
 			}
-			s.setProgress(ctx, o.md5, o.name, persistableCache)
+			s.recordScanned(o)
+			s.objectsScanned.Add(1)
+			s.bytesScanned.Add(uint64(o.size))
+			s.MarkActivity()
+			s.setProgress(ctx, o, persistableCache)
 		}(o)
 	}
 	wg.Wait()
+	stopPersisting()
+
+	if s.reportPublicAccess {
+		if err := s.reportPublicBuckets(ctx); err != nil {
+			return err
+		}
+	}
+
+	s.completeProgress(ctx, persistableCache)
 
-	s.completeProgress(ctx)
+	// The objects of an interrupted scan, or of buckets that failed, are
+	// scanned again by the next scan.
+	if (s.incremental || s.updatedSinceLastScan) && ctx.Err() == nil && s.gcsManager.BucketErrors() == nil {
+		s.completeIncrementalScan()
+	}
+	if err := s.gcsManager.BucketErrors(); err != nil {
+		return fmt.Errorf("error scanning buckets: %w", err)
+	}
 	return nil
 }
 
 func (s *Source) setupCache(ctx context.Context) *persistableCache {
-	var c cache.Cache[string]
-	if s.Progress.EncodedResumeInfo != "" {
-		keys := strings.Split(s.Progress.EncodedResumeInfo, ",")
-		entries := make([]memory.CacheEntry[string], len(keys))
-		for i, val := range keys {
-			entries[i] = memory.CacheEntry[string]{Key: val, Value: val}
-		}
+	processed := s.Progress.EncodedResumeInfo
+	if s.incremental || s.updatedSinceLastScan {
+		processed = s.setupIncremental(ctx)
+	}
+
+	compressed := memory.WithCompressedContents[string](defaultCompressResumeInfoSize)
+	c, err := memory.NewFromContents(processed, compressed)
+	if err != nil {
+		ctx.Logger().Error(err, "ignoring resume info, all objects will be scanned")
+		c = memory.New[string](compressed)
+	}
+	if c.Count() > 0 {
+		ctx.Logger().V(3).Info("Loaded cache", "num_entries", c.Count())
+	}
 
-		c = memory.NewWithData[string](entries)
-		ctx.Logger().V(3).Info("Loaded cache", "num_entries", len(entries))
-	} else {
-		c = memory.New[string]()
+	s.mu.Lock()
+	if s.processed == nil {
+		s.processed = make(map[string]map[string]struct{})
 	}
+	s.mu.Unlock()
 
-	// TODO (ahrav): Make this configurable via conn.
-	persistCache := newPersistableCache(defaultCachePersistIncrement, c, &s.Progress)
+	persistCache := newPersistableCache(c, &s.Progress)
+	persistCache.maxSize = defaultMaxResumeInfoSize
+	persistCache.compressed = true
+	persistCache.log = ctx.Logger()
+	if s.incremental || s.updatedSinceLastScan {
+		persistCache.encode = s.encodeIncrementalResumeInfo
+	}
 	return persistCache
 }
 
-func (s *Source) setProgress(ctx context.Context, md5, objName string, cache cache.Cache[string]) {
+// persistPeriodically persists the processed objects of the cache as the
+// resume info every persistInterval, in a goroutine rather than as they are
+// processed, until the returned function is called. The final state is
+// persisted by completeProgress.
+func (s *Source) persistPeriodically(cache *persistableCache) (stop func()) {
+	interval := s.persistInterval
+	if interval <= 0 {
+		interval = defaultPersistInterval
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				s.mu.Lock()
+				cache.persist()
+				s.mu.Unlock()
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			wg.Wait()
+		})
+	}
+}
+
+func (s *Source) setProgress(ctx context.Context, o object, cache cache.Cache[string]) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	ctx.Logger().V(5).Info("setting progress for object", "object-name", objName)
+	ctx.Logger().V(5).Info("setting progress for object", "object-name", o.name)
 	s.SectionsCompleted++
 
-	cache.Set(md5, md5)
-	s.Progress.SectionsRemaining = int32(s.stats.numObjects)
-	s.Progress.PercentComplete = int64(float64(s.SectionsCompleted) / float64(s.stats.numObjects) * 100)
+	// Objects without an MD5 hash, e.g. composite objects, can't be told
+	// apart in the cache.
+	if o.md5 != "" {
+		cache.Set(o.md5, o.md5)
+		s.addProcessed(o.bucket, o.md5)
+	}
+	s.bytesCompleted += uint64(max(o.size, 0))
+
+	msg := s.Progress.Message
+	if s.stats.numBytes == 0 {
+		msg = strings.TrimSuffix(msg, countBasedProgressSuffix) + countBasedProgressSuffix
+	}
+	s.updateProgress(msg)
 }
 
-func (s *Source) completeProgress(ctx context.Context) {
+// countBasedProgressSuffix marks the progress messages of scans whose
+// progress is the count of objects completed, see updateProgress.
+const countBasedProgressSuffix = " (progress by object count, object sizes are unknown)"
+
+// updateProgress sets the progress of the scan from the size of the objects
+// completed out of the size of all the objects. If their size is unknown,
+// e.g. for objects fetched from signed URLs, it's the count of objects
+// completed out of the number of objects instead, and if they weren't
+// counted either, no percentage is set. s.mu must be held.
+func (s *Source) updateProgress(msg string) {
+	completed, total := int(s.SectionsCompleted), int(s.stats.numObjects)
+	switch {
+	case s.stats.numBytes > 0:
+		s.SetProgressCompleteBytes(completed, total, s.bytesCompleted, s.stats.numBytes, msg, s.Progress.EncodedResumeInfo)
+	case total > 0:
+		s.SetProgressComplete(completed, total, msg, s.Progress.EncodedResumeInfo)
+	default:
+		s.SetProgressOngoing(msg, s.Progress.EncodedResumeInfo)
+	}
+}
+
+// completeProgress persists the final state of the cache, so the resume info
+// has all the processed objects, and sets the final progress of the scan.
+func (s *Source) completeProgress(ctx context.Context, cache *persistableCache) {
 	msg := fmt.Sprintf("GCS source finished processing %d objects", s.stats.numObjects)
 	ctx.Logger().Info(msg)
-	s.Progress.Message = msg
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cache.persist()
+	if s.stats.numObjects == 0 {
+		// The scan is complete, even if the objects weren't counted.
+		s.SetProgressComplete(int(s.SectionsCompleted), int(s.SectionsCompleted), msg, s.Progress.EncodedResumeInfo)
+		return
+	}
+	s.updateProgress(msg)
 }
 
 func (s *Source) processObject(ctx context.Context, o object) error {
+	if closer, ok := o.Reader.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	if len(o.publicACLs) > 0 {
+		ctx.Logger().Info("object is publicly accessible", "bucket", o.bucket, "name", o.name, "acls", o.publicACLs)
+	}
+
 	chunkSkel := &sources.Chunk{
 		SourceName: s.name,
 		SourceType: s.Type(),
@@ -339,19 +820,241 @@ func (s *Source) processObject(ctx context.Context, o object) error {
 		Verify:     s.verify,
 		SourceMetadata: &source_metadatapb.MetaData{
 			Data: &source_metadatapb.MetaData_Gcs{
-				Gcs: &source_metadatapb.GCS{
-					Bucket:      o.bucket,
-					Filename:    o.name,
-					Link:        o.link,
-					Email:       o.owner,
-					ContentType: o.contentType,
-					Acls:        o.acl,
-					CreatedAt:   strconv.FormatInt(o.createdAt.Unix(), 10), // Unix time as string
-					UpdatedAt:   o.updatedAt.String(),
-				},
+				Gcs: objectMetadata(o),
 			},
 		},
 	}
+	if err := chunkSkel.ValidateSkeleton(); err != nil {
+		return err
+	}
+	reporter := sources.ChanReporter{Ch: s.chunksCh}
+
+	if public := allUsersACLs(o.publicACLs); s.reportPublicAccess && len(public) > 0 {
+		resource := "gs://" + o.bucket + "/" + o.name
+		if err := s.processPublicAccess(ctx, resource, public, objectMetadata(o), reporter); err != nil {
+			return err
+		}
+	}
+
+	if s.scanPaths {
+		if err := s.processObjectName(ctx, o, reporter); err != nil {
+			return err
+		}
+	}
+
+	if len(o.metadata) > 0 {
+		if err := s.processCustomMetadata(ctx, o, reporter); err != nil {
+			return err
+		}
+	}
+
+	var reader io.Reader = o
+	if o.contentEncoding == "gzip" {
+		gzReader, err := gzip.NewReader(o)
+		if err != nil {
+			return fmt.Errorf("error decompressing object: %w", err)
+		}
+		defer gzReader.Close()
+		reader = gzReader
+	}
+
+	if s.skipBinaries {
+		binary, sniffedReader, err := s.isBinaryObject(o, reader)
+		if err != nil {
+			return fmt.Errorf("error reading object: %w", err)
+		}
+		if binary {
+			ctx.Logger().V(3).Info("skipping binary object", "name", o.name, "content_type", o.contentType)
+			return nil
+		}
+		reader = sniffedReader
+	}
+
+	if s.ndjson {
+		return s.processNDJSONObject(ctx, reader, o, reporter)
+	}
+	if s.disableArchiveHandling {
+		return s.processRawObject(ctx, reader, chunkSkel, reporter)
+	}
+	return handlers.HandleFile(ctx, io.NopCloser(reader), chunkSkel, reporter,
+		handlers.WithMaxArchiveDepth(s.maxArchiveDepth),
+		handlers.WithMaxArchiveDecompressedSize(s.maxArchiveSize),
+		handlers.WithLineContinuations(s.joinLineContinuations),
+	)
+}
+
+// processRawObject reports the content of an object as is, without detecting
+// or extracting archives.
+func (s *Source) processRawObject(ctx context.Context, reader io.Reader, chunkSkel *sources.Chunk, reporter sources.ChunkReporter) error {
+	chunker := s.chunker
+	if chunker == nil {
+		chunker = sources.DefaultChunkSplitter()
+		if s.joinLineContinuations {
+			chunker = sources.NewChunkReader(sources.WithLineContinuations())
+		}
+	}
+	// The default chunker reads overlapping chunks that each start ChunkSize
+	// bytes after the previous one. Those of a custom chunker don't start at
+	// known offsets.
+	position := sources.StartPosition()
+	for data := range chunker.Chunk(ctx, reader) {
+		if err := data.Error(); err != nil {
+			return fmt.Errorf("error reading object: %w", err)
+		}
+		chunk := *chunkSkel
+		chunk.Data = data.Bytes()
+		if s.chunker == nil {
+			chunkPosition := position
+			chunk.Position = &chunkPosition
+			position = position.Advance(data.Bytes(), sources.ChunkSize)
+		}
+		if err := reporter.ChunkOk(ctx, chunk); err != nil {
+			return fmt.Errorf("error reporting chunk: %w", err)
+		}
+	}
+	return nil
+}
+
+// processCustomMetadata reports the custom metadata of an object as a chunk of
+// "key: value" lines, sorted by key.
+func (s *Source) processCustomMetadata(ctx context.Context, o object, reporter sources.ChunkReporter) error {
+	keys := make([]string, 0, len(o.metadata))
+	for key := range o.metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var data strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&data, "%s: %s\n", key, o.metadata[key])
+	}
+
+	md := objectMetadata(o)
+	md.InCustomMetadata = true
+	return reporter.ChunkOk(ctx, sources.Chunk{
+		SourceName: s.name,
+		SourceType: s.Type(),
+		JobID:      s.JobID(),
+		SourceID:   s.sourceId,
+		Verify:     s.verify,
+		Data:       []byte(data.String()),
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Gcs{Gcs: md},
+		},
+	})
+}
+
+// processObjectName reports the name of an object as a chunk, so secrets in
+// the names of objects are found.
+func (s *Source) processObjectName(ctx context.Context, o object, reporter sources.ChunkReporter) error {
+	md := objectMetadata(o)
+	md.InPath = true
+	return reporter.ChunkOk(ctx, sources.Chunk{
+		SourceName: s.name,
+		SourceType: s.Type(),
+		JobID:      s.JobID(),
+		SourceID:   s.sourceId,
+		Verify:     s.verify,
+		Data:       []byte(o.name),
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Gcs{Gcs: md},
+		},
+	})
+}
+
+// reportPublicBuckets reports the buckets that anyone on the internet can
+// access, in order.
+func (s *Source) reportPublicBuckets(ctx context.Context) error {
+	publicBuckets := s.gcsManager.PublicBuckets()
+	buckets := make([]string, 0, len(publicBuckets))
+	for bkt := range publicBuckets {
+		buckets = append(buckets, bkt)
+	}
+	sort.Strings(buckets)
+
+	reporter := sources.ChanReporter{Ch: s.chunksCh}
+	for _, bkt := range buckets {
+		md := &source_metadatapb.GCS{
+			Bucket:     bkt,
+			Visibility: source_metadatapb.Visibility_public,
+			PublicAcls: publicBuckets[bkt],
+		}
+		if err := s.processPublicAccess(ctx, "gs://"+bkt, publicBuckets[bkt], md, reporter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processPublicAccess reports the ACL entries of an object or bucket granting
+// access to allUsers as a chunk of lines the gcspublicaccess detector finds.
+func (s *Source) processPublicAccess(ctx context.Context, resource string, acls []string, md *source_metadatapb.GCS, reporter sources.ChunkReporter) error {
+	var data strings.Builder
+	for _, acl := range acls {
+		entity, role, _ := strings.Cut(acl, ":")
+		fmt.Fprintf(&data, "gcs public access: %s %s %s\n", entity, role, resource)
+	}
+
+	if err := reporter.ChunkOk(ctx, sources.Chunk{
+		SourceName: s.name,
+		SourceType: s.Type(),
+		JobID:      s.JobID(),
+		SourceID:   s.sourceId,
+		Verify:     s.verify,
+		Data:       []byte(data.String()),
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_Gcs{Gcs: md},
+		},
+	}); err != nil {
+		return fmt.Errorf("error reporting public access: %w", err)
+	}
+	return nil
+}
+
+// objectMetadata returns the source metadata of chunks of the object.
+func objectMetadata(o object) *source_metadatapb.GCS {
+	return &source_metadatapb.GCS{
+		Bucket:        o.bucket,
+		Filename:      o.name,
+		Generation:    o.generation,
+		Link:          o.link,
+		Email:         o.owner,
+		ContentType:   o.contentType,
+		Acls:          o.acl,
+		CreatedAt:     strconv.FormatInt(o.createdAt.Unix(), 10), // Unix time as string
+		UpdatedAt:     o.updatedAt.String(),
+		Visibility:    objectVisibility(o),
+		PublicAcls:    o.publicACLs,
+		Location:      o.location.name,
+		LocationType:  o.location.typ,
+		DataLocations: o.location.dataLocations,
+		ProjectNumber: o.projectNumber,
+		BucketReaders: o.readers,
+		SoftDeleted:   o.softDeleted,
+	}
+}
+
+// objectVisibility determines whether an object is public from its ACL. The
+// ACL is empty if access is controlled by the bucket's IAM policy instead, in
+// which case the visibility is unknown.
+func objectVisibility(o object) source_metadatapb.Visibility {
+	switch {
+	case len(o.publicACLs) > 0:
+		return source_metadatapb.Visibility_public
+	case len(o.acl) == 0:
+		return source_metadatapb.Visibility_unknown
+	default:
+		return source_metadatapb.Visibility_private
+	}
+}
 
-	return handlers.HandleFile(ctx, io.NopCloser(o), chunkSkel, sources.ChanReporter{Ch: s.chunksCh})
+// isBinaryObject determines whether the object's content is binary from its
+// content type, falling back to sniffing its content when the content type
+// doesn't tell. The returned reader yields the entire content of the object
+// read from r.
+func (s *Source) isBinaryObject(o object, r io.Reader) (bool, io.Reader, error) {
+	if binary, ok := common.IsBinaryContentType(o.contentType); ok {
+		return binary, r, nil
+	}
+	return common.SniffBinary(r, s.binaryThreshold)
 }
@@ -92,6 +92,7 @@ func TestChunks_PublicBucket(t *testing.T) {
 						Email:       "",
 						Link:        "https://storage.googleapis.com/download/storage/v1/b/public-trufflehog-test-bucket/o/aws1.txt?generation=1678334408999764&alt=media",
 						Acls:        []string{},
+						Visibility:  source_metadatapb.Visibility_unknown,
 					},
 				},
 			},
@@ -177,6 +178,7 @@ func createTestChunks() []*sources.Chunk {
 						Email:       o.owner,
 						Link:        o.link,
 						Acls:        o.acl,
+						Visibility:  source_metadatapb.Visibility_unknown,
 					},
 				},
 			},
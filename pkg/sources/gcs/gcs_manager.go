@@ -1,12 +1,25 @@
 package gcs
 
 import (
+	"bytes"
+	"cmp"
 	aCtx "context"
+	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"io"
+	"math"
+	"math/rand"
+	"mime"
+	"net"
 	"net/http"
+	"net/url"
+	"regexp"
 	"runtime"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -15,9 +28,14 @@ import (
 	"github.com/gobwas/glob"
 	"github.com/googleapis/gax-go/v2"
 	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/impersonate"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
@@ -27,6 +45,9 @@ import (
 const (
 	defaultMaxObjectSize = 10 * 1024 * 1024 // 10MB
 	maxObjectSizeLimit   = 50 * 1024 * 1024 // 50MB
+
+	defaultRangeSize             = 8 * 1024 * 1024 // 8MB
+	defaultRangedReadConcurrency = 4
 )
 
 var defaultConcurrency = runtime.NumCPU()
@@ -38,6 +59,8 @@ type bucketManager interface {
 	Bucket(name string) *storage.BucketHandle
 	// Buckets returns an iterator over the buckets in the project.
 	Buckets(ctx aCtx.Context, projectID string) *storage.BucketIterator
+	// Close closes the client.
+	Close() error
 }
 
 // gcsManager serves as simple facade for interacting with GCS.
@@ -51,18 +74,109 @@ type gcsManager struct {
 	workerPool  *errgroup.Group
 
 	maxObjectSize int64
+	// minObjectSize skips the smaller objects, e.g. icons, if set.
+	minObjectSize int64
 	numBuckets    uint32
 	numObjects    uint64
 
+	// truncateLargeObjects scans the first maxObjectSize bytes of larger
+	// objects instead of skipping them.
+	truncateLargeObjects bool
+
+	// objectReadTimeout is the maximum time to read an object, if set.
+	objectReadTimeout time.Duration
+
 	includeBuckets,
 	excludeBuckets,
 	includeObjects,
 	excludeObjects map[string]struct{}
 
+	// bucketLabels are the labels a bucket must have to be scanned.
+	bucketLabels map[string]string
+
+	// updatedAfter skips the objects that weren't updated after it, if set.
+	updatedAfter time.Time
+
+	// includeContentTypes and excludeContentTypes filter the objects by
+	// their media type, see matchesContentType. excludeUnknownContentTypes
+	// skips the objects without a content type if either is set.
+	includeContentTypes,
+	excludeContentTypes []string
+	excludeUnknownContentTypes bool
+
+	// skipArchived skips the objects in the COLDLINE and ARCHIVE storage
+	// classes, whose retrieval is billed.
+	skipArchived bool
+
+	// prefixes are the object prefixes to list, in lexicographic order and
+	// without overlaps. If empty, all objects are listed.
+	prefixes []string
+
+	// versions lists the noncurrent versions of objects along with the live
+	// ones.
+	versions bool
+
+	// softDeleted also lists the soft-deleted objects of each bucket, once
+	// its live objects are listed.
+	softDeleted bool
+
+	// maxObjectsPerBucket caps the number of objects scanned in each bucket.
+	// If 0, all objects are scanned.
+	maxObjectsPerBucket int
+	// sampleObjects selects the capped objects at random, seeded by
+	// sampleSeed, instead of taking the first ones.
+	sampleObjects bool
+	sampleSeed    int64
+	// sampleRate is the probability each object is scanned with, seeded by
+	// sampleSeed too. If 0, all objects are scanned.
+	sampleRate float64
+
+	// rangedReadThreshold is the size from which objects are read in ranges
+	// of rangeSize bytes, fetching up to rangedReadConcurrency ranges of an
+	// object at once. If 0, objects are read in a single request.
+	rangedReadThreshold   int64
+	rangedReadConcurrency int
+	rangeSize             int64
+
+	// continueOnBucketError skips the buckets that fail to be enumerated or
+	// listed instead of stopping at the first one. bucketErrs holds the
+	// errors of the last call to ListObjects.
+	continueOnBucketError bool
+	bucketErrs            error
+
+	// publicAccess gets the ACL of the buckets when they are listed, and
+	// keeps the entries granting access to allUsers in publicBuckets, by
+	// bucket, for the last call to ListObjects.
+	publicAccess    bool
+	publicBucketsMu sync.Mutex
+	publicBuckets   map[string][]string
+
+	// bucketIAM gets the IAM policy of the buckets when they are listed, to
+	// report the principals that can read their objects. The readers are
+	// cached by bucket, so each policy is only fetched once.
+	bucketIAM          bool
+	bucketReadersMu    sync.Mutex
+	bucketReadersCache map[string][]string
+
+	// inventory is the inventory report listing the objects to scan instead
+	// of listing buckets, if set. inventoryListed are the objects it lists,
+	// once it's read.
+	inventory       *inventoryObject
+	inventoryListed []inventoryObject
+
 	buckets map[string]bucket
 	attr    *attributes
 
-	client bucketManager
+	// newClient creates the client with the credentials of the auth option
+	// once all the options are applied, so it can use the proxy, if any, and
+	// the private endpoint, if set.
+	newClient       func(route clientRoute) (*storage.Client, error)
+	proxy           *url.URL
+	privateEndpoint bool
+
+	client    bucketManager
+	closeOnce sync.Once
+	closeErr  error
 }
 
 // bucket is a simplified *storage.BucketHandle wrapper.
@@ -75,9 +189,52 @@ type bucket struct {
 	// This works because GCS objects are sorted lexicographically.
 	// If this is empty, the iteration will start from the beginning.
 	startOffset string
+	// bucketAttrs are only known once the bucket was listed or its attributes
+	// read.
+	bucketAttrs
+	// readers are the principals that can read the objects of the bucket,
+	// if withBucketIAM is set. See bucketReaders.
+	readers []string
 	*storage.BucketHandle
 }
 
+// bucketAttrs are the attributes of a bucket reported with its objects.
+type bucketAttrs struct {
+	// location is where the objects of the bucket are stored.
+	location bucketLocation
+	// projectNumber is the number of the project owning the bucket.
+	projectNumber uint64
+}
+
+func newBucketAttrs(attrs *storage.BucketAttrs) bucketAttrs {
+	return bucketAttrs{location: newBucketLocation(attrs), projectNumber: attrs.ProjectNumber}
+}
+
+// known reports whether the attributes were read. Every bucket has a location.
+func (a bucketAttrs) known() bool {
+	return a.location.name != ""
+}
+
+// bucketLocation is the location of a bucket.
+// https://cloud.google.com/storage/docs/locations
+type bucketLocation struct {
+	// name is the region, dual-region, or multi-region, e.g. US-EAST1, NAM4,
+	// or US.
+	name string
+	// typ is one of region, dual-region, or multi-region.
+	typ string
+	// dataLocations are the regions of a configurable dual-region.
+	dataLocations []string
+}
+
+func newBucketLocation(attrs *storage.BucketAttrs) bucketLocation {
+	loc := bucketLocation{name: attrs.Location, typ: attrs.LocationType}
+	if attrs.CustomPlacementConfig != nil {
+		loc.dataLocations = attrs.CustomPlacementConfig.DataLocations
+	}
+	return loc
+}
+
 // offsetInfo is used to resume an interrupted iteration.
 type offsetInfo struct {
 	isBucketProcessed   bool
@@ -89,40 +246,54 @@ type offsetInfo struct {
 type attributes struct {
 	numBuckets    uint32
 	numObjects    uint64
+	numBytes      uint64
 	mu            sync.RWMutex
 	bucketObjects map[string]uint64
+	// bucketAttrs are the attributes of the buckets that could be read.
+	bucketAttrs map[string]bucketAttrs
 }
 
 func newStats(numBkts int) *attributes {
 	return &attributes{
 		numBuckets:    uint32(numBkts),
 		bucketObjects: make(map[string]uint64, numBkts),
+		bucketAttrs:   make(map[string]bucketAttrs, numBkts),
 	}
 }
 
-func (s *attributes) incObjects() {
+// addBucket records the number of objects in a bucket, their total size, and
+// the attributes of the bucket, if known.
+func (s *attributes) addBucket(bkt bucket, cnt, size uint64) {
 	s.mu.Lock()
-	s.numObjects++
+	s.bucketObjects[bkt.name] = cnt
+	if bkt.known() {
+		s.bucketAttrs[bkt.name] = bkt.bucketAttrs
+	}
+	s.numObjects += cnt
+	s.numBytes += size
 	s.mu.Unlock()
 }
 
-func (s *attributes) setBucketCnt(bkt string, cnt uint64) {
-	s.mu.Lock()
-	s.bucketObjects[bkt] = cnt
-	s.mu.Unlock()
+// attrsOf returns the attributes of the bucket, if they were recorded.
+func (s *attributes) attrsOf(bkt string) (bucketAttrs, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	attrs, ok := s.bucketAttrs[bkt]
+	return attrs, ok
 }
 
 type gcsManagerOption func(*gcsManager) error
 
 // withHTTPClient uses the provided HTTP client when creating a new GCS client.
+// The client is used as is, so it must be configured to use the proxy and the
+// private endpoint, if any, but its requests are sent with common.UserAgent.
 func withHTTPClient(ctx context.Context, httpClient *http.Client) gcsManagerOption {
-	client, err := storage.NewClient(ctx, option.WithHTTPClient(httpClient), option.WithScopes(storage.ScopeReadOnly))
 	return func(m *gcsManager) error {
-		if err != nil {
-			return err
+		m.newClient = func(clientRoute) (*storage.Client, error) {
+			client := *httpClient
+			client.Transport = userAgentTransport{base: httpClient.Transport}
+			return storage.NewClient(ctx, option.WithHTTPClient(&client), option.WithScopes(storage.ScopeReadOnly))
 		}
-
-		m.client = client
 		return nil
 	}
 }
@@ -130,39 +301,94 @@ func withHTTPClient(ctx context.Context, httpClient *http.Client) gcsManagerOpti
 // withAPIKey uses the provided API key when creating a new GCS client.
 // This can ONLY be used for public buckets.
 func withAPIKey(ctx context.Context, apiKey string) gcsManagerOption {
-	client, err := storage.NewClient(ctx, option.WithAPIKey(apiKey), option.WithScopes(storage.ScopeReadOnly))
 	return func(m *gcsManager) error {
-		if err != nil {
-			return err
+		m.newClient = func(route clientRoute) (*storage.Client, error) {
+			return newStorageClient(ctx, route, option.WithAPIKey(apiKey))
 		}
-
-		m.client = client
 		return nil
 	}
 }
 
 // withJSONServiceAccount uses the provided JSON service account when creating a new GCS client.
 func withJSONServiceAccount(ctx context.Context, jsonServiceAccount []byte) gcsManagerOption {
-	client, err := storage.NewClient(ctx, option.WithCredentialsJSON(jsonServiceAccount), option.WithScopes(storage.ScopeReadOnly))
 	return func(m *gcsManager) error {
-		if err != nil {
-			return err
+		m.newClient = func(route clientRoute) (*storage.Client, error) {
+			return newStorageClient(ctx, route, option.WithCredentialsJSON(jsonServiceAccount))
 		}
-
-		m.client = client
 		return nil
 	}
 }
 
 // withDefaultADC uses the default application credentials when creating a new GCS client.
 func withDefaultADC(ctx context.Context) gcsManagerOption {
-	client, err := defaultADC(ctx)
 	return func(m *gcsManager) error {
-		if err != nil {
-			return err
+		m.newClient = func(route clientRoute) (*storage.Client, error) {
+			return defaultADC(ctx, route)
+		}
+		return nil
+	}
+}
+
+// newImpersonatedTokenSource creates the token source of withImpersonation,
+// it's a variable so tests don't need application default credentials.
+var newImpersonatedTokenSource = impersonate.CredentialsTokenSource
+
+// withImpersonation impersonates the targetSA service account with the
+// application default credentials when creating a new GCS client, e.g. to
+// scan the buckets of another project without a key file. The credentials
+// must be granted roles/iam.serviceAccountTokenCreator on targetSA, or on the
+// first of the delegates if any, each delegate being granted it on the next
+// one in the chain.
+func withImpersonation(ctx context.Context, targetSA string, delegates []string) gcsManagerOption {
+	return func(m *gcsManager) error {
+		if targetSA == "" {
+			return fmt.Errorf("service account to impersonate is required")
+		}
+		config := impersonate.CredentialsConfig{
+			TargetPrincipal: targetSA,
+			Scopes:          []string{storage.ScopeReadOnly},
+			Delegates:       slices.Clone(delegates),
+		}
+		m.newClient = func(route clientRoute) (*storage.Client, error) {
+			ts, err := newImpersonatedTokenSource(routeContext(ctx, route), config)
+			if err != nil {
+				return nil, fmt.Errorf("error impersonating service account %s: %w", targetSA, err)
+			}
+			return newStorageClient(ctx, route, option.WithTokenSource(ts))
 		}
+		return nil
+	}
+}
 
-		m.client = client
+// newExternalAccountCredentials creates the credentials of
+// withWorkloadIdentityFederation, it's a variable so tests don't need to
+// exchange tokens with Google.
+var newExternalAccountCredentials = google.CredentialsFromJSON
+
+// withWorkloadIdentityFederation uses the external account configuration of a
+// Workload Identity Federation pool when creating a new GCS client, e.g. to
+// scan from GitHub Actions without a service account key. The configuration,
+// as created by gcloud iam workload-identity-pools create-cred-config, tells
+// where the token of the external identity provider is read from, e.g. a file
+// or a URL, and the token is exchanged for short-lived Google credentials.
+func withWorkloadIdentityFederation(ctx context.Context, config []byte) gcsManagerOption {
+	return func(m *gcsManager) error {
+		var cred struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(config, &cred); err != nil {
+			return fmt.Errorf("error parsing workload identity federation configuration: %w", err)
+		}
+		if cred.Type != "external_account" {
+			return fmt.Errorf("workload identity federation configuration must be of type external_account, got %q", cred.Type)
+		}
+		m.newClient = func(route clientRoute) (*storage.Client, error) {
+			creds, err := newExternalAccountCredentials(routeContext(ctx, route), config, storage.ScopeReadOnly)
+			if err != nil {
+				return nil, fmt.Errorf("error creating workload identity federation credentials: %w", err)
+			}
+			return newStorageClient(ctx, route, option.WithTokenSource(creds.TokenSource))
+		}
 		return nil
 	}
 }
@@ -170,23 +396,136 @@ func withDefaultADC(ctx context.Context) gcsManagerOption {
 // withoutAuthentication uses an unauthenticated client when creating a new GCS client.
 // This can ONLY be used for public buckets.
 func withoutAuthentication() gcsManagerOption {
-	client, err := storage.NewClient(aCtx.Background(), option.WithoutAuthentication(), option.WithScopes(storage.ScopeReadOnly))
 	return func(m *gcsManager) error {
+		m.newClient = func(route clientRoute) (*storage.Client, error) {
+			return newStorageClient(context.Background(), route, option.WithoutAuthentication())
+		}
+		m.withoutAuth = true
+		return nil
+	}
+}
+
+func defaultADC(ctx context.Context, route clientRoute) (*storage.Client, error) {
+	return newStorageClient(ctx, route)
+}
+
+// withProxy sends the requests of the GCS client through the HTTP, HTTPS or
+// SOCKS5 proxy at proxyURL, except the ones to hosts matching NO_PROXY, e.g.
+// when the storage endpoint is reachable directly. An empty URL uses the
+// proxy configured by the environment. The proxy doesn't apply to the client
+// of withHTTPClient.
+func withProxy(proxyURL string) gcsManagerOption {
+	return func(m *gcsManager) error {
+		if proxyURL == "" {
+			return nil
+		}
+		proxy, err := common.ParseProxyURL(proxyURL)
 		if err != nil {
 			return err
 		}
-		m.client = client
-		m.withoutAuth = true
+		m.proxy = proxy
 		return nil
 	}
 }
 
-func defaultADC(ctx context.Context) (*storage.Client, error) {
-	client, err := storage.NewClient(ctx, option.WithScopes(storage.ScopeReadOnly))
+// withPrivateEndpoint connects the GCS client to Google APIs through the
+// restricted VIP, which only serves the APIs supported by VPC Service
+// Controls, so GCS can be scanned from inside a perimeter without the DNS of
+// the network resolving googleapis.com to it. The proxy, if any, must route
+// the requests to the VIP itself. The private endpoint doesn't apply to the
+// client of withHTTPClient.
+func withPrivateEndpoint(enabled bool) gcsManagerOption {
+	return func(m *gcsManager) error {
+		m.privateEndpoint = enabled
+		return nil
+	}
+}
+
+// clientRoute is how the GCS client reaches Google APIs.
+type clientRoute struct {
+	// proxy is the proxy requests are sent through, if not nil.
+	proxy *url.URL
+	// private connects to the restricted VIP instead of the public
+	// addresses of the hosts, see withPrivateEndpoint.
+	private bool
+}
+
+func (r clientRoute) isDefault() bool { return r.proxy == nil && !r.private }
+
+// restrictedVIPHost resolves to the restricted VIP of Google APIs, which is
+// reachable from inside VPC Service Controls perimeters.
+const restrictedVIPHost = "restricted.googleapis.com"
+
+// privateAddr returns the address to connect to for a Google API at addr
+// through the restricted VIP. Other addresses are returned as is. The VIP
+// serves all of the APIs, so the TLS server name and the Host header are
+// still those of the API.
+func privateAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || !strings.HasSuffix(host, ".googleapis.com") {
+		return addr
+	}
+	return net.JoinHostPort(restrictedVIPHost, port)
+}
+
+// transport returns a transport sending its requests through the proxy,
+// except the ones to hosts matching NO_PROXY, and connecting to the
+// restricted VIP if private is set.
+func (r clientRoute) transport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if r.proxy != nil {
+		t.Proxy = common.ProxyFunc(r.proxy)
+	}
+	if r.private {
+		dial := t.DialContext
+		t.DialContext = func(ctx aCtx.Context, network, addr string) (net.Conn, error) {
+			return dial(ctx, network, privateAddr(addr))
+		}
+	}
+	t.MaxIdleConnsPerHost = 100
+	return t
+}
+
+// newStorageClient creates a read-only GCS client authenticated with opts,
+// which sends its requests with common.UserAgent, following route. The
+// credentials are also fetched following route.
+func newStorageClient(ctx context.Context, route clientRoute, opts ...option.ClientOption) (*storage.Client, error) {
+	opts = append(opts, option.WithScopes(storage.ScopeReadOnly), option.WithUserAgent(common.UserAgent()))
+	if route.isDefault() {
+		return storage.NewClient(ctx, opts...)
+	}
+
+	ctx = routeContext(ctx, route)
+	rt, err := htransport.NewTransport(ctx, route.transport(), opts...)
 	if err != nil {
 		return nil, err
 	}
-	return client, nil
+	return storage.NewClient(ctx, append(opts, option.WithHTTPClient(&http.Client{Transport: rt}))...)
+}
+
+// routeContext returns a context whose OAuth2 tokens are fetched following
+// route.
+func routeContext(ctx context.Context, route clientRoute) context.Context {
+	if route.isDefault() {
+		return ctx
+	}
+	return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: route.transport()})
+}
+
+// userAgentTransport sets the User-Agent of the requests of the client of
+// withHTTPClient, which the storage client doesn't set itself.
+type userAgentTransport struct {
+	base http.RoundTripper
+}
+
+func (t userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", common.UserAgent())
+	return base.RoundTrip(req)
 }
 
 // withIncludeBuckets sets the buckets that should be included in the scan.
@@ -229,6 +568,25 @@ func withExcludeBuckets(buckets []string) gcsManagerOption {
 	}
 }
 
+// withBucketLabelSelector sets the labels that buckets must have to be
+// scanned. A bucket must have all of the labels, with the same values, to be
+// included. It is applied in addition to the include and exclude bucket
+// options, and has no effect when scanning without authentication since
+// buckets are not listed.
+func withBucketLabelSelector(labels map[string]string) gcsManagerOption {
+	return func(m *gcsManager) error {
+		if len(labels) == 0 {
+			return nil
+		}
+
+		m.bucketLabels = make(map[string]string, len(labels))
+		for k, v := range labels {
+			m.bucketLabels[k] = v
+		}
+		return nil
+	}
+}
+
 // withIncludeObjects sets the objects that should be included in the scan.
 // Using this option in conjuection with withIncludeBuckets will result in
 // only specific buckets and objects being scanned.
@@ -273,6 +631,184 @@ func withExcludeObjects(objects []string) gcsManagerOption {
 	}
 }
 
+// withIncludeContentTypes only scans the objects whose media type matches one
+// of types, e.g. "application/json", or "text/*" for all the text types. The
+// objects without a content type are scanned unless
+// withExcludeUnknownContentTypes is set.
+func withIncludeContentTypes(types []string) gcsManagerOption {
+	return func(m *gcsManager) error {
+		patterns, err := parseContentTypePatterns(types)
+		if err != nil {
+			return err
+		}
+		m.includeContentTypes = patterns
+		return nil
+	}
+}
+
+// withExcludeContentTypes skips the objects whose media type matches one of
+// types, like withIncludeContentTypes. It takes precedence over
+// withIncludeContentTypes.
+func withExcludeContentTypes(types []string) gcsManagerOption {
+	return func(m *gcsManager) error {
+		patterns, err := parseContentTypePatterns(types)
+		if err != nil {
+			return err
+		}
+		m.excludeContentTypes = patterns
+		return nil
+	}
+}
+
+// withExcludeUnknownContentTypes skips the objects without a valid content
+// type when filtering objects by content type. It has no effect otherwise.
+func withExcludeUnknownContentTypes(exclude bool) gcsManagerOption {
+	return func(m *gcsManager) error {
+		m.excludeUnknownContentTypes = exclude
+		return nil
+	}
+}
+
+// withSkipArchivedObjects skips the objects in the COLDLINE and ARCHIVE
+// storage classes. Unlike S3, they can be read without being restored, but
+// each read incurs a retrieval fee.
+func withSkipArchivedObjects(skip bool) gcsManagerOption {
+	return func(m *gcsManager) error {
+		m.skipArchived = skip
+		return nil
+	}
+}
+
+// parseContentTypePatterns returns the media types of types, in lower case and
+// without parameters, which may have a "*" subtype.
+func parseContentTypePatterns(types []string) ([]string, error) {
+	var patterns []string
+	for _, t := range types {
+		mediaType, _, err := mime.ParseMediaType(t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid content type %q: %w", t, err)
+		}
+		typ, subtype, ok := strings.Cut(mediaType, "/")
+		if !ok || typ == "" || typ == "*" || subtype == "" {
+			return nil, fmt.Errorf("invalid content type %q: must be type/subtype or type/*", t)
+		}
+		patterns = append(patterns, mediaType)
+	}
+	return patterns, nil
+}
+
+// withPrefixes limits the scan to objects whose names start with one of the
+// given prefixes. Each prefix is listed with its own call to the storage API,
+// which is much cheaper than listing the whole bucket and filtering. Prefixes
+// covered by another prefix are dropped so no object is listed twice, and an
+// empty prefix lists all objects.
+func withPrefixes(prefixes []string) gcsManagerOption {
+	return func(m *gcsManager) error {
+		m.prefixes = dedupePrefixes(prefixes)
+		return nil
+	}
+}
+
+// dedupePrefixes sorts prefixes and removes the ones that start with another
+// prefix. It returns nil if any prefix is empty, since it matches everything.
+func dedupePrefixes(prefixes []string) []string {
+	if len(prefixes) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(prefixes)
+	slices.Sort(sorted)
+
+	// After sorting, any prefix covering another one comes right before the
+	// prefixes it covers.
+	deduped := make([]string, 0, len(sorted))
+	for _, prefix := range sorted {
+		if prefix == "" {
+			return nil
+		}
+		if len(deduped) > 0 && strings.HasPrefix(prefix, deduped[len(deduped)-1]) {
+			continue
+		}
+		deduped = append(deduped, prefix)
+	}
+	return deduped
+}
+
+// queryPrefixes returns the prefixes to list objects with. There is a single
+// empty prefix when the scan isn't limited to any prefix.
+func (g *gcsManager) queryPrefixes() []string {
+	if len(g.prefixes) == 0 {
+		return []string{""}
+	}
+	return g.prefixes
+}
+
+// withVersions lists every version of objects, including noncurrent ones, in
+// buckets with object versioning. Secrets in objects that were overwritten or
+// deleted remain in their noncurrent versions.
+func withVersions(versions bool) gcsManagerOption {
+	return func(m *gcsManager) error {
+		m.versions = versions
+		return nil
+	}
+}
+
+// withSoftDeleted also lists the soft-deleted objects of buckets with a soft
+// delete policy, which are retained for the policy's duration after they are
+// deleted or overwritten, even in buckets without object versioning.
+func withSoftDeleted(softDeleted bool) gcsManagerOption {
+	return func(m *gcsManager) error {
+		m.softDeleted = softDeleted
+		return nil
+	}
+}
+
+// withMaxObjectsPerBucket caps the number of objects scanned in each bucket,
+// for a quick look at buckets too large to scan in full. Listing a bucket stops
+// once the cap is reached. If not set, or set to a non-positive number, all
+// objects are scanned.
+func withMaxObjectsPerBucket(n int) gcsManagerOption {
+	return func(m *gcsManager) error {
+		m.maxObjectsPerBucket = max(n, 0)
+		return nil
+	}
+}
+
+// withObjectSampling makes withMaxObjectsPerBucket select a random sample of
+// the objects of each bucket instead of the first ones in lexicographic order.
+// The whole bucket is listed to sample from, but only the sampled objects are
+// read. The same seed selects the same objects.
+func withObjectSampling(seed int64) gcsManagerOption {
+	return func(m *gcsManager) error {
+		m.sampleObjects = true
+		m.sampleSeed = seed
+		return nil
+	}
+}
+
+// withSampleRate scans each object with the given probability, for spot checks
+// of buckets too costly to scan in full. Whether an object is sampled only
+// depends on the seed set by withSampleSeed, and on its bucket, name, and
+// generation, so enumerating and listing select the same objects, and so do
+// runs with the same seed. A rate of 0 scans all objects.
+func withSampleRate(rate float64) gcsManagerOption {
+	return func(m *gcsManager) error {
+		if rate < 0 || rate > 1 || math.IsNaN(rate) {
+			return fmt.Errorf("sample rate must be between 0 and 1, got %v", rate)
+		}
+		m.sampleRate = rate
+		return nil
+	}
+}
+
+// withSampleSeed sets the seed of the objects selected by withSampleRate.
+func withSampleSeed(seed int64) gcsManagerOption {
+	return func(m *gcsManager) error {
+		m.sampleSeed = seed
+		return nil
+	}
+}
+
 // withConcurrency sets the number of concurrent workers that will be used
 // to process objects.
 // If not set, or set to a negative number the default value is runtime.NumCPU().
@@ -292,16 +828,109 @@ func withConcurrency(concurrency int) gcsManagerOption {
 // the default value of 50MB will be used.
 func withMaxObjectSize(maxObjectSize int64) gcsManagerOption {
 	return func(m *gcsManager) error {
-		if maxObjectSize <= 0 || maxObjectSize > maxObjectSizeLimit {
-			m.maxObjectSize = defaultMaxObjectSize
-		} else {
-			m.maxObjectSize = maxObjectSize
+		m.maxObjectSize = maxObjectSizeOrDefault(maxObjectSize)
+		return nil
+	}
+}
+
+// withMinObjectSize skips the objects smaller than minObjectSize bytes, like
+// the objects larger than the maximum object size are skipped. Both are
+// filtered out when the objects are listed, so they are neither counted nor
+// read.
+func withMinObjectSize(minObjectSize int64) gcsManagerOption {
+	return func(m *gcsManager) error {
+		if minObjectSize < 0 {
+			return fmt.Errorf("minimum object size must not be negative")
 		}
+		m.minObjectSize = minObjectSize
+		return nil
+	}
+}
 
+// withTruncateLargeObjects scans the first bytes of the objects larger than
+// the maximum object size, up to that size, instead of skipping them. Only
+// those bytes are downloaded. Secrets past the cap are missed either way, so
+// both are logged.
+func withTruncateLargeObjects(truncate bool) gcsManagerOption {
+	return func(m *gcsManager) error {
+		m.truncateLargeObjects = truncate
 		return nil
 	}
 }
 
+// withObjectReadTimeout sets the maximum time to read an object. Reads that
+// take longer fail with a timeout error, so a single hung object doesn't stall
+// the whole scan. If not set, object reads have no deadline.
+func withObjectReadTimeout(timeout time.Duration) gcsManagerOption {
+	return func(m *gcsManager) error {
+		m.objectReadTimeout = timeout
+		return nil
+	}
+}
+
+// withUpdatedAfter only lists the objects updated after t. If t is zero, all
+// the objects are listed.
+func withUpdatedAfter(t time.Time) gcsManagerOption {
+	return func(m *gcsManager) error {
+		m.updatedAfter = t
+		return nil
+	}
+}
+
+// withRangedReads reads objects of at least threshold bytes in ranges that are
+// fetched concurrently, up to concurrency ranges per object at once. The
+// ranges are scanned in order. If threshold is 0, objects are read in a single
+// request. If concurrency is 0, the default of 4 is used.
+func withRangedReads(threshold int64, concurrency int) gcsManagerOption {
+	return func(m *gcsManager) error {
+		if threshold < 0 || concurrency < 0 {
+			return fmt.Errorf("ranged read threshold and concurrency must not be negative")
+		}
+		m.rangedReadThreshold = threshold
+		m.rangedReadConcurrency = cmp.Or(concurrency, defaultRangedReadConcurrency)
+		m.rangeSize = defaultRangeSize
+		return nil
+	}
+}
+
+// withContinueOnBucketError logs and skips the buckets that fail to be
+// enumerated or listed, e.g. because the credentials can't access them, and
+// scans the rest. Otherwise the first bucket that fails stops the scan.
+func withContinueOnBucketError(continueOnBucketError bool) gcsManagerOption {
+	return func(m *gcsManager) error {
+		m.continueOnBucketError = continueOnBucketError
+		return nil
+	}
+}
+
+// withPublicAccess gets the ACL of the buckets when they are listed, to report
+// the buckets that anyone on the internet can access. See PublicBuckets.
+func withPublicAccess(publicAccess bool) gcsManagerOption {
+	return func(m *gcsManager) error {
+		m.publicAccess = publicAccess
+		return nil
+	}
+}
+
+// withBucketIAM gets the IAM policy of the buckets when they are listed, to
+// report the principals that can read their objects with them. It requires the
+// storage.buckets.getIamPolicy permission.
+func withBucketIAM(bucketIAM bool) gcsManagerOption {
+	return func(m *gcsManager) error {
+		m.bucketIAM = bucketIAM
+		return nil
+	}
+}
+
+// maxObjectSizeOrDefault returns maxObjectSize, or the default if it is not
+// set, negative, or larger than maxObjectSizeLimit.
+func maxObjectSizeOrDefault(maxObjectSize int64) int64 {
+	if maxObjectSize <= 0 || maxObjectSize > maxObjectSizeLimit {
+		return defaultMaxObjectSize
+	}
+	return maxObjectSize
+}
+
 // withBucketOffsets sets the offset for each bucket.
 // This is used to resume listing objects for a bucket.
 func withBucketOffsets(offsets map[string]offsetInfo) gcsManagerOption {
@@ -335,16 +964,27 @@ func newGCSManager(projectID string, opts ...gcsManagerOption) (*gcsManager, err
 		}
 	}
 
-	if projectID == "" && !gcs.withoutAuth {
+	if gcs.minObjectSize > gcs.maxObjectSize && !gcs.truncateLargeObjects {
+		return nil, fmt.Errorf("minimum object size %d is larger than the maximum object size %d", gcs.minObjectSize, gcs.maxObjectSize)
+	}
+
+	// The project is only needed to list its buckets.
+	if projectID == "" && !gcs.withoutAuth && gcs.inventory == nil {
 		return nil, fmt.Errorf("project ID is required, when using authentication")
 	}
 
 	// If no client was provided, use the default application credentials.
 	// A client is required to perform any operations.
 	if gcs.client == nil {
-		c, err := defaultADC(context.Background())
+		newClient := gcs.newClient
+		if newClient == nil {
+			newClient = func(route clientRoute) (*storage.Client, error) {
+				return defaultADC(context.Background(), route)
+			}
+		}
+		c, err := newClient(clientRoute{proxy: gcs.proxy, private: gcs.privateEndpoint})
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to create GCS client: %w", wrapError(ErrAuth, err))
 		}
 		gcs.client = c
 	}
@@ -362,21 +1002,134 @@ func configureWorkers(gcs *gcsManager) {
 type object struct {
 	name        string
 	bucket      string
+	generation  int64
 	contentType string
 	owner       string
 	link        string
 	md5         string
+	// crc32c is the CRC32C checksum of the object, which composite objects
+	// have even though they don't have an MD5 hash.
+	crc32c uint32
+	etag   string
 	// acl represents an ACLEntities.
 	// https://pkg.go.dev/cloud.google.com/go/storage#ACLEntity
-	acl       []string
+	acl []string
+	// publicACLs are the ACL entries granting access to allUsers or
+	// allAuthenticatedUsers, as entity:role.
+	publicACLs []string
+	// metadata is the custom metadata of the object.
+	metadata  map[string]string
 	size      int64
 	createdAt time.Time
 	updatedAt time.Time
+	// bucketAttrs and readers are those of the object's bucket, if known.
+	bucketAttrs
+	readers []string
+
+	// contentEncoding is the encoding of the content returned by Reader. It
+	// is only set when the content is still encoded, e.g. "gzip".
+	contentEncoding string
+
+	// deleted is true if the object was deleted after it was listed, before
+	// it could be read. It has no content, but is still reported so it's
+	// accounted for as processed.
+	deleted bool
+
+	// truncated is true if the object is larger than the maximum object
+	// size, and only its beginning is read.
+	truncated bool
+
+	// softDeleted is true if the object is a soft-deleted one. Its content
+	// is empty if GCS didn't serve it, as soft-deleted objects may have to
+	// be restored to be read, which scanning doesn't do.
+	softDeleted bool
 
 	io.Reader
 }
 
+// validateCredentials checks that the credentials of the manager can be used
+// to list the buckets of the project before enumerating them, which can take a
+// long time. Listing a single bucket is enough to tell whether the credentials
+// are invalid or lack permission. Unauthenticated managers, and managers
+// scanning the objects of an inventory report, don't list buckets, so there
+// is nothing to validate.
+func (g *gcsManager) validateCredentials(ctx context.Context) error {
+	if g.withoutAuth || g.inventory != nil {
+		return nil
+	}
+
+	bkts := g.client.Buckets(ctx, g.projectID)
+	bkts.PageInfo().MaxSize = 1
+	_, err := bkts.Next()
+	if err == nil || errors.Is(err, iterator.Done) {
+		return nil
+	}
+	if vpcErr := vpcServiceControlsError(err); vpcErr != err {
+		return wrapError(ErrPermission, vpcErr)
+	}
+
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case http.StatusUnauthorized:
+			return fmt.Errorf("GCS credentials are invalid or expired, check the configured credentials: %w", wrapError(ErrAuth, err))
+		case http.StatusForbidden:
+			return fmt.Errorf("GCS credentials lack permission to list buckets in project %q, grant the storage.buckets.list permission: %w", g.projectID, wrapError(ErrPermission, err))
+		}
+	}
+	return fmt.Errorf("failed to validate GCS credentials: %w", classifyError(err, nil))
+}
+
+// vpcServiceControlsIDPat matches the identifier of a request denied by VPC
+// Service Controls, which is in the message of the error.
+var vpcServiceControlsIDPat = regexp.MustCompile(`vpcServiceControlsUniqueIdentifier:\s*([A-Za-z0-9_-]+)`)
+
+// vpcServiceControlsError explains err if the request was denied by the
+// policy of a VPC Service Controls perimeter, with the identifier of the
+// violation in the audit logs. Other errors are returned as is.
+func vpcServiceControlsError(err error) error {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusForbidden {
+		return err
+	}
+
+	denied := false
+	for _, item := range apiErr.Errors {
+		if item.Reason == "vpcServiceControls" {
+			denied = true
+		}
+	}
+	id := "unknown"
+	for _, msg := range []string{apiErr.Message, apiErr.Body} {
+		if m := vpcServiceControlsIDPat.FindStringSubmatch(msg); m != nil {
+			denied, id = true, m[1]
+			break
+		}
+	}
+	if !denied {
+		return err
+	}
+	return fmt.Errorf("request denied by a VPC Service Controls perimeter (violation %s), "+
+		"scan from inside the perimeter, with the private endpoint if its DNS doesn't route to it, "+
+		"or allow the request with an ingress rule: %w", id, err)
+}
+
+// Close closes the GCS client. Subsequent calls return the result of the
+// first one.
+func (g *gcsManager) Close() error {
+	g.closeOnce.Do(func() {
+		if g.client != nil {
+			g.closeErr = g.client.Close()
+		}
+	})
+	return g.closeErr
+}
+
 func (g *gcsManager) Attributes(ctx context.Context) (*attributes, error) {
+	if g.inventory != nil {
+		return g.inventoryAttributes(ctx)
+	}
+
 	// Get all the buckets in the project.
 	buckets, err := g.listBuckets(ctx)
 	if err != nil {
@@ -386,64 +1139,106 @@ func (g *gcsManager) Attributes(ctx context.Context) (*attributes, error) {
 	return g.enumerate(ctx, buckets)
 }
 
+// enumerate lists the objects of the buckets concurrently to calculate the
+// attributes of the source. If continueOnBucketError is set, buckets that fail
+// to be listed don't stop the enumeration of the others; their errors are
+// returned together alongside the attributes of the buckets that were listed.
+// Otherwise the first bucket that fails stops the enumeration, and only its
+// error is returned.
 func (g *gcsManager) enumerate(ctx context.Context, bkts []bucket) (*attributes, error) {
 	logger := ctx.Logger().WithValues("phase", "enumeration")
+	ctx, stop := context.WithCancelCause(ctx)
+	defer stop(nil)
 
 	logger.V(5).Info("enumerating buckets", "numBuckets", len(bkts))
 	stats := newStats(len(bkts))
 
 	defer func(start time.Time) {
-		logger.V(5).Info("finished enumerating buckets", "duration-seconds", fmt.Sprintf("%.1f", time.Since(start).Seconds()), "num-buckets", len(bkts), "num-objects", stats.numObjects)
+		logger.V(5).Info("finished enumerating buckets", "duration-seconds", fmt.Sprintf("%.1f", time.Since(start).Seconds()), "num-buckets", len(bkts), "num-objects", stats.numObjects, "num-bytes", stats.numBytes)
 	}(time.Now())
 
+	enumErrs := sources.NewScanErrors()
+	bucketFailed := func(err error) {
+		enumErrs.Add(err)
+		if !g.continueOnBucketError {
+			stop(err)
+		}
+	}
 	for _, bkt := range bkts {
 		bkt := bkt
 		g.workerPool.Go(func() error {
 			// List all the objects in the bucket and calculate attributes.
 			g.setupBktHandle(&bkt)
+			g.setBucketAttrs(ctx, &bkt)
+
+			var count, size uint64
+			for _, softDeleted := range g.listingPasses() {
+				limit := g.newObjectLimit(bkt.name)
+				for _, prefix := range g.queryPrefixes() {
+					if limit.done() {
+						break
+					}
 
-			q, err := setObjectQuery(&bkt)
-			if err != nil {
-				logger.Error(err, "failed to set object query", "bucket", bkt.name)
-				return nil
-			}
+					q, err := g.setObjectQuery(&bkt, prefix, softDeleted)
+					if err != nil {
+						bucketFailed(fmt.Errorf("bucket %s: %w", bkt.name, err))
+						return nil
+					}
 
-			var count uint64
-			objs := bkt.Objects(ctx, q)
-			for {
-				obj, err := objs.Next()
-				if errors.Is(err, iterator.Done) {
-					logger.V(5).Info("finished listing objects in bucket")
-					break
-				}
-				if err != nil {
-					logger.V(1).Info("failed to list objects", "bucket", bkt.name, "error", err)
-					return nil
-				}
-				if obj == nil {
-					logger.V(5).Info("object is nil")
-					continue
-				}
+					objs := bkt.Objects(ctx, q)
+					for {
+						obj, err := objs.Next()
+						if errors.Is(err, iterator.Done) {
+							logger.V(5).Info("finished listing objects in bucket", "bucket", bkt.name, "prefix", prefix, "soft-deleted", softDeleted)
+							break
+						}
+						if err != nil {
+							logger.V(1).Info("failed to list objects", "bucket", bkt.name, "prefix", prefix, "soft-deleted", softDeleted, "error", err)
+							bucketFailed(fmt.Errorf("bucket %s: failed to list objects: %w", bkt.name, classifyError(vpcServiceControlsError(err), ErrBucketNotFound)))
+							return nil
+						}
+						if obj == nil {
+							logger.V(5).Info("object is nil")
+							continue
+						}
 
-				if !g.shouldIncludeObject(ctx, obj.Name) || g.shouldExcludeObject(ctx, obj.Name) {
-					continue
+						if !g.shouldIncludeObject(ctx, obj.Name) || g.shouldExcludeObject(ctx, obj.Name) || !g.isUpdatedAfter(obj) || !g.isObjectSizeInRange(obj.Size) || !g.matchesContentType(obj.ContentType) || g.isSkippedArchive(obj) || !g.isSampled(bkt.name, obj) {
+							continue
+						}
+						if limit.admit(obj) {
+							count++
+							size += uint64(obj.Size)
+						}
+						if limit.done() {
+							break
+						}
+					}
+				}
+				for _, obj := range limit.sampled() {
+					count++
+					size += uint64(obj.Size)
 				}
-				count++
-				stats.incObjects()
 			}
 
-			stats.setBucketCnt(bkt.name, count)
+			stats.addBucket(bkt, count, size)
 			return nil
 		})
 	}
 
 	_ = g.workerPool.Wait()
+	if !g.continueOnBucketError && enumErrs.Count() > 0 {
+		return nil, context.Cause(ctx)
+	}
 	g.attr = stats
 
-	return stats, nil
+	return stats, enumErrs.Errors()
 }
 
 func (g *gcsManager) ListObjects(ctx context.Context) (chan io.Reader, error) {
+	if g.inventory != nil {
+		return g.listInventoryObjects(ctx)
+	}
+
 	ch := make(chan io.Reader, 100) // TODO (ahrav): Determine optimal buffer size.
 
 	// Get all the buckets in the project.
@@ -461,30 +1256,50 @@ func (g *gcsManager) ListObjects(ctx context.Context) (chan io.Reader, error) {
 	for _, b := range buckets {
 		g.buckets[b.name] = b
 	}
+	g.publicBuckets = make(map[string][]string)
 
+	// The listing is only stopped when a bucket fails and
+	// continueOnBucketError isn't set. It isn't cancelled once done, as the
+	// objects that were sent are read with its context.
+	listCtx, stop := context.WithCancelCause(ctx)
 	go func() {
 		gcsErrs := sources.NewScanErrors()
+		bucketFailed := func(bkt string, err error) {
+			err = fmt.Errorf("bucket %s: %w", bkt, err)
+			gcsErrs.Add(err)
+			if !g.continueOnBucketError {
+				stop(err)
+				return
+			}
+			ctx.Logger().Error(err, "skipping bucket")
+		}
 		for _, bucket := range g.buckets {
 			g.numBuckets++
 			bucket := bucket
 			g.workerPool.Go(func() error {
-				objCh, errCh := g.listBucketObjects(ctx, &bucket)
-				for {
-					select {
-					case obj, ok := <-objCh:
-						if !ok {
-							return nil
-						}
-						ch <- obj
-					case err := <-errCh:
-						gcsErrs.Add(err)
-						return nil
-					}
+				objCh, errCh := g.listBucketObjects(listCtx, &bucket)
+				for obj := range objCh {
+					ch <- obj
+				}
+				// The error, if any, is sent before objCh is closed.
+				select {
+				case err := <-errCh:
+					bucketFailed(bucket.name, err)
+				default:
 				}
+				return nil
 			})
 		}
 
 		_ = g.workerPool.Wait()
+		switch {
+		case g.continueOnBucketError:
+			g.bucketErrs = gcsErrs.Errors()
+		case gcsErrs.Count() > 0 && ctx.Err() == nil:
+			g.bucketErrs = context.Cause(listCtx)
+		default:
+			g.bucketErrs = nil
+		}
 		if gcsErrs.Count() > 0 {
 			ctx.Logger().V(2).Info("encountered gcsErrs while scanning GCS buckets", "error-count", gcsErrs.Count(), "gcsErrs", gcsErrs.String())
 		}
@@ -494,6 +1309,21 @@ func (g *gcsManager) ListObjects(ctx context.Context) (chan io.Reader, error) {
 	return ch, nil
 }
 
+// PublicBuckets returns the ACL entries granting access to allUsers of the
+// buckets listed by the last call to ListObjects, by bucket, if withPublicAccess
+// is set. It must only be called once all the objects have been received.
+func (g *gcsManager) PublicBuckets() map[string][]string {
+	return g.publicBuckets
+}
+
+// BucketErrors returns the errors of the buckets that failed to be listed by
+// the last call to ListObjects. It must only be called once all the objects
+// have been received. Unless continueOnBucketError is set, it is the error of
+// the bucket that stopped the listing.
+func (g *gcsManager) BucketErrors() error {
+	return g.bucketErrs
+}
+
 func (g *gcsManager) listBuckets(ctx context.Context) ([]bucket, error) {
 	var buckets []bucket
 	if g.withoutAuth {
@@ -511,7 +1341,7 @@ func (g *gcsManager) listBuckets(ctx context.Context) ([]bucket, error) {
 			break
 		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to retrieve bucket: %w", err)
+			return nil, fmt.Errorf("failed to retrieve bucket: %w", classifyError(vpcServiceControlsError(err), nil))
 		}
 
 		// If the bucket is already in the map, skip it, it's already accounted for.
@@ -522,7 +1352,11 @@ func (g *gcsManager) listBuckets(ctx context.Context) ([]bucket, error) {
 		if !g.shouldIncludeBucket(ctx, bkt.Name) || g.shouldExcludeBucket(ctx, bkt.Name) {
 			continue
 		}
-		buckets = append(buckets, bucket{name: bkt.Name})
+		if !g.matchesBucketLabels(bkt.Labels) {
+			ctx.Logger().V(5).Info("skipping bucket, labels do not match", "bucket", bkt.Name)
+			continue
+		}
+		buckets = append(buckets, bucket{name: bkt.Name, bucketAttrs: newBucketAttrs(bkt)})
 
 	}
 	return buckets, nil
@@ -539,6 +1373,13 @@ func (g *gcsManager) listBucketObjects(ctx context.Context, bkt *bucket) (chan i
 		logger.V(5).Info("listing object(s) in bucket")
 
 		g.setupBktHandle(bkt)
+		g.setBucketAttrs(ctx, bkt)
+		if g.publicAccess {
+			g.checkBucketAccess(ctx, bkt)
+		}
+		if g.bucketIAM {
+			bkt.readers = g.bucketReaders(ctx, bkt)
+		}
 
 		// TODO (ahrav): Look to extend gcsManager to allow for exact buckets/objects
 		// include filters. This will increase performance substantially
@@ -562,8 +1403,146 @@ func (g *gcsManager) setupBktHandle(bkt *bucket) {
 	bkt.BucketHandle = b
 }
 
+// setBucketAttrs sets the attributes of the bucket if they aren't known yet,
+// from the enumeration if the bucket was enumerated, or by reading them
+// otherwise. Reading the attributes requires the storage.buckets.get
+// permission, so failing to read them isn't an error; the objects of the
+// bucket are reported without them.
+func (g *gcsManager) setBucketAttrs(ctx context.Context, bkt *bucket) {
+	if bkt.known() {
+		return
+	}
+	if g.attr != nil {
+		if attrs, ok := g.attr.attrsOf(bkt.name); ok {
+			bkt.bucketAttrs = attrs
+			return
+		}
+	}
+
+	attrs, err := bkt.Attrs(ctx)
+	if err != nil {
+		ctx.Logger().V(2).Info("failed to get bucket attributes", "bucket", bkt.name, "error", err)
+		return
+	}
+	bkt.bucketAttrs = newBucketAttrs(attrs)
+}
+
+// objectReaderRoles are the predefined roles granting permission to read the
+// objects of a bucket. Custom roles can't be resolved from the policy alone,
+// so their members aren't reported.
+// https://cloud.google.com/storage/docs/access-control/iam-roles
+var objectReaderRoles = map[string]struct{}{
+	"roles/storage.admin":              {},
+	"roles/storage.objectAdmin":        {},
+	"roles/storage.objectUser":         {},
+	"roles/storage.objectViewer":       {},
+	"roles/storage.legacyObjectOwner":  {},
+	"roles/storage.legacyObjectReader": {},
+	"roles/owner":                      {},
+	"roles/editor":                     {},
+	"roles/viewer":                     {},
+}
+
+// bucketReaders returns the principals that can read the objects of the
+// bucket, sorted, from the members of the bindings of its IAM policy with a
+// role in objectReaderRoles. Members of conditional bindings are included, as
+// they may have access. Failing to get the policy, e.g. for lack of
+// permission, isn't an error, and the bucket is reported without readers.
+func (g *gcsManager) bucketReaders(ctx context.Context, bkt *bucket) []string {
+	g.bucketReadersMu.Lock()
+	readers, ok := g.bucketReadersCache[bkt.name]
+	g.bucketReadersMu.Unlock()
+	if ok {
+		return readers
+	}
+
+	policy, err := bkt.IAM().V3().Policy(ctx)
+	if err != nil {
+		ctx.Logger().V(2).Info("failed to get bucket IAM policy", "error", err)
+	} else {
+		seen := make(map[string]struct{})
+		for _, binding := range policy.Bindings {
+			if _, ok := objectReaderRoles[binding.GetRole()]; !ok {
+				continue
+			}
+			for _, member := range binding.GetMembers() {
+				if _, ok := seen[member]; !ok {
+					seen[member] = struct{}{}
+					readers = append(readers, member)
+				}
+			}
+		}
+		slices.Sort(readers)
+	}
+
+	// Failures are cached too, so the policy of a bucket is only requested
+	// once.
+	g.bucketReadersMu.Lock()
+	defer g.bucketReadersMu.Unlock()
+	if g.bucketReadersCache == nil {
+		g.bucketReadersCache = make(map[string][]string)
+	}
+	g.bucketReadersCache[bkt.name] = readers
+	return readers
+}
+
+// checkBucketAccess records the ACL entries of the bucket granting access to
+// allUsers. The ACL of buckets with uniform bucket-level access can't be read,
+// as their access is only controlled by IAM, so failing to read it isn't an
+// error.
+func (g *gcsManager) checkBucketAccess(ctx context.Context, bkt *bucket) {
+	acl, err := bkt.ACL().List(ctx)
+	if err != nil {
+		ctx.Logger().V(2).Info("failed to get bucket ACL", "error", err)
+		return
+	}
+	public := allUsersACLs(publicACLs(acl))
+	if len(public) == 0 {
+		return
+	}
+
+	g.publicBucketsMu.Lock()
+	defer g.publicBucketsMu.Unlock()
+	g.publicBuckets[bkt.name] = public
+}
+
+// bucketObjects sends the objects of the bucket to ch. Each prefix is listed
+// separately, in lexicographic order, so resuming from the start offset of the
+// bucket skips the objects of the prefixes that were already listed.
+//
+// If the number of objects is capped, listing stops at the cap, or, when the
+// objects are sampled, the sample is read once all the prefixes are listed.
+// Soft-deleted objects are listed after the live ones, and capped separately.
 func (g *gcsManager) bucketObjects(ctx context.Context, bkt *bucket, ch chan<- io.Reader) error {
-	q, err := setObjectQuery(bkt)
+	for _, softDeleted := range g.listingPasses() {
+		limit := g.newObjectLimit(bkt.name)
+		for _, prefix := range g.queryPrefixes() {
+			if limit.done() {
+				break
+			}
+			if err := g.prefixObjects(ctx, bkt, prefix, softDeleted, limit, ch); err != nil {
+				return err
+			}
+		}
+
+		for _, obj := range limit.sampled() {
+			g.sendObject(ctx, bkt, obj, softDeleted, ch)
+		}
+	}
+	return nil
+}
+
+// listingPasses returns whether each listing of a bucket is of its
+// soft-deleted objects, which can't be listed along with the live ones.
+func (g *gcsManager) listingPasses() []bool {
+	if g.softDeleted {
+		return []bool{false, true}
+	}
+	return []bool{false}
+}
+
+func (g *gcsManager) prefixObjects(ctx context.Context, bkt *bucket, prefix string, softDeleted bool, limit *objectLimit, ch chan<- io.Reader) error {
+	q, err := g.setObjectQuery(bkt, prefix, softDeleted)
 	if err != nil {
 		return fmt.Errorf("failed to set object query: %w", err)
 	}
@@ -572,32 +1551,183 @@ func (g *gcsManager) bucketObjects(ctx context.Context, bkt *bucket, ch chan<- i
 	for {
 		obj, err := objs.Next()
 		if errors.Is(err, iterator.Done) {
-			ctx.Logger().V(5).Info("finished listing objects in bucket")
+			ctx.Logger().V(5).Info("finished listing objects in bucket", "prefix", prefix, "soft-deleted", softDeleted)
 			break
 		}
 		if err != nil {
-			return fmt.Errorf("failed to retrieve object iterator: %w", err)
+			return fmt.Errorf("failed to retrieve object iterator: %w", classifyError(vpcServiceControlsError(err), ErrBucketNotFound))
 		}
 		if obj == nil {
 			ctx.Logger().V(5).Info("object is nil")
 			continue
 		}
 
-		if !g.shouldIncludeObject(ctx, obj.Name) || g.shouldExcludeObject(ctx, obj.Name) {
+		if !g.shouldIncludeObject(ctx, obj.Name) || g.shouldExcludeObject(ctx, obj.Name) || !g.isUpdatedAfter(obj) || !g.matchesContentType(obj.ContentType) || g.isSkippedArchive(obj) || !g.isSampled(bkt.name, obj) {
 			continue
 		}
-
-		o, err := g.constructObject(ctx, bkt.Object(obj.Name))
-		if err != nil {
-			ctx.Logger().V(1).Info("failed to create object", "object-name", obj.Name, "error", err)
+		if !g.isObjectSizeInRange(obj.Size) {
+			// Secrets in large objects are missed, so they are logged like
+			// when they are read.
+			if obj.Size > g.maxObjectSize {
+				ctx.Logger().Info("skipping object larger than the maximum object size",
+					"bucket", bkt.name, "object-name", obj.Name, "object-size", obj.Size, "max-object-size", g.maxObjectSize)
+			} else {
+				ctx.Logger().V(3).Info("skipping object smaller than the minimum object size",
+					"bucket", bkt.name, "object-name", obj.Name, "object-size", obj.Size, "min-object-size", g.minObjectSize)
+			}
 			continue
 		}
-		ch <- o
+
+		if limit.admit(obj) {
+			g.sendObject(ctx, bkt, obj, softDeleted, ch)
+		}
+		if limit.done() {
+			ctx.Logger().V(3).Info("reached the maximum number of objects in bucket", "max-objects", g.maxObjectsPerBucket)
+			break
+		}
 	}
 	return nil
 }
 
-func setObjectQuery(bkt *bucket) (*storage.Query, error) {
+// sendObject reads the listed object and sends it to ch.
+func (g *gcsManager) sendObject(ctx context.Context, bkt *bucket, obj *storage.ObjectAttrs, softDeleted bool, ch chan<- io.Reader) {
+	if softDeleted {
+		g.sendSoftDeletedObject(ctx, bkt, obj, ch)
+		return
+	}
+
+	handle := bkt.Object(obj.Name)
+	if g.versions {
+		handle = handle.Generation(obj.Generation)
+	}
+	o, err := g.constructObject(ctx, handle)
+	if errors.Is(err, ErrObjectNotFound) {
+		ctx.Logger().V(3).Info("object was deleted before it could be read", "object-name", obj.Name)
+		ch <- object{
+			name:        obj.Name,
+			bucket:      bkt.name,
+			generation:  obj.Generation,
+			md5:         hex.EncodeToString(obj.MD5),
+			size:        obj.Size,
+			bucketAttrs: bkt.bucketAttrs,
+			readers:     bkt.readers,
+			deleted:     true,
+			Reader:      strings.NewReader(""),
+		}
+		return
+	}
+	if err != nil {
+		ctx.Logger().V(1).Info("failed to create object", "object-name", obj.Name, "error", err)
+		return
+	}
+	o.bucketAttrs, o.readers = bkt.bucketAttrs, bkt.readers
+	ch <- o
+}
+
+// sendSoftDeletedObject reads the listed soft-deleted object and sends it to
+// ch. Soft-deleted objects are addressed by their generation. GCS may refuse
+// to serve their content until they are restored, which would make them live
+// again, so such objects are sent without content instead, and only their
+// name and custom metadata are scanned.
+func (g *gcsManager) sendSoftDeletedObject(ctx context.Context, bkt *bucket, obj *storage.ObjectAttrs, ch chan<- io.Reader) {
+	logger := ctx.Logger().WithValues("object-name", obj.Name, "generation", obj.Generation)
+	handle := bkt.Object(obj.Name).SoftDeleted().Generation(obj.Generation)
+	attrs, err := handle.Attrs(ctx)
+	if err != nil {
+		// The object may have been restored or permanently deleted since
+		// it was listed.
+		logger.V(1).Info("failed to retrieve soft-deleted object attributes", "error", vpcServiceControlsError(err))
+		return
+	}
+	if !isObjectTypeValid(ctx, attrs.Name) || !g.isObjectSizeValid(ctx, attrs) {
+		return
+	}
+
+	o, err := g.readObject(ctx, handle, attrs)
+	if err != nil {
+		logger.V(2).Info("failed to read soft-deleted object, scanning its name and metadata only", "error", err)
+		o = newObject(attrs)
+		o.Reader = strings.NewReader("")
+		atomic.AddUint64(&g.numObjects, 1)
+	}
+	o.softDeleted = true
+	o.bucketAttrs, o.readers = bkt.bucketAttrs, bkt.readers
+	ch <- o
+}
+
+// objectLimit selects the objects of a bucket to scan when the number of
+// objects per bucket is capped. A nil objectLimit admits every object.
+type objectLimit struct {
+	max  int
+	seen int
+	// rng samples the objects, if set. Otherwise the first max objects are
+	// admitted.
+	rng    *rand.Rand
+	sample []*storage.ObjectAttrs
+}
+
+// newObjectLimit returns the limit of the bucket, or nil if the number of
+// objects isn't capped. Sampling is seeded by the bucket name too, so
+// enumerating and listing a bucket select the same objects.
+func (g *gcsManager) newObjectLimit(bkt string) *objectLimit {
+	if g.maxObjectsPerBucket <= 0 {
+		return nil
+	}
+
+	l := &objectLimit{max: g.maxObjectsPerBucket}
+	if g.sampleObjects {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(bkt))
+		l.rng = rand.New(rand.NewSource(g.sampleSeed ^ int64(h.Sum64())))
+	}
+	return l
+}
+
+// admit returns true if the object should be scanned right away. Sampled
+// objects are held back until the whole bucket is listed, and are returned by
+// sampled instead.
+func (l *objectLimit) admit(obj *storage.ObjectAttrs) bool {
+	if l == nil {
+		return true
+	}
+
+	l.seen++
+	if l.rng == nil {
+		return l.seen <= l.max
+	}
+
+	// Reservoir sampling, so every object is equally likely to be sampled
+	// without knowing the number of objects upfront.
+	if len(l.sample) < l.max {
+		l.sample = append(l.sample, obj)
+	} else if i := l.rng.Intn(l.seen); i < l.max {
+		l.sample[i] = obj
+	}
+	return false
+}
+
+// done returns true if no more objects will be admitted, so listing can stop.
+func (l *objectLimit) done() bool {
+	return l != nil && l.rng == nil && l.seen >= l.max
+}
+
+// sampled returns the sampled objects in lexicographic order, which resuming
+// from the start offset of a bucket relies on.
+func (l *objectLimit) sampled() []*storage.ObjectAttrs {
+	if l == nil || l.rng == nil {
+		return nil
+	}
+
+	slices.SortFunc(l.sample, func(a, b *storage.ObjectAttrs) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Generation, b.Generation)
+	})
+	return l.sample
+}
+
+func (g *gcsManager) setObjectQuery(bkt *bucket, prefix string, softDeleted bool) (*storage.Query, error) {
 	// Setting the attribute selection is a performance optimization.
 	// https://pkg.go.dev/cloud.google.com/go/storage#Query.SetAttrSelection
 	q := new(storage.Query)
@@ -609,6 +1739,9 @@ func setObjectQuery(bkt *bucket) (*storage.Query, error) {
 		"Updated",
 		"Created",
 		"ACL",
+		"Generation",
+		"MD5",
+		"StorageClass",
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to set attribute selection: %w", err)
@@ -619,6 +1752,12 @@ func setObjectQuery(bkt *bucket) (*storage.Query, error) {
 	if bkt.startOffset != "" {
 		q.StartOffset = bkt.startOffset
 	}
+	// Only list the objects under the prefix, if any.
+	q.Prefix = prefix
+	// Every soft-deleted generation of an object is listed, so there are no
+	// noncurrent versions to ask for.
+	q.SoftDeleted = softDeleted
+	q.Versions = g.versions && !softDeleted
 	return q, nil
 }
 
@@ -626,33 +1765,241 @@ func (g *gcsManager) constructObject(ctx context.Context, obj *storage.ObjectHan
 	o := object{}
 	attrs, err := obj.Attrs(ctx)
 	if err != nil {
-		return o, fmt.Errorf("failed to retrieve object attributes: %w", err)
+		return o, fmt.Errorf("failed to retrieve object attributes: %w", classifyError(vpcServiceControlsError(err), ErrObjectNotFound))
 	}
 
-	if !isObjectTypeValid(ctx, attrs.Name) || !g.isObjectSizeValid(ctx, attrs.Size) {
+	if !isObjectTypeValid(ctx, attrs.Name) || !g.isObjectSizeValid(ctx, attrs) {
 		return o, fmt.Errorf("object is not valid")
 	}
+	return g.readObject(ctx, obj, attrs)
+}
 
-	rc, err := obj.NewReader(ctx)
-	if err != nil {
-		return o, fmt.Errorf("failed to retrieve object reader: %w", err)
+// readObject returns the object with the given attributes, with a reader of
+// its content, or of its first maxObjectSize bytes if it's larger.
+func (g *gcsManager) readObject(ctx context.Context, obj *storage.ObjectHandle, attrs *storage.ObjectAttrs) (object, error) {
+	o := newObject(attrs)
+	o.truncated = attrs.Size > g.maxObjectSize
+	size := min(attrs.Size, g.maxObjectSize)
+
+	readCtx, cancel := ctx, context.CancelFunc(func() {})
+	if g.objectReadTimeout > 0 {
+		readCtx, cancel = context.WithTimeout(ctx, g.objectReadTimeout)
+	}
+	var rc io.ReadCloser
+	if g.shouldReadRanges(attrs) {
+		// Pin the generation, so all the ranges are of the same content even
+		// if the object is overwritten while it's read.
+		rc = newRangedReader(readCtx, obj.Generation(attrs.Generation), size, g.rangeSize, g.rangedReadConcurrency)
+	} else {
+		// Only the first bytes of truncated objects are downloaded. Ranges
+		// of objects with a content encoding are of the stored bytes, so
+		// those are downloaded whole, but still read up to the cap.
+		var r *storage.Reader
+		var err error
+		if o.truncated && attrs.ContentEncoding == "" {
+			r, err = obj.NewRangeReader(readCtx, 0, size)
+		} else {
+			r, err = obj.NewReader(readCtx)
+		}
+		if err != nil {
+			cancel()
+			return object{}, fmt.Errorf("failed to retrieve object reader: %w", classifyError(vpcServiceControlsError(err), ErrObjectNotFound))
+		}
+		// Objects stored with a content encoding are decompressed by the
+		// server unless the client accepts the encoding, in which case it's
+		// up to us. The encoding of the reader is that of the content served.
+		o.contentEncoding = r.Attrs.ContentEncoding
+		// The content served can be larger than the stored object, when
+		// the server decompresses it, so it's capped too.
+		rc = struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(r, g.maxObjectSize), r}
+	}
+
+	o.Reader = rc
+	if g.objectReadTimeout > 0 {
+		o.Reader = newDeadlineReader(readCtx, cancel, rc)
 	}
 
+	atomic.AddUint64(&g.numObjects, 1)
+
+	return o, nil
+}
+
+// newObject returns the object with the given attributes, without content.
+func newObject(attrs *storage.ObjectAttrs) object {
+	var o object
 	o.name = attrs.Name
 	o.bucket = attrs.Bucket
+	o.generation = attrs.Generation
 	o.contentType = attrs.ContentType
 	o.owner = attrs.Owner
 	o.link = attrs.MediaLink
 	o.md5 = hex.EncodeToString(attrs.MD5)
+	o.crc32c = attrs.CRC32C
+	o.etag = attrs.Etag
 	o.createdAt = attrs.Created
 	o.updatedAt = attrs.Updated
 	o.acl = objectACLs(attrs.ACL)
+	o.publicACLs = publicACLs(attrs.ACL)
+	o.metadata = attrs.Metadata
 	o.size = attrs.Size
-	o.Reader = rc
+	return o
+}
 
-	atomic.AddUint64(&g.numObjects, 1)
+// shouldReadRanges returns true if the object should be read in ranges. Objects
+// with a content encoding aren't, as the server may decompress them, and ranges
+// are of the stored bytes.
+func (g *gcsManager) shouldReadRanges(attrs *storage.ObjectAttrs) bool {
+	return g.rangedReadThreshold > 0 && attrs.Size >= g.rangedReadThreshold && attrs.ContentEncoding == ""
+}
 
-	return o, nil
+// rangedReader reads an object in ranges that are fetched concurrently, but
+// returned in order. Readers see the same bytes as when the object is read in
+// a single request, so chunks still overlap across the boundaries of ranges.
+type rangedReader struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	// ranges are the fetched or in-flight ranges, in order. Each is fetched
+	// by its own goroutine, which sends the result to the range's channel.
+	ranges chan chan rangeResult
+	// sem bounds the number of ranges that are fetched or held in memory.
+	sem chan struct{}
+	cur *bytes.Reader
+	err error
+}
+
+type rangeResult struct {
+	data []byte
+	err  error
+}
+
+func newRangedReader(ctx context.Context, obj *storage.ObjectHandle, size, rangeSize int64, concurrency int) *rangedReader {
+	ctx, cancel := context.WithCancel(ctx)
+	r := &rangedReader{
+		ctx:    ctx,
+		cancel: cancel,
+		ranges: make(chan chan rangeResult, concurrency),
+		sem:    make(chan struct{}, concurrency),
+	}
+	go r.fetch(obj, size, rangeSize)
+	return r
+}
+
+// fetch starts fetching the ranges of the object in order, as long as fewer
+// than the maximum are fetched or unread.
+func (r *rangedReader) fetch(obj *storage.ObjectHandle, size, rangeSize int64) {
+	defer close(r.ranges)
+	for off := int64(0); off < size; off += rangeSize {
+		select {
+		case r.sem <- struct{}{}:
+		case <-r.ctx.Done():
+			return
+		}
+		length := min(rangeSize, size-off)
+		res := make(chan rangeResult, 1)
+		go func() { res <- readRange(r.ctx, obj, off, length) }()
+		r.ranges <- res
+	}
+}
+
+func readRange(ctx context.Context, obj *storage.ObjectHandle, off, length int64) rangeResult {
+	rc, err := obj.NewRangeReader(ctx, off, length)
+	if err != nil {
+		return rangeResult{err: classifyError(err, ErrObjectNotFound)}
+	}
+	defer rc.Close()
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(rc, data); err != nil {
+		return rangeResult{err: err}
+	}
+	return rangeResult{data: data}
+}
+
+func (r *rangedReader) Read(p []byte) (int, error) {
+	for r.err == nil {
+		if r.cur != nil {
+			if r.cur.Len() > 0 {
+				return r.cur.Read(p)
+			}
+			// The range was read, so another one can be fetched.
+			r.cur = nil
+			<-r.sem
+		}
+
+		res, ok := <-r.ranges
+		if !ok {
+			// Fetching stops early if the context is done.
+			r.err = cmp.Or(r.ctx.Err(), io.EOF)
+			break
+		}
+		select {
+		case rr := <-res:
+			if rr.err != nil {
+				r.err = fmt.Errorf("failed to read object range: %w", rr.err)
+				break
+			}
+			r.cur = bytes.NewReader(rr.data)
+		case <-r.ctx.Done():
+			r.err = r.ctx.Err()
+		}
+	}
+	return 0, r.err
+}
+
+func (r *rangedReader) Close() error {
+	r.cancel()
+	return nil
+}
+
+// deadlineReader fails reads once its context is done, even while a read of
+// the underlying reader is blocked. The blocked read is abandoned.
+type deadlineReader struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	rc     io.ReadCloser
+	buf    []byte
+}
+
+func newDeadlineReader(ctx context.Context, cancel context.CancelFunc, rc io.ReadCloser) *deadlineReader {
+	return &deadlineReader{ctx: ctx, cancel: cancel, rc: rc}
+}
+
+func (r *deadlineReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, fmt.Errorf("object read timed out: %w", err)
+	}
+
+	// Read into a buffer of our own, which an abandoned read may still write
+	// to after we return. No further reads are made once it is abandoned.
+	if cap(r.buf) < len(p) {
+		r.buf = make([]byte, len(p))
+	}
+	buf := r.buf[:len(p)]
+
+	type result struct {
+		n   int
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		n, err := r.rc.Read(buf)
+		done <- result{n: n, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		return copy(p, buf[:res.n]), res.err
+	case <-r.ctx.Done():
+		return 0, fmt.Errorf("object read timed out: %w", r.ctx.Err())
+	}
+}
+
+func (r *deadlineReader) Close() error {
+	r.cancel()
+	return r.rc.Close()
 }
 
 func objectACLs(acl []storage.ACLRule) []string {
@@ -663,6 +2010,30 @@ func objectACLs(acl []storage.ACLRule) []string {
 	return acls
 }
 
+// publicACLs returns the ACL rules granting access to anyone on the internet,
+// or anyone with a Google account, as entity:role.
+func publicACLs(acl []storage.ACLRule) []string {
+	var public []string
+	for _, rule := range acl {
+		if rule.Entity == storage.AllUsers || rule.Entity == storage.AllAuthenticatedUsers {
+			public = append(public, fmt.Sprintf("%s:%s", rule.Entity, rule.Role))
+		}
+	}
+	return public
+}
+
+// allUsersACLs returns the public ACL entries, as returned by publicACLs, that
+// grant access to anyone on the internet.
+func allUsersACLs(public []string) []string {
+	var all []string
+	for _, acl := range public {
+		if strings.HasPrefix(acl, string(storage.AllUsers)+":") {
+			all = append(all, acl)
+		}
+	}
+	return all
+}
+
 func isObjectTypeValid(ctx context.Context, name string) bool {
 	isValid := !common.SkipFile(name)
 	if !isValid {
@@ -672,13 +2043,41 @@ func isObjectTypeValid(ctx context.Context, name string) bool {
 	return true
 }
 
-func (g *gcsManager) isObjectSizeValid(ctx context.Context, size int64) bool {
-	isValid := size > 0 && size <= g.maxObjectSize
-	if !isValid {
-		ctx.Logger().V(2).Info("object size is invalid", "object-size", size)
+// isObjectSizeValid returns true if the object should be read. Empty objects
+// are skipped, and so are the objects larger than the maximum object size,
+// unless they are truncated. Large objects are logged either way, as secrets
+// they contain past the maximum object size are missed.
+func (g *gcsManager) isObjectSizeValid(ctx context.Context, attrs *storage.ObjectAttrs) bool {
+	switch {
+	case attrs.Size <= 0:
+		ctx.Logger().V(2).Info("object size is invalid", "object-size", attrs.Size)
+		return false
+	case attrs.Size < g.minObjectSize:
+		ctx.Logger().V(3).Info("skipping object smaller than the minimum object size",
+			"bucket", attrs.Bucket, "object-name", attrs.Name, "object-size", attrs.Size, "min-object-size", g.minObjectSize)
+		return false
+	case attrs.Size <= g.maxObjectSize:
+		return true
+	case g.truncateLargeObjects:
+		ctx.Logger().Info("object is larger than the maximum object size, only scanning its beginning",
+			"bucket", attrs.Bucket, "object-name", attrs.Name, "object-size", attrs.Size, "max-object-size", g.maxObjectSize)
+		return true
+	default:
+		ctx.Logger().Info("skipping object larger than the maximum object size",
+			"bucket", attrs.Bucket, "object-name", attrs.Name, "object-size", attrs.Size, "max-object-size", g.maxObjectSize)
 		return false
 	}
-	return true
+}
+
+// isObjectSizeInRange returns true if an object of the size is neither smaller
+// than the minimum object size nor larger than the maximum object size, unless
+// large objects are truncated. Unlike isObjectSizeValid, it doesn't skip empty
+// objects, so they are still counted.
+func (g *gcsManager) isObjectSizeInRange(size int64) bool {
+	if size < g.minObjectSize {
+		return false
+	}
+	return size <= g.maxObjectSize || g.truncateLargeObjects
 }
 
 func (g *gcsManager) shouldIncludeBucket(ctx context.Context, bkt string) bool {
@@ -703,8 +2102,93 @@ func (g *gcsManager) shouldExcludeObject(ctx context.Context, obj string) bool {
 	return shouldProcess(ctx, obj, g.excludeObjects, globMatches)
 }
 
+// isUpdatedAfter returns true if the object was updated after updatedAfter,
+// or if it isn't set.
+func (g *gcsManager) isUpdatedAfter(obj *storage.ObjectAttrs) bool {
+	return g.updatedAfter.IsZero() || obj.Updated.After(g.updatedAfter)
+}
+
+// isSampled returns true if the object is selected by the sample rate. The
+// objects are hashed along with the seed, so the selection is random, but the
+// same for the same seed.
+func (g *gcsManager) isSampled(bkt string, obj *storage.ObjectAttrs) bool {
+	if g.sampleRate == 0 || g.sampleRate == 1 {
+		return true
+	}
+
+	h := fnv.New64a()
+	_ = binary.Write(h, binary.LittleEndian, g.sampleSeed)
+	_, _ = h.Write([]byte(bkt))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(obj.Name))
+	_ = binary.Write(h, binary.LittleEndian, obj.Generation)
+
+	// FNV hashes of similar names differ little in their high bits, so the
+	// hash is mixed with the finalizer of SplitMix64 before it's taken as a
+	// fraction of 1.
+	x := h.Sum64()
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	x ^= x >> 31
+	return float64(x>>11)/(1<<53) < g.sampleRate
+}
+
+// isSkippedArchive returns true if the object is in an archival storage
+// class and those are skipped.
+func (g *gcsManager) isSkippedArchive(obj *storage.ObjectAttrs) bool {
+	if !g.skipArchived {
+		return false
+	}
+	return obj.StorageClass == "COLDLINE" || obj.StorageClass == "ARCHIVE"
+}
+
+// matchesContentType returns true if an object of the content type should be
+// scanned according to the content type filters. Content types that are
+// missing or invalid are unknown.
+func (g *gcsManager) matchesContentType(contentType string) bool {
+	if len(g.includeContentTypes) == 0 && len(g.excludeContentTypes) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return !g.excludeUnknownContentTypes
+	}
+	if matchesMediaType(mediaType, g.excludeContentTypes) {
+		return false
+	}
+	return len(g.includeContentTypes) == 0 || matchesMediaType(mediaType, g.includeContentTypes)
+}
+
+// matchesMediaType returns true if the media type matches one of the
+// patterns of parseContentTypePatterns.
+func matchesMediaType(mediaType string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+			if strings.HasPrefix(mediaType, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if mediaType == pattern {
+			return true
+		}
+	}
+	return false
+}
+
 type globMatcherFn func(string, glob.Glob) bool
 
+// matchesBucketLabels returns true if the labels of a bucket contain all of
+// the labels of the bucket label selector.
+func (g *gcsManager) matchesBucketLabels(labels map[string]string) bool {
+	for k, want := range g.bucketLabels {
+		if got, ok := labels[k]; !ok || got != want {
+			return false
+		}
+	}
+	return true
+}
+
 func shouldProcess(ctx context.Context, s string, matchers map[string]struct{}, matcherFn globMatcherFn) bool {
 	if len(matchers) == 0 {
 		return false
@@ -1,18 +1,34 @@
 package gcs
 
 import (
+	aCtx "context"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"cloud.google.com/go/storage"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/option"
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 )
 
 const (
@@ -355,7 +371,8 @@ func TestNewGcsManager(t *testing.T) {
 
 			diff := cmp.Diff(got, tc.want,
 				cmp.AllowUnexported(gcsManager{}, bucket{}),
-				cmpopts.IgnoreFields(gcsManager{}, "client", "workerPool", "buckets"),
+				cmpopts.IgnoreFields(gcsManager{}, "client", "newClient", "workerPool", "buckets"),
+				cmpopts.IgnoreTypes(sync.Mutex{}, sync.Once{}),
 			)
 			if diff != "" {
 				t.Errorf("newGCSManager(%v, %v) got: %v, want: %v, diff: %v", tc.projID, tc.opts, got, tc.want, diff)
@@ -425,7 +442,7 @@ func TestGCSManagerStats(t *testing.T) {
 				t.Errorf("Attributes() error = %v", err)
 			}
 
-			if diff := cmp.Diff(got, tc.wantStats, cmp.AllowUnexported(attributes{}), cmpopts.IgnoreFields(attributes{}, "mu")); diff != "" {
+			if diff := cmp.Diff(got, tc.wantStats, cmp.AllowUnexported(attributes{}), cmpopts.IgnoreFields(attributes{}, "mu", "numBytes", "bucketAttrs")); diff != "" {
 				t.Errorf("Attributes() got: %v, want: %v, diff: %v", got, tc.wantStats, diff)
 			}
 		})
@@ -841,6 +858,11 @@ func Test_isObjectTypeValid(t *testing.T) {
 			objName: "aws1.zip",
 			want:    true,
 		},
+		{
+			name:    "valid object type, pdf",
+			objName: "report.pdf",
+			want:    true,
+		},
 		{
 			name:    "invalid object type",
 			objName: "video.mp4",
@@ -906,8 +928,1284 @@ func Test_isObjectSizeValid(t *testing.T) {
 			maxObjectSize: maxObjectSizeLimit,
 		}
 		t.Run(tc.name, func(t *testing.T) {
-			got := g.isObjectSizeValid(ctx, tc.objSize)
+			got := g.isObjectSizeValid(ctx, &storage.ObjectAttrs{Size: tc.objSize})
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGCSManagerEnumerate_ManyBuckets(t *testing.T) {
+	ctx := context.Background()
+
+	const numBuckets = 50
+	served := make(map[string]fakeBucket, numBuckets)
+	bkts := make([]bucket, 0, numBuckets+1)
+	wantObjects := make(map[string]uint64, numBuckets+1)
+	for i := 0; i < numBuckets; i++ {
+		name := fmt.Sprintf("bucket-%d", i)
+		served[name] = fakeBucket{objects: fakeSizedObjects(int64(i), 100)}
+		bkts = append(bkts, bucket{name: name})
+		wantObjects[name] = 2
+	}
+	// The forbidden bucket fails without affecting the others.
+	bkts = append(bkts, bucket{name: "forbidden"})
+
+	server := fakeGCSServer(t, &fakeGCS{buckets: served})
+	defer server.Close()
+
+	gm, err := newGCSManager(testProjectID, withoutAuthentication(), withConcurrency(8), withContinueOnBucketError(true))
+	assert.NoError(t, err)
+	gm.client = fakeClient(t, server)
+
+	stats, err := gm.enumerate(ctx, bkts)
+	assert.ErrorContains(t, err, "bucket forbidden")
+	assert.NotNil(t, stats)
+
+	// Sizes 0 through 49, plus 100 per bucket.
+	wantBytes := uint64(numBuckets*(numBuckets-1)/2 + numBuckets*100)
+	assert.Equal(t, uint32(numBuckets+1), stats.numBuckets)
+	assert.Equal(t, uint64(2*numBuckets), stats.numObjects)
+	assert.Equal(t, wantBytes, stats.numBytes)
+	assert.Equal(t, wantObjects, stats.bucketObjects)
+}
+
+func TestGCSManager_BucketError(t *testing.T) {
+	ctx := context.Background()
+
+	// The service account can't access the forbidden bucket.
+	server := fakeGCSServer(t, &fakeGCS{buckets: fakeBuckets(map[string]map[string][]string{
+		"alpha": {"a.txt": {"alpha"}},
+		"bravo": {"b.txt": {"bravo"}},
+	})})
+	defer server.Close()
+
+	newManager := func(t *testing.T, continueOnBucketError bool) *gcsManager {
+		gm, err := newGCSManager(testProjectID,
+			withoutAuthentication(),
+			withIncludeBuckets([]string{"alpha", "bravo", "forbidden"}),
+			withContinueOnBucketError(continueOnBucketError),
+		)
+		require.NoError(t, err)
+		gm.client = fakeClient(t, server)
+		return gm
+	}
+	listObjects := func(t *testing.T, gm *gcsManager) []string {
+		objCh, err := gm.ListObjects(ctx)
+		require.NoError(t, err)
+
+		var got []string
+		for obj := range objCh {
+			o := obj.(object)
+			got = append(got, o.bucket+"/"+o.name)
+		}
+		sort.Strings(got)
+		return got
+	}
+
+	t.Run("continue on bucket error", func(t *testing.T) {
+		gm := newManager(t, true)
+
+		stats, err := gm.Attributes(ctx)
+		assert.ErrorContains(t, err, "bucket forbidden")
+		require.NotNil(t, stats)
+		assert.Equal(t, uint64(2), stats.numObjects)
+		assert.Equal(t, map[string]uint64{"alpha": 1, "bravo": 1}, stats.bucketObjects)
+
+		// The other buckets are scanned, and the error of the forbidden one
+		// is reported at the end.
+		assert.Equal(t, []string{"alpha/a.txt", "bravo/b.txt"}, listObjects(t, gm))
+		err = gm.BucketErrors()
+		assert.ErrorContains(t, err, "bucket forbidden")
+		assert.NotContains(t, err.Error(), "bucket alpha")
+		assert.NotContains(t, err.Error(), "bucket bravo")
+	})
+
+	t.Run("stop on bucket error", func(t *testing.T) {
+		gm := newManager(t, false)
+
+		stats, err := gm.Attributes(ctx)
+		assert.ErrorContains(t, err, "bucket forbidden")
+		assert.Nil(t, stats)
+
+		// Which objects are listed before the listing stops is racy.
+		_ = listObjects(t, gm)
+		assert.ErrorContains(t, gm.BucketErrors(), "bucket forbidden")
+	})
+}
+
+func TestGCSManagerEnumerate_Prefixes(t *testing.T) {
+	ctx := context.Background()
+
+	sizes := make([]int64, 25)
+	for i := range sizes {
+		sizes[i] = int64(i)
+	}
+	server := fakeGCSServer(t, &fakeGCS{buckets: map[string]fakeBucket{testBucket: {objects: fakeSizedObjects(sizes...)}}})
+	defer server.Close()
+
+	// object12 is already covered by object1, and must only be counted once.
+	gm, err := newGCSManager(testProjectID, withoutAuthentication(), withPrefixes([]string{"object2", "object1", "object12", "object2"}))
+	assert.NoError(t, err)
+	gm.client = fakeClient(t, server)
+
+	stats, err := gm.enumerate(ctx, []bucket{{name: testBucket}})
+	assert.NoError(t, err)
+
+	// object1, object10 through object19, object2 and object20 through object24.
+	var wantBytes uint64 = 1 + 2
+	for i := 10; i < 25; i++ {
+		wantBytes += uint64(i)
+	}
+	assert.Equal(t, uint64(17), stats.numObjects)
+	assert.Equal(t, wantBytes, stats.numBytes)
+	assert.Equal(t, map[string]uint64{testBucket: 17}, stats.bucketObjects)
+}
+
+func TestGCSManagerListObjects_Versions(t *testing.T) {
+	ctx := context.Background()
+
+	server := fakeGCSServer(t, &fakeGCS{buckets: fakeBuckets(map[string]map[string][]string{testBucket: {
+		"config.txt": {"secret v1", "secret v2", "redacted"},
+		"notes.txt":  {"notes"},
+	}})})
+	defer server.Close()
+
+	testCases := []struct {
+		name     string
+		versions bool
+		want     map[string]int64
+	}{
+		{
+			name: "live versions",
+			want: map[string]int64{"redacted": 3, "notes": 1},
+		},
+		{
+			name:     "all versions",
+			versions: true,
+			want:     map[string]int64{"secret v1": 1, "secret v2": 2, "redacted": 3, "notes": 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gm, err := newGCSManager(testProjectID, withoutAuthentication(), withIncludeBuckets([]string{testBucket}), withVersions(tc.versions))
+			assert.NoError(t, err)
+			gm.client = fakeClient(t, server)
+
+			objCh, err := gm.ListObjects(ctx)
+			assert.NoError(t, err)
+
+			got := make(map[string]int64)
+			for obj := range objCh {
+				o := obj.(object)
+				content, err := io.ReadAll(o)
+				assert.NoError(t, err)
+				got[string(content)] = o.generation
+				assert.Equal(t, o.generation, objectMetadata(o).GetGeneration())
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGCSManagerListObjects_MaxObjectsPerBucket(t *testing.T) {
+	ctx := context.Background()
+
+	objects := make(map[string][]string, 10)
+	attrs := make([]*storage.ObjectAttrs, 0, 10)
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("object-%02d", i)
+		objects[name] = []string{name}
+		attrs = append(attrs, &storage.ObjectAttrs{Name: name})
+	}
+	server := fakeGCSServer(t, &fakeGCS{buckets: fakeBuckets(map[string]map[string][]string{testBucket: objects})})
+	defer server.Close()
+
+	const seed = 42
+	// The sample is the one the limit of the bucket selects from the listed objects.
+	sampler, err := newGCSManager(testProjectID, withoutAuthentication(), withMaxObjectsPerBucket(3), withObjectSampling(seed))
+	assert.NoError(t, err)
+	limit := sampler.newObjectLimit(testBucket)
+	for _, obj := range attrs {
+		assert.False(t, limit.admit(obj))
+	}
+	var sample []string
+	for _, obj := range limit.sampled() {
+		sample = append(sample, obj.Name)
+	}
+	assert.Len(t, sample, 3)
+	assert.True(t, sort.StringsAreSorted(sample))
+
+	testCases := []struct {
+		name string
+		opts []gcsManagerOption
+		want []string
+	}{
+		{
+			name: "first objects",
+			opts: []gcsManagerOption{withMaxObjectsPerBucket(3)},
+			want: []string{"object-00", "object-01", "object-02"},
+		},
+		{
+			name: "sampled objects",
+			opts: []gcsManagerOption{withMaxObjectsPerBucket(3), withObjectSampling(seed)},
+			want: sample,
+		},
+		{
+			name: "cap larger than the bucket",
+			opts: []gcsManagerOption{withMaxObjectsPerBucket(20)},
+			want: []string{
+				"object-00", "object-01", "object-02", "object-03", "object-04",
+				"object-05", "object-06", "object-07", "object-08", "object-09",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append([]gcsManagerOption{withoutAuthentication(), withIncludeBuckets([]string{testBucket})}, tc.opts...)
+			gm, err := newGCSManager(testProjectID, opts...)
+			assert.NoError(t, err)
+			gm.client = fakeClient(t, server)
+
+			stats, err := gm.enumerate(ctx, []bucket{{name: testBucket}})
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(len(tc.want)), stats.numObjects)
+			assert.Equal(t, uint64(len(tc.want)*len("object-00")), stats.numBytes)
+
+			objCh, err := gm.ListObjects(ctx)
+			assert.NoError(t, err)
+
+			var got []string
+			for obj := range objCh {
+				got = append(got, obj.(object).name)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGCSManagerListObjects_SampleRate(t *testing.T) {
+	ctx := context.Background()
+
+	const numObjects = 500
+	objects := make(map[string][]string, numObjects)
+	for i := 0; i < numObjects; i++ {
+		name := fmt.Sprintf("logs/%04d.json", i)
+		objects[name] = []string{name}
+	}
+	server := fakeGCSServer(t, &fakeGCS{buckets: fakeBuckets(map[string]map[string][]string{testBucket: objects})})
+	defer server.Close()
+
+	sample := func(rate float64, seed int64) []string {
+		gm, err := newGCSManager(testProjectID,
+			withoutAuthentication(),
+			withIncludeBuckets([]string{testBucket}),
+			withSampleRate(rate),
+			withSampleSeed(seed),
+		)
+		require.NoError(t, err)
+		gm.client = fakeClient(t, server)
+
+		stats, err := gm.Attributes(ctx)
+		require.NoError(t, err)
+
+		objCh, err := gm.ListObjects(ctx)
+		require.NoError(t, err)
+		var got []string
+		for obj := range objCh {
+			got = append(got, obj.(object).name)
+		}
+		sort.Strings(got)
+		// The enumeration counts the objects that are listed.
+		assert.Equal(t, uint64(len(got)), stats.numObjects)
+		return got
+	}
+
+	for _, rate := range []float64{0.05, 0.2, 0.5} {
+		got := sample(rate, 42)
+		// The standard deviation of the sample size is at most 11 objects.
+		assert.InDelta(t, rate*numObjects, len(got), 45, "rate %v", rate)
+		// The same seed samples the same objects.
+		assert.Equal(t, got, sample(rate, 42), "rate %v", rate)
+		assert.NotEqual(t, got, sample(rate, 43), "rate %v", rate)
+	}
+
+	assert.Len(t, sample(0, 42), numObjects)
+	assert.Len(t, sample(1, 42), numObjects)
+}
+
+func TestWithSampleRate_Invalid(t *testing.T) {
+	for _, rate := range []float64{-0.1, 1.5, math.NaN()} {
+		_, err := newGCSManager(testProjectID, withoutAuthentication(), withSampleRate(rate))
+		assert.Error(t, err, rate)
+	}
+}
+
+func TestDedupePrefixes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prefixes []string
+		want     []string
+	}{
+		{
+			name: "no prefixes",
+		},
+		{
+			name:     "distinct prefixes are sorted",
+			prefixes: []string{"logs/", "incident-2024/"},
+			want:     []string{"incident-2024/", "logs/"},
+		},
+		{
+			name:     "duplicate prefixes",
+			prefixes: []string{"logs/", "logs/"},
+			want:     []string{"logs/"},
+		},
+		{
+			name:     "overlapping prefixes",
+			prefixes: []string{"incident-2024/db/", "logs/app/", "incident-2024/", "logs/app/2024/"},
+			want:     []string{"incident-2024/", "logs/app/"},
+		},
+		{
+			name:     "empty prefix matches everything",
+			prefixes: []string{"logs/", ""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, dedupePrefixes(tc.prefixes))
+		})
+	}
+}
+
+func TestGCSManagerValidateCredentials(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name        string
+		status      int
+		withoutAuth bool
+		wantErr     string
+		wantErrIs   error
+	}{
+		{name: "valid credentials", status: http.StatusOK},
+		{name: "invalid credentials", status: http.StatusUnauthorized, wantErr: "credentials are invalid or expired", wantErrIs: ErrAuth},
+		{name: "missing permission", status: http.StatusForbidden, wantErr: "lack permission to list buckets", wantErrIs: ErrPermission},
+		{name: "other error", status: http.StatusNotFound, wantErr: "failed to validate GCS credentials"},
+		{name: "unauthenticated", status: http.StatusUnauthorized, withoutAuth: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				if tc.status == http.StatusOK {
+					_, _ = w.Write([]byte(`{"kind": "storage#buckets", "items": [{"name": "bucket1"}]}`))
+					return
+				}
+				_, _ = fmt.Fprintf(w, `{"error": {"code": %d, "message": "denied"}}`, tc.status)
+			}))
+			defer server.Close()
+
+			gm, err := newGCSManager(testProjectID, withoutAuthentication())
+			assert.NoError(t, err)
+			gm.client = fakeClient(t, server)
+			gm.withoutAuth = tc.withoutAuth
+
+			err = gm.validateCredentials(ctx)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.wantErr)
+			if tc.wantErrIs != nil {
+				assert.ErrorIs(t, err, tc.wantErrIs)
+			}
+		})
+	}
+}
+
+func TestGCSManager_ErrorKinds(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name      string
+		status    int
+		wantErrIs error
+	}{
+		{name: "invalid credentials", status: http.StatusUnauthorized, wantErrIs: ErrAuth},
+		{name: "missing permission", status: http.StatusForbidden, wantErrIs: ErrPermission},
+		{name: "missing resource", status: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Every request fails, for a bucket or an object.
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				_, _ = fmt.Fprintf(w, `{"error": {"code": %d, "message": "failed"}}`, tc.status)
+			}))
+			defer server.Close()
+
+			gm, err := newGCSManager(testProjectID, withoutAuthentication())
+			require.NoError(t, err)
+			gm.client = fakeClient(t, server)
+
+			// A 404 is a missing bucket when listing its objects, and a
+			// missing object when reading it.
+			wantBucketErr, wantObjectErr := tc.wantErrIs, tc.wantErrIs
+			if tc.status == http.StatusNotFound {
+				wantBucketErr, wantObjectErr = ErrBucketNotFound, ErrObjectNotFound
+			}
+
+			_, err = gm.enumerate(ctx, []bucket{{name: "alpha"}})
+			assert.ErrorIs(t, err, wantBucketErr)
+			// The error of the client is kept, which is its own sentinel
+			// for a missing bucket.
+			if tc.status == http.StatusNotFound {
+				assert.ErrorIs(t, err, storage.ErrBucketNotExist)
+			} else {
+				var apiErr *googleapi.Error
+				assert.ErrorAs(t, err, &apiErr)
+			}
+
+			_, err = gm.constructObject(ctx, gm.client.Bucket("alpha").Object("secret.txt"))
+			assert.ErrorIs(t, err, wantObjectErr)
+			for _, kind := range []error{ErrAuth, ErrPermission, ErrBucketNotFound, ErrObjectNotFound} {
+				if kind != wantObjectErr {
+					assert.NotErrorIs(t, err, kind)
+				}
+			}
+		})
+	}
+}
+
+func TestGCSManagerAttributes_BucketLabelSelector(t *testing.T) {
+	ctx := context.Background()
+
+	labels := func(labels map[string]string) map[string]any {
+		return map[string]any{"labels": labels}
+	}
+	server := fakeGCSServer(t, &fakeGCS{buckets: map[string]fakeBucket{
+		"prod-app":  {objects: fakeSizedObjects(10, 20), attrs: labels(map[string]string{"env": "prod", "team": "app"})},
+		"prod-data": {objects: fakeSizedObjects(30), attrs: labels(map[string]string{"env": "prod", "team": "data"})},
+		"staging":   {objects: fakeSizedObjects(40), attrs: labels(map[string]string{"env": "staging", "team": "app"})},
+		"unlabeled": {objects: fakeSizedObjects(50)},
+	}})
+	defer server.Close()
+
+	testCases := []struct {
+		name      string
+		selector  map[string]string
+		wantStats *attributes
+	}{
+		{
+			name:     "single label",
+			selector: map[string]string{"env": "prod"},
+			wantStats: &attributes{
+				numBuckets:    2,
+				numObjects:    3,
+				numBytes:      60,
+				bucketObjects: map[string]uint64{"prod-app": 2, "prod-data": 1},
+			},
+		},
+		{
+			name:     "labels are combined",
+			selector: map[string]string{"env": "prod", "team": "app"},
+			wantStats: &attributes{
+				numBuckets:    1,
+				numObjects:    2,
+				numBytes:      30,
+				bucketObjects: map[string]uint64{"prod-app": 2},
+			},
+		},
+		{
+			name:     "no matching buckets",
+			selector: map[string]string{"env": "dev"},
+			wantStats: &attributes{
+				bucketObjects: map[string]uint64{},
+			},
+		},
+		{
+			name: "no selector",
+			wantStats: &attributes{
+				numBuckets:    4,
+				numObjects:    5,
+				numBytes:      150,
+				bucketObjects: map[string]uint64{"prod-app": 2, "prod-data": 1, "staging": 1, "unlabeled": 1},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			withFakeClient := func(m *gcsManager) error {
+				m.client = fakeClient(t, server)
+				return nil
+			}
+			gm, err := newGCSManager(testProjectID, withFakeClient, withBucketLabelSelector(tc.selector))
+			if err != nil {
+				t.Fatalf("newGCSManager() error = %v", err)
+			}
+
+			got, err := gm.Attributes(ctx)
+			assert.NoError(t, err)
+
+			if diff := cmp.Diff(tc.wantStats, got, cmp.AllowUnexported(attributes{}), cmpopts.IgnoreFields(attributes{}, "mu", "bucketAttrs")); diff != "" {
+				t.Errorf("Attributes() diff: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGCSManager_Proxy(t *testing.T) {
+	ctx := context.Background()
+
+	// The storage endpoint can only be reached through the proxy, which
+	// serves the objects itself.
+	var mu sync.Mutex
+	var proxied []string
+	proxy := fakeGCSServer(t, &fakeGCS{
+		buckets: fakeBuckets(map[string]map[string][]string{
+			"alpha": {"a.txt": {"alpha"}},
+		}),
+		onRequest: func(r *http.Request) {
+			mu.Lock()
+			proxied = append(proxied, r.URL.Host)
+			mu.Unlock()
+		},
+	})
+	defer proxy.Close()
+
+	gm, err := newGCSManager(testProjectID,
+		withoutAuthentication(),
+		withIncludeBuckets([]string{"alpha"}),
+		withProxy(proxy.URL),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, gm.proxy)
+	assert.Equal(t, proxy.URL, gm.proxy.String())
+
+	gm.client, err = newStorageClient(ctx, clientRoute{proxy: gm.proxy}, option.WithoutAuthentication(), option.WithEndpoint("http://storage.test/storage/v1/"))
+	require.NoError(t, err)
+
+	objCh, err := gm.ListObjects(ctx)
+	require.NoError(t, err)
+	var got []string
+	for obj := range objCh {
+		o := obj.(object)
+		data, err := io.ReadAll(o)
+		require.NoError(t, err)
+		got = append(got, o.bucket+"/"+o.name+": "+string(data))
+	}
+	assert.Equal(t, []string{"alpha/a.txt: alpha"}, got)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.NotEmpty(t, proxied)
+	for _, host := range proxied {
+		assert.Equal(t, "storage.test", host)
+	}
+}
+
+func TestGCSManager_UserAgent(t *testing.T) {
+	ctx := context.Background()
+	common.SetUserAgent("acme-scanner/1.0")
+	defer common.SetUserAgent("")
+
+	var mu sync.Mutex
+	var userAgents []string
+	server := fakeGCSServer(t, &fakeGCS{
+		buckets: fakeBuckets(map[string]map[string][]string{
+			"alpha": {"a.txt": {"alpha"}},
+		}),
+		onRequest: func(r *http.Request) {
+			mu.Lock()
+			userAgents = append(userAgents, r.Header.Get("User-Agent"))
+			mu.Unlock()
+		},
+	})
+	defer server.Close()
+
+	gm, err := newGCSManager(testProjectID,
+		withoutAuthentication(),
+		withIncludeBuckets([]string{"alpha"}),
+	)
+	require.NoError(t, err)
+	gm.client, err = newStorageClient(ctx, clientRoute{}, option.WithoutAuthentication(), option.WithEndpoint(server.URL+"/storage/v1/"))
+	require.NoError(t, err)
+
+	objCh, err := gm.ListObjects(ctx)
+	require.NoError(t, err)
+	for obj := range objCh {
+		_, err := io.ReadAll(obj.(object))
+		require.NoError(t, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.NotEmpty(t, userAgents)
+	for _, ua := range userAgents {
+		assert.Equal(t, "acme-scanner/1.0", ua)
+	}
+}
+
+func TestUserAgentTransport(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: userAgentTransport{}}
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("User-Agent", "gcloud-golang-storage")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, common.DefaultUserAgent, got)
+	// The request of the caller isn't modified.
+	assert.Equal(t, "gcloud-golang-storage", req.Header.Get("User-Agent"))
+}
+
+func TestWithPrivateEndpoint(t *testing.T) {
+	for _, private := range []bool{false, true} {
+		var got clientRoute
+		gm, err := newGCSManager(testProjectID,
+			withoutAuthentication(),
+			withPrivateEndpoint(private),
+			func(m *gcsManager) error {
+				m.newClient = func(route clientRoute) (*storage.Client, error) {
+					got = route
+					return storage.NewClient(context.Background(), option.WithoutAuthentication())
+				}
+				return nil
+			},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, private, gm.privateEndpoint)
+		assert.Equal(t, clientRoute{private: private}, got)
+		assert.Equal(t, !private, got.isDefault())
+	}
+}
+
+func TestWithImpersonation(t *testing.T) {
+	ctx := context.Background()
+	const (
+		targetSA = "scanner@trufflehog-testing.iam.gserviceaccount.com"
+		delegate = "delegate@trufflehog-testing.iam.gserviceaccount.com"
+	)
+
+	var got []impersonate.CredentialsConfig
+	newTokenSource := newImpersonatedTokenSource
+	t.Cleanup(func() { newImpersonatedTokenSource = newTokenSource })
+	newImpersonatedTokenSource = func(_ aCtx.Context, config impersonate.CredentialsConfig, _ ...option.ClientOption) (oauth2.TokenSource, error) {
+		got = append(got, config)
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "impersonated"}), nil
+	}
+
+	delegates := []string{delegate}
+	gm, err := newGCSManager(testProjectID, withImpersonation(ctx, targetSA, delegates))
+	require.NoError(t, err)
+	assert.NotNil(t, gm.client)
+	delegates[0] = "changed"
+
+	// The token source impersonates the target through the delegates, with
+	// the scope of the client.
+	require.Len(t, got, 1)
+	assert.Equal(t, impersonate.CredentialsConfig{
+		TargetPrincipal: targetSA,
+		Scopes:          []string{storage.ScopeReadOnly},
+		Delegates:       []string{delegate},
+	}, got[0])
+
+	// Errors creating the token source, e.g. without application default
+	// credentials, fail the creation of the client.
+	newImpersonatedTokenSource = func(aCtx.Context, impersonate.CredentialsConfig, ...option.ClientOption) (oauth2.TokenSource, error) {
+		return nil, fmt.Errorf("could not find default credentials")
+	}
+	_, err = newGCSManager(testProjectID, withImpersonation(ctx, targetSA, nil))
+	assert.ErrorContains(t, err, "could not find default credentials")
+
+	_, err = newGCSManager(testProjectID, withImpersonation(ctx, "", nil))
+	assert.Error(t, err)
+}
+
+const testWIFConfig = `{
+	"type": "external_account",
+	"audience": "//iam.googleapis.com/projects/123456/locations/global/workloadIdentityPools/github/providers/github",
+	"subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+	"token_url": "https://sts.googleapis.com/v1/token",
+	"credential_source": {"file": "/var/run/secrets/oidc-token"}
+}`
+
+func TestWithWorkloadIdentityFederation(t *testing.T) {
+	ctx := context.Background()
+
+	type call struct {
+		config string
+		scopes []string
+	}
+	var got []call
+	newCredentials := newExternalAccountCredentials
+	t.Cleanup(func() { newExternalAccountCredentials = newCredentials })
+	newExternalAccountCredentials = func(_ aCtx.Context, config []byte, scopes ...string) (*google.Credentials, error) {
+		got = append(got, call{config: string(config), scopes: scopes})
+		return &google.Credentials{TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "federated"})}, nil
+	}
+
+	path := filepath.Join(t.TempDir(), "wif.json")
+	require.NoError(t, os.WriteFile(path, []byte(testWIFConfig), 0o600))
+
+	// The source reads the configuration file and creates the client with
+	// the federated credentials, with the scope of the client.
+	gm, err := configureGCSManager(ctx, &sourcespb.GCS{
+		ProjectId:  testProjectID,
+		Credential: &sourcespb.GCS_WorkloadIdentityFederationFile{WorkloadIdentityFederationFile: path},
+	}, 8)
+	require.NoError(t, err)
+	assert.NotNil(t, gm.client)
+	assert.False(t, gm.withoutAuth)
+	assert.Equal(t, []call{{config: testWIFConfig, scopes: []string{storage.ScopeReadOnly}}}, got)
+
+	// Other types of credentials, e.g. service account keys, are rejected.
+	_, err = newGCSManager(testProjectID, withWorkloadIdentityFederation(ctx, []byte(`{"type": "service_account"}`)))
+	assert.ErrorContains(t, err, "external_account")
+	_, err = newGCSManager(testProjectID, withWorkloadIdentityFederation(ctx, []byte("not json")))
+	assert.Error(t, err)
+
+	// Errors creating the credentials fail the creation of the client.
+	newExternalAccountCredentials = func(aCtx.Context, []byte, ...string) (*google.Credentials, error) {
+		return nil, fmt.Errorf("unsupported credential source")
+	}
+	_, err = newGCSManager(testProjectID, withWorkloadIdentityFederation(ctx, []byte(testWIFConfig)))
+	assert.ErrorContains(t, err, "unsupported credential source")
+
+	_, err = configureGCSManager(ctx, &sourcespb.GCS{
+		ProjectId:  testProjectID,
+		Credential: &sourcespb.GCS_WorkloadIdentityFederationFile{WorkloadIdentityFederationFile: filepath.Join(t.TempDir(), "missing.json")},
+	}, 8)
+	assert.Error(t, err)
+}
+
+func TestPrivateAddr(t *testing.T) {
+	for addr, want := range map[string]string{
+		"storage.googleapis.com:443": "restricted.googleapis.com:443",
+		"oauth2.googleapis.com:443":  "restricted.googleapis.com:443",
+		"storage.googleapis.com:80":  "restricted.googleapis.com:80",
+		"proxy.test:3128":            "proxy.test:3128",
+		"googleapis.com.test:443":    "googleapis.com.test:443",
+		"127.0.0.1:8080":             "127.0.0.1:8080",
+		"storage.googleapis.com":     "storage.googleapis.com",
+	} {
+		assert.Equal(t, want, privateAddr(addr), addr)
+	}
+}
+
+const vpcServiceControlsDenial = `{"error": {"code": 403,
+	"message": "Request is prohibited by organization's policy. vpcServiceControlsUniqueIdentifier: Ab1cD2eF3_gH",
+	"errors": [{"message": "Request is prohibited by organization's policy. vpcServiceControlsUniqueIdentifier: Ab1cD2eF3_gH",
+		"domain": "global", "reason": "vpcServiceControls"}]}}`
+
+func TestGCSManager_VPCServiceControlsDenial(t *testing.T) {
+	ctx := context.Background()
+
+	// The perimeter denies listing the objects of the bucket.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(vpcServiceControlsDenial))
+	}))
+	defer server.Close()
+
+	gm, err := newGCSManager(testProjectID,
+		withoutAuthentication(),
+		withIncludeBuckets([]string{"alpha"}),
+	)
+	require.NoError(t, err)
+	gm.client = fakeClient(t, server)
+
+	objCh, err := gm.ListObjects(ctx)
+	require.NoError(t, err)
+	for range objCh {
+	}
+
+	err = gm.BucketErrors()
+	assert.ErrorContains(t, err, "request denied by a VPC Service Controls perimeter (violation Ab1cD2eF3_gH)")
+	assert.ErrorIs(t, err, ErrPermission)
+	var apiErr *googleapi.Error
+	assert.ErrorAs(t, err, &apiErr)
+}
+
+func TestVPCServiceControlsError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantDenied string
+	}{
+		{
+			name: "denied by reason",
+			err: &googleapi.Error{
+				Code:    http.StatusForbidden,
+				Message: "Request is prohibited by organization's policy.",
+				Errors:  []googleapi.ErrorItem{{Reason: "vpcServiceControls"}},
+			},
+			wantDenied: "violation unknown",
+		},
+		{
+			name: "denied object read",
+			err: &googleapi.Error{
+				Code: http.StatusForbidden,
+				Body: "<Error><Code>SecurityPolicyViolated</Code><Details>Request is prohibited by organization's policy. vpcServiceControlsUniqueIdentifier: xYz-123</Details></Error>",
+			},
+			wantDenied: "violation xYz-123",
+		},
+		{
+			name: "missing permission",
+			err: &googleapi.Error{
+				Code:    http.StatusForbidden,
+				Message: "caller does not have storage.objects.list access",
+			},
+		},
+		{
+			name: "other status",
+			err: &googleapi.Error{
+				Code:    http.StatusNotFound,
+				Message: "vpcServiceControlsUniqueIdentifier: Ab1cD2eF3_gH",
+			},
+		},
+		{
+			name: "not an API error",
+			err:  fmt.Errorf("connection refused"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := vpcServiceControlsError(test.err)
+			if test.wantDenied == "" {
+				assert.Equal(t, test.err, err)
+				return
+			}
+			assert.ErrorContains(t, err, "VPC Service Controls perimeter ("+test.wantDenied+")")
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestGCSManager_ContentTypeFilters(t *testing.T) {
+	ctx := context.Background()
+
+	contentTypes := map[string]string{
+		"notes.txt":    "text/plain",
+		"index.html":   "text/html; charset=utf-8",
+		"config.json":  "Application/JSON",
+		"photo":        "image/jpeg",
+		"archive":      "application/octet-stream",
+		"unknown":      "",
+		"invalid.type": "not a content type",
+	}
+	// The content of an object is its name.
+	objects := make(map[string]fakeObject, len(contentTypes))
+	for name, contentType := range contentTypes {
+		objects[name] = fakeObject{versions: []string{name}, attrs: map[string]any{"contentType": contentType}}
+	}
+	server := fakeGCSServer(t, &fakeGCS{buckets: map[string]fakeBucket{testBucket: {objects: objects}}})
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		opts []gcsManagerOption
+		want []string
+	}{
+		{
+			name: "no filters",
+			want: []string{"archive", "config.json", "index.html", "invalid.type", "notes.txt", "photo", "unknown"},
+		},
+		{
+			name: "include",
+			opts: []gcsManagerOption{withIncludeContentTypes([]string{"text/*", "application/json"})},
+			want: []string{"config.json", "index.html", "invalid.type", "notes.txt", "unknown"},
+		},
+		{
+			name: "include without unknown",
+			opts: []gcsManagerOption{
+				withIncludeContentTypes([]string{"text/*", "application/json"}),
+				withExcludeUnknownContentTypes(true),
+			},
+			want: []string{"config.json", "index.html", "notes.txt"},
+		},
+		{
+			name: "exclude",
+			opts: []gcsManagerOption{withExcludeContentTypes([]string{"image/*", "application/octet-stream"})},
+			want: []string{"config.json", "index.html", "invalid.type", "notes.txt", "unknown"},
+		},
+		{
+			name: "exclude takes precedence",
+			opts: []gcsManagerOption{
+				withIncludeContentTypes([]string{"text/*"}),
+				withExcludeContentTypes([]string{"text/html"}),
+				withExcludeUnknownContentTypes(true),
+			},
+			want: []string{"notes.txt"},
+		},
+		{
+			name: "unknown excluded without filters",
+			opts: []gcsManagerOption{withExcludeUnknownContentTypes(true)},
+			want: []string{"archive", "config.json", "index.html", "invalid.type", "notes.txt", "photo", "unknown"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append([]gcsManagerOption{withoutAuthentication(), withIncludeBuckets([]string{testBucket})}, tc.opts...)
+			gm, err := newGCSManager(testProjectID, opts...)
+			require.NoError(t, err)
+			gm.client = fakeClient(t, server)
+
+			stats, err := gm.Attributes(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(len(tc.want)), stats.numObjects)
+
+			objCh, err := gm.ListObjects(ctx)
+			require.NoError(t, err)
+			var got []string
+			for obj := range objCh {
+				got = append(got, obj.(object).name)
+			}
+			sort.Strings(got)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGCSManager_SkipArchivedObjects(t *testing.T) {
+	ctx := context.Background()
+
+	storageClasses := map[string]string{
+		"standard.txt": "STANDARD",
+		"nearline.txt": "NEARLINE",
+		"coldline.txt": "COLDLINE",
+		"archive.txt":  "ARCHIVE",
+		"unknown.txt":  "",
+	}
+	objects := make(map[string]fakeObject, len(storageClasses))
+	for name, storageClass := range storageClasses {
+		obj := fakeObject{versions: []string{name}}
+		if storageClass != "" {
+			obj.attrs = map[string]any{"storageClass": storageClass}
+		}
+		objects[name] = obj
+	}
+	server := fakeGCSServer(t, &fakeGCS{buckets: map[string]fakeBucket{testBucket: {objects: objects}}})
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		skip bool
+		want []string
+	}{
+		{
+			name: "scan archived",
+			want: []string{"archive.txt", "coldline.txt", "nearline.txt", "standard.txt", "unknown.txt"},
+		},
+		{
+			name: "skip archived",
+			skip: true,
+			want: []string{"nearline.txt", "standard.txt", "unknown.txt"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gm, err := newGCSManager(testProjectID,
+				withoutAuthentication(),
+				withIncludeBuckets([]string{testBucket}),
+				withSkipArchivedObjects(tc.skip),
+			)
+			require.NoError(t, err)
+			gm.client = fakeClient(t, server)
+
+			stats, err := gm.Attributes(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(len(tc.want)), stats.numObjects)
+
+			objCh, err := gm.ListObjects(ctx)
+			require.NoError(t, err)
+			var got []string
+			for obj := range objCh {
+				got = append(got, obj.(object).name)
+			}
+			sort.Strings(got)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGCSManager_MaxObjectSize(t *testing.T) {
+	ctx := context.Background()
+
+	const maxObjectSize = 32
+	contents := map[string]string{
+		"small.txt": "token=small",
+		"large.txt": "token=large " + strings.Repeat("padding ", 10),
+	}
+
+	var mu sync.Mutex
+	ranges := make(map[string]string)
+	server := fakeGCSServer(t, &fakeGCS{
+		buckets: map[string]fakeBucket{testBucket: {objects: fakeObjects(map[string][]string{
+			"small.txt": {contents["small.txt"]},
+			"large.txt": {contents["large.txt"]},
+		})}},
+		onRequest: func(r *http.Request) {
+			name, ok := strings.CutPrefix(r.URL.Path, "/"+testBucket+"/")
+			if !ok {
+				return
+			}
+			mu.Lock()
+			ranges[name] = r.Header.Get("Range")
+			mu.Unlock()
+		},
+	})
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		truncate bool
+		want     map[string]string
+	}{
+		{
+			name: "skip large objects",
+			want: map[string]string{"small.txt": "token=small"},
+		},
+		{
+			name:     "truncate large objects",
+			truncate: true,
+			want: map[string]string{
+				"small.txt": "token=small",
+				"large.txt": contents["large.txt"][:maxObjectSize],
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gm, err := newGCSManager(testProjectID,
+				withoutAuthentication(),
+				withIncludeBuckets([]string{testBucket}),
+				withMaxObjectSize(maxObjectSize),
+				withTruncateLargeObjects(tc.truncate),
+			)
+			require.NoError(t, err)
+			gm.client = fakeClient(t, server)
+
+			objCh, err := gm.ListObjects(ctx)
+			require.NoError(t, err)
+			got := make(map[string]string)
+			for obj := range objCh {
+				o := obj.(object)
+				content, err := io.ReadAll(o)
+				require.NoError(t, err)
+				got[o.name] = string(content)
+				assert.Equal(t, o.name == "large.txt", o.truncated)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	// Only the first bytes of the large object were downloaded.
+	assert.Equal(t, fmt.Sprintf("bytes=0-%d", maxObjectSize-1), ranges["large.txt"])
+	assert.Empty(t, ranges["small.txt"])
+}
+
+func TestGCSManager_ObjectSizeRange(t *testing.T) {
+	ctx := context.Background()
+
+	const minObjectSize, maxObjectSize = 10, 100
+	sizes := map[string]int{
+		"below-min": minObjectSize - 1,
+		"at-min":    minObjectSize,
+		"within":    50,
+		"at-max":    maxObjectSize,
+		"above-max": maxObjectSize + 1,
+	}
+	objects := make(map[string][]string, len(sizes))
+	for name, size := range sizes {
+		objects[name] = []string{strings.Repeat("x", size)}
+	}
+	server := fakeGCSServer(t, &fakeGCS{buckets: fakeBuckets(map[string]map[string][]string{testBucket: objects})})
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		opts []gcsManagerOption
+		want []string
+	}{
+		{
+			name: "no minimum",
+			opts: []gcsManagerOption{withMaxObjectSize(maxObjectSize)},
+			want: []string{"at-max", "at-min", "below-min", "within"},
+		},
+		{
+			name: "minimum and maximum",
+			opts: []gcsManagerOption{withMinObjectSize(minObjectSize), withMaxObjectSize(maxObjectSize)},
+			want: []string{"at-max", "at-min", "within"},
+		},
+		{
+			name: "minimum with truncated large objects",
+			opts: []gcsManagerOption{withMinObjectSize(minObjectSize), withMaxObjectSize(maxObjectSize), withTruncateLargeObjects(true)},
+			want: []string{"above-max", "at-max", "at-min", "within"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append([]gcsManagerOption{withoutAuthentication(), withIncludeBuckets([]string{testBucket})}, tc.opts...)
+			gm, err := newGCSManager(testProjectID, opts...)
+			require.NoError(t, err)
+			gm.client = fakeClient(t, server)
+
+			// The objects out of range are neither counted nor read.
+			var wantBytes uint64
+			for _, name := range tc.want {
+				wantBytes += uint64(sizes[name])
+			}
+			stats, err := gm.Attributes(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(len(tc.want)), stats.numObjects)
+			assert.Equal(t, wantBytes, stats.numBytes)
+
+			objCh, err := gm.ListObjects(ctx)
+			require.NoError(t, err)
+			var got []string
+			for obj := range objCh {
+				got = append(got, obj.(object).name)
+			}
+			sort.Strings(got)
 			assert.Equal(t, tc.want, got)
 		})
 	}
 }
+
+func TestWithMinObjectSize_Invalid(t *testing.T) {
+	_, err := newGCSManager(testProjectID, withoutAuthentication(), withMinObjectSize(-1))
+	assert.ErrorContains(t, err, "minimum object size must not be negative")
+
+	_, err = newGCSManager(testProjectID, withoutAuthentication(), withMinObjectSize(200), withMaxObjectSize(100))
+	assert.ErrorContains(t, err, "minimum object size 200 is larger than the maximum object size 100")
+
+	// Larger objects are still scanned if they are truncated.
+	_, err = newGCSManager(testProjectID, withoutAuthentication(), withMinObjectSize(200), withMaxObjectSize(100), withTruncateLargeObjects(true))
+	assert.NoError(t, err)
+}
+
+func TestGCSManager_SoftDeleted(t *testing.T) {
+	ctx := context.Background()
+
+	// The content of an object is its name. The live objects have generation
+	// 1, and the soft-deleted ones generation 2.
+	objects := map[string]fakeObject{
+		"current.txt":     {versions: []string{"current.txt"}},
+		"overwritten.txt": {versions: []string{"overwritten.txt"}, softDeleted: []string{"overwritten.txt"}},
+		"deleted.txt":     {softDeleted: []string{"deleted.txt"}, generation: 2},
+	}
+
+	tests := []struct {
+		name        string
+		softDeleted bool
+		readable    bool
+		// want are the objects listed, as name@generation: content, with a
+		// "soft-deleted" suffix for soft-deleted objects.
+		want []string
+	}{
+		{
+			name: "live only",
+			want: []string{"current.txt@1: current.txt", "overwritten.txt@1: overwritten.txt"},
+		},
+		{
+			name:        "soft-deleted",
+			softDeleted: true,
+			readable:    true,
+			want: []string{
+				"current.txt@1: current.txt",
+				"deleted.txt@2: deleted.txt soft-deleted",
+				"overwritten.txt@1: overwritten.txt",
+				"overwritten.txt@2: overwritten.txt soft-deleted",
+			},
+		},
+		{
+			name:        "soft-deleted without content",
+			softDeleted: true,
+			want: []string{
+				"current.txt@1: current.txt",
+				"deleted.txt@2:  soft-deleted",
+				"overwritten.txt@1: overwritten.txt",
+				"overwritten.txt@2:  soft-deleted",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := fakeGCSServer(t, &fakeGCS{buckets: map[string]fakeBucket{
+				testBucket: {objects: objects, softDeletedReadable: tc.readable},
+			}})
+			defer server.Close()
+
+			gm, err := newGCSManager(testProjectID,
+				withoutAuthentication(),
+				withIncludeBuckets([]string{testBucket}),
+				withSoftDeleted(tc.softDeleted),
+			)
+			require.NoError(t, err)
+			gm.client = fakeClient(t, server)
+
+			stats, err := gm.Attributes(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(len(tc.want)), stats.numObjects)
+
+			objCh, err := gm.ListObjects(ctx)
+			require.NoError(t, err)
+			var got []string
+			for obj := range objCh {
+				o := obj.(object)
+				content, err := io.ReadAll(o)
+				require.NoError(t, err)
+				desc := fmt.Sprintf("%s@%d: %s", o.name, o.generation, content)
+				if o.softDeleted {
+					desc += " soft-deleted"
+				}
+				got = append(got, desc)
+			}
+			sort.Strings(got)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestWithContentTypes_Invalid(t *testing.T) {
+	for _, contentType := range []string{"text", "/plain", "*/*", "text/", "text/plain; charset", ""} {
+		_, err := newGCSManager(testProjectID, withoutAuthentication(), withIncludeContentTypes([]string{contentType}))
+		assert.Error(t, err, contentType)
+		_, err = newGCSManager(testProjectID, withoutAuthentication(), withExcludeContentTypes([]string{contentType}))
+		assert.Error(t, err, contentType)
+	}
+
+	gm, err := newGCSManager(testProjectID, withoutAuthentication(), withIncludeContentTypes([]string{"Text/Plain; charset=utf-8", "image/*"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"text/plain", "image/*"}, gm.includeContentTypes)
+}
+
+func TestWithProxy_Invalid(t *testing.T) {
+	for _, proxyURL := range []string{"ftp://proxy.test:21", "://proxy", "http://"} {
+		_, err := newGCSManager(testProjectID, withoutAuthentication(), withProxy(proxyURL))
+		assert.Error(t, err, proxyURL)
+	}
+}
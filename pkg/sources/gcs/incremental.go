@@ -0,0 +1,194 @@
+package gcs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// incrementalResumeInfo is the resume info of incremental scans. Unlike the
+// objects processed by an interrupted scan, the ETags and the high-water mark
+// are kept once a scan completes, so the next scan can skip the objects that
+// didn't change.
+type incrementalResumeInfo struct {
+	// Processed is the contents of the cache of the objects processed by the
+	// ongoing scan, see persistableCache.
+	Processed string `json:"processed,omitempty"`
+	// ETags are the ETags of the scanned objects, by objectKey.
+	ETags map[string]string `json:"etags,omitempty"`
+	// HighWaterMark is the latest update time of the objects of the last
+	// completed scan, if it only scanned the objects updated since the one
+	// before.
+	HighWaterMark *time.Time `json:"high_water_mark,omitempty"`
+}
+
+// decodeIncrementalResumeInfo decodes the resume info of an incremental scan.
+// The resume info of scans that weren't incremental only has the processed
+// objects.
+func decodeIncrementalResumeInfo(encoded string) (incrementalResumeInfo, error) {
+	if !strings.HasPrefix(encoded, "{") {
+		return incrementalResumeInfo{Processed: encoded}, nil
+	}
+
+	var info incrementalResumeInfo
+	if err := json.Unmarshal([]byte(encoded), &info); err != nil {
+		return incrementalResumeInfo{}, fmt.Errorf("error decoding resume info: %w", err)
+	}
+	return info, nil
+}
+
+func (info incrementalResumeInfo) encode() string {
+	// Maps of strings can always be encoded.
+	encoded, _ := json.Marshal(info)
+	return string(encoded)
+}
+
+// objectKey identifies an object across scans. Soft-deleted objects share
+// their name with the live object, if any, so they are told apart by
+// generation.
+func objectKey(o object) string {
+	if o.softDeleted {
+		return o.bucket + "/" + o.name + "#" + strconv.FormatInt(o.generation, 10)
+	}
+	return o.bucket + "/" + o.name
+}
+
+// objectETag returns the ETag of the object, or its generation if it has
+// none. It is empty if the object can't be told apart from its other
+// versions.
+func objectETag(o object) string {
+	if o.etag != "" {
+		return o.etag
+	}
+	if o.generation != 0 {
+		return strconv.FormatInt(o.generation, 10)
+	}
+	return ""
+}
+
+// setupIncremental loads the ETags of the objects scanned by the previous
+// scans and the high-water mark of the last completed scan from the resume
+// info, and returns the objects processed by the interrupted scan, if any.
+func (s *Source) setupIncremental(ctx context.Context) string {
+	info, err := decodeIncrementalResumeInfo(s.Progress.EncodedResumeInfo)
+	if err != nil {
+		ctx.Logger().Error(err, "ignoring resume info, all objects will be scanned")
+	}
+
+	s.etags = info.ETags
+	if s.etags == nil {
+		s.etags = make(map[string]string)
+	}
+	s.scannedETags = make(map[string]string)
+	ctx.Logger().V(3).Info("loaded object ETags", "num_objects", len(s.etags))
+
+	s.lastHighWaterMark, s.highWaterMark, s.objectFailed = time.Time{}, time.Time{}, false
+	if info.HighWaterMark != nil {
+		s.lastHighWaterMark, s.highWaterMark = *info.HighWaterMark, *info.HighWaterMark
+	}
+	return info.Processed
+}
+
+// lastScanCutoff returns the time after which objects must have been updated
+// to be scanned: the high-water mark of the last completed scan, minus the
+// overlap allowing for objects updated while it was listing. It is zero if
+// all the objects must be scanned.
+func (s *Source) lastScanCutoff(ctx context.Context) time.Time {
+	if !s.updatedSinceLastScan {
+		return time.Time{}
+	}
+	info, err := decodeIncrementalResumeInfo(s.Progress.EncodedResumeInfo)
+	if err != nil || info.HighWaterMark == nil {
+		return time.Time{}
+	}
+	cutoff := info.HighWaterMark.Add(-s.lastScanOverlap)
+	ctx.Logger().V(2).Info("only scanning objects updated since the last scan", "high_water_mark", *info.HighWaterMark, "cutoff", cutoff)
+	return cutoff
+}
+
+// unchanged returns whether the object is unchanged since it was last
+// scanned, in which case it counts as scanned by this scan.
+func (s *Source) unchanged(o object) bool {
+	etag := objectETag(o)
+	if etag == "" {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.etags[objectKey(o)] != etag {
+		return false
+	}
+	s.scannedETags[objectKey(o)] = etag
+	return true
+}
+
+// recordETag records the ETag of an object scanned by this scan.
+func (s *Source) recordETag(o object) {
+	etag := objectETag(o)
+	if etag == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.etags[objectKey(o)] = etag
+	s.scannedETags[objectKey(o)] = etag
+}
+
+// recordUpdated raises the high-water mark to the update time of an object
+// scanned by this scan.
+func (s *Source) recordUpdated(o object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if o.updatedAt.After(s.highWaterMark) {
+		s.highWaterMark = o.updatedAt
+	}
+}
+
+// recordScanned records an object scanned by this scan, or skipped because
+// it already was.
+func (s *Source) recordScanned(o object) {
+	if s.incremental {
+		s.recordETag(o)
+	}
+	if s.updatedSinceLastScan {
+		s.recordUpdated(o)
+	}
+}
+
+// encodeIncrementalResumeInfo encodes the processed objects, the ETags of the
+// objects scanned by this scan and the previous ones, and the high-water mark
+// of the last completed scan as the resume info. The high-water mark of this
+// scan is only kept once it completes, so an interrupted scan doesn't skip
+// the objects it didn't get to. s.mu must be held.
+func (s *Source) encodeIncrementalResumeInfo(processed string) string {
+	info := incrementalResumeInfo{Processed: processed, ETags: s.etags}
+	if !s.lastHighWaterMark.IsZero() {
+		info.HighWaterMark = &s.lastHighWaterMark
+	}
+	return info.encode()
+}
+
+// completeIncrementalScan only keeps the ETags of the objects of this scan in
+// the resume info, so the objects that were deleted are forgotten, and the
+// next scan only skips the objects that didn't change since. The high-water
+// mark is only raised if all the objects were scanned, so the ones that
+// failed are scanned again by the next scan.
+func (s *Source) completeIncrementalScan() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	info := incrementalResumeInfo{ETags: s.scannedETags}
+	mark := s.highWaterMark
+	if s.objectFailed {
+		mark = s.lastHighWaterMark
+	}
+	if s.updatedSinceLastScan && !mark.IsZero() {
+		info.HighWaterMark = &mark
+	}
+	s.Progress.EncodedResumeInfo = info.encode()
+}
@@ -0,0 +1,364 @@
+package gcs
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// inventoryManifestSuffix ends the name of the manifests Storage Insights
+// writes along with the shards of an inventory report, e.g.
+// config-id_2024-01-01T00:00:00_manifest.json.
+const inventoryManifestSuffix = "manifest.json"
+
+// inventoryObject is an object listed by an inventory report.
+type inventoryObject struct {
+	bucket string
+	name   string
+	// size is 0 if the report doesn't include the size of the objects.
+	size int64
+}
+
+// inventoryManifest is the manifest of an inventory report, which lists the
+// shards of the report. They are in the same folder as the manifest.
+type inventoryManifest struct {
+	ShardFileNames []string `json:"report_shards_file_names"`
+}
+
+// inventoryRecord is an object of an inventory report in JSON. The size may
+// be a number or a string.
+type inventoryRecord struct {
+	Bucket string      `json:"bucket"`
+	Name   string      `json:"name"`
+	Size   json.Number `json:"size"`
+}
+
+// withInventoryManifest scans the objects listed by the inventory report at
+// uri, e.g. gs://bucket/reports/inventory.csv, instead of listing the buckets
+// and their objects. If the object is the manifest of a report, the objects
+// listed by all of its shards are scanned. See parseInventoryReport for the
+// formats of the report.
+func withInventoryManifest(uri string) gcsManagerOption {
+	return func(g *gcsManager) error {
+		if uri == "" {
+			return nil
+		}
+		bkt, name, ok := strings.Cut(strings.TrimPrefix(uri, "gs://"), "/")
+		if !strings.HasPrefix(uri, "gs://") || !ok || bkt == "" || name == "" {
+			return fmt.Errorf("invalid inventory manifest %q, expected gs://bucket/object", uri)
+		}
+		g.inventory = &inventoryObject{bucket: bkt, name: name}
+		return nil
+	}
+}
+
+// inventoryObjects returns the objects of the inventory report to scan,
+// skipping those excluded by the bucket, object, prefix and size filters. The
+// report is only read once.
+func (g *gcsManager) inventoryObjects(ctx context.Context) ([]inventoryObject, error) {
+	if g.inventoryListed == nil {
+		listed, err := g.readInventory(ctx)
+		if err != nil {
+			return nil, err
+		}
+		g.inventoryListed = listed
+	}
+
+	var objs []inventoryObject
+	for _, obj := range g.inventoryListed {
+		if !g.shouldIncludeBucket(ctx, obj.bucket) || g.shouldExcludeBucket(ctx, obj.bucket) {
+			continue
+		}
+		if !g.shouldIncludeObject(ctx, obj.name) || g.shouldExcludeObject(ctx, obj.name) || !g.hasPrefix(obj.name) {
+			continue
+		}
+		// Reports without the size of the objects list them with size 0,
+		// so those are filtered once they are read.
+		if obj.size > 0 && !g.isObjectSizeInRange(obj.size) {
+			continue
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+// hasPrefix returns true if the name starts with one of the prefixes, or if
+// there are none.
+func (g *gcsManager) hasPrefix(name string) bool {
+	if len(g.prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range g.prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// readInventory reads the objects listed by the inventory report, or by the
+// shards of its manifest.
+func (g *gcsManager) readInventory(ctx context.Context) ([]inventoryObject, error) {
+	reports := []inventoryObject{*g.inventory}
+	if strings.HasSuffix(g.inventory.name, inventoryManifestSuffix) {
+		var manifest inventoryManifest
+		if err := g.readInventoryObject(ctx, *g.inventory, func(r io.Reader) error {
+			return json.NewDecoder(r).Decode(&manifest)
+		}); err != nil {
+			return nil, err
+		}
+
+		dir := path.Dir(g.inventory.name)
+		reports = reports[:0]
+		for _, shard := range manifest.ShardFileNames {
+			if dir != "." {
+				shard = path.Join(dir, shard)
+			}
+			reports = append(reports, inventoryObject{bucket: g.inventory.bucket, name: shard})
+		}
+	}
+
+	objs := make([]inventoryObject, 0)
+	for _, report := range reports {
+		reportObjs, err := g.readInventoryReport(ctx, report)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, reportObjs...)
+	}
+	ctx.Logger().V(2).Info("read inventory report", "reports", len(reports), "objects", len(objs))
+	return objs, nil
+}
+
+// readInventoryReport reads the objects listed by a single inventory report.
+func (g *gcsManager) readInventoryReport(ctx context.Context, report inventoryObject) ([]inventoryObject, error) {
+	var objs []inventoryObject
+	err := g.readInventoryObject(ctx, report, func(r io.Reader) error {
+		var err error
+		objs, err = parseInventoryReport(r)
+		return err
+	})
+	return objs, err
+}
+
+// readInventoryObject reads the content of an object of an inventory report
+// with parse.
+func (g *gcsManager) readInventoryObject(ctx context.Context, obj inventoryObject, parse func(io.Reader) error) error {
+	r, err := g.client.Bucket(obj.bucket).Object(obj.name).NewReader(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read inventory report gs://%s/%s: %w", obj.bucket, obj.name, classifyError(vpcServiceControlsError(err), ErrObjectNotFound))
+	}
+	defer r.Close()
+
+	if err := parse(r); err != nil {
+		return fmt.Errorf("failed to parse inventory report gs://%s/%s: %w", obj.bucket, obj.name, err)
+	}
+	return nil
+}
+
+// parseInventoryReport parses the objects listed by an inventory report,
+// either in CSV with a header row, as written by Storage Insights, or in JSON,
+// as an array or a stream of objects. The bucket and name fields of the
+// objects are required, and their size is optional. Other fields are ignored.
+func parseInventoryReport(r io.Reader) ([]inventoryObject, error) {
+	br := bufio.NewReader(r)
+	for {
+		c, _, err := br.ReadRune()
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if unicode.IsSpace(c) || c == '\ufeff' {
+			continue
+		}
+		if err := br.UnreadRune(); err != nil {
+			return nil, err
+		}
+		if c == '{' || c == '[' {
+			return parseJSONInventory(br, c == '[')
+		}
+		return parseCSVInventory(br)
+	}
+}
+
+// parseJSONInventory parses an inventory report in JSON.
+func parseJSONInventory(r io.Reader, isArray bool) ([]inventoryObject, error) {
+	dec := json.NewDecoder(r)
+	if isArray {
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	}
+
+	var objs []inventoryObject
+	for dec.More() {
+		var rec inventoryRecord
+		if err := dec.Decode(&rec); err != nil {
+			return nil, fmt.Errorf("record %d: %w", len(objs)+1, err)
+		}
+		obj, err := newInventoryObject(rec.Bucket, rec.Name, rec.Size.String())
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", len(objs)+1, err)
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+// parseCSVInventory parses an inventory report in CSV. The columns are
+// identified by the header row.
+func parseCSVInventory(r io.Reader) ([]inventoryObject, error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	bucketCol, nameCol, sizeCol := -1, -1, -1
+	for i, column := range header {
+		switch strings.ToLower(strings.TrimSpace(column)) {
+		case "bucket":
+			bucketCol = i
+		case "name":
+			nameCol = i
+		case "size":
+			sizeCol = i
+		}
+	}
+	if bucketCol < 0 || nameCol < 0 {
+		return nil, fmt.Errorf("the header must have bucket and name columns")
+	}
+
+	var objs []inventoryObject
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var size string
+		if sizeCol >= 0 {
+			size = row[sizeCol]
+		}
+		obj, err := newInventoryObject(row[bucketCol], row[nameCol], size)
+		if err != nil {
+			line, _ := cr.FieldPos(0)
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		objs = append(objs, obj)
+	}
+}
+
+// newInventoryObject returns the object of a record of an inventory report.
+// The size may be empty.
+func newInventoryObject(bkt, name, size string) (inventoryObject, error) {
+	if bkt == "" || name == "" {
+		return inventoryObject{}, fmt.Errorf("bucket and name are required")
+	}
+	obj := inventoryObject{bucket: bkt, name: name}
+	if size != "" {
+		var err error
+		if obj.size, err = strconv.ParseInt(size, 10, 64); err != nil {
+			return inventoryObject{}, fmt.Errorf("invalid size %q", size)
+		}
+	}
+	return obj, nil
+}
+
+// inventoryAttributes counts the objects of the inventory report to scan,
+// by bucket, without listing the buckets.
+func (g *gcsManager) inventoryAttributes(ctx context.Context) (*attributes, error) {
+	objs, err := g.inventoryObjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bucketObjects := make(map[string]uint64)
+	var numBytes uint64
+	for _, obj := range objs {
+		bucketObjects[obj.bucket]++
+		numBytes += uint64(max(obj.size, 0))
+	}
+	stats := newStats(len(bucketObjects))
+	stats.numObjects = uint64(len(objs))
+	stats.numBytes = numBytes
+	stats.bucketObjects = bucketObjects
+	g.attr = stats
+	return stats, nil
+}
+
+// listInventoryObjects reads the objects of the inventory report to scan
+// concurrently, and sends them to the returned channel. The objects are
+// filtered by their attributes like listed objects are, and those that no
+// longer exist are sent as deleted.
+func (g *gcsManager) listInventoryObjects(ctx context.Context) (chan io.Reader, error) {
+	objs, err := g.inventoryObjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan io.Reader, 100)
+	g.publicBuckets = make(map[string][]string)
+	go func() {
+		defer close(ch)
+		bkts := make(map[string]*bucket)
+		for _, obj := range objs {
+			bkt, ok := bkts[obj.bucket]
+			if !ok {
+				bkt = &bucket{name: obj.bucket}
+				g.setupBktHandle(bkt)
+				bkts[obj.bucket] = bkt
+			}
+			obj := obj
+			g.workerPool.Go(func() error {
+				g.sendInventoryObject(ctx, bkt, obj, ch)
+				return nil
+			})
+		}
+		_ = g.workerPool.Wait()
+		g.bucketErrs = nil
+	}()
+	return ch, nil
+}
+
+// sendInventoryObject reads an object of the inventory report and sends it
+// to ch, unless it's filtered out or can't be read.
+func (g *gcsManager) sendInventoryObject(ctx context.Context, bkt *bucket, obj inventoryObject, ch chan<- io.Reader) {
+	handle := bkt.Object(obj.name)
+	attrs, err := handle.Attrs(ctx)
+	if err == nil && (!isObjectTypeValid(ctx, attrs.Name) || !g.isObjectSizeValid(ctx, attrs) || !g.isUpdatedAfter(attrs) ||
+		!g.matchesContentType(attrs.ContentType) || g.isSkippedArchive(attrs) || !g.isSampled(bkt.name, attrs)) {
+		return
+	}
+
+	var o object
+	if err == nil {
+		o, err = g.readObject(ctx, handle, attrs)
+	}
+	if err != nil {
+		err = classifyError(vpcServiceControlsError(err), ErrObjectNotFound)
+	}
+	if errors.Is(err, ErrObjectNotFound) {
+		ctx.Logger().V(3).Info("object listed by the inventory report no longer exists", "bucket", obj.bucket, "object-name", obj.name)
+		ch <- object{name: obj.name, bucket: obj.bucket, size: obj.size, deleted: true, Reader: strings.NewReader("")}
+		return
+	}
+	if err != nil {
+		ctx.Logger().V(1).Info("failed to read object listed by the inventory report", "bucket", obj.bucket, "object-name", obj.name, "error", err)
+		return
+	}
+	ch <- o
+}
@@ -0,0 +1,173 @@
+package gcs
+
+import (
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+const (
+	testInventoryBucket = "inventory-reports"
+	testInventoryCSV    = `project,bucket,name,size,updated
+1234,data-bkt,config/.env,9,2024-01-01T00:00:00Z
+1234,data-bkt,"logs/app,1.log",13,2024-01-01T00:00:00Z
+1234,other-bkt,notes.txt,10,2024-01-01T00:00:00Z
+`
+	testInventoryJSON = `{"bucket": "data-bkt", "name": "keys/id_rsa", "size": "11"}
+{"bucket": "data-bkt", "name": "deleted.txt", "size": 7}
+`
+	testInventoryManifest = `{
+  "report_config": {"name": "projects/1234/locations/us/reportConfigs/cfg"},
+  "records_processed": 5,
+  "snapshot_time": "2024-01-01T00:00:00Z",
+  "shard_count": 2,
+  "report_shards_file_names": ["cfg_2024-01-01T00:00:00_0.csv", "cfg_2024-01-01T00:00:00_1.json"]
+}`
+)
+
+func TestGCSManager_InventoryManifest(t *testing.T) {
+	ctx := context.Background()
+
+	buckets := map[string]map[string][]string{
+		testInventoryBucket: {
+			"daily/cfg_2024-01-01T00:00:00_manifest.json": {testInventoryManifest},
+			"daily/cfg_2024-01-01T00:00:00_0.csv":         {testInventoryCSV},
+			"daily/cfg_2024-01-01T00:00:00_1.json":        {testInventoryJSON},
+		},
+		"data-bkt": {
+			"config/.env":    {"token=abc"},
+			"logs/app,1.log": {"password=1234"},
+			"keys/id_rsa":    {"private key"},
+			"unlisted.txt":   {"not in the inventory"},
+		},
+		"other-bkt": {
+			"notes.txt": {"some notes"},
+		},
+	}
+	// Listing buckets or objects is forbidden.
+	var listed atomic.Int32
+	server := fakeGCSServer(t, &fakeGCS{buckets: fakeBuckets(buckets), unlistable: true, listings: &listed})
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		report    string
+		opts      []gcsManagerOption
+		want      map[string]string
+		wantBytes uint64
+	}{
+		{
+			name:   "manifest",
+			report: "gs://" + testInventoryBucket + "/daily/cfg_2024-01-01T00:00:00_manifest.json",
+			want: map[string]string{
+				"data-bkt/config/.env":    "token=abc",
+				"data-bkt/logs/app,1.log": "password=1234",
+				"data-bkt/keys/id_rsa":    "private key",
+				"other-bkt/notes.txt":     "some notes",
+				// Deleted since the report was written.
+				"data-bkt/deleted.txt": "",
+			},
+			wantBytes: 9 + 13 + 10 + 11 + 7,
+		},
+		{
+			name:   "report",
+			report: "gs://" + testInventoryBucket + "/daily/cfg_2024-01-01T00:00:00_0.csv",
+			opts:   []gcsManagerOption{withIncludeBuckets([]string{"data-bkt"}), withPrefixes([]string{"config/"})},
+			want: map[string]string{
+				"data-bkt/config/.env": "token=abc",
+			},
+			wantBytes: 9,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append([]gcsManagerOption{withoutAuthentication(), withInventoryManifest(tc.report)}, tc.opts...)
+			gm, err := newGCSManager(testProjectID, opts...)
+			require.NoError(t, err)
+			gm.client = fakeClient(t, server)
+			require.NoError(t, gm.validateCredentials(ctx))
+
+			stats, err := gm.Attributes(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(len(tc.want)), stats.numObjects)
+			assert.Equal(t, tc.wantBytes, stats.numBytes)
+
+			objCh, err := gm.ListObjects(ctx)
+			require.NoError(t, err)
+			got := make(map[string]string)
+			for obj := range objCh {
+				o := obj.(object)
+				content, err := io.ReadAll(o)
+				require.NoError(t, err)
+				got[o.bucket+"/"+o.name] = string(content)
+				assert.Equal(t, o.name == "deleted.txt", o.deleted)
+			}
+			assert.Equal(t, tc.want, got)
+			assert.NoError(t, gm.BucketErrors())
+		})
+	}
+
+	// The objects were only read, never listed.
+	assert.Zero(t, listed.Load())
+}
+
+func TestParseInventoryReport(t *testing.T) {
+	csvObjs := []inventoryObject{
+		{bucket: "data-bkt", name: "config/.env", size: 9},
+		{bucket: "data-bkt", name: "logs/app,1.log", size: 13},
+		{bucket: "other-bkt", name: "notes.txt", size: 10},
+	}
+	jsonObjs := []inventoryObject{
+		{bucket: "data-bkt", name: "keys/id_rsa", size: 11},
+		{bucket: "data-bkt", name: "deleted.txt", size: 7},
+	}
+
+	tests := []struct {
+		name    string
+		report  string
+		want    []inventoryObject
+		wantErr string
+	}{
+		{name: "csv", report: testInventoryCSV, want: csvObjs},
+		{name: "csv with byte order mark", report: "\ufeff" + testInventoryCSV, want: csvObjs},
+		{name: "csv without size", report: "name,bucket\nconfig/.env,data-bkt\n", want: []inventoryObject{{bucket: "data-bkt", name: "config/.env"}}},
+		{name: "json stream", report: testInventoryJSON, want: jsonObjs},
+		{
+			name:   "json array",
+			report: `[{"bucket": "data-bkt", "name": "keys/id_rsa", "size": 11}, {"bucket": "data-bkt", "name": "deleted.txt", "size": "7"}]`,
+			want:   jsonObjs,
+		},
+		{name: "empty", report: "\n"},
+		{name: "csv without name column", report: "bucket,size\ndata-bkt,9\n", wantErr: "the header must have bucket and name columns"},
+		{name: "csv without bucket", report: "bucket,name\n,config/.env\n", wantErr: "line 2: bucket and name are required"},
+		{name: "csv invalid size", report: "bucket,name,size\ndata-bkt,config/.env,large\n", wantErr: `line 2: invalid size "large"`},
+		{name: "json without name", report: `{"bucket": "data-bkt"}`, wantErr: "record 1: bucket and name are required"},
+		{name: "invalid json", report: `{"bucket": `, wantErr: "record 1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseInventoryReport(strings.NewReader(tc.report))
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestWithInventoryManifest_Invalid(t *testing.T) {
+	for _, uri := range []string{"inventory.csv", "gs://bucket", "gs:///inventory.csv", "s3://bucket/inventory.csv"} {
+		_, err := newGCSManager(testProjectID, withoutAuthentication(), withInventoryManifest(uri))
+		assert.ErrorContains(t, err, "invalid inventory manifest", uri)
+	}
+}
@@ -0,0 +1,228 @@
+package gcs
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+)
+
+// multiManager is an objectManager that scans the buckets of several
+// managers, such as one per credential, as a single source. The objects of
+// the managers are merged into a single stream.
+type multiManager struct {
+	managers []objectManager
+}
+
+func newMultiManager(managers ...objectManager) *multiManager {
+	return &multiManager{managers: managers}
+}
+
+// Attributes returns the attributes of the buckets of all the managers. A
+// manager that fails to enumerate its buckets fails the enumeration, while
+// the errors of the buckets skipped by continueOnBucketError are returned
+// alongside the attributes, like gcsManager.Attributes does.
+func (m *multiManager) Attributes(ctx context.Context) (*attributes, error) {
+	merged := newStats(0)
+	var bucketErrs []error
+	for _, mgr := range m.managers {
+		stats, err := mgr.Attributes(ctx)
+		if stats == nil {
+			return nil, err
+		}
+		merged.merge(stats)
+		if err != nil {
+			bucketErrs = append(bucketErrs, err)
+		}
+	}
+	return merged, errors.Join(bucketErrs...)
+}
+
+// merge adds the buckets of other to the attributes.
+func (s *attributes) merge(other *attributes) {
+	other.mu.RLock()
+	defer other.mu.RUnlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.numBuckets += other.numBuckets
+	s.numObjects += other.numObjects
+	s.numBytes += other.numBytes
+	for bkt, cnt := range other.bucketObjects {
+		s.bucketObjects[bkt] = cnt
+	}
+	for bkt, attrs := range other.bucketAttrs {
+		s.bucketAttrs[bkt] = attrs
+	}
+}
+
+// ListObjects lists the objects of all the managers concurrently. The
+// returned channel is closed once all of them have been listed.
+func (m *multiManager) ListObjects(ctx context.Context) (chan io.Reader, error) {
+	listCtx, cancel := context.WithCancel(ctx)
+	objChs := make([]chan io.Reader, 0, len(m.managers))
+	for _, mgr := range m.managers {
+		objCh, err := mgr.ListObjects(listCtx)
+		if err != nil {
+			// Stop the listings that already started.
+			cancel()
+			for _, objCh := range objChs {
+				go drain(objCh)
+			}
+			return nil, err
+		}
+		objChs = append(objChs, objCh)
+	}
+
+	// listCtx isn't cancelled once done, as the objects that were sent are
+	// read with it.
+	ch := make(chan io.Reader, 100)
+	var wg sync.WaitGroup
+	for _, objCh := range objChs {
+		wg.Add(1)
+		go func(objCh chan io.Reader) {
+			defer wg.Done()
+			for obj := range objCh {
+				ch <- obj
+			}
+		}(objCh)
+	}
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+func drain(ch chan io.Reader) {
+	for range ch {
+	}
+}
+
+// BucketErrors returns the errors of the buckets that failed to be listed by
+// any of the managers. It must only be called once all the objects have been
+// received.
+func (m *multiManager) BucketErrors() error {
+	var errs []error
+	for _, mgr := range m.managers {
+		if err := mgr.BucketErrors(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// PublicBuckets returns the public buckets of all the managers. It must only
+// be called once all the objects have been received.
+func (m *multiManager) PublicBuckets() map[string][]string {
+	var public map[string][]string
+	for _, mgr := range m.managers {
+		for bkt, acls := range mgr.PublicBuckets() {
+			if public == nil {
+				public = make(map[string][]string)
+			}
+			public[bkt] = acls
+		}
+	}
+	return public
+}
+
+// Close closes all the managers.
+func (m *multiManager) Close() error {
+	var errs []error
+	for _, mgr := range m.managers {
+		if err := mgr.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// configureCredentialScopes returns a manager scanning the buckets of each
+// credential scope of the connection with its credential. The credentials are
+// validated.
+func configureCredentialScopes(ctx context.Context, conn *sourcespb.GCS, concurrency int, opts ...gcsManagerOption) (*multiManager, error) {
+	if conn.GetCredential() != nil {
+		return nil, fmt.Errorf("credential scopes can't be used with a credential")
+	}
+	if len(conn.GetIncludeBuckets()) > 0 {
+		return nil, fmt.Errorf("credential scopes can't be used with include buckets, the buckets of each scope are scanned")
+	}
+
+	// The scopes are checked before any of their credentials is validated.
+	scopedConns := make([]*sourcespb.GCS, 0, len(conn.GetCredentialScopes()))
+	seen := make(map[string]int)
+	for i, scope := range conn.GetCredentialScopes() {
+		scoped, err := scopedConnection(conn, scope)
+		if err != nil {
+			return nil, fmt.Errorf("credential scope %d: %w", i, err)
+		}
+		for _, bkt := range scoped.GetIncludeBuckets() {
+			if j, ok := seen[bkt]; ok {
+				return nil, fmt.Errorf("credential scope %d: bucket %s is already scanned by credential scope %d", i, bkt, j)
+			}
+			seen[bkt] = i
+		}
+		scopedConns = append(scopedConns, scoped)
+	}
+
+	managers := make([]objectManager, 0, len(scopedConns))
+	for i, scoped := range scopedConns {
+		gcsManager, err := configureGCSManager(ctx, scoped, concurrency, opts...)
+		if err == nil {
+			managers = append(managers, gcsManager)
+			err = gcsManager.validateCredentials(ctx)
+		}
+		if err != nil {
+			_ = newMultiManager(managers...).Close()
+			return nil, fmt.Errorf("credential scope %d: %w", i, err)
+		}
+	}
+	return newMultiManager(managers...), nil
+}
+
+// scopedConnection returns the connection scanning the buckets of a
+// credential scope with its credential. The other options of the connection
+// apply to the scope as well. The bucket files of the scope are expanded.
+func scopedConnection(conn *sourcespb.GCS, scope *sourcespb.GCSCredentialScope) (*sourcespb.GCS, error) {
+	buckets, err := expandBucketFiles(scope.GetIncludeBuckets())
+	if err != nil {
+		return nil, fmt.Errorf("error reading include buckets: %w", err)
+	}
+	if len(buckets) == 0 {
+		return nil, fmt.Errorf("no buckets to scan")
+	}
+
+	scoped := proto.Clone(conn).(*sourcespb.GCS)
+	scoped.CredentialScopes = nil
+	scoped.IncludeBuckets = buckets
+	if scope.GetProjectId() != "" {
+		scoped.ProjectId = scope.GetProjectId()
+	}
+
+	switch cred := scope.GetCredential().(type) {
+	case *sourcespb.GCSCredentialScope_JsonServiceAccount:
+		scoped.Credential = &sourcespb.GCS_JsonServiceAccount{JsonServiceAccount: cred.JsonServiceAccount}
+	case *sourcespb.GCSCredentialScope_ApiKey:
+		scoped.Credential = &sourcespb.GCS_ApiKey{ApiKey: cred.ApiKey}
+	case *sourcespb.GCSCredentialScope_Unauthenticated:
+		scoped.Credential = &sourcespb.GCS_Unauthenticated{Unauthenticated: cred.Unauthenticated}
+	case *sourcespb.GCSCredentialScope_Adc:
+		scoped.Credential = &sourcespb.GCS_Adc{Adc: cred.Adc}
+	case *sourcespb.GCSCredentialScope_ServiceAccountFile:
+		scoped.Credential = &sourcespb.GCS_ServiceAccountFile{ServiceAccountFile: cred.ServiceAccountFile}
+	case *sourcespb.GCSCredentialScope_Oauth:
+		scoped.Credential = &sourcespb.GCS_Oauth{Oauth: cred.Oauth}
+	case *sourcespb.GCSCredentialScope_WorkloadIdentityFederationFile:
+		scoped.Credential = &sourcespb.GCS_WorkloadIdentityFederationFile{WorkloadIdentityFederationFile: cred.WorkloadIdentityFederationFile}
+	default:
+		return nil, fmt.Errorf("unknown GCS authentication type: %T", cred)
+	}
+	return scoped, nil
+}
@@ -0,0 +1,207 @@
+package gcs
+
+import (
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/credentialspb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// newScopedManager returns a manager scanning the buckets of the fake server
+// with the API key.
+func newScopedManager(t *testing.T, server *httptest.Server, apiKey string, buckets ...string) *gcsManager {
+	t.Helper()
+
+	ctx := context.Background()
+	gm, err := newGCSManager(testProjectID, withAPIKey(ctx, apiKey), withIncludeBuckets(buckets))
+	require.NoError(t, err)
+	gm.client, err = newStorageClient(ctx, clientRoute{}, option.WithAPIKey(apiKey), option.WithEndpoint(server.URL+"/storage/v1/"))
+	require.NoError(t, err)
+	return gm
+}
+
+func TestSourceChunks_CredentialScopes(t *testing.T) {
+	ctx := context.Background()
+
+	// Each API key can only access its buckets.
+	server := fakeGCSServer(t, &fakeGCS{
+		buckets: fakeBuckets(map[string]map[string][]string{
+			"alpha":   {"a.txt": {"alpha"}},
+			"bravo":   {"b.txt": {"bravo"}, "c.txt": {"charlie"}},
+			"private": {"d.txt": {"delta"}},
+		}),
+		apiKeys: map[string][]string{
+			"alpha-key": {"alpha"},
+			"bravo-key": {"bravo", "private"},
+		},
+	})
+	defer server.Close()
+
+	mm := newMultiManager(
+		newScopedManager(t, server, "alpha-key", "alpha"),
+		newScopedManager(t, server, "bravo-key", "bravo"),
+	)
+	defer mm.Close()
+
+	chunksCh := make(chan *sources.Chunk, 1)
+	source := &Source{name: "test", gcsManager: mm, chunksCh: chunksCh}
+	require.NoError(t, source.enumerate(ctx))
+	assert.Equal(t, uint32(2), source.stats.numBuckets)
+	assert.Equal(t, uint64(3), source.stats.numObjects)
+
+	go func() {
+		defer close(chunksCh)
+		assert.NoError(t, source.Chunks(ctx, chunksCh))
+	}()
+
+	var got []string
+	for chunk := range chunksCh {
+		gcs := chunk.SourceMetadata.GetGcs()
+		got = append(got, gcs.GetBucket()+"/"+gcs.GetFilename()+": "+string(chunk.Data))
+	}
+	sort.Strings(got)
+	assert.Equal(t, []string{"alpha/a.txt: alpha", "bravo/b.txt: bravo", "bravo/c.txt: charlie"}, got)
+	assert.NoError(t, mm.BucketErrors())
+}
+
+func TestMultiManager_BucketErrors(t *testing.T) {
+	ctx := context.Background()
+
+	server := fakeGCSServer(t, &fakeGCS{
+		buckets: fakeBuckets(map[string]map[string][]string{
+			"alpha": {"a.txt": {"alpha"}},
+			"bravo": {"b.txt": {"bravo"}},
+		}),
+		apiKeys: map[string][]string{
+			"alpha-key": {"alpha"},
+			"bravo-key": {"bravo"},
+		},
+	})
+	defer server.Close()
+
+	// The bravo bucket is scoped to the wrong credential, which can't list
+	// its objects. The buckets of the scope aren't listed, so the bucket isn't
+	// silently skipped.
+	wrong, err := newGCSManager(testProjectID, withoutAuthentication(), withIncludeBuckets([]string{"bravo"}), withContinueOnBucketError(true))
+	require.NoError(t, err)
+	wrong.client, err = newStorageClient(ctx, clientRoute{}, option.WithAPIKey("alpha-key"), option.WithEndpoint(server.URL+"/storage/v1/"))
+	require.NoError(t, err)
+	mm := newMultiManager(newScopedManager(t, server, "alpha-key", "alpha"), wrong)
+	defer mm.Close()
+
+	objCh, err := mm.ListObjects(ctx)
+	require.NoError(t, err)
+	var got []string
+	for obj := range objCh {
+		got = append(got, obj.(object).bucket)
+	}
+	assert.Equal(t, []string{"alpha"}, got)
+	assert.ErrorContains(t, mm.BucketErrors(), "bucket bravo")
+}
+
+func TestScopedConnection(t *testing.T) {
+	conn := &sourcespb.GCS{
+		ProjectId:    testProjectID,
+		SkipBinaries: true,
+		CredentialScopes: []*sourcespb.GCSCredentialScope{
+			{
+				Credential:     &sourcespb.GCSCredentialScope_ApiKey{ApiKey: "alpha-key"},
+				IncludeBuckets: []string{"alpha"},
+			},
+			{
+				Credential:     &sourcespb.GCSCredentialScope_Unauthenticated{Unauthenticated: &credentialspb.Unauthenticated{}},
+				ProjectId:      "other-project",
+				IncludeBuckets: []string{"bravo", "charlie"},
+			},
+		},
+	}
+
+	alpha, err := scopedConnection(conn, conn.GetCredentialScopes()[0])
+	require.NoError(t, err)
+	assert.Equal(t, "alpha-key", alpha.GetApiKey())
+	assert.Equal(t, testProjectID, alpha.GetProjectId())
+	assert.Equal(t, []string{"alpha"}, alpha.GetIncludeBuckets())
+	assert.True(t, alpha.GetSkipBinaries())
+	assert.Empty(t, alpha.GetCredentialScopes())
+
+	bravo, err := scopedConnection(conn, conn.GetCredentialScopes()[1])
+	require.NoError(t, err)
+	assert.NotNil(t, bravo.GetUnauthenticated())
+	assert.Equal(t, "other-project", bravo.GetProjectId())
+	assert.Equal(t, []string{"bravo", "charlie"}, bravo.GetIncludeBuckets())
+
+	// The connection itself is unchanged.
+	assert.Len(t, conn.GetCredentialScopes(), 2)
+	assert.Empty(t, conn.GetIncludeBuckets())
+}
+
+func TestConfigureCredentialScopes_Invalid(t *testing.T) {
+	ctx := context.Background()
+	scope := func(key string, buckets ...string) *sourcespb.GCSCredentialScope {
+		return &sourcespb.GCSCredentialScope{
+			Credential:     &sourcespb.GCSCredentialScope_ApiKey{ApiKey: key},
+			IncludeBuckets: buckets,
+		}
+	}
+
+	tests := []struct {
+		name string
+		conn *sourcespb.GCS
+	}{
+		{
+			name: "credential alongside scopes",
+			conn: &sourcespb.GCS{
+				ProjectId:        testProjectID,
+				Credential:       &sourcespb.GCS_ApiKey{ApiKey: "key"},
+				CredentialScopes: []*sourcespb.GCSCredentialScope{scope("alpha-key", "alpha")},
+			},
+		},
+		{
+			name: "include buckets alongside scopes",
+			conn: &sourcespb.GCS{
+				ProjectId:        testProjectID,
+				IncludeBuckets:   []string{"bravo"},
+				CredentialScopes: []*sourcespb.GCSCredentialScope{scope("alpha-key", "alpha")},
+			},
+		},
+		{
+			name: "scope without buckets",
+			conn: &sourcespb.GCS{
+				ProjectId:        testProjectID,
+				CredentialScopes: []*sourcespb.GCSCredentialScope{scope("alpha-key")},
+			},
+		},
+		{
+			name: "scope without credential",
+			conn: &sourcespb.GCS{
+				ProjectId:        testProjectID,
+				CredentialScopes: []*sourcespb.GCSCredentialScope{{IncludeBuckets: []string{"alpha"}}},
+			},
+		},
+		{
+			name: "bucket in several scopes",
+			conn: &sourcespb.GCS{
+				ProjectId: testProjectID,
+				CredentialScopes: []*sourcespb.GCSCredentialScope{
+					scope("alpha-key", "alpha", "shared"),
+					scope("bravo-key", "bravo", "shared"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := configureCredentialScopes(ctx, tt.conn, 1)
+			assert.Error(t, err)
+		})
+	}
+}
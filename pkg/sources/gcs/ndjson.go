@@ -0,0 +1,101 @@
+package gcs
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// processNDJSONObject reports each record of an object of newline-delimited
+// JSON, such as a log file, as its own chunks, with the line number and byte
+// offset of the record in the object, so findings point to the record rather
+// than to a chunk of the object. Records larger than a chunk are split into
+// several chunks of the same record. Blank lines are skipped, but still
+// counted.
+func (s *Source) processNDJSONObject(ctx context.Context, reader io.Reader, o object, reporter sources.ChunkReporter) error {
+	chunker := s.chunker
+	if chunker == nil {
+		chunker = sources.DefaultChunkSplitter()
+	}
+
+	r := bufio.NewReaderSize(reader, sources.TotalChunkSize)
+	var offset int64
+	for record := int64(1); ; record++ {
+		if _, err := r.Peek(1); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("error reading object: %w", err)
+		}
+
+		md := objectMetadata(o)
+		md.Record = record
+		md.RecordOffset = offset
+
+		rr := &recordReader{r: r}
+		position := sources.Position{Offset: offset, Line: record, Column: 1}
+		for data := range chunker.Chunk(ctx, rr) {
+			if err := data.Error(); err != nil {
+				return fmt.Errorf("error reading object: %w", err)
+			}
+			chunkPosition := position
+			position = position.Advance(data.Bytes(), sources.ChunkSize)
+			if len(bytes.TrimSpace(data.Bytes())) == 0 {
+				continue
+			}
+			chunk := sources.Chunk{
+				SourceName: s.name,
+				SourceType: s.Type(),
+				JobID:      s.JobID(),
+				SourceID:   s.sourceId,
+				Verify:     s.verify,
+				Data:       data.Bytes(),
+				SourceMetadata: &source_metadatapb.MetaData{
+					Data: &source_metadatapb.MetaData_Gcs{Gcs: md},
+				},
+			}
+			// The chunks of a custom chunker don't start at known offsets.
+			if s.chunker == nil {
+				chunk.Position = &chunkPosition
+			}
+			if err := reporter.ChunkOk(ctx, chunk); err != nil {
+				return fmt.Errorf("error reporting chunk: %w", err)
+			}
+		}
+		offset += rr.n
+	}
+}
+
+// recordReader reads a single record from r, up to and including the newline
+// that ends it.
+type recordReader struct {
+	r    *bufio.Reader
+	n    int64 // n is the number of bytes read.
+	done bool
+}
+
+func (rr *recordReader) Read(p []byte) (int, error) {
+	if rr.done || len(p) == 0 {
+		return 0, io.EOF
+	}
+
+	// Only peek at the buffered bytes, if any, so a record is never read past
+	// its newline.
+	buf, err := rr.r.Peek(min(len(p), max(rr.r.Buffered(), 1)))
+	if len(buf) == 0 {
+		return 0, err
+	}
+	if i := bytes.IndexByte(buf, '\n'); i >= 0 {
+		buf = buf[:i+1]
+		rr.done = true
+	}
+	n := copy(p, buf)
+	_, _ = rr.r.Discard(n)
+	rr.n += int64(n)
+	return n, nil
+}
@@ -0,0 +1,202 @@
+package gcs
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/sync/semaphore"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+const defaultPrefetchMaxBytes = 64 * 1024 * 1024 // 64MB
+
+// prefetcher downloads the content of the next objects into memory while the
+// earlier ones are scanned, so downloads overlap with detection. At most depth
+// objects are downloaded ahead of being received, and the content of the
+// objects downloaded uses at most maxBytes of memory until they're closed.
+// Objects larger than maxBytes are passed through and streamed as they're
+// scanned, like without prefetching.
+type prefetcher struct {
+	maxBytes int64
+
+	// slots bounds the objects downloaded or waiting to be received.
+	slots chan struct{}
+	// mem reserves the memory of the downloaded content, until its object is
+	// closed.
+	mem *semaphore.Weighted
+
+	// free are the buffers of the closed objects, reused by later downloads
+	// as long as they total at most maxBytes. freeBytes is their total size.
+	// They're guarded by mu.
+	mu        sync.Mutex
+	free      [][]byte
+	freeBytes int64
+
+	// buffered is the memory reserved by the content downloaded, and
+	// peakBuffered its maximum. allocated counts the buffers allocated.
+	buffered     atomic.Int64
+	peakBuffered atomic.Int64
+	allocated    atomic.Int64
+}
+
+func newPrefetcher(depth int, maxBytes int64) *prefetcher {
+	if maxBytes <= 0 {
+		maxBytes = defaultPrefetchMaxBytes
+	}
+	return &prefetcher{
+		maxBytes: maxBytes,
+		slots:    make(chan struct{}, depth),
+		mem:      semaphore.NewWeighted(maxBytes),
+	}
+}
+
+// prefetch returns the objects of in, with the content of those that fit in
+// memory downloaded before they're sent. Objects are sent in the order their
+// download completes. Their content must be closed once it's scanned, to
+// release its memory.
+func (p *prefetcher) prefetch(ctx context.Context, in <-chan io.Reader) chan io.Reader {
+	out := make(chan io.Reader)
+	go func() {
+		var wg sync.WaitGroup
+		defer close(out)
+		defer wg.Wait()
+
+		for obj := range in {
+			o, ok := obj.(object)
+			if !ok || o.deleted || o.size <= 0 || o.size > p.maxBytes || ctx.Err() != nil {
+				p.send(ctx, out, obj)
+				continue
+			}
+
+			select {
+			case p.slots <- struct{}{}:
+			case <-ctx.Done():
+				p.send(ctx, out, obj)
+				continue
+			}
+			if err := p.mem.Acquire(ctx, o.size); err != nil {
+				<-p.slots
+				p.send(ctx, out, obj)
+				continue
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-p.slots }()
+				p.send(ctx, out, p.download(ctx, o))
+			}()
+		}
+	}()
+	return out
+}
+
+// send sends obj to out, or closes it if the context is done first.
+func (p *prefetcher) send(ctx context.Context, out chan<- io.Reader, obj io.Reader) {
+	select {
+	case out <- obj:
+	case <-ctx.Done():
+		if o, ok := obj.(object); ok {
+			if closer, ok := o.Reader.(io.Closer); ok {
+				_ = closer.Close()
+			}
+		}
+	}
+}
+
+// download reads the content of o, whose size in memory is reserved, into a
+// buffer. Content served larger than the object's size, e.g. decompressed by
+// the server, is streamed past the buffer. Errors are returned by the content
+// once the buffer is read, like when it's streamed.
+func (p *prefetcher) download(ctx context.Context, o object) object {
+	p.reserve(o.size)
+	buf := p.getBuffer(o.size)
+	n, err := io.ReadFull(o.Reader, buf)
+
+	content := &prefetchedReader{release: func() {
+		p.putBuffer(buf)
+		p.mem.Release(o.size)
+		p.buffered.Add(-o.size)
+	}}
+	content.closer, _ = o.Reader.(io.Closer)
+	switch {
+	case err == nil:
+		content.Reader = io.MultiReader(bytes.NewReader(buf), o.Reader)
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		content.Reader = bytes.NewReader(buf[:n])
+	default:
+		ctx.Logger().V(3).Info("failed to prefetch object", "bucket", o.bucket, "object-name", o.name, "error", err)
+		content.Reader = io.MultiReader(bytes.NewReader(buf[:n]), &errReader{err: err})
+	}
+	o.Reader = content
+	return o
+}
+
+func (p *prefetcher) reserve(size int64) {
+	buffered := p.buffered.Add(size)
+	for {
+		peak := p.peakBuffered.Load()
+		if buffered <= peak || p.peakBuffered.CompareAndSwap(peak, buffered) {
+			return
+		}
+	}
+}
+
+// getBuffer returns a buffer of the size, reusing a free one if it's large
+// enough.
+func (p *prefetcher) getBuffer(size int64) []byte {
+	p.mu.Lock()
+	for i, buf := range p.free {
+		if int64(cap(buf)) >= size {
+			p.free = append(p.free[:i], p.free[i+1:]...)
+			p.freeBytes -= int64(cap(buf))
+			p.mu.Unlock()
+			return buf[:size]
+		}
+	}
+	p.mu.Unlock()
+
+	p.allocated.Add(1)
+	return make([]byte, size)
+}
+
+// putBuffer frees the buffer for reuse, unless the free buffers would total
+// more than maxBytes.
+func (p *prefetcher) putBuffer(buf []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.freeBytes+int64(cap(buf)) > p.maxBytes {
+		return
+	}
+	p.free = append(p.free, buf)
+	p.freeBytes += int64(cap(buf))
+}
+
+// prefetchedReader reads the downloaded content of an object. Closing it
+// closes the object and releases the memory of the content.
+type prefetchedReader struct {
+	io.Reader
+	closer  io.Closer
+	release func()
+	once    sync.Once
+}
+
+func (r *prefetchedReader) Close() error {
+	var err error
+	r.once.Do(func() {
+		if r.closer != nil {
+			err = r.closer.Close()
+		}
+		r.release()
+	})
+	return err
+}
+
+// errReader returns err once the content before it is read.
+type errReader struct{ err error }
+
+func (r *errReader) Read([]byte) (int, error) { return 0, r.err }
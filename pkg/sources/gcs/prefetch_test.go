@@ -0,0 +1,215 @@
+package gcs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+// countingReader counts the objects whose content was read whole in
+// downloaded, and those closed in closed.
+type countingReader struct {
+	*strings.Reader
+	downloaded, closed *atomic.Int32
+	done               bool
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if r.Len() == 0 && !r.done {
+		r.done = true
+		r.downloaded.Add(1)
+	}
+	return n, err
+}
+
+func (r *countingReader) Close() error {
+	r.closed.Add(1)
+	return nil
+}
+
+func prefetchTestObjects(n, size int, downloaded, closed *atomic.Int32) chan io.Reader {
+	ch := make(chan io.Reader, n)
+	for i := 0; i < n; i++ {
+		content := fmt.Sprintf("%0*d", size, i)
+		ch <- object{
+			name:   fmt.Sprintf("object-%d", i),
+			size:   int64(size),
+			Reader: &countingReader{Reader: strings.NewReader(content), downloaded: downloaded, closed: closed},
+		}
+	}
+	close(ch)
+	return ch
+}
+
+func TestPrefetcher_Depth(t *testing.T) {
+	ctx := context.Background()
+	var downloaded, closed atomic.Int32
+	in := prefetchTestObjects(10, 100, &downloaded, &closed)
+
+	p := newPrefetcher(3, 0)
+	out := p.prefetch(ctx, in)
+
+	// Nothing is received, so only the next 3 objects are downloaded.
+	assert.Eventually(t, func() bool { return downloaded.Load() == 3 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(3), downloaded.Load())
+
+	got := make(map[string]string)
+	for obj := range out {
+		o := obj.(object)
+		_, ok := o.Reader.(*prefetchedReader)
+		assert.True(t, ok, o.name)
+		content, err := io.ReadAll(o)
+		require.NoError(t, err)
+		got[o.name] = string(content)
+		require.NoError(t, o.Reader.(io.Closer).Close())
+	}
+	require.Len(t, got, 10)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, fmt.Sprintf("%0100d", i), got[fmt.Sprintf("object-%d", i)])
+	}
+	assert.Equal(t, int32(10), downloaded.Load())
+	assert.Equal(t, int32(10), closed.Load())
+	assert.Zero(t, p.buffered.Load())
+}
+
+func TestPrefetcher_MaxBytes(t *testing.T) {
+	ctx := context.Background()
+	var downloaded, closed atomic.Int32
+	in := prefetchTestObjects(20, 1024, &downloaded, &closed)
+
+	// Only 3 objects fit in memory, even though 10 could be prefetched.
+	p := newPrefetcher(10, 3*1024)
+	out := p.prefetch(ctx, in)
+
+	var held []object
+	for i := 0; i < 3; i++ {
+		held = append(held, (<-out).(object))
+	}
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(3), downloaded.Load())
+
+	// Closing a scanned object frees the memory for the next download.
+	require.NoError(t, held[0].Reader.(io.Closer).Close())
+	held = append(held[1:], (<-out).(object))
+	for _, o := range held {
+		require.NoError(t, o.Reader.(io.Closer).Close())
+	}
+
+	for obj := range out {
+		o := obj.(object)
+		content, err := io.ReadAll(o)
+		require.NoError(t, err)
+		assert.Len(t, content, 1024)
+		require.NoError(t, o.Reader.(io.Closer).Close())
+	}
+	assert.Equal(t, int32(20), downloaded.Load())
+	assert.Equal(t, int32(20), closed.Load())
+	assert.LessOrEqual(t, p.peakBuffered.Load(), int64(3*1024))
+	assert.Zero(t, p.buffered.Load())
+	// The buffers of the closed objects were reused.
+	assert.LessOrEqual(t, p.allocated.Load(), int64(3))
+}
+
+func TestPrefetcher_PassThrough(t *testing.T) {
+	ctx := context.Background()
+	var downloaded, closed atomic.Int32
+	large := &countingReader{Reader: strings.NewReader(strings.Repeat("x", 2048)), downloaded: &downloaded, closed: &closed}
+	in := make(chan io.Reader, 3)
+	in <- object{name: "large", size: 2048, Reader: large}
+	in <- object{name: "deleted", deleted: true, Reader: strings.NewReader("")}
+	in <- object{name: "failed", size: 10, Reader: io.MultiReader(strings.NewReader("abc"), &errReader{err: ErrObjectNotFound})}
+	close(in)
+
+	p := newPrefetcher(2, 1024)
+	got := make(map[string]object)
+	for obj := range p.prefetch(ctx, in) {
+		o := obj.(object)
+		got[o.name] = o
+	}
+	require.Len(t, got, 3)
+
+	// Objects larger than the memory are streamed as they're scanned.
+	assert.Same(t, large, got["large"].Reader)
+	assert.Zero(t, downloaded.Load())
+	assert.True(t, got["deleted"].deleted)
+
+	// Errors are returned once the content downloaded is read.
+	content, err := io.ReadAll(got["failed"])
+	assert.Equal(t, "abc", string(content))
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+	require.NoError(t, got["failed"].Reader.(io.Closer).Close())
+	assert.Zero(t, p.buffered.Load())
+}
+
+func TestPrefetcher_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var downloaded, closed atomic.Int32
+	in := prefetchTestObjects(10, 100, &downloaded, &closed)
+
+	out := newPrefetcher(3, 0).prefetch(ctx, in)
+	assert.Eventually(t, func() bool { return downloaded.Load() == 3 }, time.Second, time.Millisecond)
+	cancel()
+
+	// The objects that aren't received are closed, without being downloaded.
+	assert.Eventually(t, func() bool { return closed.Load() == 10 }, time.Second, time.Millisecond)
+	assert.Equal(t, int32(3), downloaded.Load())
+	select {
+	case _, ok := <-out:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("prefetching didn't stop")
+	}
+}
+
+func TestSourceChunks_Prefetch(t *testing.T) {
+	ctx := context.Background()
+
+	var downloaded, closed atomic.Int32
+	var objects []object
+	for i := 0; i < 8; i++ {
+		content := fmt.Sprintf("object %d token=%d", i, i)
+		objects = append(objects, object{
+			name:   fmt.Sprintf("object-%d.txt", i),
+			bucket: "bucket",
+			md5:    fmt.Sprintf("%032d", i),
+			size:   int64(len(content)),
+			Reader: &countingReader{Reader: strings.NewReader(content), downloaded: &downloaded, closed: &closed},
+		})
+	}
+
+	chunksCh := make(chan *sources.Chunk, len(objects))
+	source := &Source{
+		name:                   "test",
+		gcsManager:             &listedObjectManager{objects: objects},
+		chunksCh:               chunksCh,
+		disableArchiveHandling: true,
+		prefetchDepth:          2,
+	}
+	assert.NoError(t, source.enumerate(ctx))
+	assert.NoError(t, source.Chunks(ctx, chunksCh))
+	close(chunksCh)
+
+	got := make(map[string]string)
+	for chunk := range chunksCh {
+		got[chunk.SourceMetadata.GetGcs().GetFilename()] = string(chunk.Data)
+	}
+	want := make(map[string]string)
+	for i := range objects {
+		want[fmt.Sprintf("object-%d.txt", i)] = fmt.Sprintf("object %d token=%d", i, i)
+	}
+	assert.Equal(t, want, got)
+	assert.Equal(t, int32(len(objects)), downloaded.Load())
+	// The memory of every object was released.
+	assert.Equal(t, int32(len(objects)), closed.Load())
+}
@@ -0,0 +1,98 @@
+package gcs
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// exportedProgressVersion is the version of the format of exportedProgress.
+const exportedProgressVersion = 1
+
+// exportedProgress is the progress of a scan, as exported by ExportProgress.
+// Unlike the resume info, which is only persisted every persistInterval, it
+// has all the objects processed at the time it is exported.
+type exportedProgress struct {
+	Version int `json:"version"`
+	// Buckets are the MD5 hashes of the processed objects, by bucket.
+	Buckets map[string][]string `json:"buckets,omitempty"`
+	// ETags are the ETags of the objects scanned by incremental scans, see
+	// incrementalResumeInfo.
+	ETags             map[string]string `json:"etags,omitempty"`
+	SectionsCompleted int32             `json:"sections_completed"`
+}
+
+// ExportProgress exports the progress of the ongoing or last scan, so it can
+// be resumed by another Source, e.g. on another machine, with ImportProgress.
+func (s *Source) ExportProgress() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exported := exportedProgress{
+		Version:           exportedProgressVersion,
+		Buckets:           make(map[string][]string, len(s.processed)),
+		SectionsCompleted: s.SectionsCompleted,
+	}
+	for bucket, objects := range s.processed {
+		md5s := make([]string, 0, len(objects))
+		for md5 := range objects {
+			md5s = append(md5s, md5)
+		}
+		slices.Sort(md5s)
+		exported.Buckets[bucket] = md5s
+	}
+	if s.incremental {
+		exported.ETags = s.etags
+	}
+
+	data, err := json.Marshal(exported)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding progress: %w", err)
+	}
+	return data, nil
+}
+
+// ImportProgress imports the progress exported by ExportProgress, so the next
+// scan skips the objects already processed. It must be called after Init, and
+// before Chunks.
+func (s *Source) ImportProgress(data []byte) error {
+	var imported exportedProgress
+	if err := json.Unmarshal(data, &imported); err != nil {
+		return fmt.Errorf("error decoding progress: %w", err)
+	}
+	if imported.Version != exportedProgressVersion {
+		return fmt.Errorf("unsupported progress version %d", imported.Version)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.processed = make(map[string]map[string]struct{}, len(imported.Buckets))
+	var processed []string
+	for bucket, md5s := range imported.Buckets {
+		for _, md5 := range md5s {
+			s.addProcessed(bucket, md5)
+			processed = append(processed, md5)
+		}
+	}
+
+	encoded := strings.Join(processed, ",")
+	if s.incremental {
+		encoded = incrementalResumeInfo{Processed: encoded, ETags: imported.ETags}.encode()
+	}
+	s.Progress.EncodedResumeInfo = encoded
+	s.SectionsCompleted = imported.SectionsCompleted
+	return nil
+}
+
+// addProcessed records that an object was processed, for ExportProgress.
+// s.mu must be held.
+func (s *Source) addProcessed(bucket, md5 string) {
+	objects, ok := s.processed[bucket]
+	if !ok {
+		objects = make(map[string]struct{})
+		s.processed[bucket] = objects
+	}
+	objects[md5] = struct{}{}
+}
@@ -0,0 +1,274 @@
+package gcs
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+)
+
+// virtualHostSuffix is the host suffix of virtual-hosted-style URLs, which
+// name the bucket in the host rather than the path.
+const virtualHostSuffix = ".storage.googleapis.com"
+
+// signedURLManager is an objectManager that fetches objects through signed
+// URLs over HTTP rather than listing them through the GCS API. This allows
+// scanning specific objects without being granted read access to their bucket.
+type signedURLManager struct {
+	urls          []string
+	maxObjectSize int64
+	client        *http.Client
+}
+
+func newSignedURLManager(urls []string, maxObjectSize int64) *signedURLManager {
+	return &signedURLManager{
+		urls:          urls,
+		maxObjectSize: maxObjectSizeOrDefault(maxObjectSize),
+		client:        common.RetryableHTTPClient(),
+	}
+}
+
+// signedURLs returns the signed URLs of the connection, including the ones
+// listed in its signed URLs file.
+func signedURLs(conn *sourcespb.GCS) ([]string, error) {
+	urls := conn.GetSignedUrls()
+	if conn.GetSignedUrlsFile() == "" {
+		return urls, nil
+	}
+
+	fileURLs, err := readListFile(conn.GetSignedUrlsFile())
+	if err != nil {
+		return nil, fmt.Errorf("error reading signed URLs file: %w", err)
+	}
+	return append(urls, fileURLs...), nil
+}
+
+// readListFile returns the lines of a file listing one value per line.
+// Surrounding whitespace is trimmed, and blank lines and comments, starting
+// with #, are skipped.
+func readListFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var values []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		values = append(values, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// Attributes counts the objects and buckets of the signed URLs without
+// fetching them. It fails if any of the URLs is malformed.
+func (m *signedURLManager) Attributes(_ context.Context) (*attributes, error) {
+	bucketObjects := make(map[string]uint64)
+	for i, rawURL := range m.urls {
+		bkt, _, _, err := parseSignedURL(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("signed URL %d: %w", i+1, err)
+		}
+		bucketObjects[bkt]++
+	}
+
+	stats := newStats(len(bucketObjects))
+	stats.numObjects = uint64(len(m.urls))
+	stats.bucketObjects = bucketObjects
+	return stats, nil
+}
+
+// Close releases the idle connections of the HTTP client.
+func (m *signedURLManager) Close() error {
+	m.client.CloseIdleConnections()
+	return nil
+}
+
+func (m *signedURLManager) ListObjects(ctx context.Context) (chan io.Reader, error) {
+	ch := make(chan io.Reader)
+
+	go func() {
+		defer close(ch)
+		for _, rawURL := range m.urls {
+			o, err := m.fetchObject(ctx, rawURL)
+			if err != nil {
+				ctx.Logger().V(1).Info("failed to fetch object from signed URL", "error", err)
+				continue
+			}
+			ch <- o
+		}
+	}()
+
+	return ch, nil
+}
+
+// BucketErrors always returns nil, as the objects of signed URLs are fetched
+// without listing their buckets.
+func (m *signedURLManager) BucketErrors() error { return nil }
+
+// PublicBuckets returns nil, as the ACL of the buckets can't be read through
+// signed URLs.
+func (m *signedURLManager) PublicBuckets() map[string][]string { return nil }
+
+// fetchObject requests the object a signed URL points to. The object's reader
+// is the response body, which the caller must close.
+func (m *signedURLManager) fetchObject(ctx context.Context, rawURL string) (object, error) {
+	bkt, name, link, err := parseSignedURL(rawURL)
+	if err != nil {
+		return object{}, err
+	}
+	if !isObjectTypeValid(ctx, name) {
+		return object{}, fmt.Errorf("object %s is not valid", link)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return object{}, fmt.Errorf("failed to create request for %s", link)
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		// The URL error includes the URL and so its signature.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return object{}, fmt.Errorf("failed to fetch %s: %w", link, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		err := fmt.Errorf("failed to fetch %s: unexpected status %s", link, resp.Status)
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			err = wrapError(ErrAuth, err)
+		case http.StatusForbidden:
+			err = wrapError(ErrPermission, err)
+		case http.StatusNotFound:
+			err = wrapError(ErrObjectNotFound, err)
+		}
+		return object{}, err
+	}
+
+	// The size is unknown if the object is transcoded, so only check it if
+	// it's known and limit how much is read otherwise.
+	var reader io.Reader = resp.Body
+	if resp.ContentLength >= 0 {
+		if resp.ContentLength == 0 || resp.ContentLength > m.maxObjectSize {
+			_ = resp.Body.Close()
+			return object{}, fmt.Errorf("object %s size %d is not valid", link, resp.ContentLength)
+		}
+	} else {
+		reader = struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(resp.Body, m.maxObjectSize), resp.Body}
+	}
+
+	o := object{
+		name:        name,
+		bucket:      bkt,
+		contentType: resp.Header.Get("Content-Type"),
+		link:        link,
+		md5:         responseMD5(resp.Header),
+		etag:        resp.Header.Get("ETag"),
+		size:        resp.ContentLength,
+		Reader:      reader,
+	}
+	// The MD5 identifies objects that were already scanned, so fall back to
+	// the link for objects without one, such as composite objects.
+	if o.md5 == "" {
+		o.md5 = link
+	}
+	// The generation of an object is the time its current version was
+	// created, in microseconds.
+	if gen, err := strconv.ParseInt(resp.Header.Get("X-Goog-Generation"), 10, 64); err == nil {
+		o.createdAt = time.UnixMicro(gen).UTC()
+	}
+	if lastModified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		o.updatedAt = lastModified
+	}
+	o.metadata = responseCustomMetadata(resp.Header)
+
+	return o, nil
+}
+
+// responseMD5 returns the hex encoded MD5 hash of the object from the
+// x-goog-hash headers of a response, or an empty string if there is none.
+func responseMD5(header http.Header) string {
+	for _, value := range header.Values("X-Goog-Hash") {
+		for _, hash := range strings.Split(value, ",") {
+			encoded, ok := strings.CutPrefix(strings.TrimSpace(hash), "md5=")
+			if !ok {
+				continue
+			}
+			md5, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return ""
+			}
+			return hex.EncodeToString(md5)
+		}
+	}
+	return ""
+}
+
+// responseCustomMetadata returns the custom metadata of the object from the
+// x-goog-meta- headers of a response.
+func responseCustomMetadata(header http.Header) map[string]string {
+	var metadata map[string]string
+	for name, values := range header {
+		key, ok := strings.CutPrefix(name, "X-Goog-Meta-")
+		if !ok {
+			continue
+		}
+		if metadata == nil {
+			metadata = make(map[string]string)
+		}
+		metadata[strings.ToLower(key)] = strings.Join(values, ",")
+	}
+	return metadata
+}
+
+// parseSignedURL returns the bucket and name of the object a signed URL points
+// to, and a link to the object without the signature. Both path-style
+// (https://storage.googleapis.com/bucket/object) and virtual-hosted-style
+// (https://bucket.storage.googleapis.com/object) URLs are supported.
+func parseSignedURL(rawURL string) (bucket, name, link string, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		// The parse error includes the URL and so its signature.
+		return "", "", "", fmt.Errorf("malformed signed URL")
+	}
+	u.RawQuery, u.Fragment = "", ""
+	link = u.String()
+
+	path := strings.TrimPrefix(u.Path, "/")
+	if b, ok := strings.CutSuffix(u.Hostname(), virtualHostSuffix); ok {
+		bucket, name = b, path
+	} else {
+		bucket, name, _ = strings.Cut(path, "/")
+	}
+	if bucket == "" || name == "" {
+		return "", "", "", fmt.Errorf("signed URL %s does not point to an object", link)
+	}
+
+	return bucket, name, link, nil
+}
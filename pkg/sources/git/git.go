@@ -9,9 +9,11 @@ import (
 	"net/url"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -19,6 +21,7 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 	"github.com/google/go-github/v62/github"
 	"golang.org/x/oauth2"
 	"golang.org/x/sync/semaphore"
@@ -27,8 +30,11 @@ import (
 
 	"github.com/trufflesecurity/trufflehog/v3/pkg/cleantemp"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/glob"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/ignore"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/gitparse"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/giturl"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/handlers"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
@@ -49,6 +55,7 @@ type Source struct {
 	scanOptions            *ScanOptions
 
 	sources.Progress
+	sources.NoopCloser
 	conn *sourcespb.Git
 }
 
@@ -66,6 +73,8 @@ type Git struct {
 	concurrency        *semaphore.Weighted
 	skipBinaries       bool
 	skipArchives       bool
+	maxArchiveDepth    int
+	maxArchiveSize     int64
 
 	parser *gitparse.Parser
 }
@@ -86,6 +95,10 @@ type Config struct {
 	Verify       bool
 	SkipBinaries bool
 	SkipArchives bool
+	// MaxArchiveDepth and MaxArchiveSize limit the extraction of archives.
+	// Zero values use the handlers defaults.
+	MaxArchiveDepth int
+	MaxArchiveSize  int64
 
 	// UseCustomContentWriter indicates whether to use a custom contentWriter.
 	// When set to true, the parser will use a custom contentWriter provided through the WithContentWriter option.
@@ -113,6 +126,8 @@ func NewGit(config *Config) *Git {
 		concurrency:        semaphore.NewWeighted(int64(config.Concurrency)),
 		skipBinaries:       config.SkipBinaries,
 		skipArchives:       config.SkipArchives,
+		maxArchiveDepth:    config.MaxArchiveDepth,
+		maxArchiveSize:     config.MaxArchiveSize,
 		parser:             parser,
 	}
 }
@@ -172,9 +187,20 @@ func (s *Source) Init(aCtx context.Context, name string, jobId sources.JobID, so
 	}
 	opts := []ScanOption{ScanOptionFilter(filter), ScanOptionLogOptions(new(git.LogOptions))}
 
+	if globs := conn.GetPathAllowlist(); len(globs) > 0 {
+		allowlist, err := glob.NewGlobFilter(glob.WithExcludePathGlobs(globs...))
+		if err != nil {
+			return fmt.Errorf("error creating path allowlist: %w", err)
+		}
+		opts = append(opts, ScanOptionPathAllowlist(allowlist))
+	}
+
 	if depth := conn.GetMaxDepth(); depth != 0 {
 		opts = append(opts, ScanOptionMaxDepth(depth))
 	}
+	if depth := conn.GetMaxDepthPerBranch(); depth != 0 {
+		opts = append(opts, ScanOptionMaxDepthPerBranch(depth))
+	}
 	if base := conn.GetBase(); base != "" {
 		opts = append(opts, ScanOptionBaseHash(base))
 	}
@@ -188,6 +214,9 @@ func (s *Source) Init(aCtx context.Context, name string, jobId sources.JobID, so
 	if isBare := conn.GetBare(); isBare {
 		opts = append(opts, ScanOptionBare(isBare))
 	}
+	if conn.GetUseIgnoreFiles() {
+		opts = append(opts, ScanOptionIgnoreFiles(true))
+	}
 	s.withScanOptions(NewScanOptions(opts...))
 
 	s.conn = &conn
@@ -201,14 +230,16 @@ func (s *Source) Init(aCtx context.Context, name string, jobId sources.JobID, so
 	}
 
 	cfg := &Config{
-		SourceName:   s.name,
-		JobID:        s.jobID,
-		SourceID:     s.sourceID,
-		SourceType:   s.Type(),
-		Verify:       s.verify,
-		SkipBinaries: conn.GetSkipBinaries(),
-		SkipArchives: conn.GetSkipArchives(),
-		Concurrency:  concurrency,
+		SourceName:      s.name,
+		JobID:           s.jobID,
+		SourceID:        s.sourceID,
+		SourceType:      s.Type(),
+		Verify:          s.verify,
+		SkipBinaries:    conn.GetSkipBinaries(),
+		SkipArchives:    conn.GetSkipArchives(),
+		MaxArchiveDepth: int(conn.GetMaxArchiveDepth()),
+		MaxArchiveSize:  conn.GetMaxArchiveDecompressedSize(),
+		Concurrency:     concurrency,
 		SourceMetadataFunc: func(file, email, commit, timestamp, repository string, line int64) *source_metadatapb.MetaData {
 			return &source_metadatapb.MetaData{
 				Data: &source_metadatapb.MetaData_Git{
@@ -219,6 +250,7 @@ func (s *Source) Init(aCtx context.Context, name string, jobId sources.JobID, so
 						Repository: sanitizer.UTF8(repository),
 						Timestamp:  sanitizer.UTF8(timestamp),
 						Line:       line,
+						Link:       sanitizer.UTF8(gitLink(repository, commit, file, line)),
 					},
 				},
 			}
@@ -259,7 +291,13 @@ func (s *Source) scanRepos(ctx context.Context, reporter sources.ChunkReporter)
 		if len(repoURI) == 0 {
 			continue
 		}
-		if err := s.scanRepo(ctx, repoURI, reporter); err != nil {
+		release, err := sources.AcquireWorker(ctx)
+		if err != nil {
+			return err
+		}
+		err = s.scanRepo(ctx, repoURI, reporter)
+		release()
+		if err != nil {
 			ctx.Logger().Info("error scanning repository", "repo", repoURI, "error", err)
 			continue
 		}
@@ -312,7 +350,13 @@ func (s *Source) scanDirs(ctx context.Context, reporter sources.ChunkReporter) e
 		if len(gitDir) == 0 {
 			continue
 		}
-		if err := s.scanDir(ctx, gitDir, reporter); err != nil {
+		release, err := sources.AcquireWorker(ctx)
+		if err != nil {
+			return err
+		}
+		err = s.scanDir(ctx, gitDir, reporter)
+		release()
+		if err != nil {
 			ctx.Logger().Info("error scanning repository", "repo", gitDir, "error", err)
 			continue
 		}
@@ -564,6 +608,19 @@ func (s *Git) ScanCommits(ctx context.Context, repo *git.Repository, path string
 		logValues = append(logValues, "max_depth", scanOptions.MaxDepth)
 	}
 
+	// When limiting the depth per branch, determine the commits to scan up front.
+	// The log is still walked once for all branches, so commits shared between
+	// branches are scanned a single time.
+	var branchCommits map[string]struct{}
+	if scanOptions.MaxDepthPerBranch > 0 {
+		logValues = append(logValues, "max_depth_per_branch", scanOptions.MaxDepthPerBranch)
+		var err error
+		branchCommits, err = commitsWithinBranchDepth(repo, scanOptions.HeadHash, scanOptions.MaxDepthPerBranch)
+		if err != nil {
+			return fmt.Errorf("error resolving commits within branch depth: %w", err)
+		}
+	}
+
 	diffChan, err := s.parser.RepoPath(repoCtx, path, scanOptions.HeadHash, scanOptions.BaseHash == "", scanOptions.ExcludeGlobs, scanOptions.Bare)
 	if err != nil {
 		return err
@@ -593,6 +650,16 @@ func (s *Git) ScanCommits(ctx context.Context, repo *git.Repository, path string
 			break
 		}
 
+		if branchCommits != nil && fullHash != lastCommitHash {
+			if depth >= int64(len(branchCommits)) {
+				logger.V(1).Info("reached max depth for all branches", "depth", depth)
+				break
+			}
+			if _, ok := branchCommits[fullHash]; !ok {
+				continue
+			}
+		}
+
 		email := commit.Author
 		when := commit.Date.UTC().Format("2006-01-02 15:04:05 -0700")
 
@@ -632,7 +699,7 @@ func (s *Git) ScanCommits(ctx context.Context, repo *git.Repository, path string
 			continue
 		}
 
-		if !scanOptions.Filter.Pass(fileName) {
+		if !scanOptions.Filter.Pass(fileName) || !scanOptions.PathAllowlist.ShouldInclude(fileName) || scanOptions.ignored.Match(fileName, false) {
 			continue
 		}
 
@@ -709,6 +776,66 @@ func (s *Git) ScanCommits(ctx context.Context, repo *git.Repository, path string
 	return nil
 }
 
+// commitsWithinBranchDepth returns the set of commit hashes within maxDepth commits of
+// each branch head. If head is provided, only that revision is considered.
+// Commits reachable from multiple branches are included in the set once.
+func commitsWithinBranchDepth(repo *git.Repository, head string, maxDepth int64) (map[string]struct{}, error) {
+	var heads []plumbing.Hash
+	if head != "" {
+		hash, err := repo.ResolveRevision(plumbing.Revision(head))
+		if err != nil {
+			return nil, fmt.Errorf("error resolving head %q: %w", head, err)
+		}
+		heads = append(heads, *hash)
+	} else {
+		// Match the refs walked by `git log --all`, limited to branches and HEAD.
+		if ref, err := repo.Head(); err == nil {
+			heads = append(heads, ref.Hash())
+		}
+
+		refs, err := repo.References()
+		if err != nil {
+			return nil, fmt.Errorf("error listing references: %w", err)
+		}
+		err = refs.ForEach(func(ref *plumbing.Reference) error {
+			if ref.Type() != plumbing.HashReference {
+				return nil
+			}
+			if name := ref.Name(); name.IsBranch() || name.IsRemote() {
+				heads = append(heads, ref.Hash())
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error iterating references: %w", err)
+		}
+	}
+
+	commits := make(map[string]struct{})
+	for _, hash := range heads {
+		iter, err := repo.Log(&git.LogOptions{From: hash, Order: git.LogOrderCommitterTime})
+		if err != nil {
+			return nil, fmt.Errorf("error getting log for %s: %w", hash, err)
+		}
+
+		var depth int64
+		err = iter.ForEach(func(commit *object.Commit) error {
+			if depth >= maxDepth {
+				return storer.ErrStop
+			}
+			commits[commit.Hash.String()] = struct{}{}
+			depth++
+			return nil
+		})
+		iter.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error walking log for %s: %w", hash, err)
+		}
+	}
+
+	return commits, nil
+}
+
 func (s *Git) gitChunk(ctx context.Context, diff *gitparse.Diff, fileName, email, hash, when, urlMetadata string, reporter sources.ChunkReporter) {
 	reader, err := diff.ReadCloser()
 	if err != nil {
@@ -848,7 +975,7 @@ func (s *Git) ScanStaged(ctx context.Context, repo *git.Repository, path string,
 			reachedBase = true
 		}
 
-		if !scanOptions.Filter.Pass(diff.PathB) {
+		if !scanOptions.Filter.Pass(diff.PathB) || !scanOptions.PathAllowlist.ShouldInclude(diff.PathB) || scanOptions.ignored.Match(diff.PathB, false) {
 			continue
 		}
 
@@ -928,6 +1055,17 @@ func (s *Git) ScanRepo(ctx context.Context, repo *git.Repository, repoPath strin
 	if err := normalizeConfig(scanOptions, repo); err != nil {
 		return err
 	}
+	if scanOptions.IgnoreFiles {
+		ignored, err := readIgnoreFiles(repo, scanOptions.HeadHash)
+		if err != nil {
+			return err
+		}
+		ctx.Logger().V(2).Info("read ignore files", "patterns", ignored.Len())
+		// The options may be shared by the repositories of the source.
+		repoOptions := *scanOptions
+		repoOptions.ignored = ignored
+		scanOptions = &repoOptions
+	}
 	start := time.Now().Unix()
 
 	if err := s.ScanCommits(ctx, repo, repoPath, scanOptions, reporter); err != nil {
@@ -962,6 +1100,60 @@ func (s *Git) ScanRepo(ctx context.Context, repo *git.Repository, repoPath strin
 	return nil
 }
 
+// readIgnoreFiles reads the .trufflehogignore files of the tree of the head
+// commit, or of HEAD if head is empty. A repository without commits has no
+// ignore files.
+func readIgnoreFiles(repo *git.Repository, head string) (*ignore.Matcher, error) {
+	rev := plumbing.Revision(plumbing.HEAD)
+	if head != "" {
+		rev = plumbing.Revision(head)
+	}
+	hash, err := repo.ResolveRevision(rev)
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve %s: %w", rev, err)
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read commit %s: %w", hash, err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read tree of commit %s: %w", hash, err)
+	}
+
+	var files []*object.File
+	err = tree.Files().ForEach(func(f *object.File) error {
+		if path.Base(f.Name) == ignore.FileName && f.Mode.IsFile() {
+			files = append(files, f)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list files of commit %s: %w", hash, err)
+	}
+	// Ignore files take precedence over those of their parent directories.
+	sort.SliceStable(files, func(i, j int) bool {
+		return strings.Count(files[i].Name, "/") < strings.Count(files[j].Name, "/")
+	})
+
+	ignored := new(ignore.Matcher)
+	for _, f := range files {
+		r, err := f.Reader()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %w", f.Name, err)
+		}
+		err = ignored.Add(path.Dir(f.Name), r)
+		r.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ignored, nil
+}
+
 // normalizeConfig updates scanOptions with the resolved base and head commit hashes.
 // It's designed to handle scenarios where BaseHash and HeadHash in scanOptions might be branch names or
 // other non-hash references. This ensures that both the base and head commits are resolved to actual commit hashes.
@@ -1200,6 +1392,17 @@ func PrepareRepo(ctx context.Context, uriString string) (string, bool, error) {
 	return path, remote, nil
 }
 
+// gitLink returns a web link to the file at the commit, or an empty string if
+// the repository isn't hosted by a known provider. Staged changes have no
+// commit to link to.
+func gitLink(repository, commit, file string, line int64) string {
+	repo, ok := giturl.WebRepoURL(repository)
+	if !ok || commit == "" || commit == "Staged" {
+		return ""
+	}
+	return giturl.GenerateLink(repo, commit, file, line)
+}
+
 // getSafeRemoteURL is a helper function that will attempt to get a safe URL first
 // from the preferred remote name, falling back to the first remote name
 // available, or an empty string if there are no remotes.
@@ -1260,7 +1463,11 @@ func (s *Git) handleBinary(ctx context.Context, gitDir string, reporter sources.
 		return fmt.Errorf("error starting git cat-file: %w\n%s", err, stderr.Bytes())
 	}
 
-	return handlers.HandleFile(fileCtx, stdout, chunkSkel, reporter, handlers.WithSkipArchives(s.skipArchives))
+	return handlers.HandleFile(fileCtx, stdout, chunkSkel, reporter,
+		handlers.WithSkipArchives(s.skipArchives),
+		handlers.WithMaxArchiveDepth(s.maxArchiveDepth),
+		handlers.WithMaxArchiveDecompressedSize(s.maxArchiveSize),
+	)
 }
 
 func (s *Source) Enumerate(ctx context.Context, reporter sources.UnitReporter) error {
@@ -3,6 +3,10 @@ package git
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 
@@ -588,3 +592,310 @@ func TestChunkUnit(t *testing.T) {
 	assert.Equal(t, 22, len(reporter.Chunks))
 	assert.Equal(t, 1, len(reporter.ChunkErrs))
 }
+
+// createBranchedRepo creates a repository with two branches that share history.
+// It returns the repository path and the commit hashes keyed by name.
+//
+//	main:    c1 - c2 ------- c3
+//	                \          \
+//	feature:         f1 ------ m1 - f2
+func createBranchedRepo(t *testing.T) (string, map[string]string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	run := func(date string, args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date,
+		)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	commit := func(date, file string) string {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(file), 0644); err != nil {
+			t.Fatal(err)
+		}
+		run(date, "add", file)
+		run(date, "commit", "-m", file)
+		return run(date, "rev-parse", "HEAD")
+	}
+
+	commits := make(map[string]string)
+	run("", "init", "-b", "main")
+	commits["c1"] = commit("2024-01-01T00:00:00Z", "c1")
+	commits["c2"] = commit("2024-01-02T00:00:00Z", "c2")
+	run("", "checkout", "-b", "feature")
+	commits["f1"] = commit("2024-01-03T00:00:00Z", "f1")
+	run("", "checkout", "main")
+	commits["c3"] = commit("2024-01-04T00:00:00Z", "c3")
+	run("", "checkout", "feature")
+	run("2024-01-05T00:00:00Z", "merge", "--no-ff", "-m", "m1", "main")
+	commits["m1"] = run("", "rev-parse", "HEAD")
+	commits["f2"] = commit("2024-01-06T00:00:00Z", "f2")
+
+	return dir, commits
+}
+
+func TestSource_MaxDepthPerBranch(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	repoPath, commits := createBranchedRepo(t)
+
+	// The merge commit m1 counts towards the depth of the feature branch, but
+	// it has no changes of its own, so it never produces a chunk.
+	tests := []struct {
+		name        string
+		maxDepth    int64
+		wantCommits []string
+	}{
+		{
+			name:        "depth 1",
+			maxDepth:    1,
+			wantCommits: []string{"c3", "f2"},
+		},
+		{
+			name:        "depth 2",
+			maxDepth:    2,
+			wantCommits: []string{"c3", "c2", "f2"},
+		},
+		{
+			// c3 is within depth of both branches but is only scanned once.
+			name:        "overlapping history",
+			maxDepth:    3,
+			wantCommits: []string{"c3", "c2", "c1", "f2"},
+		},
+		{
+			name:        "depth exceeds history",
+			maxDepth:    10,
+			wantCommits: []string{"c1", "c2", "c3", "f1", "f2"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn, err := anypb.New(&sourcespb.Git{
+				Directories:       []string{repoPath},
+				MaxDepthPerBranch: tt.maxDepth,
+			})
+			assert.NoError(t, err)
+
+			s := Source{}
+			err = s.Init(ctx, "test max depth per branch", 0, 0, false, conn, 1)
+			assert.NoError(t, err)
+
+			reporter := sourcestest.TestReporter{}
+			err = s.ChunkUnit(ctx, SourceUnit{ID: repoPath, Kind: UnitDir}, &reporter)
+			assert.NoError(t, err)
+			assert.Empty(t, reporter.ChunkErrs)
+
+			// Each commit emits a single chunk for its metadata.
+			scanned := make(map[string]int)
+			for _, chunk := range reporter.Chunks {
+				if md := chunk.SourceMetadata.GetGit(); md.GetFile() == "" {
+					scanned[md.GetCommit()]++
+				}
+			}
+
+			want := make(map[string]int, len(tt.wantCommits))
+			for _, name := range tt.wantCommits {
+				want[commits[name]] = 1
+			}
+			assert.Equal(t, want, scanned)
+		})
+	}
+}
+
+func TestSource_PathAllowlist(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	repoPath := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repoPath}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	run("init", "-b", "main")
+	for _, file := range []string{"main.go", "vendor/lib/lib.go", "app.min.js", "static/app.min.js"} {
+		path := filepath.Join(repoPath, file)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(file), 0644))
+	}
+	run("add", ".")
+	run("commit", "-m", "initial")
+
+	conn, err := anypb.New(&sourcespb.Git{
+		Directories:   []string{repoPath},
+		PathAllowlist: []string{"vendor/**", "**/*.min.js"},
+	})
+	assert.NoError(t, err)
+
+	s := Source{}
+	err = s.Init(ctx, "test path allowlist", 0, 0, false, conn, 1)
+	assert.NoError(t, err)
+
+	reporter := sourcestest.TestReporter{}
+	err = s.ChunkUnit(ctx, SourceUnit{ID: repoPath, Kind: UnitDir}, &reporter)
+	assert.NoError(t, err)
+	assert.Empty(t, reporter.ChunkErrs)
+
+	var scanned []string
+	for _, chunk := range reporter.Chunks {
+		if file := chunk.SourceMetadata.GetGit().GetFile(); file != "" {
+			scanned = append(scanned, file)
+		}
+	}
+	assert.Equal(t, []string{"main.go"}, scanned)
+}
+
+func TestSource_IgnoreFiles(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	repoPath := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repoPath}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	write := func(files map[string]string) {
+		for file, content := range files {
+			path := filepath.Join(repoPath, file)
+			assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		}
+	}
+	run("init", "-b", "main")
+	write(map[string]string{
+		"main.go":                "main",
+		"build/out.txt":          "out",
+		"test/fixture.txt":       "fixture",
+		"test/keep.txt":          "keep",
+		"docs/fixture.txt":       "docs",
+		".trufflehogignore":      "# generated\nbuild/\n",
+		"test/.trufflehogignore": "*.txt\n!keep.txt\n",
+	})
+	run("add", ".")
+	run("commit", "-m", "initial")
+	// The files of earlier commits are ignored too.
+	write(map[string]string{"build/out.txt": "new out", "main.go": "new main"})
+	run("commit", "-am", "update")
+
+	tests := []struct {
+		name        string
+		ignoreFiles bool
+		want        []string
+	}{
+		{
+			name:        "ignore files",
+			ignoreFiles: true,
+			want:        []string{".trufflehogignore", "docs/fixture.txt", "main.go", "test/.trufflehogignore", "test/keep.txt"},
+		},
+		{
+			name: "disabled",
+			want: []string{".trufflehogignore", "build/out.txt", "docs/fixture.txt", "main.go", "test/.trufflehogignore", "test/fixture.txt", "test/keep.txt"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn, err := anypb.New(&sourcespb.Git{
+				Directories:    []string{repoPath},
+				UseIgnoreFiles: tt.ignoreFiles,
+			})
+			assert.NoError(t, err)
+
+			s := Source{}
+			err = s.Init(ctx, "test ignore files", 0, 0, false, conn, 1)
+			assert.NoError(t, err)
+
+			reporter := sourcestest.TestReporter{}
+			err = s.ChunkUnit(ctx, SourceUnit{ID: repoPath, Kind: UnitDir}, &reporter)
+			assert.NoError(t, err)
+			assert.Empty(t, reporter.ChunkErrs)
+
+			scanned := make(map[string]struct{})
+			for _, chunk := range reporter.Chunks {
+				if file := chunk.SourceMetadata.GetGit().GetFile(); file != "" {
+					scanned[file] = struct{}{}
+				}
+			}
+			got := make([]string, 0, len(scanned))
+			for file := range scanned {
+				got = append(got, file)
+			}
+			sort.Strings(got)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSource_Link(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	repoPath := t.TempDir()
+	run := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", repoPath}, args...)...)
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	run("init", "-b", "main")
+	run("remote", "add", "origin", "git@github.com:org/repo.git")
+	assert.NoError(t, os.WriteFile(filepath.Join(repoPath, "config.yaml"), []byte("key: value\n"), 0644))
+	run("add", ".")
+	run("commit", "-m", "initial")
+	commit := run("rev-parse", "HEAD")
+
+	conn, err := anypb.New(&sourcespb.Git{Directories: []string{repoPath}})
+	assert.NoError(t, err)
+
+	s := Source{}
+	err = s.Init(ctx, "test link", 0, 0, false, conn, 1)
+	assert.NoError(t, err)
+
+	reporter := sourcestest.TestReporter{}
+	err = s.ChunkUnit(ctx, SourceUnit{ID: repoPath, Kind: UnitDir}, &reporter)
+	assert.NoError(t, err)
+	assert.Empty(t, reporter.ChunkErrs)
+
+	links := make(map[string]string)
+	for _, chunk := range reporter.Chunks {
+		md := chunk.SourceMetadata.GetGit()
+		links[md.GetFile()] = md.GetLink()
+		assert.Equal(t, md.GetLink(), sources.SourceLink(chunk.SourceMetadata))
+	}
+	assert.Equal(t, map[string]string{
+		"":            "https://github.com/org/repo/commit/" + commit,
+		"config.yaml": "https://github.com/org/repo/blob/" + commit + "/config.yaml#L1",
+	}, links)
+}
@@ -3,16 +3,30 @@ package git
 import (
 	"github.com/go-git/go-git/v5"
 	"github.com/trufflesecurity/trufflehog/v3/pkg/common"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/glob"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/common/ignore"
 )
 
 type ScanOptions struct {
-	Filter       *common.Filter
-	BaseHash     string // When scanning a git.Log, this is the oldest/first commit.
-	HeadHash     string
-	MaxDepth     int64
-	Bare         bool
-	ExcludeGlobs []string
-	LogOptions   *git.LogOptions
+	Filter   *common.Filter
+	BaseHash string // When scanning a git.Log, this is the oldest/first commit.
+	HeadHash string
+	MaxDepth int64
+	// MaxDepthPerBranch limits the scan to the most recent commits of each branch.
+	// Commits reachable from multiple branches are only scanned once.
+	MaxDepthPerBranch int64
+	Bare              bool
+	ExcludeGlobs      []string
+	// PathAllowlist skips files whose path matches one of its globs.
+	PathAllowlist *glob.Filter
+	// IgnoreFiles skips the paths listed by the .trufflehogignore files of the
+	// head commit, or of HEAD if it isn't set.
+	IgnoreFiles bool
+	LogOptions  *git.LogOptions
+
+	// ignored matches the paths listed by the ignore files of the scanned
+	// repository. ScanRepo sets it on a copy of the options.
+	ignored *ignore.Matcher
 }
 
 type ScanOption func(*ScanOptions)
@@ -23,6 +37,18 @@ func ScanOptionFilter(filter *common.Filter) ScanOption {
 	}
 }
 
+func ScanOptionPathAllowlist(allowlist *glob.Filter) ScanOption {
+	return func(scanOptions *ScanOptions) {
+		scanOptions.PathAllowlist = allowlist
+	}
+}
+
+func ScanOptionIgnoreFiles(ignoreFiles bool) ScanOption {
+	return func(scanOptions *ScanOptions) {
+		scanOptions.IgnoreFiles = ignoreFiles
+	}
+}
+
 func ScanOptionBaseHash(hash string) ScanOption {
 	return func(scanOptions *ScanOptions) {
 		scanOptions.BaseHash = hash
@@ -41,6 +67,12 @@ func ScanOptionMaxDepth(maxDepth int64) ScanOption {
 	}
 }
 
+func ScanOptionMaxDepthPerBranch(maxDepth int64) ScanOption {
+	return func(scanOptions *ScanOptions) {
+		scanOptions.MaxDepthPerBranch = maxDepth
+	}
+}
+
 func ScanOptionExcludeGlobs(globs []string) ScanOption {
 	return func(scanOptions *ScanOptions) {
 		scanOptions.ExcludeGlobs = globs
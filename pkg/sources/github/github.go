@@ -84,6 +84,7 @@ type Source struct {
 
 	sources.Progress
 	sources.CommonSourceUnitUnmarshaller
+	sources.NoopCloser
 }
 
 // WithCustomContentWriter sets the useCustomContentWriter flag on the source.
@@ -708,6 +709,12 @@ func (s *Source) scan(ctx context.Context, installationClient *github.Client, ch
 				return nil
 			}
 
+			release, err := sources.AcquireWorker(ctx)
+			if err != nil {
+				return nil
+			}
+			defer release()
+
 			// TODO: set progress complete is being called concurrently with i
 			s.setProgressCompleteWithRepo(i, progressIndexOffset, repoURL)
 			// Ensure the repo is removed from the resume info after being scanned.
@@ -56,6 +56,7 @@ type Source struct {
 
 	jobPool *errgroup.Group
 	sources.CommonSourceUnitUnmarshaller
+	sources.NoopCloser
 }
 
 // WithCustomContentWriter sets the useCustomContentWriter flag on the source.
@@ -571,6 +572,12 @@ func (s *Source) scanRepos(ctx context.Context, chunksChan chan *sources.Chunk)
 				return nil
 			}
 
+			release, err := sources.AcquireWorker(ctx)
+			if err != nil {
+				return nil
+			}
+			defer release()
+
 			s.setProgressCompleteWithRepo(i, progressIndexOffset, repoURL)
 			// Ensure the repo is removed from the resume info after being scanned.
 			defer func(s *Source) {
@@ -581,7 +588,6 @@ func (s *Source) scanRepos(ctx context.Context, chunksChan chan *sources.Chunk)
 
 			var path string
 			var repo *gogit.Repository
-			var err error
 			if s.authMethod == "UNAUTHENTICATED" {
 				path, repo, err = git.CloneRepoUsingUnauthenticated(ctx, repoURL)
 			} else {
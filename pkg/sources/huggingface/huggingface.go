@@ -82,6 +82,7 @@ type Source struct {
 
 	sources.Progress
 	sources.CommonSourceUnitUnmarshaller
+	sources.NoopCloser
 }
 
 // Ensure the Source satisfies the interfaces at compile time
@@ -526,6 +527,12 @@ func (s *Source) scanRepos(ctx context.Context, chunksChan chan *sources.Chunk,
 				return nil
 			}
 
+			release, err := sources.AcquireWorker(ctx)
+			if err != nil {
+				return nil
+			}
+			defer release()
+
 			// TODO: set progress complete is being called concurrently with i
 			s.setProgressCompleteWithRepo(i, progressIndexOffset, repoURL, resourceType, repos)
 			// Ensure the repo is removed from the resume info after being scanned.
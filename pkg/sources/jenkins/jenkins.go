@@ -39,6 +39,7 @@ type Source struct {
 	log      logr.Logger
 	client   *http.Client
 	sources.Progress
+	sources.NoopCloser
 }
 
 type header struct {
@@ -225,6 +225,11 @@ func (jp *JobProgress) ReportChunk(unit SourceUnit, chunk *Chunk) {
 	jp.metricsLock.Lock()
 	jp.metrics.TotalChunks++
 	jp.metricsLock.Unlock()
+	// Every chunk a source produces is progress, whether or not the source
+	// marks its own activity.
+	if jp.progress != nil {
+		jp.progress.MarkActivity()
+	}
 	jp.executeHooks(func(hook JobProgressHook) { hook.ReportChunk(jp.Ref(), unit, chunk) })
 }
 func (jp *JobProgress) StartUnitChunking(unit SourceUnit, start time.Time) {
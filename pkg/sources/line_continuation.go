@@ -0,0 +1,115 @@
+package sources
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+)
+
+// maxContinuedLineSize caps the size of the lines joined by
+// lineContinuationReader. Longer lines are read as is, without joining the
+// rest of their continuations.
+const maxContinuedLineSize = TotalChunkSize
+
+// lineContinuationReader joins the lines that are continued with a trailing
+// backslash, as some config formats wrap long values, so secrets split
+// across lines are found whole by detectors reading one line at a time.
+// The leading spaces and tabs of the continuation lines are dropped.
+//
+// The joined data keeps the size and line count of the original data: the
+// newlines and other bytes removed from a continued line are moved to its
+// end. Secrets in a continued line are therefore reported on the line it
+// starts on, and the offsets of the data past it are preserved.
+type lineContinuationReader struct {
+	r   *bufio.Reader
+	buf bytes.Buffer
+	err error
+}
+
+func newLineContinuationReader(r io.Reader) *lineContinuationReader {
+	return &lineContinuationReader{r: bufio.NewReader(r)}
+}
+
+func (r *lineContinuationReader) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 && r.err == nil {
+		r.readLine()
+	}
+	if r.buf.Len() > 0 {
+		return r.buf.Read(p)
+	}
+	return 0, r.err
+}
+
+// readLine reads a line and its continuations into the buffer, joined.
+func (r *lineContinuationReader) readLine() {
+	var (
+		line []byte
+		// removed is the number of bytes removed from the line, other than
+		// newlines, and newlines the number of newlines removed.
+		removed, newlines int
+		// continued is set while reading a continuation line, until its
+		// leading spaces and tabs are dropped.
+		continued bool
+	)
+	for {
+		segment, err := r.r.ReadSlice('\n')
+		if continued {
+			trimmed := bytes.TrimLeft(segment, " \t")
+			removed += len(segment) - len(trimmed)
+			segment = trimmed
+			continued = len(segment) == 0 && errors.Is(err, bufio.ErrBufferFull)
+		}
+		line = append(line, segment...)
+		if errors.Is(err, bufio.ErrBufferFull) && len(line) < maxContinuedLineSize {
+			continue
+		}
+		if err != nil && !errors.Is(err, bufio.ErrBufferFull) {
+			r.err = err
+			break
+		}
+
+		n := continuationSize(line)
+		if n == 0 || len(line) >= maxContinuedLineSize {
+			break
+		}
+		line = line[:len(line)-n]
+		removed += n - 1
+		newlines++
+		continued = true
+	}
+
+	// The removed newlines are moved right after the joined line, followed
+	// by as many spaces as the other bytes removed, and the line's own
+	// newline, if any.
+	end := len(line)
+	if bytes.HasSuffix(line, []byte("\r\n")) {
+		end -= 2
+	} else if bytes.HasSuffix(line, []byte("\n")) {
+		end--
+	}
+	r.buf.Write(line[:end])
+	r.buf.Write(bytes.Repeat([]byte("\n"), newlines))
+	r.buf.Write(bytes.Repeat([]byte(" "), removed))
+	r.buf.Write(line[end:])
+}
+
+// continuationSize returns the size of the backslash and newline that end a
+// continued line, or 0 if the line isn't continued. An escaped backslash,
+// i.e. an even number of backslashes, doesn't continue the line.
+func continuationSize(line []byte) int {
+	n := 1
+	body, ok := bytes.CutSuffix(line, []byte("\n"))
+	if !ok {
+		return 0
+	}
+	if trimmed, ok := bytes.CutSuffix(body, []byte("\r")); ok {
+		body = trimmed
+		n++
+	}
+	backslashes := len(body) - len(bytes.TrimRight(body, `\`))
+	if backslashes%2 == 0 {
+		return 0
+	}
+	return n + 1
+}
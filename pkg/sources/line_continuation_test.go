@@ -0,0 +1,94 @@
+package sources
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+func TestLineContinuationReader(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "no continuations",
+			input: "first\nsecond\n",
+			want:  "first\nsecond\n",
+		},
+		{
+			name:  "continued line",
+			input: "API_KEY=abc\\\ndef\nnext\n",
+			want:  "API_KEY=abcdef\n \nnext\n",
+		},
+		{
+			name:  "indented continuations",
+			input: "API_KEY=abc\\\n    def\\\n\tghi\nnext",
+			want:  "API_KEY=abcdefghi\n\n       \nnext",
+		},
+		{
+			name:  "crlf",
+			input: "API_KEY=abc\\\r\ndef\r\nnext\r\n",
+			want:  "API_KEY=abcdef\n  \r\nnext\r\n",
+		},
+		{
+			name:  "escaped backslash",
+			input: "path=C:\\\\\nnext\n",
+			want:  "path=C:\\\\\nnext\n",
+		},
+		{
+			name:  "continued last line",
+			input: "API_KEY=abc\\\n",
+			want:  "API_KEY=abc\n ",
+		},
+		{
+			name:  "trailing backslash without newline",
+			input: "API_KEY=abc\\",
+			want:  "API_KEY=abc\\",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := io.ReadAll(newLineContinuationReader(iotest.OneByteReader(strings.NewReader(tt.input))))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+			// The joined data keeps the size and lines of the original data.
+			assert.Equal(t, len(tt.input), len(got))
+			assert.Equal(t, strings.Count(tt.input, "\n"), strings.Count(string(got), "\n"))
+		})
+	}
+}
+
+func TestLineContinuationReader_LongLine(t *testing.T) {
+	// Continuations past maxContinuedLineSize aren't joined.
+	long := strings.Repeat("a", maxContinuedLineSize) + "\\\nb\n"
+	got, err := io.ReadAll(newLineContinuationReader(strings.NewReader(long)))
+	assert.NoError(t, err)
+	assert.Equal(t, long, string(got))
+}
+
+func TestChunkReader_WithLineContinuations(t *testing.T) {
+	input := "# credentials\nAPI_KEY=apikey_0123456789\\\n  abcdefghijklmn\nother=value\n"
+	chunkReader := NewChunkReader(WithLineContinuations())
+
+	var data []byte
+	for chunk := range chunkReader(context.Background(), strings.NewReader(input)) {
+		assert.NoError(t, chunk.Error())
+		data = append(data, chunk.Bytes()...)
+	}
+
+	before, _, found := bytes.Cut(data, []byte("apikey_0123456789abcdefghijklmn"))
+	assert.True(t, found)
+	// The secret is on the line the continued line starts on.
+	assert.Equal(t, 1, bytes.Count(before, []byte("\n")))
+	assert.Equal(t, len(input), len(data))
+	assert.True(t, bytes.HasSuffix(data, []byte("\nother=value\n")))
+}
@@ -0,0 +1,41 @@
+package sources
+
+import "bytes"
+
+// Position is where a chunk, or a secret found in it, starts within the data
+// it was read from, e.g. an object, a file, or an archive entry. It locates
+// findings the same way whatever their source type.
+type Position struct {
+	// Offset is the byte offset from the start of the data.
+	Offset int64
+	// Line and Column are the 1-based line and byte column, or 0 if the
+	// source doesn't track them.
+	Line   int64 `json:",omitempty"`
+	Column int64 `json:",omitempty"`
+}
+
+// StartPosition returns the position of the start of the data, on its first
+// line and column.
+func StartPosition() Position {
+	return Position{Line: 1, Column: 1}
+}
+
+// Advance returns the position n bytes into data, which starts at p. The line
+// and column are only advanced if they are tracked.
+func (p Position) Advance(data []byte, n int) Position {
+	n = min(n, len(data))
+	next := p
+	next.Offset += int64(n)
+	if p.Line == 0 {
+		return next
+	}
+
+	skipped := data[:n]
+	if lines := bytes.Count(skipped, []byte("\n")); lines > 0 {
+		next.Line += int64(lines)
+		next.Column = int64(n - bytes.LastIndexByte(skipped, '\n'))
+	} else {
+		next.Column += int64(n)
+	}
+	return next
+}
@@ -0,0 +1,38 @@
+package sources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPosition_Advance(t *testing.T) {
+	data := []byte("first\nsecond\n\nfourth")
+
+	tests := []struct {
+		name  string
+		start Position
+		n     int
+		want  Position
+	}{
+		{name: "none", start: StartPosition(), n: 0, want: Position{Offset: 0, Line: 1, Column: 1}},
+		{name: "same line", start: StartPosition(), n: 3, want: Position{Offset: 3, Line: 1, Column: 4}},
+		{name: "next line", start: StartPosition(), n: 8, want: Position{Offset: 8, Line: 2, Column: 3}},
+		{name: "after newline", start: StartPosition(), n: 6, want: Position{Offset: 6, Line: 2, Column: 1}},
+		{name: "empty line", start: StartPosition(), n: 14, want: Position{Offset: 14, Line: 4, Column: 1}},
+		{name: "past end", start: StartPosition(), n: 100, want: Position{Offset: 20, Line: 4, Column: 7}},
+		{
+			name:  "from position",
+			start: Position{Offset: 1000, Line: 10, Column: 5},
+			n:     3,
+			want:  Position{Offset: 1003, Line: 10, Column: 8},
+		},
+		{name: "untracked lines", start: Position{Offset: 1000}, n: 8, want: Position{Offset: 1008}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.start.Advance(data, tt.n))
+		})
+	}
+}
@@ -51,6 +51,7 @@ type Source struct {
 
 	sources.Progress
 	sources.CommonSourceUnitUnmarshaller
+	sources.NoopCloser
 }
 
 func (s *Source) addKeywords(keywords []string) {
@@ -0,0 +1,117 @@
+package s3
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+const (
+	// defaultRestorePollInterval is how often the objects whose restore was
+	// requested are checked.
+	defaultRestorePollInterval = time.Minute
+	// defaultRestoreTimeout is how long to wait for the objects of a bucket
+	// to be restored, enough for the standard retrieval of DEEP_ARCHIVE.
+	defaultRestoreTimeout = 12 * time.Hour
+	// restoreDays is the number of days restored copies are kept for.
+	restoreDays = 1
+)
+
+// objectRestorer is the part of the S3 API used to restore archived objects.
+type objectRestorer interface {
+	HeadObjectWithContext(ctx aws.Context, input *s3.HeadObjectInput, opts ...request.Option) (*s3.HeadObjectOutput, error)
+	RestoreObjectWithContext(ctx aws.Context, input *s3.RestoreObjectInput, opts ...request.Option) (*s3.RestoreObjectOutput, error)
+}
+
+// isArchived returns true if objects of the storage class can't be read until
+// they are restored.
+func isArchived(storageClass string) bool {
+	return storageClass == s3.ObjectStorageClassGlacier || storageClass == s3.ObjectStorageClassDeepArchive
+}
+
+// isRestored returns whether the restored copy of an archived object can be
+// read, and whether its restore was requested, from its x-amz-restore header,
+// e.g. ongoing-request="false", expiry-date="Fri, 21 Dec 2012 00:00:00 GMT".
+func isRestored(ctx context.Context, client objectRestorer, bucket string, obj *s3.Object) (restored, requested bool, err error) {
+	head, err := client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{Bucket: &bucket, Key: obj.Key})
+	if err != nil {
+		return false, false, fmt.Errorf("could not get the restore status of the object: %w", err)
+	}
+	if head.Restore == nil {
+		return false, false, nil
+	}
+	return strings.Contains(*head.Restore, `ongoing-request="false"`), true, nil
+}
+
+// requestRestore requests the restore of an archived object, unless it was
+// already requested, and returns whether it is already restored.
+func requestRestore(ctx context.Context, client objectRestorer, bucket string, obj *s3.Object) (bool, error) {
+	restored, requested, err := isRestored(ctx, client, bucket, obj)
+	if err != nil || requested {
+		return restored, err
+	}
+
+	_, err = client.RestoreObjectWithContext(ctx, &s3.RestoreObjectInput{
+		Bucket: &bucket,
+		Key:    obj.Key,
+		RestoreRequest: &s3.RestoreRequest{
+			Days:                 aws.Int64(restoreDays),
+			GlacierJobParameters: &s3.GlacierJobParameters{Tier: aws.String(s3.TierStandard)},
+		},
+	})
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) && awsErr.Code() == "RestoreAlreadyInProgress" {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not request the restore of the object: %w", err)
+	}
+	return false, nil
+}
+
+// waitForRestores polls the archived objects whose restore was requested
+// until they are restored, and scans each of them once it is. The objects
+// that aren't restored within the restore timeout are skipped.
+func (s *Source) waitForRestores(ctx context.Context, client objectRestorer, bucket string, pending []*s3.Object, scan func(*s3.Object)) {
+	if len(pending) == 0 {
+		return
+	}
+	s.log.V(2).Info("waiting for archived objects to be restored", "bucket", bucket, "objects", len(pending))
+
+	deadline := time.Now().Add(s.restoreTimeout)
+	for len(pending) > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.restorePollInterval):
+		}
+
+		remaining := pending[:0]
+		for _, obj := range pending {
+			restored, _, err := isRestored(ctx, client, bucket, obj)
+			if err != nil {
+				s.log.Error(err, "skipping archived object", "bucket", bucket, "object", *obj.Key)
+				continue
+			}
+			if !restored {
+				remaining = append(remaining, obj)
+				continue
+			}
+			scan(obj)
+		}
+		pending = remaining
+
+		if len(pending) > 0 && time.Now().After(deadline) {
+			s.log.Info("skipping archived objects that weren't restored in time", "bucket", bucket, "objects", len(pending), "timeout", s.restoreTimeout)
+			return
+		}
+	}
+}
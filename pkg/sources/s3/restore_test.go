@@ -0,0 +1,124 @@
+package s3
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// fakeRestorer is an objectRestorer that reports the x-amz-restore header of
+// the objects, and records the restores requested.
+type fakeRestorer struct {
+	mu         sync.Mutex
+	restore    map[string]string
+	restoreErr error
+	requested  []string
+}
+
+func (f *fakeRestorer) HeadObjectWithContext(_ aws.Context, input *s3.HeadObjectInput, _ ...request.Option) (*s3.HeadObjectOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	header, ok := f.restore[*input.Key]
+	if !ok {
+		return &s3.HeadObjectOutput{}, nil
+	}
+	return &s3.HeadObjectOutput{Restore: aws.String(header)}, nil
+}
+
+func (f *fakeRestorer) RestoreObjectWithContext(_ aws.Context, input *s3.RestoreObjectInput, _ ...request.Option) (*s3.RestoreObjectOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requested = append(f.requested, *input.Key)
+	if f.restoreErr != nil {
+		return nil, f.restoreErr
+	}
+	f.restore[*input.Key] = `ongoing-request="true"`
+	return &s3.RestoreObjectOutput{}, nil
+}
+
+func (f *fakeRestorer) setRestored(key string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.restore[key] = `ongoing-request="false", expiry-date="Fri, 21 Dec 2012 00:00:00 GMT"`
+}
+
+func TestRequestRestore(t *testing.T) {
+	tests := []struct {
+		name          string
+		header        string
+		restoreErr    error
+		wantRestored  bool
+		wantRequested bool
+		wantErr       bool
+	}{
+		{name: "not requested", wantRequested: true},
+		{name: "ongoing", header: `ongoing-request="true"`},
+		{name: "restored", header: `ongoing-request="false", expiry-date="Fri, 21 Dec 2012 00:00:00 GMT"`, wantRestored: true},
+		{
+			name:          "already in progress",
+			restoreErr:    awserr.New("RestoreAlreadyInProgress", "Object restore is already in progress", nil),
+			wantRequested: true,
+		},
+		{
+			name:          "access denied",
+			restoreErr:    awserr.New("AccessDenied", "Access Denied", nil),
+			wantRequested: true,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeRestorer{restore: map[string]string{}, restoreErr: tt.restoreErr}
+			if tt.header != "" {
+				client.restore["archive.txt"] = tt.header
+			}
+
+			obj := &s3.Object{Key: aws.String("archive.txt"), StorageClass: aws.String(s3.ObjectStorageClassDeepArchive)}
+			restored, err := requestRestore(context.Background(), client, "bucket", obj)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantRestored, restored)
+			if tt.wantRequested {
+				assert.Equal(t, []string{"archive.txt"}, client.requested)
+			} else {
+				assert.Empty(t, client.requested)
+			}
+		})
+	}
+}
+
+func TestSource_WaitForRestores(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s := Source{restorePollInterval: time.Millisecond, restoreTimeout: 100 * time.Millisecond}
+	s.log = ctx.Logger()
+	client := &fakeRestorer{restore: map[string]string{
+		"restored.txt": `ongoing-request="true"`,
+		"archived.txt": `ongoing-request="true"`,
+	}}
+	pending := []*s3.Object{{Key: aws.String("restored.txt")}, {Key: aws.String("archived.txt")}}
+
+	// Only the objects restored before the timeout are scanned.
+	var scanned []string
+	s.waitForRestores(ctx, client, "bucket", pending, func(obj *s3.Object) {
+		scanned = append(scanned, *obj.Key)
+	})
+	assert.Empty(t, scanned)
+
+	pending = []*s3.Object{{Key: aws.String("restored.txt")}, {Key: aws.String("archived.txt")}}
+	client.setRestored("restored.txt")
+	s.waitForRestores(ctx, client, "bucket", pending, func(obj *s3.Object) {
+		scanned = append(scanned, *obj.Key)
+	})
+	assert.Equal(t, []string{"restored.txt"}, scanned)
+	assert.Empty(t, client.requested)
+}
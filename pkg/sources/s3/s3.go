@@ -49,7 +49,12 @@ type Source struct {
 	conn          *sourcespb.S3
 	jobPool       *errgroup.Group
 	maxObjectSize int64
+	// restorePollInterval and restoreTimeout are how often and for how long
+	// archived objects are checked once their restore was requested.
+	restorePollInterval time.Duration
+	restoreTimeout      time.Duration
 	sources.CommonSourceUnitUnmarshaller
+	sources.NoopCloser
 }
 
 // Ensure the Source satisfies the interfaces at compile time
@@ -92,6 +97,8 @@ func (s *Source) Init(aCtx context.Context, name string, jobId sources.JobID, so
 	s.conn = &conn
 
 	s.setMaxObjectSize(conn.GetMaxObjectSize())
+	s.restorePollInterval = defaultRestorePollInterval
+	s.restoreTimeout = defaultRestoreTimeout
 
 	if len(conn.Buckets) > 0 && len(conn.IgnoreBuckets) > 0 {
 		return fmt.Errorf("either a bucket include list or a bucket ignore list can be specified, but not both")
@@ -220,13 +227,21 @@ func (s *Source) scanBuckets(ctx context.Context, client *s3.S3, role string, bu
 
 		errorCount := sync.Map{}
 
+		var pending []*s3.Object
 		err = regionalClient.ListObjectsV2PagesWithContext(
 			ctx, &s3.ListObjectsV2Input{Bucket: &bucket},
 			func(page *s3.ListObjectsV2Output, last bool) bool {
-				s.pageChunker(ctx, regionalClient, chunksChan, bucket, page, &errorCount, i+1, &objectCount)
+				pending = append(pending, s.pageChunker(ctx, regionalClient, chunksChan, bucket, page, &errorCount, i+1, &objectCount)...)
 				return true
 			})
 
+		// The archived objects are scanned once the rest of the bucket is,
+		// as they take hours to be restored.
+		s.waitForRestores(ctx, regionalClient, bucket, pending, func(obj *s3.Object) {
+			s.scanObject(ctx, regionalClient, chunksChan, bucket, obj, &errorCount, i+1, &objectCount)
+		})
+		_ = s.jobPool.Wait()
+
 		if err != nil {
 			if role == "" {
 				logger.Error(err, "could not list objects in bucket")
@@ -270,22 +285,25 @@ func (s *Source) getRegionalClientForBucket(ctx context.Context, defaultRegionCl
 	return regionalClient, nil
 }
 
-// pageChunker emits chunks onto the given channel from a page
-func (s *Source) pageChunker(ctx context.Context, client *s3.S3, chunksChan chan *sources.Chunk, bucket string, page *s3.ListObjectsV2Output, errorCount *sync.Map, pageNumber int, objectCount *uint64) {
+// pageChunker emits chunks onto the given channel from a page. It returns the
+// archived objects whose restore was requested, which are scanned once they
+// are restored.
+func (s *Source) pageChunker(ctx context.Context, client *s3.S3, chunksChan chan *sources.Chunk, bucket string, page *s3.ListObjectsV2Output, errorCount *sync.Map, pageNumber int, objectCount *uint64) []*s3.Object {
+	var pending []*s3.Object
 	for _, obj := range page.Contents {
-		obj := obj
 		if common.IsDone(ctx) {
-			return
+			return pending
 		}
 
 		if obj == nil {
 			continue
 		}
 
-		// skip GLACIER and GLACIER_IR objects
-		if obj.StorageClass == nil || strings.Contains(*obj.StorageClass, "GLACIER") {
-			s.log.V(5).Info("Skipping object in storage class", "storage_class", *obj.StorageClass, "object", *obj.Key)
-			continue
+		// the key is scanned even if the object itself is skipped
+		if s.conn.GetScanPaths() {
+			if err := s.scanKey(ctx, client, chunksChan, bucket, obj); err != nil {
+				return pending
+			}
 		}
 
 		// ignore large files
@@ -306,100 +324,136 @@ func (s *Source) pageChunker(ctx context.Context, client *s3.S3, chunksChan chan
 			continue
 		}
 
-		s.jobPool.Go(func() error {
-			defer common.RecoverWithExit(ctx)
-
-			if strings.HasSuffix(*obj.Key, "/") {
-				s.log.V(5).Info("Skipping directory", "object", *obj.Key)
-				return nil
+		// GLACIER and DEEP_ARCHIVE objects must be restored to be read, and
+		// GLACIER_IR objects are billed to be read, so they are skipped
+		// unless archived objects are restored.
+		if obj.StorageClass == nil {
+			s.log.V(5).Info("Skipping object without storage class", "object", *obj.Key)
+			continue
+		}
+		if isArchived(*obj.StorageClass) && s.conn.GetRestoreArchivedObjects() {
+			restored, err := requestRestore(ctx, client, bucket, obj)
+			if err != nil {
+				s.log.Error(err, "Skipping archived object", "storage_class", *obj.StorageClass, "object", *obj.Key)
+				continue
 			}
+			if !restored {
+				s.log.V(5).Info("Waiting for archived object to be restored", "storage_class", *obj.StorageClass, "object", *obj.Key)
+				pending = append(pending, obj)
+				continue
+			}
+		} else if strings.Contains(*obj.StorageClass, "GLACIER") || isArchived(*obj.StorageClass) {
+			s.log.V(5).Info("Skipping object in storage class", "storage_class", *obj.StorageClass, "object", *obj.Key)
+			continue
+		}
+
+		s.scanObject(ctx, client, chunksChan, bucket, obj, errorCount, pageNumber, objectCount)
+	}
 
-			path := strings.Split(*obj.Key, "/")
-			prefix := strings.Join(path[:len(path)-1], "/")
+	_ = s.jobPool.Wait()
+	return pending
+}
+
+// scanObject reads an object in the job pool and emits its chunks onto the
+// given channel.
+func (s *Source) scanObject(ctx context.Context, client *s3.S3, chunksChan chan *sources.Chunk, bucket string, obj *s3.Object, errorCount *sync.Map, pageNumber int, objectCount *uint64) {
+	s.jobPool.Go(func() error {
+		defer common.RecoverWithExit(ctx)
+
+		release, err := sources.AcquireWorker(ctx)
+		if err != nil {
+			return nil
+		}
+		defer release()
+
+		if strings.HasSuffix(*obj.Key, "/") {
+			s.log.V(5).Info("Skipping directory", "object", *obj.Key)
+			return nil
+		}
+
+		path := strings.Split(*obj.Key, "/")
+		prefix := strings.Join(path[:len(path)-1], "/")
+
+		nErr, ok := errorCount.Load(prefix)
+		if !ok {
+			nErr = 0
+		}
+		if nErr.(int) > 3 {
+			s.log.V(2).Info("Skipped due to excessive errors", "object", *obj.Key)
+			return nil
+		}
+
+		// files break with spaces, must replace with +
+		// objKey := strings.ReplaceAll(*obj.Key, " ", "+")
+		ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+		defer cancel()
+		res, err := client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+			Bucket: &bucket,
+			Key:    obj.Key,
+		})
+		if err != nil {
+			if !strings.Contains(err.Error(), "AccessDenied") {
+				s.log.Error(err, "could not get S3 object", "object", *obj.Key)
+			}
 
 			nErr, ok := errorCount.Load(prefix)
 			if !ok {
 				nErr = 0
 			}
 			if nErr.(int) > 3 {
-				s.log.V(2).Info("Skipped due to excessive errors", "object", *obj.Key)
+				s.log.V(3).Info("Skipped due to excessive errors", "object", *obj.Key)
 				return nil
 			}
-
-			// files break with spaces, must replace with +
-			// objKey := strings.ReplaceAll(*obj.Key, " ", "+")
-			ctx, cancel := context.WithTimeout(ctx, time.Second*5)
-			defer cancel()
-			res, err := client.GetObjectWithContext(ctx, &s3.GetObjectInput{
-				Bucket: &bucket,
-				Key:    obj.Key,
-			})
-			if err != nil {
-				if !strings.Contains(err.Error(), "AccessDenied") {
-					s.log.Error(err, "could not get S3 object", "object", *obj.Key)
-				}
-
-				nErr, ok := errorCount.Load(prefix)
-				if !ok {
-					nErr = 0
-				}
-				if nErr.(int) > 3 {
-					s.log.V(3).Info("Skipped due to excessive errors", "object", *obj.Key)
-					return nil
-				}
-				nErr = nErr.(int) + 1
-				errorCount.Store(prefix, nErr)
-				// too many consecutive errors on this page
-				if nErr.(int) > 3 {
-					s.log.V(2).Info("Too many consecutive errors, excluding prefix", "prefix", prefix)
-				}
-				return nil
+			nErr = nErr.(int) + 1
+			errorCount.Store(prefix, nErr)
+			// too many consecutive errors on this page
+			if nErr.(int) > 3 {
+				s.log.V(2).Info("Too many consecutive errors, excluding prefix", "prefix", prefix)
 			}
+			return nil
+		}
 
-			email := "Unknown"
-			if obj.Owner != nil {
-				email = *obj.Owner.DisplayName
-			}
-			modified := obj.LastModified.String()
-			chunkSkel := &sources.Chunk{
-				SourceType: s.Type(),
-				SourceName: s.name,
-				SourceID:   s.SourceID(),
-				JobID:      s.JobID(),
-				SourceMetadata: &source_metadatapb.MetaData{
-					Data: &source_metadatapb.MetaData_S3{
-						S3: &source_metadatapb.S3{
-							Bucket:    bucket,
-							File:      sanitizer.UTF8(*obj.Key),
-							Link:      sanitizer.UTF8(makeS3Link(bucket, *client.Config.Region, *obj.Key)),
-							Email:     sanitizer.UTF8(email),
-							Timestamp: sanitizer.UTF8(modified),
-						},
+		email := "Unknown"
+		if obj.Owner != nil {
+			email = *obj.Owner.DisplayName
+		}
+		modified := obj.LastModified.String()
+		chunkSkel := &sources.Chunk{
+			SourceType: s.Type(),
+			SourceName: s.name,
+			SourceID:   s.SourceID(),
+			JobID:      s.JobID(),
+			SourceMetadata: &source_metadatapb.MetaData{
+				Data: &source_metadatapb.MetaData_S3{
+					S3: &source_metadatapb.S3{
+						Bucket:    bucket,
+						File:      sanitizer.UTF8(*obj.Key),
+						Link:      sanitizer.UTF8(makeS3Link(bucket, *client.Config.Region, *obj.Key)),
+						Email:     sanitizer.UTF8(email),
+						Timestamp: sanitizer.UTF8(modified),
 					},
 				},
-				Verify: s.verify,
-			}
-
-			if err := handlers.HandleFile(ctx, res.Body, chunkSkel, sources.ChanReporter{Ch: chunksChan}); err != nil {
-				ctx.Logger().Error(err, "error handling file")
-				return nil
-			}
-
-			atomic.AddUint64(objectCount, 1)
-			s.log.V(5).Info("S3 object scanned.", "object_count", objectCount, "page_number", pageNumber)
-			nErr, ok = errorCount.Load(prefix)
-			if !ok {
-				nErr = 0
-			}
-			if nErr.(int) > 0 {
-				errorCount.Store(prefix, 0)
-			}
+			},
+			Verify: s.verify,
+		}
 
+		if err := handlers.HandleFile(ctx, res.Body, chunkSkel, sources.ChanReporter{Ch: chunksChan}); err != nil {
+			ctx.Logger().Error(err, "error handling file")
 			return nil
-		})
-	}
+		}
 
-	_ = s.jobPool.Wait()
+		atomic.AddUint64(objectCount, 1)
+		s.log.V(5).Info("S3 object scanned.", "object_count", objectCount, "page_number", pageNumber)
+		nErr, ok = errorCount.Load(prefix)
+		if !ok {
+			nErr = 0
+		}
+		if nErr.(int) > 0 {
+			errorCount.Store(prefix, 0)
+		}
+
+		return nil
+	})
 }
 
 func (s *Source) validateBucketAccess(ctx context.Context, client *s3.S3, roleArn string, buckets []string) []error {
@@ -463,6 +517,30 @@ func (s *Source) visitRoles(ctx context.Context, f func(c context.Context, defau
 
 // S3 links currently have the general format of:
 // https://[bucket].s3[.region unless us-east-1].amazonaws.com/[key]
+// scanKey reports the key of an object as a chunk, so secrets in the keys of
+// objects are found. Its metadata has InPath set.
+func (s *Source) scanKey(ctx context.Context, client *s3.S3, chunksChan chan *sources.Chunk, bucket string, obj *s3.Object) error {
+	chunk := sources.Chunk{
+		SourceType: s.Type(),
+		SourceName: s.name,
+		SourceID:   s.SourceID(),
+		JobID:      s.JobID(),
+		SourceMetadata: &source_metadatapb.MetaData{
+			Data: &source_metadatapb.MetaData_S3{
+				S3: &source_metadatapb.S3{
+					Bucket: bucket,
+					File:   sanitizer.UTF8(*obj.Key),
+					Link:   sanitizer.UTF8(makeS3Link(bucket, *client.Config.Region, *obj.Key)),
+					InPath: true,
+				},
+			},
+		},
+		Data:   []byte(*obj.Key),
+		Verify: s.verify,
+	}
+	return sources.ChanReporter{Ch: chunksChan}.ChunkOk(ctx, chunk)
+}
+
 func makeS3Link(bucket, region, key string) string {
 	if region == "us-east-1" {
 		region = ""
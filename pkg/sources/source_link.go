@@ -0,0 +1,48 @@
+package sources
+
+import (
+	"net/url"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+// SourceLink returns a web URL to view where the metadata points to, e.g. the
+// GitHub blob URL of a file at a commit and line, or an empty string if there
+// is none. Most sources set the link field of their metadata, which is
+// returned as is. GCS objects link to the Cloud Console, as the links set by
+// the GCS source are API download links.
+func SourceLink(md *source_metadatapb.MetaData) string {
+	if md == nil {
+		return ""
+	}
+	if gcs := md.GetGcs(); gcs != nil {
+		return gcsConsoleLink(gcs.GetBucket(), gcs.GetFilename())
+	}
+
+	msg := md.ProtoReflect()
+	data := msg.WhichOneof(msg.Descriptor().Oneofs().ByName("data"))
+	if data == nil || data.Kind() != protoreflect.MessageKind {
+		return ""
+	}
+	meta := msg.Get(data).Message()
+	link := meta.Descriptor().Fields().ByName("link")
+	if link == nil || link.Kind() != protoreflect.StringKind {
+		return ""
+	}
+	return meta.Get(link).String()
+}
+
+// gcsConsoleLink returns the link to the details of an object in the Cloud Console.
+func gcsConsoleLink(bucket, object string) string {
+	if bucket == "" || object == "" {
+		return ""
+	}
+	segments := strings.Split(object, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return "https://console.cloud.google.com/storage/browser/_details/" + url.PathEscape(bucket) + "/" + strings.Join(segments, "/")
+}
@@ -0,0 +1,60 @@
+package sources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+)
+
+func TestSourceLink(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		md   *source_metadatapb.MetaData
+		want string
+	}{
+		{
+			name: "gcs object",
+			md: &source_metadatapb.MetaData{Data: &source_metadatapb.MetaData_Gcs{Gcs: &source_metadatapb.GCS{
+				Bucket:   "my-bucket",
+				Filename: "configs/prod #1.env",
+				Link:     "https://storage.googleapis.com/download/storage/v1/b/my-bucket/o/configs%2Fprod%20%231.env?alt=media",
+			}}},
+			want: "https://console.cloud.google.com/storage/browser/_details/my-bucket/configs/prod%20%231.env",
+		},
+		{
+			name: "git file",
+			md: &source_metadatapb.MetaData{Data: &source_metadatapb.MetaData_Git{Git: &source_metadatapb.Git{
+				Repository: "git@github.com:org/repo.git",
+				Commit:     "047b4a2ba42fc5b6c0bd535c5307434a666db5ec",
+				File:       "config.yaml",
+				Line:       4,
+				Link:       "https://github.com/org/repo/blob/047b4a2ba42fc5b6c0bd535c5307434a666db5ec/config.yaml#L4",
+			}}},
+			want: "https://github.com/org/repo/blob/047b4a2ba42fc5b6c0bd535c5307434a666db5ec/config.yaml#L4",
+		},
+		{
+			name: "github file",
+			md: &source_metadatapb.MetaData{Data: &source_metadatapb.MetaData_Github{Github: &source_metadatapb.Github{
+				Link: "https://github.com/org/repo/blob/047b4a2ba42fc5b6c0bd535c5307434a666db5ec/main.go#L10",
+			}}},
+			want: "https://github.com/org/repo/blob/047b4a2ba42fc5b6c0bd535c5307434a666db5ec/main.go#L10",
+		},
+		{
+			name: "metadata without link",
+			md:   &source_metadatapb.MetaData{Data: &source_metadatapb.MetaData_Syslog{Syslog: &source_metadatapb.Syslog{Hostname: "host"}}},
+		},
+		{
+			name: "no metadata",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SourceLink(tt.md))
+		})
+	}
+}
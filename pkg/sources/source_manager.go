@@ -27,6 +27,11 @@ type SourceManager struct {
 	wg          sync.WaitGroup
 	// Max number of units to scan concurrently per source.
 	concurrentUnits int
+	// Pool of workers shared by all running sources. Nil if sources only
+	// limit their own concurrency.
+	workerBudget *WorkerBudget
+	// Number of bytes the chunks of the sources overlap by.
+	chunkOverlap int
 	// Run the sources using source unit enumeration / chunking if available.
 	// Checked at runtime to allow feature flagging.
 	useSourceUnitsFunc func() bool
@@ -36,6 +41,11 @@ type SourceManager struct {
 	firstErr chan error
 	waitErr  error
 	done     bool
+	// Cancel functions of the running sources, and the cause they were
+	// cancelled with by Cancel, if any.
+	cancelMu    sync.Mutex
+	running     map[*JobProgress]context.CancelCauseFunc
+	cancelCause error
 }
 
 // apiClient is an interface for optionally communicating with an external API.
@@ -100,6 +110,23 @@ func WithConcurrentUnits(n int) func(*SourceManager) {
 	return func(mgr *SourceManager) { mgr.concurrentUnits = n }
 }
 
+// WithWorkerBudget limits the number of workers running concurrently across
+// all sources, rather than per source. Sources acquire workers with
+// AcquireWorker, and each unit being chunked uses one worker.
+func WithWorkerBudget(workers int) func(*SourceManager) {
+	return func(mgr *SourceManager) { mgr.workerBudget = NewWorkerBudget(workers) }
+}
+
+// WithChunkOverlap sets the number of bytes the chunks read by the sources
+// overlap by, which is PeekSize by default, so secrets that straddle the
+// boundary between two chunks are found whole if they're at most that long.
+// It applies to the chunk readers that don't set their own peek size, and must
+// be smaller than their chunk size, see ValidateChunkOverlap, or reading the
+// chunks fails.
+func WithChunkOverlap(overlap int) func(*SourceManager) {
+	return func(mgr *SourceManager) { mgr.chunkOverlap = overlap }
+}
+
 // The default channel size for all the channels that are used to transport chunks.
 const defaultChannelSize = 64
 
@@ -112,6 +139,8 @@ func NewManager(opts ...func(*SourceManager)) *SourceManager {
 		prioritySem:  semaphore.New(runtime.NumCPU()),
 		outputChunks: make(chan *Chunk, defaultChannelSize),
 		firstErr:     make(chan error, 1),
+		chunkOverlap: PeekSize,
+		running:      make(map[*JobProgress]context.CancelCauseFunc),
 	}
 	for _, opt := range opts {
 		opt(&mgr)
@@ -125,11 +154,13 @@ func (s *SourceManager) GetIDs(ctx context.Context, sourceName string, kind sour
 
 // Run blocks until a resource is available to run the source, then
 // asynchronously runs it. Error information is stored and accessible via the
-// JobProgressRef as it becomes available.
+// JobProgressRef as it becomes available. The source is closed once it has
+// finished running, or right away if it can't be run.
 func (s *SourceManager) Run(ctx context.Context, sourceName string, source Source, targets ...ChunkingTarget) (JobProgressRef, error) {
 	sourceID, jobID := source.SourceID(), source.JobID()
 	// Do preflight checks before waiting on the pool.
 	if err := s.preflightChecks(ctx); err != nil {
+		closeSource(ctx, source)
 		return JobProgressRef{
 			SourceName: sourceName,
 			SourceID:   sourceID,
@@ -143,8 +174,11 @@ func (s *SourceManager) Run(ctx context.Context, sourceName string, source Sourc
 	}
 	ctx, cancel := context.WithCancelCause(ctx)
 	progress := NewJobProgress(jobID, sourceID, sourceName, WithHooks(s.hooks...), WithCancel(cancel))
+	s.track(progress, cancel)
 	if err := sem.Acquire(ctx, 1); err != nil {
 		// Context cancelled.
+		closeSource(ctx, source)
+		s.untrack(progress)
 		progress.ReportError(Fatal{err})
 		return progress.Ref(), Fatal{err}
 	}
@@ -154,11 +188,13 @@ func (s *SourceManager) Run(ctx context.Context, sourceName string, source Sourc
 		defer progress.Finish()
 		defer sem.Release(1)
 		defer s.wg.Done()
+		defer s.untrack(progress)
 		ctx := context.WithValues(ctx,
 			"source_manager_worker_id", common.RandomID(5),
 		)
 		defer common.Recover(ctx)
 		defer cancel(nil)
+		defer closeSource(ctx, source)
 		if err := s.run(ctx, source, progress, targets...); err != nil {
 			select {
 			case s.firstErr <- err:
@@ -169,6 +205,48 @@ func (s *SourceManager) Run(ctx context.Context, sourceName string, source Sourc
 	return progress.Ref(), nil
 }
 
+// closeSource closes a source the manager is done with.
+func closeSource(ctx context.Context, source Source) {
+	if err := source.Close(); err != nil {
+		ctx.Logger().Error(err, "error closing source")
+	}
+}
+
+// Cancel cancels the running sources with the cause, and makes any later
+// calls to Run fail. The sources stop at their next check of their context,
+// and the chunks they already produced are still output.
+func (s *SourceManager) Cancel(cause error) {
+	s.cancelMu.Lock()
+	defer s.cancelMu.Unlock()
+
+	if s.cancelCause != nil {
+		return
+	}
+	s.cancelCause = cause
+	for _, cancel := range s.running {
+		cancel(cause)
+	}
+}
+
+// track registers the cancel function of a source that is about to run. If
+// the manager was already cancelled, the source is cancelled right away.
+func (s *SourceManager) track(progress *JobProgress, cancel context.CancelCauseFunc) {
+	s.cancelMu.Lock()
+	defer s.cancelMu.Unlock()
+
+	if s.cancelCause != nil {
+		cancel(s.cancelCause)
+		return
+	}
+	s.running[progress] = cancel
+}
+
+func (s *SourceManager) untrack(progress *JobProgress) {
+	s.cancelMu.Lock()
+	defer s.cancelMu.Unlock()
+	delete(s.running, progress)
+}
+
 // Chunks returns the read only channel of all the chunks produced by all of
 // the sources managed by this manager.
 func (s *SourceManager) Chunks() <-chan *Chunk {
@@ -242,6 +320,12 @@ func (s *SourceManager) preflightChecks(ctx context.Context) error {
 	if s.done {
 		return fmt.Errorf("manager is done")
 	}
+	s.cancelMu.Lock()
+	cause := s.cancelCause
+	s.cancelMu.Unlock()
+	if cause != nil {
+		return fmt.Errorf("manager is cancelled: %w", cause)
+	}
 	return ctx.Err()
 }
 
@@ -271,6 +355,10 @@ func (s *SourceManager) run(ctx context.Context, source Source, report *JobProgr
 	if ctx.Value("source_type") == "" {
 		ctx = context.WithValue(ctx, "source_type", source.Type().String())
 	}
+	if s.workerBudget != nil {
+		ctx = withWorkerBudget(ctx, s.workerBudget)
+	}
+	ctx = withChunkOverlap(ctx, s.chunkOverlap)
 
 	// Check for the preferred method of tracking source units.
 	canUseSourceUnits := len(targets) == 0 && s.useSourceUnitsFunc != nil
@@ -360,11 +448,18 @@ func (s *SourceManager) runWithUnits(ctx context.Context, source SourceUnitEnumC
 		}
 		// Consume units and produce chunks.
 		unitPool.Go(func() error {
-			report.StartUnitChunking(unit, time.Now())
 			// TODO: Catch panics and add to report.
 			defer close(chunkReporter.chunkCh)
+			release, err := AcquireWorker(ctx)
+			if err != nil {
+				// Context cancelled.
+				report.ReportError(ChunkError{Unit: unit, Err: err})
+				return nil
+			}
+			defer release()
+			report.StartUnitChunking(unit, time.Now())
 			id, kind := unit.SourceUnitID()
-			ctx := context.WithValues(ctx, "unit", id, "unit_kind", kind)
+			ctx := context.WithValues(withWorkerHeld(ctx), "unit", id, "unit_kind", kind)
 			ctx.Logger().V(3).Info("chunking unit")
 			if err := source.ChunkUnit(ctx, unit, chunkReporter); err != nil {
 				report.ReportError(Fatal{ChunkError{Unit: unit, Err: err}})
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -30,7 +32,9 @@ func (d *DummySource) Init(_ context.Context, _ string, jobID JobID, sourceID So
 	d.jobID = jobID
 	return nil
 }
-func (d *DummySource) GetProgress() *Progress { return nil }
+func (d *DummySource) GetProgress() *Progress  { return nil }
+func (d *DummySource) LastActivity() time.Time { return time.Time{} }
+func (d *DummySource) Close() error            { return nil }
 
 // Interface to easily test different chunking methods.
 type chunker interface {
@@ -302,6 +306,39 @@ func TestSourceManagerCancelRun(t *testing.T) {
 	assert.True(t, errors.Is(ref.Snapshot().FatalErrors(), cancelErr))
 }
 
+func TestSourceManagerCancel(t *testing.T) {
+	// The sources run until they're cancelled, so they must all run at once.
+	mgr := NewManager(WithBufferedOutput(8), WithConcurrentSources(3))
+	newSource := func() Source {
+		source, err := buildDummy(callbackChunker{func(ctx context.Context, _ chan *Chunk) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}})
+		assert.NoError(t, err)
+		return source
+	}
+
+	refs := make([]JobProgressRef, 3)
+	for i := range refs {
+		ref, err := mgr.Run(context.Background(), "dummy", newSource())
+		assert.NoError(t, err)
+		refs[i] = ref
+	}
+
+	// All the running sources are cancelled with the cause.
+	cancelErr := fmt.Errorf("out of time")
+	mgr.Cancel(cancelErr)
+	for _, ref := range refs {
+		<-ref.Done()
+		assert.ErrorIs(t, ref.Snapshot().FatalErrors(), cancelErr)
+	}
+
+	// Sources can't run anymore.
+	_, err := mgr.Run(context.Background(), "dummy", newSource())
+	assert.ErrorIs(t, err, cancelErr)
+	assert.Error(t, mgr.Wait())
+}
+
 func TestSourceManagerAvailableCapacity(t *testing.T) {
 	mgr := NewManager(WithConcurrentSources(1337))
 	start, end := make(chan struct{}), make(chan struct{})
@@ -448,3 +485,299 @@ func TestSourceManagerUnitHookNoUnits(t *testing.T) {
 	assert.NotZero(t, m.ElapsedTime())
 	assert.Equal(t, 0, len(m.Errors))
 }
+
+// budgetChunker runs count workers concurrently, both with and without units,
+// and records the highest number of workers running across all sources.
+type budgetChunker struct {
+	count    int
+	inFlight *inFlightTracker
+}
+
+type inFlightTracker struct {
+	current atomic.Int32
+	max     atomic.Int32
+}
+
+func (t *inFlightTracker) work() {
+	n := t.current.Add(1)
+	for {
+		m := t.max.Load()
+		if n <= m || t.max.CompareAndSwap(m, n) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	t.current.Add(-1)
+}
+
+func (c budgetChunker) Chunks(ctx context.Context, ch chan *Chunk, _ ...ChunkingTarget) error {
+	var wg sync.WaitGroup
+	for i := 0; i < c.count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			release, err := AcquireWorker(ctx)
+			if err != nil {
+				return
+			}
+			defer release()
+			c.inFlight.work()
+			ch <- &Chunk{Data: []byte{byte(i)}}
+		}(i)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (c budgetChunker) Enumerate(ctx context.Context, reporter UnitReporter) error {
+	for i := 0; i < c.count; i++ {
+		if err := reporter.UnitOk(ctx, countChunk(byte(i))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c budgetChunker) ChunkUnit(ctx context.Context, unit SourceUnit, reporter ChunkReporter) error {
+	// The unit already holds a worker, so this must not wait on the budget.
+	release, err := AcquireWorker(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	c.inFlight.work()
+	return reporter.ChunkOk(ctx, Chunk{Data: []byte{byte(unit.(countChunk))}})
+}
+
+func TestSourceManagerWorkerBudget(t *testing.T) {
+	const budget, workersPerSource = 3, 20
+
+	tests := []struct {
+		name string
+		opts []func(*SourceManager)
+	}{
+		{name: "without units"},
+		{name: "with units", opts: []func(*SourceManager){WithSourceUnits(), WithConcurrentUnits(workersPerSource)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]func(*SourceManager){
+				WithWorkerBudget(budget),
+				WithConcurrentSources(2),
+				WithBufferedOutput(2 * workersPerSource),
+			}, tt.opts...)
+			mgr := NewManager(opts...)
+
+			inFlight := new(inFlightTracker)
+			var refs []JobProgressRef
+			for i := 0; i < 2; i++ {
+				source, err := buildDummy(budgetChunker{count: workersPerSource, inFlight: inFlight})
+				assert.NoError(t, err)
+				ref, err := mgr.Run(context.Background(), "dummy", source)
+				assert.NoError(t, err)
+				refs = append(refs, ref)
+			}
+			for _, ref := range refs {
+				<-ref.Done()
+				assert.NoError(t, ref.Snapshot().FatalError())
+			}
+			assert.NoError(t, mgr.Wait())
+
+			var chunks int
+			for range mgr.Chunks() {
+				chunks++
+			}
+			assert.Equal(t, 2*workersPerSource, chunks)
+			assert.LessOrEqual(t, inFlight.max.Load(), int32(budget))
+			assert.Equal(t, 0, mgr.workerBudget.InUse())
+		})
+	}
+}
+
+func TestSourceManagerWorkerBudgetExistingSources(t *testing.T) {
+	// Sources that don't acquire workers themselves still run to completion,
+	// even with a budget smaller than the number of sources and units.
+	const numSources, count = 3, 10
+
+	tests := []struct {
+		name string
+		opts []func(*SourceManager)
+	}{
+		{name: "without units"},
+		{name: "with units", opts: []func(*SourceManager){WithSourceUnits(), WithConcurrentUnits(4)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]func(*SourceManager){
+				WithWorkerBudget(1),
+				WithConcurrentSources(numSources),
+				WithBufferedOutput(numSources * count),
+			}, tt.opts...)
+			mgr := NewManager(opts...)
+
+			var refs []JobProgressRef
+			for i := 0; i < numSources; i++ {
+				source, err := buildDummy(&counterChunker{count: count})
+				assert.NoError(t, err)
+				ref, err := mgr.Run(context.Background(), "dummy", source)
+				assert.NoError(t, err)
+				refs = append(refs, ref)
+			}
+			for _, ref := range refs {
+				select {
+				case <-ref.Done():
+				case <-time.After(5 * time.Second):
+					t.Fatal("source didn't finish under the worker budget")
+				}
+				assert.NoError(t, ref.Snapshot().FatalError())
+			}
+			assert.NoError(t, mgr.Wait())
+
+			var chunks int
+			for range mgr.Chunks() {
+				chunks++
+			}
+			assert.Equal(t, numSources*count, chunks)
+			assert.Equal(t, 0, mgr.workerBudget.InUse())
+		})
+	}
+}
+
+// closingSource is a DummySource that records how many times it was closed,
+// and how many times its resources were released.
+type closingSource struct {
+	DummySource
+	closes      atomic.Int32
+	releases    atomic.Int32
+	releaseOnce sync.Once
+}
+
+// release releases the resources of the source once, like the sources that
+// close their clients or listeners themselves.
+func (s *closingSource) release() {
+	s.releaseOnce.Do(func() { s.releases.Add(1) })
+}
+
+func (s *closingSource) Close() error {
+	s.closes.Add(1)
+	s.release()
+	return nil
+}
+
+func TestSourceManagerClose(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []func(*SourceManager)
+		chunker func(*closingSource) chunker
+	}{
+		{
+			name:    "chunks",
+			chunker: func(*closingSource) chunker { return &counterChunker{count: 2} },
+		},
+		{
+			name:    "units",
+			opts:    []func(*SourceManager){WithSourceUnits()},
+			chunker: func(*closingSource) chunker { return &counterChunker{count: 2} },
+		},
+		{
+			name:    "fatal error",
+			chunker: func(*closingSource) chunker { return errorChunker{fmt.Errorf("oops")} },
+		},
+		{
+			name: "closes itself",
+			chunker: func(s *closingSource) chunker {
+				return callbackChunker{func(context.Context, chan *Chunk) error {
+					s.release()
+					return nil
+				}}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := NewManager(append([]func(*SourceManager){WithBufferedOutput(8)}, tt.opts...)...)
+			source := new(closingSource)
+			source.chunker = tt.chunker(source)
+			assert.NoError(t, source.Init(context.Background(), "dummy", 123, 456, true, nil, 42))
+
+			ref, err := mgr.Run(context.Background(), "dummy", source)
+			assert.NoError(t, err)
+			<-ref.Done()
+
+			// The source manager closes the source once it finished running,
+			// and the resources of a source that closed itself aren't released
+			// again.
+			assert.Equal(t, int32(1), source.closes.Load())
+			assert.Equal(t, int32(1), source.releases.Load())
+		})
+	}
+}
+
+func TestSourceManagerClose_NotRun(t *testing.T) {
+	t.Run("manager done", func(t *testing.T) {
+		mgr := NewManager()
+		assert.NoError(t, mgr.Wait())
+
+		source := new(closingSource)
+		_, err := mgr.Run(context.Background(), "dummy", source)
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), source.closes.Load())
+	})
+
+	t.Run("cancelled waiting to run", func(t *testing.T) {
+		mgr := NewManager(WithConcurrentSources(1), WithBufferedOutput(8))
+
+		// Occupy the only slot until the test is done.
+		done := make(chan struct{})
+		defer close(done)
+		blocking := new(closingSource)
+		blocking.chunker = callbackChunker{func(context.Context, chan *Chunk) error {
+			<-done
+			return nil
+		}}
+		assert.NoError(t, blocking.Init(context.Background(), "blocking", 123, 456, true, nil, 42))
+		_, err := mgr.Run(context.Background(), "blocking", blocking)
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		source := new(closingSource)
+		_, err = mgr.Run(ctx, "dummy", source)
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), source.closes.Load())
+	})
+}
+
+// activitySource is a DummySource that tracks its progress.
+type activitySource struct {
+	DummySource
+	progress Progress
+}
+
+func (s *activitySource) GetProgress() *Progress  { return &s.progress }
+func (s *activitySource) LastActivity() time.Time { return s.progress.LastActivity() }
+
+func TestSourceManagerMarksActivity(t *testing.T) {
+	for name, opts := range map[string][]func(*SourceManager){
+		"chunks": nil,
+		"units":  {WithSourceUnits()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mgr := NewManager(append([]func(*SourceManager){WithBufferedOutput(8)}, opts...)...)
+			source := &activitySource{DummySource: DummySource{chunker: &counterChunker{count: 1}}}
+			assert.NoError(t, source.Init(context.Background(), "dummy", 123, 456, true, nil, 42))
+
+			start := time.Now()
+			ref, err := mgr.Run(context.Background(), "dummy", source)
+			assert.NoError(t, err)
+			<-ref.Done()
+
+			// The source doesn't mark its own activity, the manager marks it
+			// for every chunk.
+			assert.False(t, source.LastActivity().Before(start))
+		})
+	}
+}
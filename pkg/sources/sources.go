@@ -1,7 +1,12 @@
 package sources
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"google.golang.org/protobuf/types/known/anypb"
 
@@ -38,6 +43,9 @@ type Chunk struct {
 
 	// SourceMetadata holds the context of where the Chunk was found.
 	SourceMetadata *source_metadatapb.MetaData
+	// Position is where the Chunk starts within the data it was read from,
+	// if the source knows it.
+	Position *Position
 	// SourceType is the type of Source that produced the chunk.
 	SourceType sourcespb.SourceType
 
@@ -45,6 +53,48 @@ type Chunk struct {
 	Verify bool
 }
 
+var (
+	// ErrChunkMissingSourceName is returned by Chunk.Validate for chunks
+	// without a SourceName.
+	ErrChunkMissingSourceName = errors.New("missing source name")
+	// ErrChunkMissingMetadata is returned by Chunk.Validate for chunks without
+	// SourceMetadata.
+	ErrChunkMissingMetadata = errors.New("missing source metadata")
+	// ErrChunkEmptyData is returned by Chunk.Validate for chunks without data.
+	ErrChunkEmptyData = errors.New("empty data")
+)
+
+// Validate checks that the chunk has the fields required to scan it and
+// report its results. The error identifies the source that produced the
+// chunk, and wraps the ErrChunk errors of the missing fields.
+func (c *Chunk) Validate() error {
+	return c.validate(true)
+}
+
+// ValidateSkeleton is like Validate, for chunk skeletons that are copied into
+// the chunks of the data read from a file, and don't have data of their own.
+func (c *Chunk) ValidateSkeleton() error {
+	return c.validate(false)
+}
+
+func (c *Chunk) validate(requireData bool) error {
+	var errs []error
+	if c.SourceName == "" {
+		errs = append(errs, ErrChunkMissingSourceName)
+	}
+	if c.SourceMetadata == nil {
+		errs = append(errs, ErrChunkMissingMetadata)
+	}
+	if requireData && len(c.Data) == 0 {
+		errs = append(errs, ErrChunkEmptyData)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid chunk from %s source %q (source ID %d, job ID %d): %w",
+		c.SourceType, c.SourceName, c.SourceID, c.JobID, errors.Join(errs...))
+}
+
 // ChunkingTarget specifies criteria for a targeted chunking process.
 // Instead of collecting data indiscriminately, this struct allows the caller
 // to specify particular subsets of data they're interested in. This becomes
@@ -75,8 +125,24 @@ type Source interface {
 	Chunks(ctx context.Context, chunksChan chan *Chunk, targets ...ChunkingTarget) error
 	// GetProgress is the completion progress (percentage) for Scanned Source.
 	GetProgress() *Progress
+	// LastActivity is the last time the source made progress, e.g. processed
+	// an object. The SourceManager marks activity on the source's progress for
+	// every chunk it produces. It is the zero time if the source hasn't made
+	// any progress.
+	LastActivity() time.Time
+	// Close releases any resources held by the source, such as API clients.
+	// It is safe to call more than once.
+	Close() error
 }
 
+// NoopCloser is an implementation of Close for sources that hold no resources
+// that need to be released. A source can embed this struct to satisfy the
+// Source interface.
+type NoopCloser struct{}
+
+// Close implements the Source interface.
+func (NoopCloser) Close() error { return nil }
+
 // SourceUnitEnumChunker are the two required interfaces to support enumerating
 // and chunking of units.
 type SourceUnitEnumChunker interface {
@@ -167,18 +233,177 @@ type GCSConfig struct {
 	ProjectID,
 	// ServiceAccount is the service account to use to authenticate with the source.
 	ServiceAccount string
+	// ImpersonateServiceAccount is the email of a service account to
+	// impersonate with the application default credentials. It implies
+	// CloudCred.
+	ImpersonateServiceAccount string
+	// ImpersonationDelegates is the delegation chain to
+	// ImpersonateServiceAccount, each service account being able to create
+	// tokens for the next one.
+	ImpersonationDelegates []string
+	// WorkloadIdentityFederation is the path to the external account
+	// configuration of a Workload Identity Federation pool, to authenticate
+	// with the token of an external identity provider instead of a key.
+	WorkloadIdentityFederation string
 	// MaxObjectSize is the maximum object size to scan.
 	MaxObjectSize int64
+	// MinObjectSize is the minimum object size to scan, to skip small files
+	// such as icons.
+	MinObjectSize int64
+	// TruncateLargeObjects scans the first MaxObjectSize bytes of larger
+	// objects instead of skipping them.
+	TruncateLargeObjects bool
 	// Concurrency is the number of concurrent workers to use to scan the source.
 	Concurrency int
-	// IncludeBuckets is a list of buckets to include in the scan.
+	// IncludeBuckets is a list of buckets to include in the scan. Values
+	// prefixed with @ are the path of a file listing one bucket per line.
 	IncludeBuckets,
-	// ExcludeBuckets is a list of buckets to exclude from the scan.
+	// ExcludeBuckets is a list of buckets to exclude from the scan. Values
+	// prefixed with @ are the path of a file listing one bucket per line.
 	ExcludeBuckets,
 	// IncludeObjects is a list of objects to include in the scan.
 	IncludeObjects,
 	// ExcludeObjects is a list of objects to exclude from the scan.
 	ExcludeObjects []string
+	// IncludeContentTypes only scans the objects whose media type matches one
+	// of them, e.g. "text/*" or "application/json". ExcludeContentTypes skips
+	// the objects whose media type matches one of them, and takes precedence.
+	IncludeContentTypes,
+	ExcludeContentTypes []string
+	// ExcludeUnknownContentTypes skips the objects without a valid content
+	// type when filtering objects by content type. They are scanned otherwise.
+	ExcludeUnknownContentTypes bool
+	// SkipBinaries allows skipping binary objects from the scan.
+	SkipBinaries bool
+	// BinaryThreshold is the fraction of null bytes in the start of an object
+	// above which SkipBinaries treats it as binary.
+	BinaryThreshold float64
+	// SignedURLs is a list of signed URLs of objects to scan instead of
+	// listing buckets. No other authentication is used with them.
+	SignedURLs []string
+	// SignedURLsFile is the path to a file with newline separated signed URLs.
+	SignedURLsFile string
+	// DisableArchiveHandling reads objects as plain bytes, without detecting
+	// or extracting archives.
+	DisableArchiveHandling bool
+	// Prefixes limits the scan to objects whose names start with one of the
+	// prefixes.
+	Prefixes []string
+	// IncludeVersions also scans the noncurrent versions of objects in
+	// versioned buckets.
+	IncludeVersions bool
+	// MaxObjectsPerBucket caps the number of objects scanned in each bucket.
+	// If 0, all objects are scanned.
+	MaxObjectsPerBucket int64
+	// SampleObjects scans a random sample of MaxObjectsPerBucket objects in
+	// each bucket instead of the first ones.
+	SampleObjects bool
+	// SampleRate is the probability each object is scanned with, for spot
+	// checks. If 0, all objects are scanned.
+	SampleRate float64
+	// SampleSeed seeds the objects selected by SampleObjects and SampleRate,
+	// so runs with the same seed select the same objects. If 0, a random
+	// seed is used.
+	SampleSeed int64
+	// RangedReadThreshold is the size from which objects are read in ranges
+	// that are fetched concurrently. If 0, objects are read in one request.
+	RangedReadThreshold int64
+	// RangedReadConcurrency is the maximum number of ranges of an object that
+	// are fetched at once.
+	RangedReadConcurrency int
+	// PrefetchDepth is the number of objects downloaded into memory ahead of
+	// being scanned, so downloads overlap with detection. If 0, objects are
+	// downloaded as they're scanned.
+	PrefetchDepth int
+	// PrefetchMaxBytes caps the memory of the objects downloaded ahead. Larger
+	// objects are downloaded as they're scanned. If 0, it defaults to 64MB.
+	PrefetchMaxBytes int64
+	// ContinueOnBucketError skips the buckets that fail to be listed, e.g.
+	// because the credentials can't access them, instead of stopping the scan.
+	ContinueOnBucketError bool
+	// ReportPublicAccess reports the objects and buckets that anyone on the
+	// internet can read as findings, even if they don't contain secrets.
+	ReportPublicAccess bool
+	// ScanPaths also scans the names of objects for secrets.
+	ScanPaths bool
+	// NDJSON scans objects as newline-delimited JSON, reporting the record
+	// each secret is found in.
+	NDJSON bool
+	// JoinLineContinuations joins the lines continued with a trailing
+	// backslash, so secrets wrapped across lines are found.
+	JoinLineContinuations bool
+	// BucketIAM reports the principals that can read the objects of a bucket,
+	// from its IAM policy, with the findings in the bucket. It requires the
+	// storage.buckets.getIamPolicy permission.
+	BucketIAM bool
+	// Proxy is the URL of the HTTP, HTTPS or SOCKS5 proxy to request GCS
+	// through, except for the hosts matching NO_PROXY.
+	Proxy string
+	// PrivateEndpoint connects to Google APIs through the restricted VIP, to
+	// scan GCS from inside a VPC Service Controls perimeter whose DNS doesn't
+	// route googleapis.com to it.
+	PrivateEndpoint bool
+	// SkipArchivedObjects skips the objects in the COLDLINE and ARCHIVE
+	// storage classes, whose retrieval is billed.
+	SkipArchivedObjects bool
+	// DedupeObjects skips the objects whose stored MD5 or CRC32C hash is
+	// that of an object already scanned, without reading them.
+	DedupeObjects bool
+	// SoftDeletedObjects also scans the soft-deleted objects retained by the
+	// soft delete policy of the buckets.
+	SoftDeletedObjects bool
+	// InventoryManifest is the gs:// URI of a Storage Insights inventory
+	// report, or of its manifest, listing the objects to scan instead of
+	// listing the buckets.
+	InventoryManifest string
+}
+
+// GCPSecretManagerConfig defines the optional configuration for a Google Cloud
+// Secret Manager source.
+type GCPSecretManagerConfig struct {
+	// ProjectID is the project whose secrets are scanned.
+	ProjectID string
+	// ServiceAccount is the path to a service account JSON file to
+	// authenticate with. If empty, Application Default Credentials are used.
+	ServiceAccount string
+	// Concurrency is the number of concurrent workers to use to scan the source.
+	Concurrency int
+	// IncludeSecrets is a list of secrets to include in the scan.
+	IncludeSecrets,
+	// ExcludeSecrets is a list of secrets to exclude from the scan.
+	ExcludeSecrets []string
+}
+
+// AzureBlobConfig defines the optional configuration for an Azure Blob Storage source.
+type AzureBlobConfig struct {
+	// AccountURL is the blob service URL of the storage account. It is
+	// required unless a connection string is used.
+	AccountURL string
+	// ConnectionString is a storage account connection string to authenticate with.
+	ConnectionString string
+	// SASToken is a shared access signature to authenticate with.
+	SASToken string
+	// ManagedIdentity authenticates with the managed identity of the host.
+	ManagedIdentity bool
+	// ManagedIdentityClientID selects a user-assigned managed identity.
+	ManagedIdentityClientID string
+	// Concurrency is the number of concurrent workers to use to scan the source.
+	Concurrency int
+	// IncludeContainers is a list of containers to include in the scan.
+	IncludeContainers,
+	// ExcludeContainers is a list of containers to exclude from the scan.
+	ExcludeContainers,
+	// IncludeBlobs is a list of blobs to include in the scan.
+	IncludeBlobs,
+	// ExcludeBlobs is a list of blobs to exclude from the scan.
+	ExcludeBlobs []string
+	// MaxBlobSize is the maximum blob size to scan.
+	MaxBlobSize int64
+	// SkipBinaries allows skipping binary blobs from the scan.
+	SkipBinaries bool
+	// DisableArchiveHandling reads blobs as plain bytes, without detecting
+	// or extracting archives.
+	DisableArchiveHandling bool
 }
 
 // GitConfig defines the optional configuration for a git source.
@@ -189,6 +414,8 @@ type GitConfig struct {
 	BaseRef string
 	// MaxDepth is the maximum depth to scan the source.
 	MaxDepth int
+	// MaxDepthPerBranch is the maximum number of commits to scan for each branch.
+	MaxDepthPerBranch int
 	// Bare is an indicator to handle bare repositories properly.
 	Bare bool
 	// URI is the URI of the repository to scan. file://, http://, https:// and ssh:// are supported.
@@ -200,8 +427,13 @@ type GitConfig struct {
 	// ExcludeGlobs is a list of comma separated globs to exclude from the scan.
 	// This differs from the Filter exclusions as ExcludeGlobs is applied at the `git log -p` level
 	ExcludeGlobs string
+	// PathAllowlist is a list of globs of file paths to skip, e.g. "vendor/**".
+	PathAllowlist []string
 	// SkipBinaries allows skipping binary files from the scan.
 	SkipBinaries bool
+	// UseIgnoreFiles skips the paths listed by the .trufflehogignore files of
+	// the scanned commit.
+	UseIgnoreFiles bool
 }
 
 // GithubConfig defines the optional configuration for a github source.
@@ -260,6 +492,25 @@ type FilesystemConfig struct {
 	IncludePathsFile string
 	// ExcludePathsFile is the path to a file containing a list of regexps to exclude from the scan.
 	ExcludePathsFile string
+	// PathAllowlist is a list of globs of file paths to skip, e.g. "vendor/**".
+	PathAllowlist []string
+	// SkipBinaries allows skipping binary files from the scan.
+	SkipBinaries bool
+	// BinaryThreshold is the fraction of null bytes in the start of a file
+	// above which SkipBinaries treats it as binary.
+	BinaryThreshold float64
+	// ScanPaths also scans the paths of files for secrets.
+	ScanPaths bool
+	// JoinLineContinuations joins the lines continued with a trailing
+	// backslash, so secrets wrapped across lines are found.
+	JoinLineContinuations bool
+	// MmapThreshold is the file size in bytes above which files are
+	// memory-mapped rather than read with buffered IO. Zero disables
+	// memory-mapping.
+	MmapThreshold int64
+	// UseIgnoreFiles skips the paths listed by the .trufflehogignore files of
+	// the scanned directories.
+	UseIgnoreFiles bool
 }
 
 // S3Config defines the optional configuration for an S3 source.
@@ -281,6 +532,12 @@ type S3Config struct {
 	Roles []string
 	// MaxObjectSize is the maximum object size to scan.
 	MaxObjectSize int64
+	// ScanPaths also scans the keys of objects for secrets.
+	ScanPaths bool
+	// RestoreArchivedObjects requests the restore of the objects in the
+	// GLACIER and DEEP_ARCHIVE storage classes and scans them once they are
+	// restored. They are skipped otherwise.
+	RestoreArchivedObjects bool
 }
 
 // SyslogConfig defines the optional configuration for a syslog source.
@@ -299,6 +556,15 @@ type SyslogConfig struct {
 	Concurrency int
 }
 
+// StdinConfig defines the optional configuration for a stdin source.
+type StdinConfig struct {
+	// Name labels the input in the metadata of its findings, e.g. the file it
+	// was piped from. It defaults to "stdin".
+	Name string
+	// Reader is the input to scan. If nil, standard input is read.
+	Reader io.Reader
+}
+
 // PostmanConfig defines the optional configuration for a Postman source.
 type PostmanConfig struct {
 	// Workspace UUID(s) or file path(s) to Postman workspace (.zip)
@@ -350,6 +616,70 @@ type Progress struct {
 	EncodedResumeInfo string
 	SectionsCompleted int32
 	SectionsRemaining int32
+
+	reporter ProgressReporter
+	// lastActivity is the time of the last activity of the source, in Unix
+	// nanoseconds, see MarkActivity.
+	lastActivity atomic.Int64
+}
+
+// ProgressSnapshot is a copy of a source's progress at the time it was updated.
+type ProgressSnapshot struct {
+	PercentComplete   int64
+	Message           string
+	SectionsCompleted int32
+	SectionsRemaining int32
+}
+
+// ProgressReporter receives progress updates from a source. This allows
+// applications embedding TruffleHog to be notified of progress instead of
+// polling GetProgress.
+type ProgressReporter interface {
+	// OnProgress is called every time the source updates its progress. Calls
+	// are made in the order the updates happen, so OnProgress should return
+	// quickly and must not update the progress of the source itself.
+	OnProgress(ProgressSnapshot)
+}
+
+// SetProgressReporter sets the reporter notified of progress updates. A nil
+// reporter disables notifications.
+func (p *Progress) SetProgressReporter(reporter ProgressReporter) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	p.reporter = reporter
+}
+
+// report notifies the progress reporter, if any, of the current progress.
+// The caller must hold p.mut.
+func (p *Progress) report() {
+	if p.reporter == nil {
+		return
+	}
+	p.reporter.OnProgress(ProgressSnapshot{
+		PercentComplete:   p.PercentComplete,
+		Message:           p.Message,
+		SectionsCompleted: p.SectionsCompleted,
+		SectionsRemaining: p.SectionsRemaining,
+	})
+}
+
+// MarkActivity records that the source made progress now, e.g. that it
+// processed an object. Setting the progress marks activity too. It is safe to
+// call concurrently and doesn't take the progress lock, so that it can be
+// called for every object processed.
+func (p *Progress) MarkActivity() {
+	p.lastActivity.Store(time.Now().UnixNano())
+}
+
+// LastActivity returns the last time the source made progress. A supervisor
+// can compare it to the current time to detect a stalled scan. It is the zero
+// time if the source hasn't made any progress.
+func (p *Progress) LastActivity() time.Time {
+	nanos := p.lastActivity.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
 }
 
 // Validator is an interface for validating a source. Sources can optionally implement this interface to validate
@@ -358,6 +688,13 @@ type Validator interface {
 	Validate(ctx context.Context) []error
 }
 
+// ObjectCounter is an optional interface that sources of objects, e.g. the
+// objects of buckets, can implement to report how many objects they scanned.
+type ObjectCounter interface {
+	// ObjectsScanned returns the number of objects scanned and their size in bytes.
+	ObjectsScanned() (objects, bytes uint64)
+}
+
 // SetProgressComplete sets job progress information for a running job based on the highest level objects in the source.
 // i is the current iteration in the loop of target scope
 // scope should be the len(scopedItems)
@@ -370,6 +707,9 @@ func (p *Progress) SetProgressComplete(i, scope int, message, encodedResumeInfo
 	p.mut.Lock()
 	defer p.mut.Unlock()
 
+	defer p.report()
+	p.MarkActivity()
+
 	p.Message = message
 	p.EncodedResumeInfo = encodedResumeInfo
 	p.SectionsCompleted = int32(i)
@@ -384,6 +724,29 @@ func (p *Progress) SetProgressComplete(i, scope int, message, encodedResumeInfo
 	p.PercentComplete = int64((float64(i) / float64(scope)) * 100)
 }
 
+// SetProgressCompleteBytes sets job progress information like
+// SetProgressComplete, but the completion percentage is that of the bytes
+// completed out of bytesScope, for sources whose items vary widely in size.
+// If bytesScope is 0, completion is 100%.
+func (p *Progress) SetProgressCompleteBytes(i, scope int, bytes, bytesScope uint64, message, encodedResumeInfo string) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	defer p.report()
+	p.MarkActivity()
+
+	p.Message = message
+	p.EncodedResumeInfo = encodedResumeInfo
+	p.SectionsCompleted = int32(i)
+	p.SectionsRemaining = int32(scope)
+
+	if bytesScope == 0 {
+		p.PercentComplete = 100
+		return
+	}
+	p.PercentComplete = int64((float64(min(bytes, bytesScope)) / float64(bytesScope)) * 100)
+}
+
 // SetProgressOngoing sets information about the current running job based on
 // the highest level objects in the source.
 // message is the public facing user information about the current progress
@@ -395,10 +758,12 @@ func (p *Progress) SetProgressOngoing(message string, encodedResumeInfo string)
 	p.mut.Lock()
 	defer p.mut.Unlock()
 
+	p.MarkActivity()
 	p.Message = message
 	p.EncodedResumeInfo = encodedResumeInfo
 	// Explicitly set SectionsRemaining to 0 so the frontend does not display a percent.
 	p.SectionsRemaining = 0
+	p.report()
 }
 
 // GetProgress gets job completion percentage for metrics reporting.
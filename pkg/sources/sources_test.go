@@ -1,14 +1,228 @@
 package sources
 
 import (
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 	"unsafe"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
 )
 
-// TestChunkSize ensures that the Chunk struct does not exceed 80 bytes.
+// TestChunkSize ensures that the Chunk struct does not exceed 88 bytes.
 func TestChunkSize(t *testing.T) {
 	t.Parallel()
-	assert.Equal(t, unsafe.Sizeof(Chunk{}), uintptr(80), "Chunk struct size exceeds 80 bytes")
+	assert.Equal(t, unsafe.Sizeof(Chunk{}), uintptr(88), "Chunk struct size exceeds 88 bytes")
+}
+
+func TestChunk_Validate(t *testing.T) {
+	t.Parallel()
+
+	validChunk := func() *Chunk {
+		return &Chunk{
+			SourceName: "objects",
+			SourceType: sourcespb.SourceType_SOURCE_TYPE_GCS,
+			SourceMetadata: &source_metadatapb.MetaData{
+				Data: &source_metadatapb.MetaData_Gcs{Gcs: &source_metadatapb.GCS{Filename: "config.json"}},
+			},
+			Data: []byte("data"),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*Chunk)
+		wantErr []error
+	}{
+		{
+			name:   "valid",
+			modify: func(*Chunk) {},
+		},
+		{
+			name:    "missing source name",
+			modify:  func(c *Chunk) { c.SourceName = "" },
+			wantErr: []error{ErrChunkMissingSourceName},
+		},
+		{
+			name:    "nil metadata",
+			modify:  func(c *Chunk) { c.SourceMetadata = nil },
+			wantErr: []error{ErrChunkMissingMetadata},
+		},
+		{
+			name:    "empty data",
+			modify:  func(c *Chunk) { c.Data = []byte{} },
+			wantErr: []error{ErrChunkEmptyData},
+		},
+		{
+			name:    "nil data",
+			modify:  func(c *Chunk) { c.Data = nil },
+			wantErr: []error{ErrChunkEmptyData},
+		},
+		{
+			name: "several missing fields",
+			modify: func(c *Chunk) {
+				c.SourceMetadata = nil
+				c.Data = nil
+			},
+			wantErr: []error{ErrChunkMissingMetadata, ErrChunkEmptyData},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			chunk := validChunk()
+			tt.modify(chunk)
+			err := chunk.Validate()
+			if len(tt.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			for _, want := range tt.wantErr {
+				assert.ErrorIs(t, err, want)
+			}
+			assert.Contains(t, err.Error(), "SOURCE_TYPE_GCS")
+		})
+	}
+}
+
+func TestChunk_ValidateSkeleton(t *testing.T) {
+	t.Parallel()
+
+	skeleton := &Chunk{
+		SourceName:     "objects",
+		SourceMetadata: &source_metadatapb.MetaData{},
+	}
+	assert.NoError(t, skeleton.ValidateSkeleton())
+	assert.ErrorIs(t, skeleton.Validate(), ErrChunkEmptyData)
+
+	skeleton.SourceName = ""
+	err := skeleton.ValidateSkeleton()
+	assert.ErrorIs(t, err, ErrChunkMissingSourceName)
+	assert.NotErrorIs(t, err, ErrChunkEmptyData)
+}
+
+// progressRecorder is a ProgressReporter that records every snapshot it receives.
+type progressRecorder struct {
+	mu        sync.Mutex
+	snapshots []ProgressSnapshot
+}
+
+func (r *progressRecorder) OnProgress(snapshot ProgressSnapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snapshots = append(r.snapshots, snapshot)
+}
+
+func TestProgressReporter(t *testing.T) {
+	t.Parallel()
+
+	const scope = 10
+	var progress Progress
+	recorder := new(progressRecorder)
+	progress.SetProgressReporter(recorder)
+
+	progress.SetProgressOngoing("enumerating", "")
+	for i := 1; i <= scope; i++ {
+		progress.SetProgressComplete(i, scope, fmt.Sprintf("scanned item %d", i), "")
+	}
+
+	assert.Len(t, recorder.snapshots, scope+1)
+	assert.Equal(t, ProgressSnapshot{Message: "enumerating"}, recorder.snapshots[0])
+	for i := 2; i < len(recorder.snapshots); i++ {
+		assert.GreaterOrEqual(t, recorder.snapshots[i].PercentComplete, recorder.snapshots[i-1].PercentComplete)
+		assert.Greater(t, recorder.snapshots[i].SectionsCompleted, recorder.snapshots[i-1].SectionsCompleted)
+	}
+	assert.Equal(t, ProgressSnapshot{
+		PercentComplete:   100,
+		Message:           "scanned item 10",
+		SectionsCompleted: scope,
+		SectionsRemaining: scope,
+	}, recorder.snapshots[len(recorder.snapshots)-1])
+}
+
+func TestProgressReporter_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	const scope = 100
+	var progress Progress
+	recorder := new(progressRecorder)
+	progress.SetProgressReporter(recorder)
+
+	var wg sync.WaitGroup
+	for i := 1; i <= scope; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			progress.SetProgressComplete(i, scope, "", "")
+		}(i)
+	}
+	wg.Wait()
+
+	// Every update is reported, and the last report matches the final state.
+	assert.Len(t, recorder.snapshots, scope)
+	last := recorder.snapshots[len(recorder.snapshots)-1]
+	assert.Equal(t, progress.GetProgress().PercentComplete, last.PercentComplete)
+	assert.Equal(t, progress.GetProgress().SectionsCompleted, last.SectionsCompleted)
+}
+
+func TestProgressReporter_Unset(t *testing.T) {
+	t.Parallel()
+
+	var progress Progress
+	recorder := new(progressRecorder)
+	progress.SetProgressReporter(recorder)
+	progress.SetProgressComplete(1, 2, "", "")
+
+	progress.SetProgressReporter(nil)
+	progress.SetProgressComplete(2, 2, "", "")
+
+	assert.Len(t, recorder.snapshots, 1)
+	assert.Equal(t, int64(100), progress.GetProgress().PercentComplete)
+}
+
+func TestSetProgressCompleteBytes(t *testing.T) {
+	t.Parallel()
+
+	var progress Progress
+	progress.SetProgressCompleteBytes(1, 4, 75, 100, "scanned item 1", "")
+	assert.Equal(t, int64(75), progress.PercentComplete)
+	assert.Equal(t, int32(1), progress.SectionsCompleted)
+	assert.Equal(t, int32(4), progress.SectionsRemaining)
+
+	// Completion is capped at 100%, e.g. if items grew since they were sized.
+	progress.SetProgressCompleteBytes(2, 4, 150, 100, "", "")
+	assert.Equal(t, int64(100), progress.PercentComplete)
+
+	progress.SetProgressCompleteBytes(0, 0, 0, 0, "", "")
+	assert.Equal(t, int64(100), progress.PercentComplete)
+}
+
+func TestProgress_LastActivity(t *testing.T) {
+	t.Parallel()
+
+	var progress Progress
+	assert.True(t, progress.LastActivity().IsZero())
+
+	progress.MarkActivity()
+	first := progress.LastActivity()
+	assert.False(t, first.IsZero())
+
+	// The last activity is stable while the source is idle.
+	time.Sleep(time.Millisecond)
+	assert.Equal(t, first, progress.LastActivity())
+
+	// Processing objects and setting the progress advance it.
+	progress.MarkActivity()
+	second := progress.LastActivity()
+	assert.True(t, second.After(first))
+
+	time.Sleep(time.Millisecond)
+	progress.SetProgressOngoing("scanning", "")
+	assert.True(t, progress.LastActivity().After(second))
 }
@@ -0,0 +1,134 @@
+package stdin
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/source_metadatapb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/pb/sourcespb"
+	"github.com/trufflesecurity/trufflehog/v3/pkg/sources"
+)
+
+const SourceType = sourcespb.SourceType_SOURCE_TYPE_STDIN
+
+// defaultInputName labels the input in the metadata of its chunks unless the
+// connection names it.
+const defaultInputName = "stdin"
+
+// Ensure the Source satisfies the interfaces at compile time.
+var _ sources.Source = (*Source)(nil)
+var _ sources.SourceUnitUnmarshaller = (*Source)(nil)
+
+// Source scans arbitrary text read from standard input, e.g. a pasted config
+// file, without configuring another source. The input is streamed in
+// overlapping chunks, so it can be larger than memory.
+type Source struct {
+	name     string
+	jobId    sources.JobID
+	sourceId sources.SourceID
+	verify   bool
+
+	// reader is the input to scan, os.Stdin unless set by NewSource.
+	reader io.Reader
+	// inputName labels the input in the metadata of its chunks.
+	inputName string
+
+	sources.Progress
+	sources.CommonSourceUnitUnmarshaller
+	sources.NoopCloser
+}
+
+// NewSource returns a source that scans the text read from reader instead of
+// standard input. It must be initialized with Init before it is run.
+func NewSource(reader io.Reader) *Source {
+	return &Source{reader: reader}
+}
+
+// Type returns the type of source.
+// It is used for matching source types in configuration and job input.
+func (s *Source) Type() sourcespb.SourceType {
+	return SourceType
+}
+
+// SourceID number for the stdin source.
+func (s *Source) SourceID() sources.SourceID {
+	return s.sourceId
+}
+
+// JobID number for the stdin source.
+func (s *Source) JobID() sources.JobID {
+	return s.jobId
+}
+
+// Init returns an initialized stdin source. The input is read once, so the
+// concurrency is ignored.
+func (s *Source) Init(_ context.Context, name string, id sources.JobID, sourceID sources.SourceID, verify bool, connection *anypb.Any, _ int) error {
+	s.name = name
+	s.verify = verify
+	s.sourceId = sourceID
+	s.jobId = id
+
+	var conn sourcespb.Stdin
+	if err := anypb.UnmarshalTo(connection, &conn, proto.UnmarshalOptions{}); err != nil {
+		return fmt.Errorf("error unmarshalling connection: %w", err)
+	}
+
+	s.inputName = conn.GetName()
+	if s.inputName == "" {
+		s.inputName = defaultInputName
+	}
+	if s.reader == nil {
+		s.reader = os.Stdin
+	}
+	return nil
+}
+
+// Chunks emits the chunks of the input over a channel, until it is exhausted.
+func (s *Source) Chunks(ctx context.Context, chunksChan chan *sources.Chunk, _ ...sources.ChunkingTarget) error {
+	s.Progress.Message = "reading input..."
+
+	// The default chunker reads overlapping chunks that each start ChunkSize
+	// bytes after the previous one.
+	position := sources.StartPosition()
+	var numChunks, numBytes int
+	for data := range sources.DefaultChunkSplitter().Chunk(ctx, s.reader) {
+		if err := data.Error(); err != nil {
+			return fmt.Errorf("error reading %s: %w", s.inputName, err)
+		}
+
+		chunkPosition := position
+		chunk := &sources.Chunk{
+			SourceType: s.Type(),
+			SourceName: s.name,
+			SourceID:   s.SourceID(),
+			JobID:      s.JobID(),
+			Data:       data.Bytes(),
+			Position:   &chunkPosition,
+			SourceMetadata: &source_metadatapb.MetaData{
+				Data: &source_metadatapb.MetaData_Stdin{
+					Stdin: &source_metadatapb.Stdin{Name: s.inputName},
+				},
+			},
+			Verify: s.verify,
+		}
+		position = position.Advance(data.Bytes(), sources.ChunkSize)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case chunksChan <- chunk:
+		}
+		numChunks++
+		numBytes += min(len(data.Bytes()), sources.ChunkSize)
+	}
+
+	msg := fmt.Sprintf("stdin source finished reading %d bytes in %d chunks", numBytes, numChunks)
+	ctx.Logger().V(2).Info(msg)
+	s.SetProgressComplete(1, 1, msg, "")
+	return nil
+}
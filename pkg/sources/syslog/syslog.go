@@ -36,6 +36,7 @@ type Source struct {
 	verify   bool
 	syslog   *Syslog
 	sources.Progress
+	sources.NoopCloser
 	conn *sourcespb.Syslog
 }
 
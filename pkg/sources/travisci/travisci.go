@@ -34,6 +34,7 @@ type Source struct {
 	sources.Progress
 	client *travis.Client
 	sources.CommonSourceUnitUnmarshaller
+	sources.NoopCloser
 	returnAfterFirstChunk bool
 }
 
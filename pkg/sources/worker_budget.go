@@ -0,0 +1,78 @@
+package sources
+
+import (
+	"github.com/marusama/semaphore/v2"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+// WorkerBudget is a pool of workers shared by every source a SourceManager
+// runs. Without it, each source limits its own concurrency, so running several
+// sources at once multiplies the number of goroutines and open connections.
+type WorkerBudget struct {
+	sem semaphore.Semaphore
+}
+
+// NewWorkerBudget creates a WorkerBudget that allows at most workers
+// concurrent workers across all sources.
+func NewWorkerBudget(workers int) *WorkerBudget {
+	return &WorkerBudget{sem: semaphore.New(workers)}
+}
+
+// Acquire blocks until a worker is available or the context is done.
+func (b *WorkerBudget) Acquire(ctx context.Context) error {
+	return b.sem.Acquire(ctx, 1)
+}
+
+// Release returns a worker to the budget.
+func (b *WorkerBudget) Release() {
+	b.sem.Release(1)
+}
+
+// Limit returns the maximum number of concurrent workers.
+func (b *WorkerBudget) Limit() int {
+	return b.sem.GetLimit()
+}
+
+// InUse returns the number of workers currently acquired.
+func (b *WorkerBudget) InUse() int {
+	return b.sem.GetCount()
+}
+
+type (
+	workerBudgetKey struct{}
+	workerHeldKey   struct{}
+)
+
+// withWorkerBudget returns a context that sources can acquire workers from.
+func withWorkerBudget(ctx context.Context, budget *WorkerBudget) context.Context {
+	return context.WithValue(ctx, workerBudgetKey{}, budget)
+}
+
+// withWorkerHeld marks that the caller already holds a worker, so any work it
+// does on the returned context is accounted for.
+func withWorkerHeld(ctx context.Context) context.Context {
+	return context.WithValue(ctx, workerHeldKey{}, true)
+}
+
+// AcquireWorker acquires a worker from the budget of the SourceManager running
+// the source. Sources should call it before each unit of work they run
+// concurrently, and call the returned release function when that work is done.
+//
+// If the source is not run with a worker budget, or the context already holds a
+// worker (e.g. while the SourceManager chunks a unit), AcquireWorker returns
+// immediately. A worker is therefore never acquired twice for the same work,
+// which would deadlock once the budget is exhausted.
+func AcquireWorker(ctx context.Context) (release func(), err error) {
+	budget, ok := ctx.Value(workerBudgetKey{}).(*WorkerBudget)
+	if !ok || budget == nil {
+		return func() {}, nil
+	}
+	if held, _ := ctx.Value(workerHeldKey{}).(bool); held {
+		return func() {}, nil
+	}
+	if err := budget.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	return budget.Release, nil
+}
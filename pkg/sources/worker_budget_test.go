@@ -0,0 +1,43 @@
+package sources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trufflesecurity/trufflehog/v3/pkg/context"
+)
+
+func TestAcquireWorker(t *testing.T) {
+	t.Parallel()
+
+	budget := NewWorkerBudget(1)
+	ctx := withWorkerBudget(context.Background(), budget)
+
+	release, err := AcquireWorker(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, budget.InUse())
+
+	// Work done while holding a worker doesn't acquire another one.
+	nestedRelease, err := AcquireWorker(withWorkerHeld(ctx))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, budget.InUse())
+	nestedRelease()
+
+	// The budget is exhausted, so acquiring waits until the context is done.
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = AcquireWorker(cancelCtx)
+	assert.Error(t, err)
+
+	release()
+	assert.Equal(t, 0, budget.InUse())
+}
+
+func TestAcquireWorker_NoBudget(t *testing.T) {
+	t.Parallel()
+
+	release, err := AcquireWorker(context.Background())
+	assert.NoError(t, err)
+	release()
+}